@@ -54,7 +54,7 @@ module Bosh::Template::Test
     end
 
     let(:tag_link) do
-      Link.new(name: 'tag_length', instances: [LinkInstance.new()], properties: {'tag_length' => 1})
+      Link.new(name: 'tag_length', instances: [LinkInstance.new()], properties: {'tag_length' => 1, 'tag_quarantine' => 300})
     end
 
     let(:db_link) do
@@ -81,6 +81,12 @@ module Bosh::Template::Test
           'debug_server_port' => 1234,
           'health_check_port' => 2345,
           'internal_listen_port' => 3456,
+          'grpc_listen_port' => 0,
+          'max_request_body_size' => 10485760,
+          'tracing' => {
+            'exporter' => '',
+            'collector_url' => 'http://127.0.0.1:4318/v1/traces'
+          },
           'database' => {
             'type' => 'some-database-type',
             'user' => 'some-database-username',
@@ -94,7 +100,11 @@ module Bosh::Template::Test
           },
           'max_idle_connections' => 4,
           'max_open_connections' => 5,
+          'read_replicas' => [],
+          'replica_max_lag' => 10,
+          'degrade_on_schema_mismatch' => false,
           'tag_length' => 1,
+          'tag_quarantine' => 300,
           'metron_address' => '127.0.0.1:4567',
           'log_level' => 'error',
 
@@ -108,6 +118,21 @@ module Bosh::Template::Test
           })
       end
 
+      context 'when read replica hosts are set' do
+        before do
+          merged_manifest_properties['database']['read_replica_hosts'] = ['replica-1', 'replica-2']
+          merged_manifest_properties['replica_max_lag'] = 30
+        end
+
+        it 'renders a database config for each replica' do
+          config = JSON.parse(template.render(merged_manifest_properties, consumes: links))
+          expect(config['read_replicas'].length).to eq(2)
+          expect(config['read_replicas'][0]).to eq(config['database'].merge('host' => 'replica-1'))
+          expect(config['read_replicas'][1]).to eq(config['database'].merge('host' => 'replica-2'))
+          expect(config['replica_max_lag']).to eq(30)
+        end
+      end
+
       context 'when dbconn does not have host' do
         let(:dbconn_host) {nil}
 
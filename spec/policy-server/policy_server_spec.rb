@@ -13,6 +13,9 @@ module Bosh::Template::Test
       {
         'disable' => false,
         'policy_cleanup_interval' => 1,
+        'policy_cleanup_max_deletions' => 100,
+        'policy_cleanup_max_deletions_percent' => 25,
+        'deleted_policy_retention_days' => 3,
         'max_policies_per_app_source' => 2,
         'enable_space_developer_self_service' => true,
         'listen_ip' => '111.11.11.1',
@@ -40,6 +43,7 @@ module Bosh::Template::Test
         'max_idle_connections' => 4,
         'max_open_connections' => 5,
         'tag_length' => 2,
+        'tag_quarantine' => 300,
         'metron_port' => 6789,
         'log_level' => 'debug',
         'allowed_cors_domains' => ['some-cors-domain'],
@@ -69,7 +73,23 @@ module Bosh::Template::Test
           'uaa_client_secret' => 'some-uaa-client-secret',
           'uaa_url' => 'https://some-uaa-hostname',
           'uaa_port' => 3456,
+          'uaa_issuer' => '',
+          'local_token_validation' => false,
+          'check_token_fallback' => true,
           'cc_url' => 'http://some-cc-hostname:4567',
+          'cc_cache_ttl' => 30,
+          'cc_policy_roles' => ['space_developer'],
+          'user_rate_limit' => 0,
+          'user_rate_limit_burst' => 0,
+          'global_rate_limit' => 0,
+          'global_rate_limit_burst' => 0,
+          'max_request_body_size' => 10485760,
+          'authorization' => {},
+          'service_accounts' => [],
+          'tracing' => {
+            'exporter' => '',
+            'collector_url' => 'http://127.0.0.1:4318/v1/traces'
+          },
           'skip_ssl_validation' => true,
           'database' => {
             'type' => 'postgres',
@@ -85,10 +105,15 @@ module Bosh::Template::Test
           'database_migration_timeout' => 600,
           'max_idle_connections' => 4,
           'max_open_connections' => 5,
+          'degrade_on_schema_mismatch' => false,
           'tag_length' => 2,
+          'tag_quarantine' => 300,
           'metron_address' => '127.0.0.1:6789',
           'log_level' => 'debug',
           'cleanup_interval' => 60,
+          'cleanup_max_deletions' => 100,
+          'cleanup_max_deletions_percent' => 25,
+          'deleted_policy_retention_days' => 3,
           'max_policies' => 2,
           'enable_space_developer_self_service' => true,
           'allowed_cors_domains' => ['some-cors-domain'],
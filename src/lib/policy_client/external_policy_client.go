@@ -1,7 +1,9 @@
 package policy_client
 
 import (
+	"context"
 	"fmt"
+	"lib/tracing"
 	"net/http"
 	"policy-server/api/api_v0"
 	"strings"
@@ -27,15 +29,35 @@ type ExternalPolicyClient interface {
 type ExternalClient struct {
 	JsonClient json_client.JsonClient
 	Chunker    Chunker
+
+	logger     lager.Logger
+	httpClient json_client.HttpClient
+	baseURL    string
 }
 
 func NewExternal(logger lager.Logger, httpClient json_client.HttpClient, baseURL string) *ExternalClient {
 	return &ExternalClient{
 		JsonClient: json_client.New(logger, httpClient, baseURL),
 		Chunker:    &SimpleChunker{ChunkSize: DefaultMaxPolicies},
+		logger:     logger,
+		httpClient: httpClient,
+		baseURL:    baseURL,
 	}
 }
 
+// WithContext returns a copy of the client whose requests carry the trace
+// context of ctx. Only clients made with NewExternal propagate it.
+func (c *ExternalClient) WithContext(ctx context.Context) *ExternalClient {
+	client := *c
+	if c.httpClient == nil {
+		return &client
+	}
+
+	httpClient := &tracing.HTTPClient{Client: c.httpClient, Context: ctx}
+	client.JsonClient = json_client.New(c.logger, httpClient, c.baseURL)
+	return &client
+}
+
 func (c *ExternalClient) GetPolicies(token string) ([]api.Policy, error) {
 	var policies struct {
 		Policies []api.Policy `json:"policies"`
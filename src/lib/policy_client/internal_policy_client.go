@@ -1,9 +1,19 @@
 package policy_client
 
 import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"lib/tracing"
+	"mime"
+	"net/http"
 	"policy-server/api"
 	"strings"
+	"sync"
 
 	"code.cloudfoundry.org/cf-networking-helpers/json_client"
 	"code.cloudfoundry.org/lager"
@@ -11,36 +21,189 @@ import (
 
 type InternalClient struct {
 	JsonClient json_client.JsonClient
+
+	logger     lager.Logger
+	httpClient json_client.HttpClient
+	baseURL    string
+	// protobuf is set by NewInternalProtobuf to get policies in the compact
+	// protobuf encoding instead of JSON.
+	protobuf bool
+	ctx      context.Context
+	last     *lastResponse
+}
+
+// lastResponse is the last policies response of the protobuf client. It is
+// shared by the copies WithContext makes, and its body is decoded again when
+// the policy server answers If-None-Match with 304 Not Modified.
+type lastResponse struct {
+	mutex    sync.Mutex
+	response *cachedResponse
+}
+
+type cachedResponse struct {
+	route       string
+	etag        string
+	contentType string
+	body        []byte
+}
+
+func (l *lastResponse) get(route string) *cachedResponse {
+	if l == nil {
+		return nil
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.response == nil || l.response.route != route {
+		return nil
+	}
+	return l.response
+}
+
+func (l *lastResponse) set(response *cachedResponse) {
+	if l == nil {
+		return
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.response = response
 }
 
 func NewInternal(logger lager.Logger, httpClient json_client.HttpClient, baseURL string) *InternalClient {
 	return &InternalClient{
 		JsonClient: json_client.New(logger, httpClient, baseURL),
+		logger:     logger,
+		httpClient: httpClient,
+		baseURL:    baseURL,
+		last:       &lastResponse{},
 	}
 }
 
+// NewInternalProtobuf returns a client that asks for policies in the protobuf
+// encoding. It still understands JSON responses from older policy servers.
+func NewInternalProtobuf(logger lager.Logger, httpClient json_client.HttpClient, baseURL string) *InternalClient {
+	client := NewInternal(logger, httpClient, baseURL)
+	client.protobuf = true
+	return client
+}
+
+// WithContext returns a copy of the client whose requests carry the trace
+// context of ctx. Only clients made with NewInternal or NewInternalProtobuf
+// propagate it.
+func (c *InternalClient) WithContext(ctx context.Context) *InternalClient {
+	client := *c
+	if c.httpClient == nil {
+		return &client
+	}
+
+	httpClient := &tracing.HTTPClient{Client: c.httpClient, Context: ctx}
+	client.JsonClient = json_client.New(c.logger, httpClient, c.baseURL)
+	client.ctx = ctx
+	return &client
+}
+
 func (c *InternalClient) GetPolicies() ([]api.Policy, error) {
+	return c.getPolicies("/networking/v1/internal/policies")
+}
+
+func (c *InternalClient) GetPoliciesByID(ids ...string) ([]api.Policy, error) {
+	if len(ids) == 0 {
+		return nil, errors.New("ids cannot be empty")
+	}
+	return c.getPolicies("/networking/v1/internal/policies?id=" + strings.Join(ids, ","))
+}
+
+func (c *InternalClient) getPolicies(route string) ([]api.Policy, error) {
+	if c.protobuf {
+		return c.getProtobufPolicies(route)
+	}
+
 	var policies struct {
 		Policies []api.Policy `json:"policies"`
 	}
-	err := c.JsonClient.Do("GET", "/networking/v1/internal/policies", nil, &policies, "")
+	err := c.JsonClient.Do("GET", route, nil, &policies, "")
 	if err != nil {
 		return nil, err
 	}
 	return policies.Policies, nil
 }
 
-func (c *InternalClient) GetPoliciesByID(ids ...string) ([]api.Policy, error) {
-	var policies struct {
-		Policies []api.Policy `json:"policies"`
+func (c *InternalClient) getProtobufPolicies(route string) ([]api.Policy, error) {
+	req, err := http.NewRequest("GET", c.baseURL+route, nil)
+	if err != nil {
+		return nil, fmt.Errorf("http new request: %s", err)
 	}
-	if len(ids) == 0 {
-		return nil, errors.New("ids cannot be empty")
+	req.Header.Set("Accept", api.ProtobufContentType+", application/json;q=0.5")
+
+	cached := c.last.get(route)
+	if cached != nil {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+
+	if c.ctx != nil {
+		tracing.Inject(c.ctx, req.Header)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("http client do: %s", err)
+	}
+	defer resp.Body.Close() // untested
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		return decodePolicies(cached.contentType, cached.body)
+	}
+
+	var body io.Reader = resp.Body
+	if resp.Header.Get("Content-Encoding") == "gzip" {
+		body, err = gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("gzip reader: %s", err)
+		}
+	}
+
+	respBytes, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("body read: %s", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &json_client.HttpResponseCodeError{
+			StatusCode: resp.StatusCode,
+			Message:    string(respBytes),
+		}
 	}
-	err := c.JsonClient.Do("GET", "/networking/v1/internal/policies?id="+strings.Join(ids, ","), nil, &policies, "")
+
+	contentType := resp.Header.Get("Content-Type")
+	policies, err := decodePolicies(contentType, respBytes)
 	if err != nil {
 		return nil, err
 	}
+
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		c.last.set(&cachedResponse{
+			route:       route,
+			etag:        etag,
+			contentType: contentType,
+			body:        respBytes,
+		})
+	}
+	return policies, nil
+}
+
+func decodePolicies(contentType string, body []byte) ([]api.Policy, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err == nil && mediaType == api.ProtobufContentType {
+		policies, _, err := api.PoliciesFromProtobuf(body)
+		return policies, err
+	}
+
+	var policies struct {
+		Policies []api.Policy `json:"policies"`
+	}
+	err = json.Unmarshal(body, &policies)
+	if err != nil {
+		return nil, fmt.Errorf("json unmarshal: %s", err)
+	}
 	return policies.Policies, nil
 }
 
@@ -1,10 +1,16 @@
 package policy_client_test
 
 import (
+	"compress/gzip"
+	"context"
 	"encoding/json"
 	"errors"
 	"lib/policy_client"
+	"lib/tracing"
+	"net/http"
+	"net/http/httptest"
 	"policy-server/api"
+	"policy-server/store"
 
 	hfakes "code.cloudfoundry.org/cf-networking-helpers/fakes"
 
@@ -133,6 +139,206 @@ var _ = Describe("InternalClient", func() {
 		})
 	})
 
+	Describe("with protobuf", func() {
+		var (
+			server         *httptest.Server
+			handler        http.HandlerFunc
+			protobufBytes  []byte
+			storePolicies  []store.Policy
+			expectedPolicy api.Policy
+		)
+
+		BeforeEach(func() {
+			storePolicies = []store.Policy{{
+				Source: store.Source{ID: "some-app-guid", Tag: "BEEF"},
+				Destination: store.Destination{
+					ID:       "some-other-app-guid",
+					Protocol: "tcp",
+					Ports:    store.Ports{Start: 8090, End: 8090},
+				},
+			}}
+			expectedPolicy = api.Policy{
+				Source: api.Source{ID: "some-app-guid", Tag: "BEEF"},
+				Destination: api.Destination{
+					ID:       "some-other-app-guid",
+					Protocol: "tcp",
+					Ports:    api.Ports{Start: 8090, End: 8090},
+				},
+			}
+
+			var err error
+			protobufBytes, err = api.NewProtobufMapper().AsBytes(storePolicies, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			handler = func(w http.ResponseWriter, req *http.Request) {
+				defer GinkgoRecover()
+				Expect(req.Header.Get("Accept")).To(HavePrefix("application/x-protobuf"))
+				w.Header().Set("Content-Type", "application/x-protobuf")
+				w.Write(protobufBytes)
+			}
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				handler(w, req)
+			}))
+			client = policy_client.NewInternalProtobuf(nil, http.DefaultClient, server.URL)
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("decodes the protobuf response", func() {
+			policies, err := client.GetPolicies()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policies).To(Equal([]api.Policy{expectedPolicy}))
+		})
+
+		It("requests policies by id", func() {
+			var requestURI string
+			inner := handler
+			handler = func(w http.ResponseWriter, req *http.Request) {
+				requestURI = req.URL.RequestURI()
+				inner(w, req)
+			}
+
+			policies, err := client.GetPoliciesByID("some-app-guid", "some-other-app-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policies).To(Equal([]api.Policy{expectedPolicy}))
+			Expect(requestURI).To(Equal("/networking/v1/internal/policies?id=some-app-guid,some-other-app-guid"))
+		})
+
+		It("sends the trace context of WithContext", func() {
+			var traceparent string
+			inner := handler
+			handler = func(w http.ResponseWriter, req *http.Request) {
+				traceparent = req.Header.Get("traceparent")
+				inner(w, req)
+			}
+
+			sc, err := tracing.ParseTraceparent("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+			Expect(err).NotTo(HaveOccurred())
+			ctx := tracing.ContextWithSpanContext(context.Background(), sc)
+
+			_, err = client.WithContext(ctx).GetPolicies()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(traceparent).To(Equal("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"))
+
+			_, err = client.GetPolicies()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(traceparent).To(BeEmpty())
+		})
+
+		Context("when the server sends an ETag", func() {
+			var ifNoneMatch []string
+
+			BeforeEach(func() {
+				ifNoneMatch = nil
+				handler = func(w http.ResponseWriter, req *http.Request) {
+					ifNoneMatch = append(ifNoneMatch, req.Header.Get("If-None-Match"))
+					w.Header().Set("ETag", `"some-etag"`)
+					if req.Header.Get("If-None-Match") == `"some-etag"` {
+						w.WriteHeader(http.StatusNotModified)
+						return
+					}
+					w.Header().Set("Content-Type", "application/x-protobuf")
+					w.Write(protobufBytes)
+				}
+			})
+
+			It("sends it back and reuses the last body when the policies have not changed", func() {
+				_, err := client.GetPolicies()
+				Expect(err).NotTo(HaveOccurred())
+
+				policies, err := client.WithContext(context.Background()).GetPolicies()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(policies).To(Equal([]api.Policy{expectedPolicy}))
+				Expect(ifNoneMatch).To(Equal([]string{"", `"some-etag"`}))
+			})
+
+			It("does not send it for another route", func() {
+				_, err := client.GetPolicies()
+				Expect(err).NotTo(HaveOccurred())
+
+				policies, err := client.GetPoliciesByID("some-app-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(policies).To(Equal([]api.Policy{expectedPolicy}))
+				Expect(ifNoneMatch).To(Equal([]string{"", ""}))
+			})
+		})
+
+		Context("when the response is gzipped", func() {
+			BeforeEach(func() {
+				handler = func(w http.ResponseWriter, req *http.Request) {
+					w.Header().Set("Content-Type", "application/x-protobuf")
+					w.Header().Set("Content-Encoding", "gzip")
+					writer := gzip.NewWriter(w)
+					writer.Write(protobufBytes)
+					writer.Close()
+				}
+			})
+
+			It("decompresses it", func() {
+				policies, err := client.GetPolicies()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(policies).To(Equal([]api.Policy{expectedPolicy}))
+			})
+		})
+
+		Context("when the content type has parameters", func() {
+			BeforeEach(func() {
+				handler = func(w http.ResponseWriter, req *http.Request) {
+					w.Header().Set("Content-Type", "application/x-protobuf; charset=binary")
+					w.Write(protobufBytes)
+				}
+			})
+
+			It("still decodes the protobuf response", func() {
+				policies, err := client.GetPolicies()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(policies).To(Equal([]api.Policy{expectedPolicy}))
+			})
+		})
+
+		Context("when the server responds with json", func() {
+			BeforeEach(func() {
+				handler = func(w http.ResponseWriter, req *http.Request) {
+					w.Header().Set("Content-Type", "application/json")
+					w.Write([]byte(`{ "policies": [ {"source": { "id": "some-app-guid", "tag": "BEEF" }, "destination": { "id": "some-other-app-guid", "protocol": "tcp", "ports": { "start": 8090, "end": 8090 } } } ] }`))
+				}
+			})
+
+			It("decodes the json", func() {
+				policies, err := client.GetPolicies()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(policies).To(Equal([]api.Policy{expectedPolicy}))
+			})
+		})
+
+		Context("when the server responds with an error", func() {
+			BeforeEach(func() {
+				handler = func(w http.ResponseWriter, req *http.Request) {
+					w.WriteHeader(http.StatusInternalServerError)
+					w.Write([]byte("potato"))
+				}
+			})
+
+			It("returns the status code and body", func() {
+				_, err := client.GetPolicies()
+				Expect(err).To(MatchError("http status 500: potato"))
+			})
+		})
+
+		Context("when the protobuf is invalid", func() {
+			BeforeEach(func() {
+				protobufBytes = []byte{0xff, 0xff}
+			})
+
+			It("returns an error", func() {
+				_, err := client.GetPolicies()
+				Expect(err).To(MatchError(HavePrefix("unmarshal protobuf:")))
+			})
+		})
+	})
+
 	Describe("HealthCheck", func() {
 		BeforeEach(func() {
 			jsonClient.DoStub = func(method, route string, reqData, respData interface{}, token string) error {
@@ -0,0 +1,264 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// WriterExporter writes each span to Writer as a line of JSON.
+type WriterExporter struct {
+	Writer io.Writer
+
+	mutex sync.Mutex
+}
+
+func (e *WriterExporter) ExportSpan(span SpanData) {
+	line, err := json.Marshal(span)
+	if err != nil {
+		return // not tested
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.Writer.Write(append(line, '\n'))
+}
+
+const (
+	DefaultBatchSize     = 512
+	DefaultBatchInterval = 5 * time.Second
+	collectorQueueSize   = 4096
+)
+
+// CollectorExporter sends spans to an OpenTelemetry collector with OTLP over
+// HTTP, in the JSON encoding. Spans are sent in batches by Run; when the
+// queue is full they are dropped.
+type CollectorExporter struct {
+	URL           string
+	HTTPClient    httpClient
+	Logger        lager.Logger
+	BatchSize     int
+	BatchInterval time.Duration
+
+	queue chan SpanData
+}
+
+func NewCollectorExporter(logger lager.Logger, httpClient httpClient, url string) *CollectorExporter {
+	return &CollectorExporter{
+		URL:           url,
+		HTTPClient:    httpClient,
+		Logger:        logger,
+		BatchSize:     DefaultBatchSize,
+		BatchInterval: DefaultBatchInterval,
+		queue:         make(chan SpanData, collectorQueueSize),
+	}
+}
+
+func (e *CollectorExporter) ExportSpan(span SpanData) {
+	select {
+	case e.queue <- span:
+	default:
+		e.Logger.Debug("span-dropped", lager.Data{"name": span.Name})
+	}
+}
+
+// Run sends the queued spans every BatchInterval, or as soon as BatchSize of
+// them are queued, and sends what is left when signaled.
+func (e *CollectorExporter) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	close(ready)
+
+	ticker := time.NewTicker(e.BatchInterval)
+	defer ticker.Stop()
+
+	batch := []SpanData{}
+	for {
+		select {
+		case <-signals:
+			e.drain(batch)
+			return nil
+		case span := <-e.queue:
+			batch = append(batch, span)
+			if len(batch) >= e.BatchSize {
+				e.send(batch)
+				batch = []SpanData{}
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				e.send(batch)
+				batch = []SpanData{}
+			}
+		}
+	}
+}
+
+func (e *CollectorExporter) drain(batch []SpanData) {
+	for {
+		select {
+		case span := <-e.queue:
+			batch = append(batch, span)
+		default:
+			if len(batch) > 0 {
+				e.send(batch)
+			}
+			return
+		}
+	}
+}
+
+func (e *CollectorExporter) send(batch []SpanData) {
+	err := e.post(batch)
+	if err != nil {
+		e.Logger.Error("sending-spans", err, lager.Data{"spans": len(batch)})
+	}
+}
+
+func (e *CollectorExporter) post(batch []SpanData) error {
+	body, err := json.Marshal(otlpRequest(batch))
+	if err != nil {
+		return fmt.Errorf("marshal spans: %s", err) // not tested
+	}
+
+	req, err := http.NewRequest("POST", e.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("http new request: %s", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("http client do: %s", err)
+	}
+	defer resp.Body.Close() // untested
+
+	if resp.StatusCode != http.StatusOK {
+		respBytes, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("collector responded with %d: %s", resp.StatusCode, respBytes)
+	}
+	return nil
+}
+
+type otlpExportRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           TraceID         `json:"traceId"`
+	SpanID            SpanID          `json:"spanId"`
+	ParentSpanID      SpanID          `json:"parentSpanId"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpAttribute struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+const (
+	otlpSpanKindInternal = 1
+	otlpStatusCodeError  = 2
+)
+
+// otlpRequest groups spans by service, which OTLP records as a resource
+// attribute.
+func otlpRequest(batch []SpanData) otlpExportRequest {
+	spansByService := map[string][]otlpSpan{}
+	for _, span := range batch {
+		spansByService[span.Service] = append(spansByService[span.Service], otlpSpanFrom(span))
+	}
+
+	services := []string{}
+	for service := range spansByService {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+
+	request := otlpExportRequest{ResourceSpans: []otlpResourceSpans{}}
+	for _, service := range services {
+		request.ResourceSpans = append(request.ResourceSpans, otlpResourceSpans{
+			Resource: otlpResource{
+				Attributes: []otlpAttribute{attribute("service.name", service)},
+			},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: "lib/tracing"},
+				Spans: spansByService[service],
+			}},
+		})
+	}
+	return request
+}
+
+func otlpSpanFrom(span SpanData) otlpSpan {
+	keys := []string{}
+	for key := range span.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	attributes := []otlpAttribute{}
+	for _, key := range keys {
+		attributes = append(attributes, attribute(key, span.Attributes[key]))
+	}
+
+	status := otlpStatus{}
+	if span.Error != "" {
+		status = otlpStatus{Code: otlpStatusCodeError, Message: span.Error}
+	}
+
+	return otlpSpan{
+		TraceID:           span.TraceID,
+		SpanID:            span.SpanID,
+		ParentSpanID:      span.ParentSpanID,
+		Name:              span.Name,
+		Kind:              otlpSpanKindInternal,
+		StartTimeUnixNano: strconv.FormatInt(span.StartTime.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.EndTime.UnixNano(), 10),
+		Attributes:        attributes,
+		Status:            status,
+	}
+}
+
+func attribute(key, value string) otlpAttribute {
+	return otlpAttribute{Key: key, Value: otlpAnyValue{StringValue: value}}
+}
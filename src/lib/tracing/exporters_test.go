@@ -0,0 +1,152 @@
+package tracing_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"lib/tracing"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/tedsuo/ifrit"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Exporters", func() {
+	var span tracing.SpanData
+
+	BeforeEach(func() {
+		sc, err := tracing.ParseTraceparent("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+		Expect(err).NotTo(HaveOccurred())
+		span = tracing.SpanData{
+			Name:         "cc.GetAppSpaces",
+			Service:      "policy-server",
+			TraceID:      sc.TraceID,
+			SpanID:       tracing.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+			ParentSpanID: sc.SpanID,
+			StartTime:    time.Unix(1500000000, 0).UTC(),
+			EndTime:      time.Unix(1500000001, 0).UTC(),
+			Attributes:   map[string]string{"some-key": "some-value"},
+			Error:        "banana",
+		}
+	})
+
+	Describe("WriterExporter", func() {
+		It("writes each span as a line of JSON", func() {
+			buffer := &bytes.Buffer{}
+			exporter := &tracing.WriterExporter{Writer: buffer}
+			exporter.ExportSpan(span)
+
+			Expect(buffer.String()).To(HaveSuffix("\n"))
+			Expect(buffer.String()).To(MatchJSON(`{
+				"name": "cc.GetAppSpaces",
+				"service": "policy-server",
+				"trace_id": "0af7651916cd43dd8448eb211c80319c",
+				"span_id": "0102030405060708",
+				"parent_span_id": "b7ad6b7169203331",
+				"start_time": "2017-07-14T02:40:00Z",
+				"end_time": "2017-07-14T02:40:01Z",
+				"attributes": {"some-key": "some-value"},
+				"error": "banana"
+			}`))
+		})
+	})
+
+	Describe("CollectorExporter", func() {
+		var (
+			server   *httptest.Server
+			bodies   chan []byte
+			exporter *tracing.CollectorExporter
+			logger   *lagertest.TestLogger
+		)
+
+		BeforeEach(func() {
+			bodies = make(chan []byte, 10)
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				defer GinkgoRecover()
+				Expect(req.URL.Path).To(Equal("/v1/traces"))
+				Expect(req.Header.Get("Content-Type")).To(Equal("application/json"))
+				body, err := ioutil.ReadAll(req.Body)
+				Expect(err).NotTo(HaveOccurred())
+				bodies <- body
+			}))
+
+			logger = lagertest.NewTestLogger("test")
+			exporter = tracing.NewCollectorExporter(logger, http.DefaultClient, server.URL+"/v1/traces")
+			exporter.BatchSize = 2
+			exporter.BatchInterval = time.Hour
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("sends full batches in the OTLP JSON encoding", func() {
+			process := ifrit.Invoke(exporter)
+			defer func() {
+				process.Signal(os.Interrupt)
+				Eventually(process.Wait()).Should(Receive())
+			}()
+
+			exporter.ExportSpan(span)
+			Consistently(bodies).ShouldNot(Receive())
+
+			exporter.ExportSpan(span)
+			var body []byte
+			Eventually(bodies).Should(Receive(&body))
+
+			var request map[string]interface{}
+			Expect(json.Unmarshal(body, &request)).To(Succeed())
+			spanJSON, err := json.Marshal(request["resourceSpans"].([]interface{})[0].(map[string]interface{})["scopeSpans"].([]interface{})[0].(map[string]interface{})["spans"].([]interface{})[0])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spanJSON).To(MatchJSON(`{
+				"traceId": "0af7651916cd43dd8448eb211c80319c",
+				"spanId": "0102030405060708",
+				"parentSpanId": "b7ad6b7169203331",
+				"name": "cc.GetAppSpaces",
+				"kind": 1,
+				"startTimeUnixNano": "1500000000000000000",
+				"endTimeUnixNano": "1500000001000000000",
+				"attributes": [{"key": "some-key", "value": {"stringValue": "some-value"}}],
+				"status": {"code": 2, "message": "banana"}
+			}`))
+
+			resourceJSON, err := json.Marshal(request["resourceSpans"].([]interface{})[0].(map[string]interface{})["resource"])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resourceJSON).To(MatchJSON(`{"attributes": [{"key": "service.name", "value": {"stringValue": "policy-server"}}]}`))
+		})
+
+		It("sends the queued spans when signaled", func() {
+			process := ifrit.Invoke(exporter)
+			exporter.ExportSpan(span)
+
+			process.Signal(os.Interrupt)
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+			Expect(bodies).To(Receive())
+		})
+
+		Context("when the collector fails", func() {
+			BeforeEach(func() {
+				server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+					w.WriteHeader(http.StatusServiceUnavailable)
+				})
+			})
+
+			It("logs the error", func() {
+				process := ifrit.Invoke(exporter)
+				exporter.ExportSpan(span)
+				exporter.ExportSpan(span)
+
+				Eventually(logger).Should(gbytes.Say("sending-spans.*collector responded with 503"))
+				process.Signal(os.Interrupt)
+				Eventually(process.Wait()).Should(Receive())
+			})
+		})
+	})
+})
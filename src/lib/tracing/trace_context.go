@@ -0,0 +1,167 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// TraceparentHeader is the W3C trace context header.
+const TraceparentHeader = "traceparent"
+
+type TraceID [16]byte
+
+type SpanID [8]byte
+
+func (id TraceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+func (id TraceID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+func (id SpanID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+func (id SpanID) MarshalText() ([]byte, error) {
+	if id == (SpanID{}) {
+		return []byte{}, nil
+	}
+	return []byte(id.String()), nil
+}
+
+// SpanContext identifies a span within a trace, as carried by the
+// traceparent header.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+}
+
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != TraceID{} && sc.SpanID != SpanID{}
+}
+
+// Traceparent formats sc as a version 00 traceparent header value.
+func (sc SpanContext) Traceparent() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return fmt.Sprintf("00-%s-%s-%s", sc.TraceID, sc.SpanID, flags)
+}
+
+// ParseTraceparent parses a traceparent header value. Values with a version
+// other than 00 are read as far as version 00 defines them.
+func ParseTraceparent(value string) (SpanContext, error) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 {
+		return SpanContext{}, errors.New("traceparent: expected 4 fields")
+	}
+	version, traceID, spanID, flags := parts[0], parts[1], parts[2], parts[3]
+	if len(version) != 2 || version == "ff" || (version == "00" && len(parts) != 4) {
+		return SpanContext{}, fmt.Errorf("traceparent: unsupported version %q", version)
+	}
+
+	var sc SpanContext
+	if err := decodeHex(traceID, sc.TraceID[:]); err != nil {
+		return SpanContext{}, fmt.Errorf("traceparent: trace id: %s", err)
+	}
+	if err := decodeHex(spanID, sc.SpanID[:]); err != nil {
+		return SpanContext{}, fmt.Errorf("traceparent: span id: %s", err)
+	}
+	var flagBytes [1]byte
+	if err := decodeHex(flags, flagBytes[:]); err != nil {
+		return SpanContext{}, fmt.Errorf("traceparent: flags: %s", err)
+	}
+	sc.Sampled = flagBytes[0]&1 == 1
+
+	if !sc.IsValid() {
+		return SpanContext{}, errors.New("traceparent: ids must not be all zero")
+	}
+	return sc, nil
+}
+
+func decodeHex(value string, dst []byte) error {
+	if len(value) != hex.EncodedLen(len(dst)) || strings.ToLower(value) != value {
+		return fmt.Errorf("expected %d lowercase hex digits", hex.EncodedLen(len(dst)))
+	}
+	_, err := hex.Decode(dst, []byte(value))
+	return err
+}
+
+func newTraceID() TraceID {
+	var id TraceID
+	rand.Read(id[:])
+	return id
+}
+
+func newSpanID() SpanID {
+	var id SpanID
+	rand.Read(id[:])
+	return id
+}
+
+type contextKey struct{}
+
+// ContextWithSpanContext returns a copy of ctx in which spans started are
+// children of sc.
+func ContextWithSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, contextKey{}, sc)
+}
+
+// SpanContextFromContext returns the span context in ctx, which is invalid
+// when there is none.
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	if ctx == nil {
+		return SpanContext{}
+	}
+	sc, _ := ctx.Value(contextKey{}).(SpanContext)
+	return sc
+}
+
+// Inject sets the traceparent header from the span context in ctx, if any.
+func Inject(ctx context.Context, header http.Header) {
+	sc := SpanContextFromContext(ctx)
+	if sc.IsValid() {
+		header.Set(TraceparentHeader, sc.Traceparent())
+	}
+}
+
+// Extract returns a copy of ctx with the span context of the traceparent
+// header. A missing or malformed header leaves ctx as it is, so that a new
+// trace is started.
+func Extract(ctx context.Context, header http.Header) context.Context {
+	sc, err := ParseTraceparent(header.Get(TraceparentHeader))
+	if err != nil {
+		return ctx
+	}
+	return ContextWithSpanContext(ctx, sc)
+}
+
+type httpClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// HTTPClient sets the traceparent header on requests it sends. The span
+// context is taken from the request, or from Context for clients, such as the
+// json_client, that build requests without one.
+type HTTPClient struct {
+	Client  httpClient
+	Context context.Context
+}
+
+func (c *HTTPClient) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if !SpanContextFromContext(ctx).IsValid() && c.Context != nil {
+		ctx = c.Context
+	}
+	Inject(ctx, req.Header)
+	return c.Client.Do(req)
+}
@@ -0,0 +1,128 @@
+package tracing_test
+
+import (
+	"context"
+	"lib/tracing"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Trace context", func() {
+	const traceparent = "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+
+	Describe("ParseTraceparent", func() {
+		It("parses the trace id, parent id and sampled flag", func() {
+			sc, err := tracing.ParseTraceparent(traceparent)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sc.TraceID.String()).To(Equal("0af7651916cd43dd8448eb211c80319c"))
+			Expect(sc.SpanID.String()).To(Equal("b7ad6b7169203331"))
+			Expect(sc.Sampled).To(BeTrue())
+			Expect(sc.Traceparent()).To(Equal(traceparent))
+		})
+
+		It("reads the fields it knows of later versions", func() {
+			sc, err := tracing.ParseTraceparent("01-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-00-extra")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sc.Sampled).To(BeFalse())
+		})
+
+		DescribeTable("rejects malformed values",
+			func(value, message string) {
+				_, err := tracing.ParseTraceparent(value)
+				Expect(err).To(MatchError(ContainSubstring(message)))
+			},
+			Entry("too few fields", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331", "expected 4 fields"),
+			Entry("extra fields in version 00", traceparent+"-extra", "unsupported version"),
+			Entry("invalid version", "ff-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01", "unsupported version"),
+			Entry("short trace id", "00-0af7651916cd43dd-b7ad6b7169203331-01", "trace id"),
+			Entry("uppercase span id", "00-0af7651916cd43dd8448eb211c80319c-B7AD6B7169203331-01", "span id"),
+			Entry("bad flags", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-zz", "flags"),
+			Entry("zero trace id", "00-00000000000000000000000000000000-b7ad6b7169203331-01", "all zero"),
+		)
+	})
+
+	Describe("Inject and Extract", func() {
+		It("carries the span context in the traceparent header", func() {
+			header := http.Header{}
+			header.Set("traceparent", traceparent)
+			ctx := tracing.Extract(context.Background(), header)
+
+			outgoing := http.Header{}
+			tracing.Inject(ctx, outgoing)
+			Expect(outgoing.Get("traceparent")).To(Equal(traceparent))
+		})
+
+		It("ignores a malformed header", func() {
+			header := http.Header{}
+			header.Set("traceparent", "banana")
+			ctx := tracing.Extract(context.Background(), header)
+			Expect(tracing.SpanContextFromContext(ctx).IsValid()).To(BeFalse())
+
+			outgoing := http.Header{}
+			tracing.Inject(ctx, outgoing)
+			Expect(outgoing).To(BeEmpty())
+		})
+	})
+
+	Describe("HTTPClient", func() {
+		var (
+			sc        tracing.SpanContext
+			requests  []*http.Request
+			client    *tracing.HTTPClient
+			doRequest func(*http.Request) (*http.Response, error)
+		)
+
+		BeforeEach(func() {
+			var err error
+			sc, err = tracing.ParseTraceparent(traceparent)
+			Expect(err).NotTo(HaveOccurred())
+
+			requests = nil
+			doRequest = func(req *http.Request) (*http.Response, error) {
+				requests = append(requests, req)
+				return &http.Response{StatusCode: http.StatusOK}, nil
+			}
+			client = &tracing.HTTPClient{Client: doerFunc(func(req *http.Request) (*http.Response, error) {
+				return doRequest(req)
+			})}
+		})
+
+		It("sets the traceparent of the request's context", func() {
+			req, err := http.NewRequest("GET", "http://example.com", nil)
+			Expect(err).NotTo(HaveOccurred())
+			req = req.WithContext(tracing.ContextWithSpanContext(context.Background(), sc))
+
+			_, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requests[0].Header.Get("traceparent")).To(Equal(traceparent))
+		})
+
+		It("falls back to the traceparent of its Context", func() {
+			client.Context = tracing.ContextWithSpanContext(context.Background(), sc)
+			req, err := http.NewRequest("GET", "http://example.com", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requests[0].Header.Get("traceparent")).To(Equal(traceparent))
+		})
+
+		It("sends requests without a span context as they are", func() {
+			req, err := http.NewRequest("GET", "http://example.com", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requests[0].Header).NotTo(HaveKey("Traceparent"))
+		})
+	})
+})
+
+type doerFunc func(*http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
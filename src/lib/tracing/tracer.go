@@ -0,0 +1,173 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// SpanData is a finished span, as given to an Exporter.
+type SpanData struct {
+	Name         string            `json:"name"`
+	Service      string            `json:"service"`
+	TraceID      TraceID           `json:"trace_id"`
+	SpanID       SpanID            `json:"span_id"`
+	ParentSpanID SpanID            `json:"parent_span_id"`
+	StartTime    time.Time         `json:"start_time"`
+	EndTime      time.Time         `json:"end_time"`
+	Attributes   map[string]string `json:"attributes,omitempty"`
+	Error        string            `json:"error,omitempty"`
+}
+
+//go:generate counterfeiter -o ../fakes/span_exporter.go --fake-name SpanExporter . Exporter
+type Exporter interface {
+	ExportSpan(SpanData)
+}
+
+// Tracer starts spans and gives them to Exporter when they end. A nil Tracer,
+// or one without an Exporter, starts no spans, and the nil spans it returns
+// may be used as usual.
+type Tracer struct {
+	Service  string
+	Exporter Exporter
+	Now      func() time.Time
+}
+
+// Start starts a span that is a child of the span context in ctx, or the root
+// of a new trace when there is none. The returned context carries the new
+// span.
+func (t *Tracer) Start(ctx context.Context, name string) (context.Context, *Span) {
+	if t == nil || t.Exporter == nil {
+		return ctx, nil
+	}
+
+	parent := SpanContextFromContext(ctx)
+	span := &Span{
+		tracer: t,
+		data: SpanData{
+			Name:      name,
+			Service:   t.Service,
+			StartTime: t.now(),
+		},
+		sampled: true,
+	}
+	if parent.IsValid() {
+		span.data.TraceID = parent.TraceID
+		span.data.ParentSpanID = parent.SpanID
+		span.sampled = parent.Sampled
+	} else {
+		span.data.TraceID = newTraceID()
+	}
+	span.data.SpanID = newSpanID()
+
+	return ContextWithSpanContext(ctx, span.SpanContext()), span
+}
+
+// Wrap runs handle in a span named name. Requests that are not already in a
+// span continue the trace in their traceparent header.
+func (t *Tracer) Wrap(name string, handle http.Handler) http.Handler {
+	if t == nil || t.Exporter == nil {
+		return handle
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		if !SpanContextFromContext(ctx).IsValid() {
+			ctx = Extract(ctx, req.Header)
+		}
+		ctx, span := t.Start(ctx, name)
+		span.SetAttribute("http.method", req.Method)
+		span.SetAttribute("http.target", req.URL.Path)
+
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		handle.ServeHTTP(recorder, req.WithContext(ctx))
+
+		span.SetAttribute("http.status_code", strconv.Itoa(recorder.status))
+		var err error
+		if recorder.status >= http.StatusInternalServerError {
+			err = errors.New(http.StatusText(recorder.status))
+		}
+		span.End(err)
+	})
+}
+
+func (t *Tracer) now() time.Time {
+	if t.Now != nil {
+		return t.Now()
+	}
+	return time.Now()
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Span is a timed operation within a trace. The methods of a nil Span do
+// nothing.
+type Span struct {
+	tracer  *Tracer
+	sampled bool
+
+	mutex sync.Mutex
+	data  SpanData
+	ended bool
+}
+
+func (s *Span) SpanContext() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return SpanContext{TraceID: s.data.TraceID, SpanID: s.data.SpanID, Sampled: s.sampled}
+}
+
+func (s *Span) SetAttribute(key, value string) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.ended {
+		return
+	}
+	if s.data.Attributes == nil {
+		s.data.Attributes = map[string]string{}
+	}
+	s.data.Attributes[key] = value
+}
+
+// End ends the span, recording err if it is not nil, and exports it if it is
+// sampled. Only the first call has any effect.
+func (s *Span) End(err error) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	if s.ended {
+		s.mutex.Unlock()
+		return
+	}
+	s.ended = true
+	s.data.EndTime = s.tracer.now()
+	if err != nil {
+		s.data.Error = err.Error()
+	}
+	data := s.data
+	s.mutex.Unlock()
+
+	if s.sampled {
+		s.tracer.Exporter.ExportSpan(data)
+	}
+}
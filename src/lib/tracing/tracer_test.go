@@ -0,0 +1,159 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"lib/fakes"
+	"lib/tracing"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tracer", func() {
+	var (
+		tracer       *tracing.Tracer
+		fakeExporter *fakes.SpanExporter
+		now          time.Time
+	)
+
+	BeforeEach(func() {
+		now = time.Unix(1500000000, 0)
+		fakeExporter = &fakes.SpanExporter{}
+		tracer = &tracing.Tracer{
+			Service:  "some-service",
+			Exporter: fakeExporter,
+			Now: func() time.Time {
+				now = now.Add(time.Millisecond)
+				return now
+			},
+		}
+	})
+
+	Describe("Start", func() {
+		It("starts a new trace when the context has no span", func() {
+			ctx, span := tracer.Start(context.Background(), "some-span")
+			Expect(tracing.SpanContextFromContext(ctx)).To(Equal(span.SpanContext()))
+			Expect(span.SpanContext().IsValid()).To(BeTrue())
+			Expect(span.SpanContext().Sampled).To(BeTrue())
+
+			span.SetAttribute("some-key", "some-value")
+			span.End(nil)
+
+			Expect(fakeExporter.ExportSpanCallCount()).To(Equal(1))
+			data := fakeExporter.ExportSpanArgsForCall(0)
+			Expect(data.Name).To(Equal("some-span"))
+			Expect(data.Service).To(Equal("some-service"))
+			Expect(data.TraceID).To(Equal(span.SpanContext().TraceID))
+			Expect(data.ParentSpanID).To(Equal(tracing.SpanID{}))
+			Expect(data.EndTime.Sub(data.StartTime)).To(Equal(time.Millisecond))
+			Expect(data.Attributes).To(Equal(map[string]string{"some-key": "some-value"}))
+			Expect(data.Error).To(BeEmpty())
+		})
+
+		It("starts children of the span in the context", func() {
+			ctx, parent := tracer.Start(context.Background(), "parent")
+			_, child := tracer.Start(ctx, "child")
+			child.End(errors.New("banana"))
+
+			data := fakeExporter.ExportSpanArgsForCall(0)
+			Expect(data.TraceID).To(Equal(parent.SpanContext().TraceID))
+			Expect(data.ParentSpanID).To(Equal(parent.SpanContext().SpanID))
+			Expect(data.SpanID).NotTo(Equal(parent.SpanContext().SpanID))
+			Expect(data.Error).To(Equal("banana"))
+		})
+
+		It("exports each span once", func() {
+			_, span := tracer.Start(context.Background(), "some-span")
+			span.End(nil)
+			span.End(nil)
+			Expect(fakeExporter.ExportSpanCallCount()).To(Equal(1))
+		})
+
+		Context("when the parent is not sampled", func() {
+			It("does not export the span", func() {
+				sc, err := tracing.ParseTraceparent("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-00")
+				Expect(err).NotTo(HaveOccurred())
+
+				ctx, span := tracer.Start(tracing.ContextWithSpanContext(context.Background(), sc), "some-span")
+				span.End(nil)
+				Expect(fakeExporter.ExportSpanCallCount()).To(Equal(0))
+				Expect(tracing.SpanContextFromContext(ctx).TraceID).To(Equal(sc.TraceID))
+			})
+		})
+
+		Context("when the tracer is nil", func() {
+			It("returns a nil span that can be used", func() {
+				var nilTracer *tracing.Tracer
+				ctx, span := nilTracer.Start(context.Background(), "some-span")
+				Expect(span).To(BeNil())
+				Expect(ctx).To(Equal(context.Background()))
+
+				span.SetAttribute("some-key", "some-value")
+				span.End(errors.New("banana"))
+			})
+		})
+	})
+
+	Describe("Wrap", func() {
+		var (
+			handlerContext context.Context
+			status         int
+			wrapped        http.Handler
+		)
+
+		BeforeEach(func() {
+			status = http.StatusOK
+			wrapped = tracer.Wrap("some-route", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				handlerContext = req.Context()
+				w.WriteHeader(status)
+			}))
+		})
+
+		It("runs the handler in a span that continues the trace in the traceparent header", func() {
+			request, err := http.NewRequest("POST", "/networking/v1/external/policies", nil)
+			Expect(err).NotTo(HaveOccurred())
+			request.Header.Set("traceparent", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+
+			wrapped.ServeHTTP(httptest.NewRecorder(), request)
+
+			data := fakeExporter.ExportSpanArgsForCall(0)
+			Expect(data.Name).To(Equal("some-route"))
+			Expect(data.TraceID.String()).To(Equal("0af7651916cd43dd8448eb211c80319c"))
+			Expect(data.ParentSpanID.String()).To(Equal("b7ad6b7169203331"))
+			Expect(data.Attributes).To(Equal(map[string]string{
+				"http.method":      "POST",
+				"http.target":      "/networking/v1/external/policies",
+				"http.status_code": "200",
+			}))
+			Expect(tracing.SpanContextFromContext(handlerContext).SpanID).To(Equal(data.SpanID))
+		})
+
+		It("records server errors", func() {
+			status = http.StatusInternalServerError
+			request, err := http.NewRequest("GET", "/", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			wrapped.ServeHTTP(httptest.NewRecorder(), request)
+
+			data := fakeExporter.ExportSpanArgsForCall(0)
+			Expect(data.Attributes["http.status_code"]).To(Equal("500"))
+			Expect(data.Error).To(Equal("Internal Server Error"))
+		})
+
+		It("does not take the traceparent header of requests already in a span", func() {
+			ctx, parent := tracer.Start(context.Background(), "parent")
+			request, err := http.NewRequest("GET", "/", nil)
+			Expect(err).NotTo(HaveOccurred())
+			request.Header.Set("traceparent", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+
+			wrapped.ServeHTTP(httptest.NewRecorder(), request.WithContext(ctx))
+
+			data := fakeExporter.ExportSpanArgsForCall(0)
+			Expect(data.ParentSpanID).To(Equal(parent.SpanContext().SpanID))
+		})
+	})
+})
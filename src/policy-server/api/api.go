@@ -1,6 +1,9 @@
 package api
 
-import "policy-server/store"
+import (
+	"io"
+	"policy-server/store"
+)
 
 //go:generate counterfeiter -o fakes/policy_mapper.go --fake-name PolicyMapper . PolicyMapper
 type PolicyMapper interface {
@@ -8,6 +11,12 @@ type PolicyMapper interface {
 	AsBytes([]store.Policy, []store.EgressPolicy) ([]byte, error) // unmarshal
 }
 
+// PolicyStreamMapper is implemented by mappers that can also decode a payload
+// as it is read.
+type PolicyStreamMapper interface {
+	AsStorePolicyFromReader(io.Reader) (store.PolicyCollection, error)
+}
+
 type PoliciesPayload struct {
 	TotalPolicies       int            `json:"total_policies"`
 	Policies            []Policy       `json:"policies"`
@@ -65,6 +74,11 @@ type Tag struct {
 	Type string `json:"type"`
 }
 
+type App struct {
+	Name      string `json:"name"`
+	SpaceGUID string `json:"space_guid"`
+}
+
 type Space struct {
 	Name    string `json:"name"`
 	OrgGUID string `json:"organization_guid"`
@@ -1,8 +1,11 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"policy-server/store"
+	"strings"
 
 	"code.cloudfoundry.org/cf-networking-helpers/marshal"
 )
@@ -28,7 +31,23 @@ func (p *policyMapper) AsStorePolicy(bytes []byte) (store.PolicyCollection, erro
 		return store.PolicyCollection{}, fmt.Errorf("unmarshal json: %s", err)
 	}
 
-	err = p.PayloadValidator.ValidatePayload(payload)
+	return p.asStorePolicyCollection(payload)
+}
+
+// AsStorePolicyFromReader is AsStorePolicy for a payload that is decoded as it
+// is read from r, one policy at a time, so that the raw payload is never held
+// in memory as a whole.
+func (p *policyMapper) AsStorePolicyFromReader(r io.Reader) (store.PolicyCollection, error) {
+	payload, err := decodePayload(json.NewDecoder(r))
+	if err != nil {
+		return store.PolicyCollection{}, fmt.Errorf("unmarshal json: %s", err)
+	}
+
+	return p.asStorePolicyCollection(payload)
+}
+
+func (p *policyMapper) asStorePolicyCollection(payload *PoliciesPayload) (store.PolicyCollection, error) {
+	err := p.PayloadValidator.ValidatePayload(payload)
 	if err != nil {
 		return store.PolicyCollection{}, fmt.Errorf("validate policies: %s", err)
 	}
@@ -49,16 +68,105 @@ func (p *policyMapper) AsStorePolicy(bytes []byte) (store.PolicyCollection, erro
 	}, nil
 }
 
+// decodePayload decodes a PoliciesPayload element by element. Fields other
+// than the policy lists are decoded whole.
+func decodePayload(decoder *json.Decoder) (*PoliciesPayload, error) {
+	payload := &PoliciesPayload{}
+	if err := expectDelim(decoder, '{'); err != nil {
+		return nil, err
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := token.(string)
+
+		// json.Unmarshal matches keys case-insensitively, and so does this.
+		switch strings.ToLower(key) {
+		case "policies":
+			err = decodeArray(decoder, func() {
+				payload.Policies = []Policy{}
+			}, func() error {
+				var policy Policy
+				err := decoder.Decode(&policy)
+				payload.Policies = append(payload.Policies, policy)
+				return err
+			})
+		case "egress_policies":
+			err = decodeArray(decoder, func() {
+				payload.EgressPolicies = []EgressPolicy{}
+			}, func() error {
+				var egressPolicy EgressPolicy
+				err := decoder.Decode(&egressPolicy)
+				payload.EgressPolicies = append(payload.EgressPolicies, egressPolicy)
+				return err
+			})
+		case "total_policies":
+			err = decoder.Decode(&payload.TotalPolicies)
+		case "total_egress_policies":
+			err = decoder.Decode(&payload.TotalEgressPolicies)
+		default:
+			var ignored json.RawMessage
+			err = decoder.Decode(&ignored)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := expectDelim(decoder, '}'); err != nil {
+		return nil, err
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after payload")
+	}
+	return payload, nil
+}
+
+// decodeArray calls start at the start of an array and decodeElement for each
+// of its elements. The array may also be null.
+func decodeArray(decoder *json.Decoder, start func(), decodeElement func() error) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return nil
+	}
+	if token != json.Delim('[') {
+		return fmt.Errorf("expected an array, got %v", token)
+	}
+	start()
+	for decoder.More() {
+		if err := decodeElement(); err != nil {
+			return err
+		}
+	}
+	return expectDelim(decoder, ']')
+}
+
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expected %s, got %v", delim, token)
+	}
+	return nil
+}
+
 func (p *policyMapper) AsBytes(storePolicies []store.Policy, storeEgressPolicies []store.EgressPolicy) ([]byte, error) {
 	// convert store.Policy to api.Policy
 	apiPolicies := make([]Policy, len(storePolicies))
 	for i, policy := range storePolicies {
-		apiPolicies[i] = mapStorePolicy(policy)
+		apiPolicies[i] = MapStorePolicy(policy)
 	}
 
 	apiEgressPolicies := make([]EgressPolicy, len(storeEgressPolicies))
 	for i, egressPolicy := range storeEgressPolicies {
-		apiEgressPolicies[i] = mapStoreEgressPolicy(egressPolicy)
+		apiEgressPolicies[i] = MapStoreEgressPolicy(egressPolicy)
 	}
 
 	// convert api.Policy payload to bytes
@@ -116,7 +224,7 @@ func (p *Policy) asStorePolicy() store.Policy {
 		},
 	}
 }
-func mapStoreEgressPolicy(storeEgressPolicy store.EgressPolicy) EgressPolicy {
+func MapStoreEgressPolicy(storeEgressPolicy store.EgressPolicy) EgressPolicy {
 	firstIPRange := storeEgressPolicy.Destination.IPRanges[0]
 	return EgressPolicy{
 		Source: &EgressSource{
@@ -131,7 +239,7 @@ func mapStoreEgressPolicy(storeEgressPolicy store.EgressPolicy) EgressPolicy {
 		},
 	}
 }
-func mapStorePolicy(storePolicy store.Policy) Policy {
+func MapStorePolicy(storePolicy store.Policy) Policy {
 	return Policy{
 		Source: Source{
 			ID:  storePolicy.Source.ID,
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"policy-server/api"
 	"policy-server/store"
+	"strings"
 
 	"policy-server/api/fakes"
 
@@ -200,6 +201,74 @@ var _ = Describe("ApiPolicyMapper", func() {
 		})
 	})
 
+	Describe("AsStorePolicyFromReader", func() {
+		var streamMapper api.PolicyStreamMapper
+
+		BeforeEach(func() {
+			streamMapper = mapper.(api.PolicyStreamMapper)
+		})
+
+		table.DescribeTable("decodes the payload as AsStorePolicy does", func(payload string) {
+			expected, err := mapper.AsStorePolicy([]byte(payload))
+			Expect(err).NotTo(HaveOccurred())
+
+			policyCollection, err := streamMapper.AsStorePolicyFromReader(strings.NewReader(payload))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policyCollection).To(Equal(expected))
+
+			Expect(fakeValidator.ValidatePayloadCallCount()).To(Equal(2))
+			Expect(fakeValidator.ValidatePayloadArgsForCall(1)).To(Equal(fakeValidator.ValidatePayloadArgsForCall(0)))
+		},
+			table.Entry("c2c and egress policies", `{
+				"total_policies": 2,
+				"policies": [
+					{"source": {"id": "some-src-id"}, "destination": {"id": "some-dst-id", "protocol": "tcp", "ports": {"start": 8080, "end": 9090}}},
+					{"source": {"id": "some-src-id-2"}, "destination": {"id": "some-dst-id-2", "protocol": "udp", "port": 53}}
+				],
+				"egress_policies": [
+					{"source": {"id": "some-src-id"}, "destination": {"protocol": "tcp", "ips": [{"start": "1.2.3.4", "end": "1.2.3.5"}]}}
+				]
+			}`),
+			table.Entry("an empty payload", `{}`),
+			table.Entry("null lists", `{"policies": null, "egress_policies": null}`),
+			table.Entry("unknown fields", `{"something": {"else": [1, 2]}, "policies": []}`),
+			table.Entry("keys in another case", `{"Policies": [{"source": {"id": "some-src-id"}}]}`),
+		)
+
+		Context("when the payload is not valid json", func() {
+			It("wraps and returns an error", func() {
+				_, err := streamMapper.AsStorePolicyFromReader(strings.NewReader(`{"policies": [{"source": `))
+				Expect(err).To(MatchError(HavePrefix("unmarshal json: ")))
+				Expect(fakeValidator.ValidatePayloadCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the policies are not a list", func() {
+			It("wraps and returns an error", func() {
+				_, err := streamMapper.AsStorePolicyFromReader(strings.NewReader(`{"policies": {}}`))
+				Expect(err).To(MatchError("unmarshal json: expected an array, got {"))
+			})
+		})
+
+		Context("when there is data after the payload", func() {
+			It("wraps and returns an error", func() {
+				_, err := streamMapper.AsStorePolicyFromReader(strings.NewReader(`{} {}`))
+				Expect(err).To(MatchError("unmarshal json: unexpected data after payload"))
+			})
+		})
+
+		Context("when a policy includes a validation error", func() {
+			BeforeEach(func() {
+				fakeValidator.ValidatePayloadReturns(errors.New("banana"))
+			})
+
+			It("wraps and returns an error", func() {
+				_, err := streamMapper.AsStorePolicyFromReader(strings.NewReader(`{}`))
+				Expect(err).To(MatchError(errors.New("validate policies: banana")))
+			})
+		})
+	})
+
 	Describe("AsBytes", func() {
 		It("maps a slice of store.Policy to a payload with api.Policy", func() {
 			policies := []store.Policy{
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"fmt"
+	"policy-server/store"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+//go:generate protoc --proto_path=.. --gogo_out=.. api/policies.proto
+
+const ProtobufContentType = "application/x-protobuf"
+
+type protobufPolicyMapper struct{}
+
+// NewProtobufMapper returns a PolicyMapper for the compact protobuf encoding
+// in policies.proto.
+func NewProtobufMapper() PolicyMapper {
+	return &protobufPolicyMapper{}
+}
+
+func (p *protobufPolicyMapper) AsStorePolicy(bytes []byte) (store.PolicyCollection, error) {
+	policies, egressPolicies, err := PoliciesFromProtobuf(bytes)
+	if err != nil {
+		return store.PolicyCollection{}, err
+	}
+
+	var storePolicies []store.Policy
+	for _, policy := range policies {
+		storePolicies = append(storePolicies, policy.asStorePolicy())
+	}
+
+	var storeEgressPolicies []store.EgressPolicy
+	for _, egressPolicy := range egressPolicies {
+		storeEgressPolicies = append(storeEgressPolicies, egressPolicy.asStoreEgressPolicy())
+	}
+
+	return store.PolicyCollection{
+		Policies:       storePolicies,
+		EgressPolicies: storeEgressPolicies,
+	}, nil
+}
+
+func (p *protobufPolicyMapper) AsBytes(storePolicies []store.Policy, storeEgressPolicies []store.EgressPolicy) ([]byte, error) {
+	bytes, err := proto.Marshal(AsPoliciesMessage(storePolicies, storeEgressPolicies))
+	if err != nil {
+		return nil, fmt.Errorf("marshal protobuf: %s", err)
+	}
+	return bytes, nil
+}
+
+func AsPoliciesMessage(storePolicies []store.Policy, storeEgressPolicies []store.EgressPolicy) *PoliciesMessage {
+	message := &PoliciesMessage{
+		Policies:       make([]*PolicyMessage, len(storePolicies)),
+		EgressPolicies: make([]*EgressPolicyMessage, len(storeEgressPolicies)),
+	}
+
+	for i, policy := range storePolicies {
+		message.Policies[i] = &PolicyMessage{
+			SourceId:       policy.Source.ID,
+			SourceTag:      policy.Source.Tag,
+			DestinationId:  policy.Destination.ID,
+			DestinationTag: policy.Destination.Tag,
+			Protocol:       policy.Destination.Protocol,
+			StartPort:      int32(policy.Destination.Ports.Start),
+			EndPort:        int32(policy.Destination.Ports.End),
+		}
+	}
+
+	for i, egressPolicy := range storeEgressPolicies {
+		ipRanges := make([]*IPRangeMessage, len(egressPolicy.Destination.IPRanges))
+		for j, ipRange := range egressPolicy.Destination.IPRanges {
+			ipRanges[j] = &IPRangeMessage{Start: ipRange.Start, End: ipRange.End}
+		}
+		message.EgressPolicies[i] = &EgressPolicyMessage{
+			SourceId: egressPolicy.Source.ID,
+			Protocol: egressPolicy.Destination.Protocol,
+			IpRanges: ipRanges,
+		}
+	}
+
+	return message
+}
+
+// PoliciesFromProtobuf decodes a response in the protobuf encoding into the
+// same types as the JSON response.
+func PoliciesFromProtobuf(bytes []byte) ([]Policy, []EgressPolicy, error) {
+	message := &PoliciesMessage{}
+	err := proto.Unmarshal(bytes, message)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unmarshal protobuf: %s", err)
+	}
+
+	policies, egressPolicies := PoliciesFromMessage(message.Policies, message.EgressPolicies)
+	return policies, egressPolicies, nil
+}
+
+func PoliciesFromMessage(policyMessages []*PolicyMessage, egressPolicyMessages []*EgressPolicyMessage) ([]Policy, []EgressPolicy) {
+	policies := make([]Policy, len(policyMessages))
+	for i, policy := range policyMessages {
+		policies[i] = Policy{
+			Source: Source{
+				ID:  policy.SourceId,
+				Tag: policy.SourceTag,
+			},
+			Destination: Destination{
+				ID:       policy.DestinationId,
+				Tag:      policy.DestinationTag,
+				Protocol: policy.Protocol,
+				Ports: Ports{
+					Start: int(policy.StartPort),
+					End:   int(policy.EndPort),
+				},
+			},
+		}
+	}
+
+	egressPolicies := make([]EgressPolicy, len(egressPolicyMessages))
+	for i, egressPolicy := range egressPolicyMessages {
+		ipRanges := make([]IPRange, len(egressPolicy.IpRanges))
+		for j, ipRange := range egressPolicy.IpRanges {
+			ipRanges[j] = IPRange{Start: ipRange.Start, End: ipRange.End}
+		}
+		egressPolicies[i] = EgressPolicy{
+			Source: &EgressSource{ID: egressPolicy.SourceId},
+			Destination: &EgressDestination{
+				Protocol: egressPolicy.Protocol,
+				IPRanges: ipRanges,
+			},
+		}
+	}
+
+	return policies, egressPolicies
+}
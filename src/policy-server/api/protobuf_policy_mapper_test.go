@@ -0,0 +1,111 @@
+package api_test
+
+import (
+	"policy-server/api"
+	"policy-server/store"
+
+	"github.com/gogo/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ProtobufPolicyMapper", func() {
+	var (
+		mapper         api.PolicyMapper
+		storePolicies  []store.Policy
+		egressPolicies []store.EgressPolicy
+	)
+
+	BeforeEach(func() {
+		mapper = api.NewProtobufMapper()
+		storePolicies = []store.Policy{{
+			Source: store.Source{ID: "some-src-id", Tag: "0001"},
+			Destination: store.Destination{
+				ID:       "some-dst-id",
+				Tag:      "0002",
+				Protocol: "tcp",
+				Port:     8080,
+				Ports:    store.Ports{Start: 8080, End: 8080},
+			},
+		}, {
+			Source: store.Source{ID: "some-src-id-2", Tag: "0003"},
+			Destination: store.Destination{
+				ID:       "some-dst-id-2",
+				Tag:      "0004",
+				Protocol: "udp",
+				Ports:    store.Ports{Start: 1000, End: 2000},
+			},
+		}}
+		egressPolicies = []store.EgressPolicy{{
+			Source: store.EgressSource{ID: "some-egress-src-id"},
+			Destination: store.EgressDestination{
+				Protocol: "tcp",
+				IPRanges: []store.IPRange{{Start: "1.2.3.4", End: "1.2.3.5"}},
+			},
+		}}
+	})
+
+	Describe("AsBytes", func() {
+		It("encodes the policies as a Policies message", func() {
+			bytes, err := mapper.AsBytes(storePolicies, egressPolicies)
+			Expect(err).NotTo(HaveOccurred())
+
+			message := &api.PoliciesMessage{}
+			Expect(proto.Unmarshal(bytes, message)).To(Succeed())
+			Expect(message.Policies).To(Equal([]*api.PolicyMessage{{
+				SourceId:       "some-src-id",
+				SourceTag:      "0001",
+				DestinationId:  "some-dst-id",
+				DestinationTag: "0002",
+				Protocol:       "tcp",
+				StartPort:      8080,
+				EndPort:        8080,
+			}, {
+				SourceId:       "some-src-id-2",
+				SourceTag:      "0003",
+				DestinationId:  "some-dst-id-2",
+				DestinationTag: "0004",
+				Protocol:       "udp",
+				StartPort:      1000,
+				EndPort:        2000,
+			}}))
+			Expect(message.EgressPolicies).To(Equal([]*api.EgressPolicyMessage{{
+				SourceId: "some-egress-src-id",
+				Protocol: "tcp",
+				IpRanges: []*api.IPRangeMessage{{Start: "1.2.3.4", End: "1.2.3.5"}},
+			}}))
+		})
+	})
+
+	Describe("AsStorePolicy", func() {
+		It("decodes what AsBytes encodes", func() {
+			bytes, err := mapper.AsBytes(storePolicies, egressPolicies)
+			Expect(err).NotTo(HaveOccurred())
+
+			collection, err := mapper.AsStorePolicy(bytes)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(collection.Policies).To(Equal(storePolicies))
+			Expect(collection.EgressPolicies).To(Equal(egressPolicies))
+		})
+
+		Context("when the bytes are not a Policies message", func() {
+			It("returns an error", func() {
+				_, err := mapper.AsStorePolicy([]byte{0xff, 0xff})
+				Expect(err).To(MatchError(HavePrefix("unmarshal protobuf:")))
+			})
+		})
+	})
+
+	Describe("PoliciesFromProtobuf", func() {
+		It("decodes into the api types", func() {
+			bytes, err := mapper.AsBytes(storePolicies, egressPolicies)
+			Expect(err).NotTo(HaveOccurred())
+
+			policies, apiEgressPolicies, err := api.PoliciesFromProtobuf(bytes)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policies[0]).To(Equal(api.MapStorePolicy(storePolicies[0])))
+			Expect(policies[1]).To(Equal(api.MapStorePolicy(storePolicies[1])))
+			Expect(apiEgressPolicies).To(Equal([]api.EgressPolicy{api.MapStoreEgressPolicy(egressPolicies[0])}))
+		})
+	})
+})
@@ -0,0 +1,287 @@
+package cc_client
+
+import (
+	"fmt"
+	"policy-server/api"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:generate counterfeiter -o fakes/cc_client.go --fake-name CCClient . ccClient
+type ccClient interface {
+	GetAllAppGUIDs(token string) (map[string]struct{}, error)
+	GetLiveAppGUIDs(token string, appGUIDs []string) (map[string]struct{}, error)
+	GetAppSpaces(token string, appGUIDs []string) (map[string]string, error)
+	GetApps(token string, appGUIDs []string) (map[string]api.App, error)
+	GetSpace(token, spaceGUID string) (*api.Space, error)
+	GetUserSpace(token, userGUID string, space api.Space, roles []string) (*api.Space, error)
+	GetUserSpaces(token, userGUID string, roles []string) (map[string]struct{}, error)
+}
+
+//go:generate counterfeiter -o fakes/metrics_sender.go --fake-name MetricsSender . metricsSender
+type metricsSender interface {
+	IncrementCounter(string)
+	SendDuration(string, time.Duration)
+}
+
+// CachingClient caches app to space, space, and user to spaces lookups for
+// TTL. Every caller uses the policy server's own client token, so entries
+// are not keyed by token. When Cloud Controller no longer finds an app or
+// space, the entries that refer to it are dropped.
+//
+// A zero TTL disables caching.
+//
+// Each call increments a <Name>CacheHit counter when it was answered from the
+// cache alone, and a <Name>CacheMiss counter otherwise.
+type CachingClient struct {
+	Client        ccClient
+	TTL           time.Duration
+	MetricsSender metricsSender
+	Now           func() time.Time
+
+	mutex      sync.Mutex
+	appSpaces  map[string]cacheEntry
+	spaces     map[string]cacheEntry
+	userSpace  map[string]cacheEntry
+	userSpaces map[string]cacheEntry
+	nextSweep  time.Time
+}
+
+type cacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+func (c *CachingClient) GetAllAppGUIDs(token string) (map[string]struct{}, error) {
+	return c.Client.GetAllAppGUIDs(token)
+}
+
+// GetLiveAppGUIDs is not cached, since the policy cleaner relies on it being
+// current, but apps it no longer finds are dropped from the cache.
+func (c *CachingClient) GetLiveAppGUIDs(token string, appGUIDs []string) (map[string]struct{}, error) {
+	liveAppGUIDs, err := c.Client.GetLiveAppGUIDs(token, appGUIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, appGUID := range appGUIDs {
+		if _, ok := liveAppGUIDs[appGUID]; !ok {
+			delete(c.appSpaces, appGUID)
+		}
+	}
+	return liveAppGUIDs, nil
+}
+
+func (c *CachingClient) GetApps(token string, appGUIDs []string) (map[string]api.App, error) {
+	return c.Client.GetApps(token, appGUIDs)
+}
+
+func (c *CachingClient) GetAppSpaces(token string, appGUIDs []string) (map[string]string, error) {
+	appSpaces := map[string]string{}
+	missing := []string{}
+
+	c.mutex.Lock()
+	for _, appGUID := range appGUIDs {
+		if spaceGUID, ok := c.get(c.appSpaces, appGUID); ok {
+			appSpaces[appGUID] = spaceGUID.(string)
+		} else {
+			missing = append(missing, appGUID)
+		}
+	}
+	c.mutex.Unlock()
+
+	c.count("CCAppSpace", len(missing) == 0)
+	if len(missing) == 0 {
+		return appSpaces, nil
+	}
+
+	fetched, err := c.Client.GetAppSpaces(token, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.appSpaces = c.ensure(c.appSpaces)
+	for appGUID, spaceGUID := range fetched {
+		c.set(c.appSpaces, appGUID, spaceGUID)
+		appSpaces[appGUID] = spaceGUID
+	}
+	return appSpaces, nil
+}
+
+func (c *CachingClient) GetSpaceGUIDs(token string, appGUIDs []string) ([]string, error) {
+	appSpaces, err := c.GetAppSpaces(token, appGUIDs)
+	if err != nil {
+		return nil, err
+	}
+	return uniqueSpaceGUIDs(appSpaces), nil
+}
+
+func (c *CachingClient) GetSpace(token, spaceGUID string) (*api.Space, error) {
+	c.mutex.Lock()
+	space, ok := c.get(c.spaces, spaceGUID)
+	c.mutex.Unlock()
+	if ok {
+		c.count("CCSpace", true)
+		return space.(*api.Space), nil
+	}
+	c.count("CCSpace", false)
+
+	fetched, err := c.Client.GetSpace(token, spaceGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if fetched == nil {
+		c.forgetSpace(spaceGUID)
+		return nil, nil
+	}
+	c.spaces = c.ensure(c.spaces)
+	c.set(c.spaces, spaceGUID, fetched)
+	return fetched, nil
+}
+
+// GetUserSpace only caches spaces that were found, so a user who has just
+// been given a role in the space does not have to wait for TTL.
+func (c *CachingClient) GetUserSpace(token, userGUID string, space api.Space, roles []string) (*api.Space, error) {
+	key := fmt.Sprintf("%s/%s/%s/%s", userGUID, space.OrgGUID, space.Name, strings.Join(roles, ","))
+
+	c.mutex.Lock()
+	userSpace, ok := c.get(c.userSpace, key)
+	c.mutex.Unlock()
+	if ok {
+		c.count("CCUserSpace", true)
+		return userSpace.(*api.Space), nil
+	}
+	c.count("CCUserSpace", false)
+
+	fetched, err := c.Client.GetUserSpace(token, userGUID, space, roles)
+	if err != nil || fetched == nil {
+		return fetched, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.userSpace = c.ensure(c.userSpace)
+	c.set(c.userSpace, key, fetched)
+	return fetched, nil
+}
+
+func (c *CachingClient) GetUserSpaces(token, userGUID string, roles []string) (map[string]struct{}, error) {
+	key := fmt.Sprintf("%s/%s", userGUID, strings.Join(roles, ","))
+
+	c.mutex.Lock()
+	userSpaces, ok := c.get(c.userSpaces, key)
+	c.mutex.Unlock()
+	if ok {
+		c.count("CCUserSpaces", true)
+		return copySet(userSpaces.(map[string]struct{})), nil
+	}
+	c.count("CCUserSpaces", false)
+
+	fetched, err := c.Client.GetUserSpaces(token, userGUID, roles)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.userSpaces = c.ensure(c.userSpaces)
+	c.set(c.userSpaces, key, copySet(fetched))
+	return fetched, nil
+}
+
+// forgetSpace drops a space that Cloud Controller no longer finds, along with
+// the apps cached as being in it.
+func (c *CachingClient) forgetSpace(spaceGUID string) {
+	delete(c.spaces, spaceGUID)
+	for appGUID, entry := range c.appSpaces {
+		if entry.value.(string) == spaceGUID {
+			delete(c.appSpaces, appGUID)
+		}
+	}
+}
+
+func (c *CachingClient) get(cache map[string]cacheEntry, key string) (interface{}, bool) {
+	entry, ok := cache[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.expires) {
+		delete(cache, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// set stores a value and, at most once per TTL, sweeps expired entries so
+// that keys which are never looked up again do not accumulate.
+func (c *CachingClient) set(cache map[string]cacheEntry, key string, value interface{}) {
+	if c.TTL <= 0 {
+		return
+	}
+
+	now := c.now()
+	cache[key] = cacheEntry{value: value, expires: now.Add(c.TTL)}
+
+	if now.Before(c.nextSweep) {
+		return
+	}
+	for _, m := range []map[string]cacheEntry{c.appSpaces, c.spaces, c.userSpace, c.userSpaces} {
+		for k, entry := range m {
+			if !now.Before(entry.expires) {
+				delete(m, k)
+			}
+		}
+	}
+	c.nextSweep = now.Add(c.TTL)
+}
+
+func (c *CachingClient) ensure(cache map[string]cacheEntry) map[string]cacheEntry {
+	if cache == nil {
+		return map[string]cacheEntry{}
+	}
+	return cache
+}
+
+func (c *CachingClient) count(name string, hit bool) {
+	if hit {
+		c.MetricsSender.IncrementCounter(name + "CacheHit")
+	} else {
+		c.MetricsSender.IncrementCounter(name + "CacheMiss")
+	}
+}
+
+func (c *CachingClient) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+	return time.Now()
+}
+
+func uniqueSpaceGUIDs(appSpaces map[string]string) []string {
+	deduplicated := map[string]struct{}{}
+	for _, spaceID := range appSpaces {
+		deduplicated[spaceID] = struct{}{}
+	}
+
+	ret := []string{}
+	for spaceID := range deduplicated {
+		ret = append(ret, spaceID)
+	}
+	return ret
+}
+
+func copySet(set map[string]struct{}) map[string]struct{} {
+	copied := make(map[string]struct{}, len(set))
+	for k := range set {
+		copied[k] = struct{}{}
+	}
+	return copied
+}
@@ -0,0 +1,255 @@
+package cc_client_test
+
+import (
+	"errors"
+	"policy-server/api"
+	"policy-server/cc_client"
+	"policy-server/cc_client/fakes"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CachingClient", func() {
+	var (
+		client            *cc_client.CachingClient
+		fakeCCClient      *fakes.CCClient
+		fakeMetricsSender *fakes.MetricsSender
+		now               time.Time
+		roles             []string
+	)
+
+	BeforeEach(func() {
+		fakeCCClient = &fakes.CCClient{}
+		fakeMetricsSender = &fakes.MetricsSender{}
+		now = time.Unix(1500000000, 0)
+		roles = []string{cc_client.RoleSpaceDeveloper}
+		client = &cc_client.CachingClient{
+			Client:        fakeCCClient,
+			TTL:           time.Minute,
+			MetricsSender: fakeMetricsSender,
+			Now:           func() time.Time { return now },
+		}
+	})
+
+	counters := func() []string {
+		names := []string{}
+		for i := 0; i < fakeMetricsSender.IncrementCounterCallCount(); i++ {
+			names = append(names, fakeMetricsSender.IncrementCounterArgsForCall(i))
+		}
+		return names
+	}
+
+	Describe("GetAppSpaces", func() {
+		BeforeEach(func() {
+			fakeCCClient.GetAppSpacesReturns(map[string]string{
+				"app-1": "space-1",
+				"app-2": "space-2",
+			}, nil)
+		})
+
+		It("only asks Cloud Controller for apps that are not cached", func() {
+			_, err := client.GetAppSpaces("some-token", []string{"app-1", "app-2"})
+			Expect(err).NotTo(HaveOccurred())
+
+			fakeCCClient.GetAppSpacesReturns(map[string]string{"app-3": "space-3"}, nil)
+			appSpaces, err := client.GetAppSpaces("some-token", []string{"app-1", "app-3"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(appSpaces).To(Equal(map[string]string{"app-1": "space-1", "app-3": "space-3"}))
+
+			Expect(fakeCCClient.GetAppSpacesCallCount()).To(Equal(2))
+			token, appGUIDs := fakeCCClient.GetAppSpacesArgsForCall(1)
+			Expect(token).To(Equal("some-token"))
+			Expect(appGUIDs).To(Equal([]string{"app-3"}))
+		})
+
+		It("counts hits and misses", func() {
+			_, err := client.GetAppSpaces("some-token", []string{"app-1", "app-2"})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = client.GetAppSpaces("some-token", []string{"app-1", "app-2"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(counters()).To(Equal([]string{"CCAppSpaceCacheMiss", "CCAppSpaceCacheHit"}))
+			Expect(fakeCCClient.GetAppSpacesCallCount()).To(Equal(1))
+		})
+
+		It("asks again once the TTL has passed", func() {
+			_, err := client.GetAppSpaces("some-token", []string{"app-1"})
+			Expect(err).NotTo(HaveOccurred())
+
+			now = now.Add(time.Minute)
+			_, err = client.GetAppSpaces("some-token", []string{"app-1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeCCClient.GetAppSpacesCallCount()).To(Equal(2))
+		})
+
+		Context("when the TTL is zero", func() {
+			BeforeEach(func() {
+				client.TTL = 0
+			})
+
+			It("does not cache", func() {
+				_, err := client.GetAppSpaces("some-token", []string{"app-1"})
+				Expect(err).NotTo(HaveOccurred())
+				_, err = client.GetAppSpaces("some-token", []string{"app-1"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeCCClient.GetAppSpacesCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when Cloud Controller fails", func() {
+			BeforeEach(func() {
+				fakeCCClient.GetAppSpacesReturns(nil, errors.New("potato"))
+			})
+
+			It("returns the error and caches nothing", func() {
+				_, err := client.GetAppSpaces("some-token", []string{"app-1"})
+				Expect(err).To(MatchError("potato"))
+
+				_, err = client.GetAppSpaces("some-token", []string{"app-1"})
+				Expect(err).To(MatchError("potato"))
+				Expect(fakeCCClient.GetAppSpacesCallCount()).To(Equal(2))
+			})
+		})
+	})
+
+	Describe("GetSpaceGUIDs", func() {
+		It("returns the unique spaces of the apps", func() {
+			fakeCCClient.GetAppSpacesReturns(map[string]string{
+				"app-1": "space-1",
+				"app-2": "space-1",
+			}, nil)
+
+			spaceGUIDs, err := client.GetSpaceGUIDs("some-token", []string{"app-1", "app-2"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spaceGUIDs).To(Equal([]string{"space-1"}))
+		})
+	})
+
+	Describe("GetSpace", func() {
+		BeforeEach(func() {
+			fakeCCClient.GetSpaceReturns(&api.Space{Name: "some-space", OrgGUID: "some-org"}, nil)
+		})
+
+		It("caches the space", func() {
+			for i := 0; i < 2; i++ {
+				space, err := client.GetSpace("some-token", "space-1")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(space).To(Equal(&api.Space{Name: "some-space", OrgGUID: "some-org"}))
+			}
+			Expect(fakeCCClient.GetSpaceCallCount()).To(Equal(1))
+			Expect(counters()).To(Equal([]string{"CCSpaceCacheMiss", "CCSpaceCacheHit"}))
+		})
+
+		Context("when Cloud Controller no longer finds the space", func() {
+			BeforeEach(func() {
+				fakeCCClient.GetAppSpacesReturns(map[string]string{"app-1": "space-1"}, nil)
+				_, err := client.GetAppSpaces("some-token", []string{"app-1"})
+				Expect(err).NotTo(HaveOccurred())
+				_, err = client.GetSpace("some-token", "space-1")
+				Expect(err).NotTo(HaveOccurred())
+
+				now = now.Add(2 * time.Minute)
+				_, err = client.GetAppSpaces("some-token", []string{"app-1"})
+				Expect(err).NotTo(HaveOccurred())
+				fakeCCClient.GetSpaceReturns(nil, nil)
+			})
+
+			It("drops the space and the apps in it", func() {
+				space, err := client.GetSpace("some-token", "space-1")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(space).To(BeNil())
+
+				_, err = client.GetAppSpaces("some-token", []string{"app-1"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeCCClient.GetAppSpacesCallCount()).To(Equal(3))
+			})
+		})
+	})
+
+	Describe("GetUserSpace", func() {
+		var space api.Space
+
+		BeforeEach(func() {
+			space = api.Space{Name: "some-space", OrgGUID: "some-org"}
+			fakeCCClient.GetUserSpaceReturns(&space, nil)
+		})
+
+		It("caches the space per user", func() {
+			_, err := client.GetUserSpace("some-token", "user-1", space, roles)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = client.GetUserSpace("some-token", "user-1", space, roles)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = client.GetUserSpace("some-token", "user-2", space, roles)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeCCClient.GetUserSpaceCallCount()).To(Equal(2))
+		})
+
+		It("caches the space per set of roles", func() {
+			_, err := client.GetUserSpace("some-token", "user-1", space, roles)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = client.GetUserSpace("some-token", "user-1", space, []string{cc_client.RoleSpaceAuditor})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeCCClient.GetUserSpaceCallCount()).To(Equal(2))
+			_, _, _, passedRoles := fakeCCClient.GetUserSpaceArgsForCall(1)
+			Expect(passedRoles).To(Equal([]string{cc_client.RoleSpaceAuditor}))
+		})
+
+		Context("when the user is not a developer in the space", func() {
+			BeforeEach(func() {
+				fakeCCClient.GetUserSpaceReturns(nil, nil)
+			})
+
+			It("does not cache the result", func() {
+				for i := 0; i < 2; i++ {
+					userSpace, err := client.GetUserSpace("some-token", "user-1", space, roles)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(userSpace).To(BeNil())
+				}
+				Expect(fakeCCClient.GetUserSpaceCallCount()).To(Equal(2))
+			})
+		})
+	})
+
+	Describe("GetUserSpaces", func() {
+		BeforeEach(func() {
+			fakeCCClient.GetUserSpacesReturns(map[string]struct{}{"space-1": {}}, nil)
+		})
+
+		It("caches the spaces per user", func() {
+			for i := 0; i < 2; i++ {
+				userSpaces, err := client.GetUserSpaces("some-token", "user-1", roles)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(userSpaces).To(Equal(map[string]struct{}{"space-1": {}}))
+			}
+			Expect(fakeCCClient.GetUserSpacesCallCount()).To(Equal(1))
+			Expect(counters()).To(Equal([]string{"CCUserSpacesCacheMiss", "CCUserSpacesCacheHit"}))
+		})
+	})
+
+	Describe("GetLiveAppGUIDs", func() {
+		BeforeEach(func() {
+			fakeCCClient.GetAppSpacesReturns(map[string]string{"app-1": "space-1", "app-2": "space-2"}, nil)
+			_, err := client.GetAppSpaces("some-token", []string{"app-1", "app-2"})
+			Expect(err).NotTo(HaveOccurred())
+			fakeCCClient.GetLiveAppGUIDsReturns(map[string]struct{}{"app-2": {}}, nil)
+		})
+
+		It("is not cached and drops apps that are gone", func() {
+			liveAppGUIDs, err := client.GetLiveAppGUIDs("some-token", []string{"app-1", "app-2"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(liveAppGUIDs).To(Equal(map[string]struct{}{"app-2": {}}))
+
+			_, err = client.GetAppSpaces("some-token", []string{"app-2"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeCCClient.GetAppSpacesCallCount()).To(Equal(1))
+
+			_, err = client.GetAppSpaces("some-token", []string{"app-1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeCCClient.GetAppSpacesCallCount()).To(Equal(2))
+		})
+	})
+})
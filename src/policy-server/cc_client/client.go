@@ -12,26 +12,65 @@ import (
 	"code.cloudfoundry.org/lager"
 )
 
+// CC v3 role types that can give a user access to a space.
+const (
+	RoleSpaceDeveloper = "space_developer"
+	RoleSpaceManager   = "space_manager"
+	RoleSpaceAuditor   = "space_auditor"
+	RoleOrgManager     = "organization_manager"
+	RoleOrgAuditor     = "organization_auditor"
+)
+
+const (
+	// maxPerPage is the largest page size the Cloud Controller accepts.
+	maxPerPage = 5000
+	// appGUIDsPerRequest bounds the guids filter of a single apps request.
+	appGUIDsPerRequest = 100
+)
+
+var orgRoles = map[string]bool{
+	RoleOrgManager: true,
+	RoleOrgAuditor: true,
+}
+
+// IsValidRole reports whether role is one of the role types above.
+func IsValidRole(role string) bool {
+	switch role {
+	case RoleSpaceDeveloper, RoleSpaceManager, RoleSpaceAuditor, RoleOrgManager, RoleOrgAuditor:
+		return true
+	}
+	return false
+}
+
 type Client struct {
 	Logger     lager.Logger
 	JSONClient json_client.JsonClient
 }
 
+type Pagination struct {
+	TotalPages int `json:"total_pages"`
+	First      struct {
+		Href string `json:"href"`
+	} `json:"first"`
+	Last struct {
+		Href string `json:"href"`
+	} `json:"last"`
+	Next struct {
+		Href string `json:"href"`
+	} `json:"next"`
+}
+
+type Relationship struct {
+	Data struct {
+		GUID string `json:"guid"`
+	} `json:"data"`
+}
+
 type AppsV3Response struct {
-	Pagination struct {
-		TotalPages int `json:"total_pages"`
-		First      struct {
-			Href string `json:"href"`
-		} `json:"first"`
-		Last struct {
-			Href string `json:"href"`
-		} `json:"last"`
-		Next struct {
-			Href string `json:"href"`
-		} `json:"next"`
-	} `json:"pagination"`
-	Resources []struct {
+	Pagination Pagination `json:"pagination"`
+	Resources  []struct {
 		GUID  string `json:"guid"`
+		Name  string `json:"name"`
 		Links struct {
 			Space struct {
 				Href string `json:"href"`
@@ -40,82 +79,148 @@ type AppsV3Response struct {
 	} `json:"resources"`
 }
 
-type SpaceResponse struct {
-	Entity struct {
-		Name             string `json:"name"`
-		OrganizationGUID string `json:"organization_guid"`
-	} `json:"entity"`
+type SpaceV3 struct {
+	GUID          string `json:"guid"`
+	Name          string `json:"name"`
+	Relationships struct {
+		Organization Relationship `json:"organization"`
+	} `json:"relationships"`
 }
 
-type SpacesResponse struct {
-	Resources []struct {
-		Metadata struct {
-			GUID string `json:"guid"`
-		}
-		Entity struct {
-			Name             string `json:"name"`
-			OrganizationGUID string `json:"organization_guid"`
-		} `json:"entity"`
-	} `json:"resources"`
+type SpacesV3Response struct {
+	Pagination Pagination `json:"pagination"`
+	Resources  []SpaceV3  `json:"resources"`
 }
 
-func (c *Client) GetAllAppGUIDs(token string) (map[string]struct{}, error) {
-	token = fmt.Sprintf("bearer %s", token)
+type RolesV3Response struct {
+	Pagination Pagination `json:"pagination"`
+	Resources  []struct {
+		GUID          string `json:"guid"`
+		Type          string `json:"type"`
+		Relationships struct {
+			User         Relationship `json:"user"`
+			Space        Relationship `json:"space"`
+			Organization Relationship `json:"organization"`
+		} `json:"relationships"`
+	} `json:"resources"`
+}
 
-	set := make(map[string]struct{})
-	nextPage := "?"
-	for nextPage != "" {
-		queryParams := strings.Split(nextPage, "?")[1]
-		response, err := c.makeAppsV3Request(queryParams, token)
+// get requests route and then every page linked from pagination.next. newPage
+// returns the response to decode the next page into, and visit is called
+// with each decoded page.
+func (c *Client) get(route, token string, newPage func() interface{}, visit func(page interface{}) Pagination) error {
+	for route != "" {
+		page := newPage()
+		err := c.JSONClient.Do("GET", route, nil, page, token)
 		if err != nil {
-			return nil, err
+			return fmt.Errorf("json client do: %s", err)
 		}
-		for _, resource := range response.Resources {
-			set[resource.GUID] = struct{}{}
-		}
-		nextPage = response.Pagination.Next.Href
+		route = nextRoute(visit(page))
 	}
-
-	return set, nil
+	return nil
 }
 
-func (c *Client) makeAppsV3Request(queryParams, token string) (AppsV3Response, error) {
-	route := "/v3/apps"
-	if queryParams != "" {
-		route = fmt.Sprintf("%s?%s", route, queryParams)
+// nextRoute turns the absolute next link into a route for the json client.
+func nextRoute(pagination Pagination) string {
+	href := pagination.Next.Href
+	if href == "" {
+		return ""
 	}
-	var response AppsV3Response
-	err := c.JSONClient.Do("GET", route, nil, &response, token)
-	if err != nil {
-		return AppsV3Response{}, fmt.Errorf("json client do: %s", err)
+	if i := strings.Index(href, "/v3/"); i >= 0 {
+		return href[i:]
 	}
-	return response, nil
+	return href
 }
 
-func (c *Client) GetLiveAppGUIDs(token string, appGUIDs []string) (map[string]struct{}, error) {
-	token = fmt.Sprintf("bearer %s", token)
+func (c *Client) getApps(route, token string, visit func(AppsV3Response)) error {
+	return c.get(route, token,
+		func() interface{} { return &AppsV3Response{} },
+		func(page interface{}) Pagination {
+			response := page.(*AppsV3Response)
+			visit(*response)
+			return response.Pagination
+		})
+}
+
+func (c *Client) getSpaces(route, token string, visit func(SpacesV3Response)) error {
+	return c.get(route, token,
+		func() interface{} { return &SpacesV3Response{} },
+		func(page interface{}) Pagination {
+			response := page.(*SpacesV3Response)
+			visit(*response)
+			return response.Pagination
+		})
+}
 
+func (c *Client) getRoles(route, token string, visit func(RolesV3Response)) error {
+	return c.get(route, token,
+		func() interface{} { return &RolesV3Response{} },
+		func(page interface{}) Pagination {
+			response := page.(*RolesV3Response)
+			visit(*response)
+			return response.Pagination
+		})
+}
+
+// getAppsByGUID looks up appGUIDs in requests of at most appGUIDsPerRequest
+// guids each, to keep the query string short, and asks for at most
+// maxPerPage apps per page. Any further pages are read from the next links.
+func (c *Client) getAppsByGUID(appGUIDs []string, token string, visit func(AppsV3Response)) error {
+	for i := 0; i < len(appGUIDs); i += appGUIDsPerRequest {
+		last := i + appGUIDsPerRequest
+		if last > len(appGUIDs) {
+			last = len(appGUIDs)
+		}
+		err := c.getApps(appsByGUIDRoute(appGUIDs[i:last]), token, visit)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func appsByGUIDRoute(appGUIDs []string) string {
+	perPage := len(appGUIDs)
+	if perPage > maxPerPage {
+		perPage = maxPerPage
+	}
 	values := url.Values{}
 	values.Add("guids", strings.Join(appGUIDs, ","))
-	values.Add("per_page", strconv.Itoa(len(appGUIDs)))
+	values.Add("per_page", strconv.Itoa(perPage))
+	return fmt.Sprintf("/v3/apps?%s", values.Encode())
+}
 
-	route := fmt.Sprintf("/v3/apps?%s", values.Encode())
+func (c *Client) GetAllAppGUIDs(token string) (map[string]struct{}, error) {
+	token = fmt.Sprintf("bearer %s", token)
 
-	var response AppsV3Response
-	err := c.JSONClient.Do("GET", route, nil, &response, token)
+	set := make(map[string]struct{})
+	err := c.getApps("/v3/apps", token, func(response AppsV3Response) {
+		for _, resource := range response.Resources {
+			set[resource.GUID] = struct{}{}
+		}
+	})
 	if err != nil {
-		return nil, fmt.Errorf("json client do: %s", err)
+		return nil, err
 	}
+	return set, nil
+}
 
-	if response.Pagination.TotalPages > 1 {
-		return nil, fmt.Errorf("pagination support not yet implemented")
+func (c *Client) GetLiveAppGUIDs(token string, appGUIDs []string) (map[string]struct{}, error) {
+	if len(appGUIDs) < 1 {
+		return map[string]struct{}{}, nil
 	}
 
+	token = fmt.Sprintf("bearer %s", token)
+
 	set := make(map[string]struct{})
-	for _, r := range response.Resources {
-		set[r.GUID] = struct{}{}
+	err := c.getAppsByGUID(appGUIDs, token, func(response AppsV3Response) {
+		for _, r := range response.Resources {
+			set[r.GUID] = struct{}{}
+		}
+	})
+	if err != nil {
+		return nil, err
 	}
-
 	return set, nil
 }
 
@@ -124,18 +229,7 @@ func (c *Client) GetSpaceGUIDs(token string, appGUIDs []string) ([]string, error
 	if err != nil {
 		return nil, err
 	}
-
-	deduplicated := map[string]struct{}{}
-	for _, spaceID := range mapping {
-		deduplicated[spaceID] = struct{}{}
-	}
-
-	ret := []string{}
-	for spaceID, _ := range deduplicated {
-		ret = append(ret, spaceID)
-	}
-
-	return ret, nil
+	return uniqueSpaceGUIDs(mapping), nil
 }
 
 func (c *Client) GetAppSpaces(token string, appGUIDs []string) (map[string]string, error) {
@@ -145,38 +239,45 @@ func (c *Client) GetAppSpaces(token string, appGUIDs []string) (map[string]strin
 
 	token = fmt.Sprintf("bearer %s", token)
 
-	values := url.Values{}
-	values.Add("guids", strings.Join(appGUIDs, ","))
-	values.Add("per_page", strconv.Itoa(len(appGUIDs)))
-
-	route := fmt.Sprintf("/v3/apps?%s", values.Encode())
-
-	var response AppsV3Response
-	err := c.JSONClient.Do("GET", route, nil, &response, token)
+	set := make(map[string]string)
+	err := c.getAppsByGUID(appGUIDs, token, func(response AppsV3Response) {
+		for _, r := range response.Resources {
+			set[r.GUID] = lastPathSegment(r.Links.Space.Href)
+		}
+	})
 	if err != nil {
-		return nil, fmt.Errorf("json client do: %s", err)
+		return nil, err
 	}
+	return set, nil
+}
 
-	if response.Pagination.TotalPages > 1 {
-		return nil, fmt.Errorf("pagination support not yet implemented")
+func (c *Client) GetApps(token string, appGUIDs []string) (map[string]api.App, error) {
+	if len(appGUIDs) < 1 {
+		return map[string]api.App{}, nil
 	}
 
-	set := make(map[string]string)
-	for _, r := range response.Resources {
-		href := r.Links.Space.Href
-		parts := strings.Split(href, "/")
-		appID := r.GUID
-		spaceID := parts[len(parts)-1]
-		set[appID] = spaceID
+	token = fmt.Sprintf("bearer %s", token)
+
+	apps := make(map[string]api.App)
+	err := c.getAppsByGUID(appGUIDs, token, func(response AppsV3Response) {
+		for _, r := range response.Resources {
+			apps[r.GUID] = api.App{
+				Name:      r.Name,
+				SpaceGUID: lastPathSegment(r.Links.Space.Href),
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
 	}
-	return set, nil
+	return apps, nil
 }
 
 func (c *Client) GetSpace(token, spaceGUID string) (*api.Space, error) {
 	token = fmt.Sprintf("bearer %s", token)
-	route := fmt.Sprintf("/v2/spaces/%s", spaceGUID)
+	route := fmt.Sprintf("/v3/spaces/%s", spaceGUID)
 
-	var response SpaceResponse
+	var response SpaceV3
 	err := c.JSONClient.Do("GET", route, nil, &response, token)
 	if err != nil {
 		typedErr, ok := err.(*json_client.HttpResponseCodeError)
@@ -190,28 +291,33 @@ func (c *Client) GetSpace(token, spaceGUID string) (*api.Space, error) {
 	}
 
 	return &api.Space{
-		Name:    response.Entity.Name,
-		OrgGUID: response.Entity.OrganizationGUID,
+		Name:    response.Name,
+		OrgGUID: response.Relationships.Organization.Data.GUID,
 	}, nil
 }
 
-func (c *Client) GetUserSpace(token, userGUID string, space api.Space) (*api.Space, error) {
+// GetUserSpace returns the space if the user has one of roles in it, or in
+// its org for org roles, and nil otherwise.
+func (c *Client) GetUserSpace(token, userGUID string, space api.Space, roles []string) (*api.Space, error) {
+	if len(roles) == 0 {
+		return nil, nil
+	}
+
 	token = fmt.Sprintf("bearer %s", token)
 
 	values := url.Values{}
-	values.Add("q", fmt.Sprintf("developer_guid:%s", userGUID))
-	values.Add("q", fmt.Sprintf("name:%s", space.Name))
-	values.Add("q", fmt.Sprintf("organization_guid:%s", space.OrgGUID))
-
-	route := fmt.Sprintf("/v2/spaces?%s", values.Encode())
+	values.Add("names", space.Name)
+	values.Add("organization_guids", space.OrgGUID)
 
-	var response SpacesResponse
-	err := c.JSONClient.Do("GET", route, nil, &response, token)
+	var spaces []SpaceV3
+	err := c.getSpaces(fmt.Sprintf("/v3/spaces?%s", values.Encode()), token, func(response SpacesV3Response) {
+		spaces = append(spaces, response.Resources...)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("json client do: %s", err)
+		return nil, err
 	}
 
-	numSpaces := len(response.Resources)
+	numSpaces := len(spaces)
 	if numSpaces == 0 {
 		return nil, nil
 	}
@@ -219,28 +325,108 @@ func (c *Client) GetUserSpace(token, userGUID string, space api.Space) (*api.Spa
 		return nil, fmt.Errorf("found more than one matching space")
 	}
 
+	spaceRoleTypes, orgRoleTypes := splitRoles(roles)
+
+	found := false
+	if len(spaceRoleTypes) > 0 {
+		found, err = c.hasRole(token, userGUID, spaceRoleTypes, "space_guids", spaces[0].GUID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !found && len(orgRoleTypes) > 0 {
+		found, err = c.hasRole(token, userGUID, orgRoleTypes, "organization_guids", space.OrgGUID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !found {
+		return nil, nil
+	}
+
 	return &api.Space{
-		Name:    response.Resources[0].Entity.Name,
-		OrgGUID: response.Resources[0].Entity.OrganizationGUID,
+		Name:    spaces[0].Name,
+		OrgGUID: spaces[0].Relationships.Organization.Data.GUID,
 	}, nil
 }
 
-func (c *Client) GetUserSpaces(token, userGUID string) (map[string]struct{}, error) {
+func (c *Client) hasRole(token, userGUID string, types []string, filter, guid string) (bool, error) {
+	values := url.Values{}
+	values.Add("types", strings.Join(types, ","))
+	values.Add("user_guids", userGUID)
+	values.Add(filter, guid)
+
+	found := false
+	err := c.getRoles(fmt.Sprintf("/v3/roles?%s", values.Encode()), token, func(response RolesV3Response) {
+		if len(response.Resources) > 0 {
+			found = true
+		}
+	})
+	return found, err
+}
+
+// GetUserSpaces returns the spaces in which the user has one of roles,
+// including every space of an org in which the user has an org role.
+func (c *Client) GetUserSpaces(token, userGUID string, roles []string) (map[string]struct{}, error) {
+	if len(roles) == 0 {
+		return map[string]struct{}{}, nil
+	}
+
 	token = fmt.Sprintf("bearer %s", token)
 
-	route := fmt.Sprintf("/v2/users/%s/spaces", userGUID)
+	spaceRoleTypes, orgRoleTypes := splitRoles(roles)
 
-	var response SpacesResponse
-	err := c.JSONClient.Do("GET", route, nil, &response, token)
+	values := url.Values{}
+	values.Add("types", strings.Join(append(spaceRoleTypes, orgRoleTypes...), ","))
+	values.Add("user_guids", userGUID)
+
+	userSpaces := map[string]struct{}{}
+	orgGUIDs := []string{}
+	err := c.getRoles(fmt.Sprintf("/v3/roles?%s", values.Encode()), token, func(response RolesV3Response) {
+		for _, role := range response.Resources {
+			if orgRoles[role.Type] {
+				orgGUIDs = append(orgGUIDs, role.Relationships.Organization.Data.GUID)
+			} else {
+				userSpaces[role.Relationships.Space.Data.GUID] = struct{}{}
+			}
+		}
+	})
 	if err != nil {
-		return nil, fmt.Errorf("json client do: %s", err)
+		return nil, err
 	}
 
-	userSpaces := map[string]struct{}{}
-	for _, space := range response.Resources {
-		spaceID := space.Metadata.GUID
-		userSpaces[spaceID] = struct{}{}
+	if len(orgGUIDs) == 0 {
+		return userSpaces, nil
+	}
+
+	values = url.Values{}
+	values.Add("organization_guids", strings.Join(orgGUIDs, ","))
+	err = c.getSpaces(fmt.Sprintf("/v3/spaces?%s", values.Encode()), token, func(response SpacesV3Response) {
+		for _, space := range response.Resources {
+			userSpaces[space.GUID] = struct{}{}
+		}
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return userSpaces, nil
 }
+
+func splitRoles(roles []string) ([]string, []string) {
+	spaceRoleTypes := []string{}
+	orgRoleTypes := []string{}
+	for _, role := range roles {
+		if orgRoles[role] {
+			orgRoleTypes = append(orgRoleTypes, role)
+		} else {
+			spaceRoleTypes = append(spaceRoleTypes, role)
+		}
+	}
+	return spaceRoleTypes, orgRoleTypes
+}
+
+func lastPathSegment(href string) string {
+	parts := strings.Split(href, "/")
+	return parts[len(parts)-1]
+}
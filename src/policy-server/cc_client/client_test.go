@@ -3,10 +3,12 @@ package cc_client_test
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"policy-server/api"
 	"policy-server/cc_client"
 	"policy-server/cc_client/fixtures"
+	"strings"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -158,22 +160,65 @@ var _ = Describe("Client", func() {
 			})
 
 			It("returns the error", func() {
-				_, err := client.GetLiveAppGUIDs("some-token", []string{})
+				_, err := client.GetLiveAppGUIDs("some-token", []string{"live-app-1-guid"})
 				Expect(err).To(MatchError(ContainSubstring("json client do: banana")))
 			})
 		})
 
+		Context("when called with an empty list of app GUIDs", func() {
+			It("returns no guids without calling the cloud controller", func() {
+				appGUIDs, err := client.GetLiveAppGUIDs("some-token", []string{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(appGUIDs).To(BeEmpty())
+				Expect(fakeJSONClient.DoCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when there are more app GUIDs than fit in one request", func() {
+			It("looks them up in chunks of 100", func() {
+				guids := make([]string, 250)
+				for i := range guids {
+					guids[i] = fmt.Sprintf("app-guid-%d", i)
+				}
+
+				_, err := client.GetLiveAppGUIDs("some-token", guids)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeJSONClient.DoCallCount()).To(Equal(3))
+				_, route, _, _, _ := fakeJSONClient.DoArgsForCall(0)
+				Expect(route).To(ContainSubstring("app-guid-0%2C"))
+				Expect(route).To(ContainSubstring("app-guid-99&per_page=100"))
+				_, route, _, _, _ = fakeJSONClient.DoArgsForCall(2)
+				Expect(route).To(HavePrefix("/v3/apps?guids=app-guid-200%2C"))
+				Expect(route).To(HaveSuffix("app-guid-249&per_page=50"))
+			})
+		})
+
 		Context("when there are multiple pages", func() {
 			BeforeEach(func() {
 				fakeJSONClient.DoStub = func(method, route string, reqData, respData interface{}, token string) error {
-					_ = json.Unmarshal([]byte(fixtures.AppsV3MultiplePages), respData)
+					if route == "/v3/apps?page=2&per_page=1" {
+						json.Unmarshal([]byte(fixtures.AppsV3MultiplePagesPg2), respData)
+					} else if route == "/v3/apps?page=3&per_page=1" {
+						json.Unmarshal([]byte(fixtures.AppsV3MultiplePagesPg3), respData)
+					} else {
+						json.Unmarshal([]byte(fixtures.AppsV3MultiplePages), respData)
+					}
 					return nil
 				}
 			})
 
-			It("should immediately return an error", func() {
-				_, err := client.GetLiveAppGUIDs("some-token", []string{})
-				Expect(err).To(MatchError("pagination support not yet implemented"))
+			It("follows the next links", func() {
+				liveAppGUIDs, err := client.GetLiveAppGUIDs("some-token", []string{"live-app-1-guid", "live-app-2-guid", "live-app-3-guid"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(liveAppGUIDs).To(Equal(map[string]struct{}{
+					"live-app-1-guid": {},
+					"live-app-2-guid": {},
+					"live-app-3-guid": {},
+				}))
+				Expect(fakeJSONClient.DoCallCount()).To(Equal(3))
+				_, route, _, _, _ := fakeJSONClient.DoArgsForCall(2)
+				Expect(route).To(Equal("/v3/apps?page=3&per_page=1"))
 			})
 		})
 	})
@@ -252,7 +297,7 @@ var _ = Describe("Client", func() {
 			method, route, reqData, _, token := fakeJSONClient.DoArgsForCall(0)
 
 			Expect(method).To(Equal("GET"))
-			Expect(route).To(Equal("/v2/spaces/some-space-guid"))
+			Expect(route).To(Equal("/v3/spaces/some-space-guid"))
 			Expect(reqData).To(BeNil())
 			Expect(token).To(Equal("bearer some-token"))
 
@@ -368,28 +413,51 @@ var _ = Describe("Client", func() {
 		Context("when there are multiple pages", func() {
 			BeforeEach(func() {
 				fakeJSONClient.DoStub = func(method, route string, reqData, respData interface{}, token string) error {
-					_ = json.Unmarshal([]byte(fixtures.AppsV3MultiplePages), respData)
+					if route == "/v3/apps?page=2&per_page=1" {
+						json.Unmarshal([]byte(fixtures.AppsV3MultiplePagesPg2), respData)
+					} else if route == "/v3/apps?page=3&per_page=1" {
+						json.Unmarshal([]byte(fixtures.AppsV3MultiplePagesPg3), respData)
+					} else {
+						json.Unmarshal([]byte(fixtures.AppsV3MultiplePages), respData)
+					}
 					return nil
 				}
 			})
 
-			It("should immediately return an error", func() {
-				_, err := client.GetAppSpaces("some-token", []string{"some-guid"})
-				Expect(err).To(MatchError("pagination support not yet implemented"))
+			It("follows the next links", func() {
+				appSpaces, err := client.GetAppSpaces("some-token", []string{"live-app-1-guid", "live-app-2-guid", "live-app-3-guid"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(appSpaces).To(Equal(map[string]string{
+					"live-app-1-guid": "space-1-guid",
+					"live-app-2-guid": "space-1-guid",
+					"live-app-3-guid": "space-2-guid",
+				}))
+				Expect(fakeJSONClient.DoCallCount()).To(Equal(3))
+				_, route, _, _, _ := fakeJSONClient.DoArgsForCall(2)
+				Expect(route).To(Equal("/v3/apps?page=3&per_page=1"))
 			})
 		})
 	})
 
-	Describe("GetUserSpaces", func() {
+	Describe("GetApps", func() {
+		var appGUIDs []string
+
 		BeforeEach(func() {
+			appGUIDs = []string{
+				"live-app-1-guid",
+				"live-app-2-guid",
+				"live-app-3-guid",
+				"live-app-4-guid",
+				"live-app-5-guid",
+			}
 			fakeJSONClient.DoStub = func(method, route string, reqData, respData interface{}, token string) error {
-				_ = json.Unmarshal([]byte(fixtures.UserSpaces), respData)
+				_ = json.Unmarshal([]byte(fixtures.AppsV3), respData)
 				return nil
 			}
 		})
 
-		It("returns the list of spaces a user has access to", func() {
-			userSpaces, err := client.GetUserSpaces("some-token", "some-user-guid")
+		It("returns the name and space of each app", func() {
+			apps, err := client.GetApps("some-token", appGUIDs)
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(fakeJSONClient.DoCallCount()).To(Equal(1))
@@ -397,57 +465,252 @@ var _ = Describe("Client", func() {
 			method, route, reqData, _, token := fakeJSONClient.DoArgsForCall(0)
 
 			Expect(method).To(Equal("GET"))
-			Expect(route).To(Equal("/v2/users/some-user-guid/spaces"))
+			Expect(route).To(ContainSubstring("/v3/apps?guids="))
+			for _, appGuid := range appGUIDs {
+				Expect(route).To(ContainSubstring(appGuid))
+			}
+			Expect(route).To(ContainSubstring("per_page=5"))
 			Expect(reqData).To(BeNil())
 			Expect(token).To(Equal("bearer some-token"))
 
+			Expect(apps).To(Equal(map[string]api.App{
+				"live-app-1-guid": {Name: "live-app-1", SpaceGUID: "space-1-guid"},
+				"live-app-2-guid": {Name: "live-app-2", SpaceGUID: "space-1-guid"},
+				"live-app-3-guid": {Name: "live-app-3", SpaceGUID: "space-2-guid"},
+				"live-app-4-guid": {Name: "live-app-4", SpaceGUID: "space-2-guid"},
+				"live-app-5-guid": {Name: "live-app-5", SpaceGUID: "space-3-guid"},
+			}))
+		})
+
+		Context("when the list of app GUIDs is empty", func() {
+			It("returns an empty map without calling CC", func() {
+				apps, err := client.GetApps("some-token", []string{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(apps).To(BeEmpty())
+				Expect(fakeJSONClient.DoCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the json client returns an error", func() {
+			BeforeEach(func() {
+				fakeJSONClient.DoReturns(errors.New("banana"))
+			})
+
+			It("returns a helpful error", func() {
+				_, err := client.GetApps("some-token", []string{"some-guid"})
+				Expect(err).To(MatchError(ContainSubstring("json client do: banana")))
+			})
+		})
+
+		Context("when there are multiple pages", func() {
+			BeforeEach(func() {
+				fakeJSONClient.DoStub = func(method, route string, reqData, respData interface{}, token string) error {
+					if route == "/v3/apps?page=2&per_page=1" {
+						json.Unmarshal([]byte(fixtures.AppsV3MultiplePagesPg2), respData)
+					} else if route == "/v3/apps?page=3&per_page=1" {
+						json.Unmarshal([]byte(fixtures.AppsV3MultiplePagesPg3), respData)
+					} else {
+						json.Unmarshal([]byte(fixtures.AppsV3MultiplePages), respData)
+					}
+					return nil
+				}
+			})
+
+			It("follows the next links", func() {
+				apps, err := client.GetApps("some-token", []string{"live-app-1-guid", "live-app-2-guid", "live-app-3-guid"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(apps).To(HaveLen(3))
+				Expect(apps["live-app-3-guid"].SpaceGUID).To(Equal("space-2-guid"))
+				Expect(fakeJSONClient.DoCallCount()).To(Equal(3))
+				_, route, _, _, _ := fakeJSONClient.DoArgsForCall(2)
+				Expect(route).To(Equal("/v3/apps?page=3&per_page=1"))
+			})
+		})
+	})
+
+	Describe("GetUserSpaces", func() {
+		var roles []string
+
+		BeforeEach(func() {
+			roles = []string{cc_client.RoleSpaceDeveloper}
+			fakeJSONClient.DoStub = func(method, route string, reqData, respData interface{}, token string) error {
+				if strings.Contains(route, "page=2") {
+					_ = json.Unmarshal([]byte(fixtures.UserRolesPg2), respData)
+				} else {
+					_ = json.Unmarshal([]byte(fixtures.UserRoles), respData)
+				}
+				return nil
+			}
+		})
+
+		It("returns the spaces in which the user is a space developer", func() {
+			userSpaces, err := client.GetUserSpaces("some-token", "some-user-guid", roles)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeJSONClient.DoCallCount()).To(Equal(2))
+
+			method, route, reqData, _, token := fakeJSONClient.DoArgsForCall(0)
+
+			Expect(method).To(Equal("GET"))
+			Expect(route).To(Equal("/v3/roles?types=space_developer&user_guids=some-user-guid"))
+			Expect(reqData).To(BeNil())
+			Expect(token).To(Equal("bearer some-token"))
+
+			_, route, _, _, _ = fakeJSONClient.DoArgsForCall(1)
+			Expect(route).To(Equal("/v3/roles?page=2&per_page=1&types=space_developer&user_guids=some-user-guid"))
+
 			Expect(userSpaces).To(Equal(map[string]struct{}{
 				"space-1-guid": struct{}{},
 				"space-2-guid": struct{}{},
 			}))
 		})
 
+		Context("when org roles are configured", func() {
+			BeforeEach(func() {
+				roles = []string{cc_client.RoleSpaceManager, cc_client.RoleOrgManager}
+				fakeJSONClient.DoStub = func(method, route string, reqData, respData interface{}, token string) error {
+					switch {
+					case strings.HasPrefix(route, "/v3/roles"):
+						_ = json.Unmarshal([]byte(fixtures.UserRolesWithOrgManager), respData)
+					case strings.Contains(route, "page=2"):
+						_ = json.Unmarshal([]byte(fixtures.OrgSpacesPg2), respData)
+					default:
+						_ = json.Unmarshal([]byte(fixtures.OrgSpaces), respData)
+					}
+					return nil
+				}
+			})
+
+			It("includes every space in the orgs the user manages", func() {
+				userSpaces, err := client.GetUserSpaces("some-token", "some-user-guid", roles)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeJSONClient.DoCallCount()).To(Equal(3))
+				_, route, _, _, _ := fakeJSONClient.DoArgsForCall(0)
+				Expect(route).To(Equal("/v3/roles?types=space_manager%2Corganization_manager&user_guids=some-user-guid"))
+				_, route, _, _, _ = fakeJSONClient.DoArgsForCall(1)
+				Expect(route).To(Equal("/v3/spaces?organization_guids=org-3-guid"))
+
+				Expect(userSpaces).To(Equal(map[string]struct{}{
+					"space-1-guid": struct{}{},
+					"space-3-guid": struct{}{},
+					"space-4-guid": struct{}{},
+				}))
+			})
+		})
+
+		Context("when no roles are given", func() {
+			It("returns no spaces without calling CC", func() {
+				userSpaces, err := client.GetUserSpaces("some-token", "some-user-guid", nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(userSpaces).To(BeEmpty())
+				Expect(fakeJSONClient.DoCallCount()).To(Equal(0))
+			})
+		})
+
 		Context("when the json client returns an error", func() {
 			BeforeEach(func() {
 				fakeJSONClient.DoReturns(errors.New("banana"))
 			})
 
 			It("returns a helpful error", func() {
-				_, err := client.GetUserSpaces("some-token", "some-user-guid")
+				_, err := client.GetUserSpaces("some-token", "some-user-guid", roles)
 				Expect(err).To(MatchError(ContainSubstring("json client do: banana")))
 			})
 		})
 	})
 
 	Describe("GetUserSpace", func() {
+		var (
+			roles        []string
+			rolesFixture string
+		)
+
 		space := api.Space{
 			Name:    "some-space-name",
 			OrgGUID: "some-org-guid",
 		}
 		BeforeEach(func() {
+			roles = []string{cc_client.RoleSpaceDeveloper}
+			rolesFixture = fixtures.UserRolesPg2
 			fakeJSONClient.DoStub = func(method, route string, reqData, respData interface{}, token string) error {
-				_ = json.Unmarshal([]byte(fixtures.UserSpace), respData)
+				if strings.HasPrefix(route, "/v3/roles") {
+					_ = json.Unmarshal([]byte(rolesFixture), respData)
+				} else {
+					_ = json.Unmarshal([]byte(fixtures.UserSpace), respData)
+				}
 				return nil
 			}
 		})
 
-		It("returns the matching spaces for the user", func() {
-			matchingSpace, err := client.GetUserSpace("some-token", "some-developer-guid", space)
+		It("returns the matching space for the user", func() {
+			matchingSpace, err := client.GetUserSpace("some-token", "some-developer-guid", space, roles)
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(fakeJSONClient.DoCallCount()).To(Equal(1))
+			Expect(fakeJSONClient.DoCallCount()).To(Equal(2))
 
 			method, route, reqData, _, token := fakeJSONClient.DoArgsForCall(0)
 
 			Expect(method).To(Equal("GET"))
-			Expect(route).To(Equal("/v2/spaces?q=developer_guid%3Asome-developer-guid&q=name%3Asome-space-name&q=organization_guid%3Asome-org-guid"))
+			Expect(route).To(Equal("/v3/spaces?names=some-space-name&organization_guids=some-org-guid"))
 			Expect(reqData).To(BeNil())
 			Expect(token).To(Equal("bearer some-token"))
 
+			_, route, _, _, _ = fakeJSONClient.DoArgsForCall(1)
+			Expect(route).To(Equal("/v3/roles?space_guids=2e100106-0b74-4062-8671-0d375f951cb4&types=space_developer&user_guids=some-developer-guid"))
+
 			Expect(matchingSpace).To(Equal(&space))
 		})
 
-		Context("when the user has no spaces", func() {
+		Context("when the user has no role in the space", func() {
+			BeforeEach(func() {
+				rolesFixture = fixtures.UserRolesEmpty
+			})
+
+			It("returns nil", func() {
+				space, err := client.GetUserSpace("some-token", "some-developer-guid", space, roles)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(space).To(BeNil())
+			})
+
+			Context("when org roles are configured", func() {
+				BeforeEach(func() {
+					roles = []string{cc_client.RoleSpaceDeveloper, cc_client.RoleOrgManager}
+					fakeJSONClient.DoStub = func(method, route string, reqData, respData interface{}, token string) error {
+						switch {
+						case strings.Contains(route, "organization_guids=some-org-guid&types"):
+							_ = json.Unmarshal([]byte(fixtures.UserRolesWithOrgManager), respData)
+						case strings.HasPrefix(route, "/v3/roles"):
+							_ = json.Unmarshal([]byte(fixtures.UserRolesEmpty), respData)
+						default:
+							_ = json.Unmarshal([]byte(fixtures.UserSpace), respData)
+						}
+						return nil
+					}
+				})
+
+				It("checks the user's roles in the org", func() {
+					matchingSpace, err := client.GetUserSpace("some-token", "some-developer-guid", space, roles)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(matchingSpace).To(Equal(&space))
+
+					Expect(fakeJSONClient.DoCallCount()).To(Equal(3))
+					_, route, _, _, _ := fakeJSONClient.DoArgsForCall(2)
+					Expect(route).To(Equal("/v3/roles?organization_guids=some-org-guid&types=organization_manager&user_guids=some-developer-guid"))
+				})
+			})
+		})
+
+		Context("when no roles are given", func() {
+			It("returns nil without calling CC", func() {
+				space, err := client.GetUserSpace("some-token", "some-developer-guid", space, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(space).To(BeNil())
+				Expect(fakeJSONClient.DoCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the space does not exist", func() {
 			BeforeEach(func() {
 				fakeJSONClient.DoStub = func(method, route string, reqData, respData interface{}, token string) error {
 					_ = json.Unmarshal([]byte(fixtures.UserSpaceEmpty), respData)
@@ -455,10 +718,11 @@ var _ = Describe("Client", func() {
 				}
 			})
 
-			It("returns nil", func() {
-				space, err := client.GetUserSpace("some-token", "some-developer-guid", space)
+			It("returns nil without looking up roles", func() {
+				space, err := client.GetUserSpace("some-token", "some-developer-guid", space, roles)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(space).To(BeNil())
+				Expect(fakeJSONClient.DoCallCount()).To(Equal(1))
 			})
 		})
 
@@ -471,7 +735,7 @@ var _ = Describe("Client", func() {
 			})
 
 			It("returns an error", func() {
-				_, err := client.GetUserSpace("some-token", "some-developer-guid", space)
+				_, err := client.GetUserSpace("some-token", "some-developer-guid", space, roles)
 				Expect(err).To(MatchError("found more than one matching space"))
 			})
 		})
@@ -482,7 +746,7 @@ var _ = Describe("Client", func() {
 			})
 
 			It("returns a helpful error", func() {
-				_, err := client.GetUserSpace("some-token", "some-developer-guid", space)
+				_, err := client.GetUserSpace("some-token", "some-developer-guid", space, roles)
 				Expect(err).To(MatchError(ContainSubstring("json client do: banana")))
 			})
 		})
@@ -14,6 +14,7 @@ const AppsV3 = `{
   "resources": [
     {
       "guid": "live-app-1-guid",
+      "name": "live-app-1",
 			"links": {
 				"space": {
 					"href": "https://api.example.org/v2/spaces/space-1-guid"
@@ -22,6 +23,7 @@ const AppsV3 = `{
     },
     {
       "guid": "live-app-2-guid",
+      "name": "live-app-2",
 			"links": {
 				"space": {
 					"href": "https://api.example.org/v2/spaces/space-1-guid"
@@ -30,6 +32,7 @@ const AppsV3 = `{
     },
     {
       "guid": "live-app-3-guid",
+      "name": "live-app-3",
 			"links": {
 				"space": {
 					"href": "https://api.example.org/v2/spaces/space-2-guid"
@@ -38,6 +41,7 @@ const AppsV3 = `{
     },
     {
       "guid": "live-app-4-guid",
+      "name": "live-app-4",
 			"links": {
 				"space": {
 					"href": "https://api.example.org/v2/spaces/space-2-guid"
@@ -46,6 +50,7 @@ const AppsV3 = `{
     },
     {
       "guid": "live-app-5-guid",
+      "name": "live-app-5",
 			"links": {
 				"space": {
 					"href": "https://api.example.org/v2/spaces/space-3-guid"
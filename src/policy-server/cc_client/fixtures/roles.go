@@ -0,0 +1,148 @@
+package fixtures
+
+const UserRoles = `{
+  "pagination": {
+    "total_results": 2,
+    "total_pages": 2,
+    "first": {
+      "href": "https://api.example.org/v3/roles?page=1&per_page=1&types=space_developer&user_guids=some-user-guid"
+    },
+    "last": {
+      "href": "https://api.example.org/v3/roles?page=2&per_page=1&types=space_developer&user_guids=some-user-guid"
+    },
+    "next": {
+      "href": "https://api.example.org/v3/roles?page=2&per_page=1&types=space_developer&user_guids=some-user-guid"
+    },
+    "previous": null
+  },
+  "resources": [
+    {
+      "guid": "role-1-guid",
+      "type": "space_developer",
+      "relationships": {
+        "user": {
+          "data": {
+            "guid": "some-user-guid"
+          }
+        },
+        "space": {
+          "data": {
+            "guid": "space-1-guid"
+          }
+        },
+        "organization": {
+          "data": null
+        }
+      }
+    }
+  ]
+}`
+
+const UserRolesPg2 = `{
+  "pagination": {
+    "total_results": 2,
+    "total_pages": 2,
+    "first": {
+      "href": "https://api.example.org/v3/roles?page=1&per_page=1&types=space_developer&user_guids=some-user-guid"
+    },
+    "last": {
+      "href": "https://api.example.org/v3/roles?page=2&per_page=1&types=space_developer&user_guids=some-user-guid"
+    },
+    "next": null,
+    "previous": {
+      "href": "https://api.example.org/v3/roles?page=1&per_page=1&types=space_developer&user_guids=some-user-guid"
+    }
+  },
+  "resources": [
+    {
+      "guid": "role-2-guid",
+      "type": "space_developer",
+      "relationships": {
+        "user": {
+          "data": {
+            "guid": "some-user-guid"
+          }
+        },
+        "space": {
+          "data": {
+            "guid": "space-2-guid"
+          }
+        },
+        "organization": {
+          "data": null
+        }
+      }
+    }
+  ]
+}`
+
+const UserRolesWithOrgManager = `{
+  "pagination": {
+    "total_results": 2,
+    "total_pages": 1,
+    "first": {
+      "href": "https://api.example.org/v3/roles?page=1&per_page=50"
+    },
+    "last": {
+      "href": "https://api.example.org/v3/roles?page=1&per_page=50"
+    },
+    "next": null,
+    "previous": null
+  },
+  "resources": [
+    {
+      "guid": "role-1-guid",
+      "type": "space_manager",
+      "relationships": {
+        "user": {
+          "data": {
+            "guid": "some-user-guid"
+          }
+        },
+        "space": {
+          "data": {
+            "guid": "space-1-guid"
+          }
+        },
+        "organization": {
+          "data": null
+        }
+      }
+    },
+    {
+      "guid": "role-3-guid",
+      "type": "organization_manager",
+      "relationships": {
+        "user": {
+          "data": {
+            "guid": "some-user-guid"
+          }
+        },
+        "space": {
+          "data": null
+        },
+        "organization": {
+          "data": {
+            "guid": "org-3-guid"
+          }
+        }
+      }
+    }
+  ]
+}`
+
+const UserRolesEmpty = `{
+  "pagination": {
+    "total_results": 0,
+    "total_pages": 1,
+    "first": {
+      "href": "https://api.example.org/v3/roles?page=1&per_page=50"
+    },
+    "last": {
+      "href": "https://api.example.org/v3/roles?page=1&per_page=50"
+    },
+    "next": null,
+    "previous": null
+  },
+  "resources": []
+}`
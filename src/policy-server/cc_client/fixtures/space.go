@@ -1,40 +1,49 @@
 package fixtures
 
 const Space = `{
-  "metadata": {
-    "guid": "bc8d3381-390d-4bd7-8c71-25309900a2e3",
-    "url": "/v2/spaces/bc8d3381-390d-4bd7-8c71-25309900a2e3",
-    "created_at": "2016-06-08T16:41:40Z",
-    "updated_at": "2016-06-08T16:41:26Z"
+  "guid": "bc8d3381-390d-4bd7-8c71-25309900a2e3",
+  "created_at": "2016-06-08T16:41:40Z",
+  "updated_at": "2016-06-08T16:41:26Z",
+  "name": "name-2064",
+  "relationships": {
+    "organization": {
+      "data": {
+        "guid": "6e1ca5aa-55f1-4110-a97f-1f3473e771b9"
+      }
+    },
+    "quota": {
+      "data": null
+    }
   },
-  "entity": {
-    "name": "name-2064",
-    "organization_guid": "6e1ca5aa-55f1-4110-a97f-1f3473e771b9",
-    "space_quota_definition_guid": null,
-    "allow_ssh": true,
-    "organization_url": "/v2/organizations/6e1ca5aa-55f1-4110-a97f-1f3473e771b9",
-    "developers_url": "/v2/spaces/bc8d3381-390d-4bd7-8c71-25309900a2e3/developers",
-    "managers_url": "/v2/spaces/bc8d3381-390d-4bd7-8c71-25309900a2e3/managers",
-    "auditors_url": "/v2/spaces/bc8d3381-390d-4bd7-8c71-25309900a2e3/auditors",
-    "apps_url": "/v2/spaces/bc8d3381-390d-4bd7-8c71-25309900a2e3/apps",
-    "routes_url": "/v2/spaces/bc8d3381-390d-4bd7-8c71-25309900a2e3/routes",
-    "domains_url": "/v2/spaces/bc8d3381-390d-4bd7-8c71-25309900a2e3/domains",
-    "service_instances_url": "/v2/spaces/bc8d3381-390d-4bd7-8c71-25309900a2e3/service_instances",
-    "app_events_url": "/v2/spaces/bc8d3381-390d-4bd7-8c71-25309900a2e3/app_events",
-    "events_url": "/v2/spaces/bc8d3381-390d-4bd7-8c71-25309900a2e3/events",
-    "security_groups_url": "/v2/spaces/bc8d3381-390d-4bd7-8c71-25309900a2e3/security_groups"
+  "links": {
+    "self": {
+      "href": "https://api.example.org/v3/spaces/bc8d3381-390d-4bd7-8c71-25309900a2e3"
+    },
+    "organization": {
+      "href": "https://api.example.org/v3/organizations/6e1ca5aa-55f1-4110-a97f-1f3473e771b9"
+    }
   }
 }`
 
 const Space1 = `{
-  "entity": {
-    "name": "space-1",
-    "organization_guid": "org-1-guid"
+  "guid": "space-1-guid",
+  "name": "space-1",
+  "relationships": {
+    "organization": {
+      "data": {
+        "guid": "org-1-guid"
+      }
+    }
   }
 }`
 const Space2 = `{
-  "entity": {
-    "name": "space-2",
-    "organization_guid": "org-1-guid"
+  "guid": "space-2-guid",
+  "name": "space-2",
+  "relationships": {
+    "organization": {
+      "data": {
+        "guid": "org-1-guid"
+      }
+    }
   }
 }`
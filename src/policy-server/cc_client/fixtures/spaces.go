@@ -1,59 +1,99 @@
 package fixtures
 
 const Spaces = `{
-  "total_results": 2,
-  "total_pages": 1,
-  "prev_url": null,
-  "next_url": null,
+  "pagination": {
+    "total_results": 2,
+    "total_pages": 1,
+    "first": {
+      "href": "https://api.example.org/v3/spaces?page=1&per_page=50"
+    },
+    "last": {
+      "href": "https://api.example.org/v3/spaces?page=1&per_page=50"
+    },
+    "next": null,
+    "previous": null
+  },
+  "resources": [
+    {
+      "guid": "2e100106-0b74-4062-8671-0d375f951cb4",
+      "name": "name-2050",
+      "relationships": {
+        "organization": {
+          "data": {
+            "guid": "d154425c-dccc-42e6-b6b4-27d46c3b42cb"
+          }
+        }
+      }
+    },
+    {
+      "guid": "2e100106-0b74-4062-8671-0d375f951cb5",
+      "name": "name-2051",
+      "relationships": {
+        "organization": {
+          "data": {
+            "guid": "d154425c-dccc-42e6-b6b4-27d46c3b42cb"
+          }
+        }
+      }
+    }
+  ]
+}`
+
+const OrgSpaces = `{
+  "pagination": {
+    "total_results": 2,
+    "total_pages": 2,
+    "first": {
+      "href": "https://api.example.org/v3/spaces?organization_guids=org-3-guid&page=1&per_page=1"
+    },
+    "last": {
+      "href": "https://api.example.org/v3/spaces?organization_guids=org-3-guid&page=2&per_page=1"
+    },
+    "next": {
+      "href": "https://api.example.org/v3/spaces?organization_guids=org-3-guid&page=2&per_page=1"
+    },
+    "previous": null
+  },
   "resources": [
     {
-      "metadata": {
-        "guid": "2e100106-0b74-4062-8671-0d375f951cb4",
-        "url": "/v2/spaces/2e100106-0b74-4062-8671-0d375f951cb4",
-        "created_at": "2016-06-08T16:41:40Z",
-        "updated_at": "2016-06-08T16:41:26Z"
-      },
-      "entity": {
-        "name": "name-2050",
-        "organization_guid": "d154425c-dccc-42e6-b6b4-27d46c3b42cb",
-        "space_quota_definition_guid": null,
-        "allow_ssh": true,
-        "organization_url": "/v2/organizations/d154425c-dccc-42e6-b6b4-27d46c3b42cb",
-        "developers_url": "/v2/spaces/2e100106-0b74-4062-8671-0d375f951cb4/developers",
-        "managers_url": "/v2/spaces/2e100106-0b74-4062-8671-0d375f951cb4/managers",
-        "auditors_url": "/v2/spaces/2e100106-0b74-4062-8671-0d375f951cb4/auditors",
-        "apps_url": "/v2/spaces/2e100106-0b74-4062-8671-0d375f951cb4/apps",
-        "routes_url": "/v2/spaces/2e100106-0b74-4062-8671-0d375f951cb4/routes",
-        "domains_url": "/v2/spaces/2e100106-0b74-4062-8671-0d375f951cb4/domains",
-        "service_instances_url": "/v2/spaces/2e100106-0b74-4062-8671-0d375f951cb4/service_instances",
-        "app_events_url": "/v2/spaces/2e100106-0b74-4062-8671-0d375f951cb4/app_events",
-        "events_url": "/v2/spaces/2e100106-0b74-4062-8671-0d375f951cb4/events",
-        "security_groups_url": "/v2/spaces/2e100106-0b74-4062-8671-0d375f951cb4/security_groups"
+      "guid": "space-3-guid",
+      "name": "space-3-name",
+      "relationships": {
+        "organization": {
+          "data": {
+            "guid": "org-3-guid"
+          }
+        }
       }
+    }
+  ]
+}`
+
+const OrgSpacesPg2 = `{
+  "pagination": {
+    "total_results": 2,
+    "total_pages": 2,
+    "first": {
+      "href": "https://api.example.org/v3/spaces?organization_guids=org-3-guid&page=1&per_page=1"
+    },
+    "last": {
+      "href": "https://api.example.org/v3/spaces?organization_guids=org-3-guid&page=2&per_page=1"
     },
+    "next": null,
+    "previous": {
+      "href": "https://api.example.org/v3/spaces?organization_guids=org-3-guid&page=1&per_page=1"
+    }
+  },
+  "resources": [
     {
-      "metadata": {
-        "guid": "2e100106-0b74-4062-8671-0d375f951cb5",
-        "url": "/v2/spaces/2e100106-0b74-4062-8671-0d375f951cb4",
-        "created_at": "2016-06-08T16:41:40Z",
-        "updated_at": "2016-06-08T16:41:26Z"
-      },
-      "entity": {
-        "name": "name-2051",
-        "organization_guid": "d154425c-dccc-42e6-b6b4-27d46c3b42cb",
-        "space_quota_definition_guid": null,
-        "allow_ssh": true,
-        "organization_url": "/v2/organizations/d154425c-dccc-42e6-b6b4-27d46c3b42cb",
-        "developers_url": "/v2/spaces/2e100106-0b74-4062-8671-0d375f951cb4/developers",
-        "managers_url": "/v2/spaces/2e100106-0b74-4062-8671-0d375f951cb4/managers",
-        "auditors_url": "/v2/spaces/2e100106-0b74-4062-8671-0d375f951cb4/auditors",
-        "apps_url": "/v2/spaces/2e100106-0b74-4062-8671-0d375f951cb4/apps",
-        "routes_url": "/v2/spaces/2e100106-0b74-4062-8671-0d375f951cb4/routes",
-        "domains_url": "/v2/spaces/2e100106-0b74-4062-8671-0d375f951cb4/domains",
-        "service_instances_url": "/v2/spaces/2e100106-0b74-4062-8671-0d375f951cb4/service_instances",
-        "app_events_url": "/v2/spaces/2e100106-0b74-4062-8671-0d375f951cb4/app_events",
-        "events_url": "/v2/spaces/2e100106-0b74-4062-8671-0d375f951cb4/events",
-        "security_groups_url": "/v2/spaces/2e100106-0b74-4062-8671-0d375f951cb4/security_groups"
+      "guid": "space-4-guid",
+      "name": "space-4-name",
+      "relationships": {
+        "organization": {
+          "data": {
+            "guid": "org-3-guid"
+          }
+        }
       }
     }
   ]
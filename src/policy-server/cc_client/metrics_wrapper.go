@@ -0,0 +1,73 @@
+package cc_client
+
+import (
+	"policy-server/api"
+	"time"
+)
+
+// MetricsWrapper sends the duration of each Cloud Controller call as
+// CC<Method>SuccessTime or CC<Method>ErrorTime, and counts errors as
+// CC<Method>Error.
+type MetricsWrapper struct {
+	Client        ccClient
+	MetricsSender metricsSender
+}
+
+func (mw *MetricsWrapper) GetAllAppGUIDs(token string) (map[string]struct{}, error) {
+	startTime := time.Now()
+	appGUIDs, err := mw.Client.GetAllAppGUIDs(token)
+	mw.send("GetAllAppGUIDs", startTime, err)
+	return appGUIDs, err
+}
+
+func (mw *MetricsWrapper) GetLiveAppGUIDs(token string, appGUIDs []string) (map[string]struct{}, error) {
+	startTime := time.Now()
+	liveAppGUIDs, err := mw.Client.GetLiveAppGUIDs(token, appGUIDs)
+	mw.send("GetLiveAppGUIDs", startTime, err)
+	return liveAppGUIDs, err
+}
+
+func (mw *MetricsWrapper) GetAppSpaces(token string, appGUIDs []string) (map[string]string, error) {
+	startTime := time.Now()
+	appSpaces, err := mw.Client.GetAppSpaces(token, appGUIDs)
+	mw.send("GetAppSpaces", startTime, err)
+	return appSpaces, err
+}
+
+func (mw *MetricsWrapper) GetApps(token string, appGUIDs []string) (map[string]api.App, error) {
+	startTime := time.Now()
+	apps, err := mw.Client.GetApps(token, appGUIDs)
+	mw.send("GetApps", startTime, err)
+	return apps, err
+}
+
+func (mw *MetricsWrapper) GetSpace(token, spaceGUID string) (*api.Space, error) {
+	startTime := time.Now()
+	space, err := mw.Client.GetSpace(token, spaceGUID)
+	mw.send("GetSpace", startTime, err)
+	return space, err
+}
+
+func (mw *MetricsWrapper) GetUserSpace(token, userGUID string, space api.Space, roles []string) (*api.Space, error) {
+	startTime := time.Now()
+	userSpace, err := mw.Client.GetUserSpace(token, userGUID, space, roles)
+	mw.send("GetUserSpace", startTime, err)
+	return userSpace, err
+}
+
+func (mw *MetricsWrapper) GetUserSpaces(token, userGUID string, roles []string) (map[string]struct{}, error) {
+	startTime := time.Now()
+	userSpaces, err := mw.Client.GetUserSpaces(token, userGUID, roles)
+	mw.send("GetUserSpaces", startTime, err)
+	return userSpaces, err
+}
+
+func (mw *MetricsWrapper) send(method string, startTime time.Time, err error) {
+	duration := time.Now().Sub(startTime)
+	if err != nil {
+		mw.MetricsSender.IncrementCounter("CC" + method + "Error")
+		mw.MetricsSender.SendDuration("CC"+method+"ErrorTime", duration)
+	} else {
+		mw.MetricsSender.SendDuration("CC"+method+"SuccessTime", duration)
+	}
+}
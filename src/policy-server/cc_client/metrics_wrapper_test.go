@@ -0,0 +1,93 @@
+package cc_client_test
+
+import (
+	"errors"
+	"policy-server/api"
+	"policy-server/cc_client"
+	"policy-server/cc_client/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MetricsWrapper", func() {
+	var (
+		metricsWrapper    *cc_client.MetricsWrapper
+		fakeClient        *fakes.CCClient
+		fakeMetricsSender *fakes.MetricsSender
+	)
+
+	BeforeEach(func() {
+		fakeClient = &fakes.CCClient{}
+		fakeMetricsSender = &fakes.MetricsSender{}
+		metricsWrapper = &cc_client.MetricsWrapper{
+			Client:        fakeClient,
+			MetricsSender: fakeMetricsSender,
+		}
+	})
+
+	Describe("GetAppSpaces", func() {
+		BeforeEach(func() {
+			fakeClient.GetAppSpacesReturns(map[string]string{"app-1": "space-1"}, nil)
+		})
+
+		It("calls GetAppSpaces on the Client", func() {
+			appSpaces, err := metricsWrapper.GetAppSpaces("some-token", []string{"app-1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(appSpaces).To(Equal(map[string]string{"app-1": "space-1"}))
+
+			Expect(fakeClient.GetAppSpacesCallCount()).To(Equal(1))
+			token, appGUIDs := fakeClient.GetAppSpacesArgsForCall(0)
+			Expect(token).To(Equal("some-token"))
+			Expect(appGUIDs).To(Equal([]string{"app-1"}))
+		})
+
+		It("emits a metric", func() {
+			_, err := metricsWrapper.GetAppSpaces("some-token", []string{"app-1"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeMetricsSender.IncrementCounterCallCount()).To(Equal(0))
+			Expect(fakeMetricsSender.SendDurationCallCount()).To(Equal(1))
+			name, _ := fakeMetricsSender.SendDurationArgsForCall(0)
+			Expect(name).To(Equal("CCGetAppSpacesSuccessTime"))
+		})
+
+		Context("when there is an error", func() {
+			BeforeEach(func() {
+				fakeClient.GetAppSpacesReturns(nil, errors.New("banana"))
+			})
+
+			It("emits an error metric", func() {
+				_, err := metricsWrapper.GetAppSpaces("some-token", []string{"app-1"})
+				Expect(err).To(MatchError("banana"))
+
+				Expect(fakeMetricsSender.IncrementCounterCallCount()).To(Equal(1))
+				Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("CCGetAppSpacesError"))
+
+				Expect(fakeMetricsSender.SendDurationCallCount()).To(Equal(1))
+				name, _ := fakeMetricsSender.SendDurationArgsForCall(0)
+				Expect(name).To(Equal("CCGetAppSpacesErrorTime"))
+			})
+		})
+	})
+
+	Describe("GetUserSpace", func() {
+		It("calls GetUserSpace on the Client and emits a metric", func() {
+			space := api.Space{Name: "some-space", OrgGUID: "some-org"}
+			fakeClient.GetUserSpaceReturns(&space, nil)
+
+			userSpace, err := metricsWrapper.GetUserSpace("some-token", "some-user", space, []string{"developers"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(userSpace).To(Equal(&space))
+
+			token, userGUID, passedSpace, roles := fakeClient.GetUserSpaceArgsForCall(0)
+			Expect(token).To(Equal("some-token"))
+			Expect(userGUID).To(Equal("some-user"))
+			Expect(passedSpace).To(Equal(space))
+			Expect(roles).To(Equal([]string{"developers"}))
+
+			name, _ := fakeMetricsSender.SendDurationArgsForCall(0)
+			Expect(name).To(Equal("CCGetUserSpaceSuccessTime"))
+		})
+	})
+})
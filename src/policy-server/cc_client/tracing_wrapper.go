@@ -0,0 +1,71 @@
+package cc_client
+
+import (
+	"context"
+	"lib/tracing"
+	"policy-server/api"
+)
+
+// TracingWrapper runs each Cloud Controller call in a cc.<Method> span that
+// is a child of the span in the context it is given.
+type TracingWrapper struct {
+	Client ccClient
+	Tracer *tracing.Tracer
+}
+
+func (tw *TracingWrapper) GetAllAppGUIDs(ctx context.Context, token string) (map[string]struct{}, error) {
+	_, span := tw.Tracer.Start(ctx, "cc.GetAllAppGUIDs")
+	appGUIDs, err := tw.Client.GetAllAppGUIDs(token)
+	span.End(err)
+	return appGUIDs, err
+}
+
+func (tw *TracingWrapper) GetLiveAppGUIDs(ctx context.Context, token string, appGUIDs []string) (map[string]struct{}, error) {
+	_, span := tw.Tracer.Start(ctx, "cc.GetLiveAppGUIDs")
+	liveAppGUIDs, err := tw.Client.GetLiveAppGUIDs(token, appGUIDs)
+	span.End(err)
+	return liveAppGUIDs, err
+}
+
+func (tw *TracingWrapper) GetAppSpaces(ctx context.Context, token string, appGUIDs []string) (map[string]string, error) {
+	_, span := tw.Tracer.Start(ctx, "cc.GetAppSpaces")
+	appSpaces, err := tw.Client.GetAppSpaces(token, appGUIDs)
+	span.End(err)
+	return appSpaces, err
+}
+
+func (tw *TracingWrapper) GetSpaceGUIDs(ctx context.Context, token string, appGUIDs []string) ([]string, error) {
+	appSpaces, err := tw.GetAppSpaces(ctx, token, appGUIDs)
+	if err != nil {
+		return nil, err
+	}
+	return uniqueSpaceGUIDs(appSpaces), nil
+}
+
+func (tw *TracingWrapper) GetApps(ctx context.Context, token string, appGUIDs []string) (map[string]api.App, error) {
+	_, span := tw.Tracer.Start(ctx, "cc.GetApps")
+	apps, err := tw.Client.GetApps(token, appGUIDs)
+	span.End(err)
+	return apps, err
+}
+
+func (tw *TracingWrapper) GetSpace(ctx context.Context, token, spaceGUID string) (*api.Space, error) {
+	_, span := tw.Tracer.Start(ctx, "cc.GetSpace")
+	space, err := tw.Client.GetSpace(token, spaceGUID)
+	span.End(err)
+	return space, err
+}
+
+func (tw *TracingWrapper) GetUserSpace(ctx context.Context, token, userGUID string, space api.Space, roles []string) (*api.Space, error) {
+	_, span := tw.Tracer.Start(ctx, "cc.GetUserSpace")
+	userSpace, err := tw.Client.GetUserSpace(token, userGUID, space, roles)
+	span.End(err)
+	return userSpace, err
+}
+
+func (tw *TracingWrapper) GetUserSpaces(ctx context.Context, token, userGUID string, roles []string) (map[string]struct{}, error) {
+	_, span := tw.Tracer.Start(ctx, "cc.GetUserSpaces")
+	userSpaces, err := tw.Client.GetUserSpaces(token, userGUID, roles)
+	span.End(err)
+	return userSpaces, err
+}
@@ -0,0 +1,97 @@
+package cc_client_test
+
+import (
+	"context"
+	"errors"
+	libfakes "lib/fakes"
+	"lib/tracing"
+	"policy-server/cc_client"
+	"policy-server/cc_client/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TracingWrapper", func() {
+	var (
+		tracingWrapper *cc_client.TracingWrapper
+		fakeClient     *fakes.CCClient
+		fakeExporter   *libfakes.SpanExporter
+		tracer         *tracing.Tracer
+	)
+
+	BeforeEach(func() {
+		fakeClient = &fakes.CCClient{}
+		fakeExporter = &libfakes.SpanExporter{}
+		tracer = &tracing.Tracer{Service: "some-service", Exporter: fakeExporter}
+		tracingWrapper = &cc_client.TracingWrapper{
+			Client: fakeClient,
+			Tracer: tracer,
+		}
+	})
+
+	Describe("GetSpaceGUIDs", func() {
+		BeforeEach(func() {
+			fakeClient.GetAppSpacesReturns(map[string]string{
+				"app-1": "space-1",
+				"app-2": "space-1",
+			}, nil)
+		})
+
+		It("calls GetAppSpaces in a child span of the one in the context", func() {
+			ctx, parent := tracer.Start(context.Background(), "parent")
+
+			spaceGUIDs, err := tracingWrapper.GetSpaceGUIDs(ctx, "some-token", []string{"app-1", "app-2"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spaceGUIDs).To(Equal([]string{"space-1"}))
+
+			token, appGUIDs := fakeClient.GetAppSpacesArgsForCall(0)
+			Expect(token).To(Equal("some-token"))
+			Expect(appGUIDs).To(Equal([]string{"app-1", "app-2"}))
+
+			Expect(fakeExporter.ExportSpanCallCount()).To(Equal(1))
+			span := fakeExporter.ExportSpanArgsForCall(0)
+			Expect(span.Name).To(Equal("cc.GetAppSpaces"))
+			Expect(span.TraceID).To(Equal(parent.SpanContext().TraceID))
+			Expect(span.ParentSpanID).To(Equal(parent.SpanContext().SpanID))
+			Expect(span.Error).To(BeEmpty())
+		})
+
+		Context("when the client returns an error", func() {
+			BeforeEach(func() {
+				fakeClient.GetAppSpacesReturns(nil, errors.New("banana"))
+			})
+
+			It("records the error on the span", func() {
+				_, err := tracingWrapper.GetSpaceGUIDs(context.Background(), "some-token", []string{"app-1"})
+				Expect(err).To(MatchError("banana"))
+
+				span := fakeExporter.ExportSpanArgsForCall(0)
+				Expect(span.Error).To(Equal("banana"))
+			})
+		})
+	})
+
+	Describe("GetUserSpaces", func() {
+		It("calls GetUserSpaces in a cc.GetUserSpaces span", func() {
+			fakeClient.GetUserSpacesReturns(map[string]struct{}{"space-1": {}}, nil)
+
+			userSpaces, err := tracingWrapper.GetUserSpaces(context.Background(), "some-token", "some-user", []string{"space_developer"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(userSpaces).To(HaveKey("space-1"))
+
+			Expect(fakeExporter.ExportSpanArgsForCall(0).Name).To(Equal("cc.GetUserSpaces"))
+		})
+	})
+
+	Context("when the tracer has no exporter", func() {
+		It("still calls the client", func() {
+			tracingWrapper.Tracer = &tracing.Tracer{}
+			fakeClient.GetSpaceReturns(nil, nil)
+
+			_, err := tracingWrapper.GetSpace(context.Background(), "some-token", "space-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeClient.GetSpaceCallCount()).To(Equal(1))
+		})
+	})
+})
@@ -1,156 +0,0 @@
-// Code generated by counterfeiter. DO NOT EDIT.
-package fakes
-
-import (
-	"policy-server/store"
-	"sync"
-)
-
-type ListDeleteStore struct {
-	AllStub        func() ([]store.Policy, error)
-	allMutex       sync.RWMutex
-	allArgsForCall []struct{}
-	allReturns     struct {
-		result1 []store.Policy
-		result2 error
-	}
-	allReturnsOnCall map[int]struct {
-		result1 []store.Policy
-		result2 error
-	}
-	DeleteStub        func([]store.Policy) error
-	deleteMutex       sync.RWMutex
-	deleteArgsForCall []struct {
-		arg1 []store.Policy
-	}
-	deleteReturns struct {
-		result1 error
-	}
-	deleteReturnsOnCall map[int]struct {
-		result1 error
-	}
-	invocations      map[string][][]interface{}
-	invocationsMutex sync.RWMutex
-}
-
-func (fake *ListDeleteStore) All() ([]store.Policy, error) {
-	fake.allMutex.Lock()
-	ret, specificReturn := fake.allReturnsOnCall[len(fake.allArgsForCall)]
-	fake.allArgsForCall = append(fake.allArgsForCall, struct{}{})
-	fake.recordInvocation("All", []interface{}{})
-	fake.allMutex.Unlock()
-	if fake.AllStub != nil {
-		return fake.AllStub()
-	}
-	if specificReturn {
-		return ret.result1, ret.result2
-	}
-	return fake.allReturns.result1, fake.allReturns.result2
-}
-
-func (fake *ListDeleteStore) AllCallCount() int {
-	fake.allMutex.RLock()
-	defer fake.allMutex.RUnlock()
-	return len(fake.allArgsForCall)
-}
-
-func (fake *ListDeleteStore) AllReturns(result1 []store.Policy, result2 error) {
-	fake.AllStub = nil
-	fake.allReturns = struct {
-		result1 []store.Policy
-		result2 error
-	}{result1, result2}
-}
-
-func (fake *ListDeleteStore) AllReturnsOnCall(i int, result1 []store.Policy, result2 error) {
-	fake.AllStub = nil
-	if fake.allReturnsOnCall == nil {
-		fake.allReturnsOnCall = make(map[int]struct {
-			result1 []store.Policy
-			result2 error
-		})
-	}
-	fake.allReturnsOnCall[i] = struct {
-		result1 []store.Policy
-		result2 error
-	}{result1, result2}
-}
-
-func (fake *ListDeleteStore) Delete(arg1 []store.Policy) error {
-	var arg1Copy []store.Policy
-	if arg1 != nil {
-		arg1Copy = make([]store.Policy, len(arg1))
-		copy(arg1Copy, arg1)
-	}
-	fake.deleteMutex.Lock()
-	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
-	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
-		arg1 []store.Policy
-	}{arg1Copy})
-	fake.recordInvocation("Delete", []interface{}{arg1Copy})
-	fake.deleteMutex.Unlock()
-	if fake.DeleteStub != nil {
-		return fake.DeleteStub(arg1)
-	}
-	if specificReturn {
-		return ret.result1
-	}
-	return fake.deleteReturns.result1
-}
-
-func (fake *ListDeleteStore) DeleteCallCount() int {
-	fake.deleteMutex.RLock()
-	defer fake.deleteMutex.RUnlock()
-	return len(fake.deleteArgsForCall)
-}
-
-func (fake *ListDeleteStore) DeleteArgsForCall(i int) []store.Policy {
-	fake.deleteMutex.RLock()
-	defer fake.deleteMutex.RUnlock()
-	return fake.deleteArgsForCall[i].arg1
-}
-
-func (fake *ListDeleteStore) DeleteReturns(result1 error) {
-	fake.DeleteStub = nil
-	fake.deleteReturns = struct {
-		result1 error
-	}{result1}
-}
-
-func (fake *ListDeleteStore) DeleteReturnsOnCall(i int, result1 error) {
-	fake.DeleteStub = nil
-	if fake.deleteReturnsOnCall == nil {
-		fake.deleteReturnsOnCall = make(map[int]struct {
-			result1 error
-		})
-	}
-	fake.deleteReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
-}
-
-func (fake *ListDeleteStore) Invocations() map[string][][]interface{} {
-	fake.invocationsMutex.RLock()
-	defer fake.invocationsMutex.RUnlock()
-	fake.allMutex.RLock()
-	defer fake.allMutex.RUnlock()
-	fake.deleteMutex.RLock()
-	defer fake.deleteMutex.RUnlock()
-	copiedInvocations := map[string][][]interface{}{}
-	for key, value := range fake.invocations {
-		copiedInvocations[key] = value
-	}
-	return copiedInvocations
-}
-
-func (fake *ListDeleteStore) recordInvocation(key string, args []interface{}) {
-	fake.invocationsMutex.Lock()
-	defer fake.invocationsMutex.Unlock()
-	if fake.invocations == nil {
-		fake.invocations = map[string][][]interface{}{}
-	}
-	if fake.invocations[key] == nil {
-		fake.invocations[key] = [][]interface{}{}
-	}
-	fake.invocations[key] = append(fake.invocations[key], args)
-}
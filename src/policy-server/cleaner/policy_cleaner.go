@@ -18,78 +18,303 @@ type ccClient interface {
 	GetLiveAppGUIDs(token string, appGUIDs []string) (map[string]struct{}, error)
 }
 
-//go:generate counterfeiter -o fakes/list_delete_store.go --fake-name ListDeleteStore . listDeleteStore
-type listDeleteStore interface {
+//go:generate counterfeiter -o fakes/list_store.go --fake-name ListStore . listStore
+type listStore interface {
 	All() ([]store.Policy, error)
-	Delete([]store.Policy) error
+}
+
+//go:generate counterfeiter -o fakes/egress_policy_store.go --fake-name EgressPolicyStore . egressPolicyStore
+type egressPolicyStore interface {
+	All() ([]store.EgressPolicy, error)
+}
+
+//go:generate counterfeiter -o fakes/policy_collection_store.go --fake-name PolicyCollectionStore . policyCollectionStore
+type policyCollectionStore interface {
+	Delete(store.PolicyCollection) error
+}
+
+//go:generate counterfeiter -o fakes/tag_store.go --fake-name TagStore . tagStore
+type tagStore interface {
+	Tags() ([]store.Tag, error)
+	ReleaseTags([]store.Tag) ([]store.Tag, error)
+}
+
+//go:generate counterfeiter -o fakes/metrics_sender.go --fake-name MetricsSender . metricsSender
+type metricsSender interface {
+	IncrementCounter(string)
+}
+
+type Result struct {
+	Policies       []store.Policy
+	EgressPolicies []store.EgressPolicy
+	Tags           []store.Tag
+}
+
+// DeletionLimit caps how much a single automatic cleanup may delete, either
+// as a number of policies or as a percentage of all policies. A zero value
+// disables that limit.
+type DeletionLimit struct {
+	Count   int
+	Percent int
+}
+
+func (l DeletionLimit) exceededBy(stale, total int) bool {
+	if l.Count > 0 && stale > l.Count {
+		return true
+	}
+	if l.Percent > 0 && total > 0 && stale*100 > l.Percent*total {
+		return true
+	}
+	return false
+}
+
+type cleanupPlan struct {
+	stale         Result
+	policyChunks  [][]store.Policy
+	egressChunks  [][]store.EgressPolicy
+	totalPolicies int
 }
 
 type PolicyCleaner struct {
 	Logger                lager.Logger
-	Store                 listDeleteStore
+	Store                 listStore
+	EgressStore           egressPolicyStore
+	PolicyCollectionStore policyCollectionStore
+	TagStore              tagStore
 	UAAClient             uaaClient
 	CCClient              ccClient
+	MetricsSender         metricsSender
 	CCAppRequestChunkSize int
 	RequestTimeout        time.Duration
+	DeletionLimit         DeletionLimit
 }
 
-func NewPolicyCleaner(logger lager.Logger, store listDeleteStore, uaaClient uaaClient,
-	ccClient ccClient, ccAppRequestChunkSize int, requestTimeout time.Duration) *PolicyCleaner {
+func NewPolicyCleaner(logger lager.Logger, store listStore, egressStore egressPolicyStore,
+	policyCollectionStore policyCollectionStore, tagStore tagStore, uaaClient uaaClient,
+	ccClient ccClient, metricsSender metricsSender, ccAppRequestChunkSize int, requestTimeout time.Duration,
+	deletionLimit DeletionLimit) *PolicyCleaner {
 	return &PolicyCleaner{
 		Logger:                logger,
 		Store:                 store,
+		EgressStore:           egressStore,
+		PolicyCollectionStore: policyCollectionStore,
+		TagStore:              tagStore,
 		UAAClient:             uaaClient,
 		CCClient:              ccClient,
+		MetricsSender:         metricsSender,
 		CCAppRequestChunkSize: ccAppRequestChunkSize,
 		RequestTimeout:        requestTimeout,
+		DeletionLimit:         deletionLimit,
+	}
+}
+
+// FindStalePolicies returns what DeleteStalePolicies would delete, without
+// deleting anything.
+func (p *PolicyCleaner) FindStalePolicies() (Result, error) {
+	plan, err := p.plan()
+	if err != nil {
+		return Result{}, err
+	}
+	return plan.stale, nil
+}
+
+// DeleteStalePolicies deletes c2c and egress policies that reference apps
+// which no longer exist in Cloud Controller, then releases the tags of any
+// remaining apps which no longer exist.
+func (p *PolicyCleaner) DeleteStalePolicies() (Result, error) {
+	plan, err := p.plan()
+	if err != nil {
+		return Result{}, err
 	}
+	return p.apply(plan)
 }
 
-func (p *PolicyCleaner) DeleteStalePolicies() ([]store.Policy, error) {
+// DeleteStalePoliciesWrapper is run by the poller. Unlike an explicit
+// cleanup request it refuses to delete anything when the DeletionLimit is
+// exceeded, since that usually means Cloud Controller returned an incomplete
+// list of apps.
+func (p *PolicyCleaner) DeleteStalePoliciesWrapper() error {
+	plan, err := p.plan()
+	if err != nil {
+		return err
+	}
+
+	stale := len(plan.stale.Policies) + len(plan.stale.EgressPolicies)
+	if p.DeletionLimit.exceededBy(stale, plan.totalPolicies) {
+		p.MetricsSender.IncrementCounter("PolicyCleanupLimitExceeded")
+		err = fmt.Errorf("refusing to delete %d of %d policies: deletion limit exceeded", stale, plan.totalPolicies)
+		p.Logger.Error("deletion-limit-exceeded", err, lager.Data{
+			"stale_policies":         stale,
+			"total_policies":         plan.totalPolicies,
+			"deletion_limit_count":   p.DeletionLimit.Count,
+			"deletion_limit_percent": p.DeletionLimit.Percent,
+		})
+		return err
+	}
+
+	_, err = p.apply(plan)
+	return err
+}
+
+func (p *PolicyCleaner) plan() (cleanupPlan, error) {
 	policies, err := p.Store.All()
 	if err != nil {
 		p.Logger.Error("store-list-policies-failed", err)
-		return nil, fmt.Errorf("database read failed: %s", err)
+		return cleanupPlan{}, fmt.Errorf("database read failed: %s", err)
+	}
+	egressPolicies, err := p.EgressStore.All()
+	if err != nil {
+		p.Logger.Error("store-list-egress-policies-failed", err)
+		return cleanupPlan{}, fmt.Errorf("database read failed: %s", err)
 	}
 	token, err := p.UAAClient.GetToken()
 	if err != nil {
 		p.Logger.Error("get-uaa-token-failed", err)
-		return nil, fmt.Errorf("get UAA token failed: %s", err)
+		return cleanupPlan{}, fmt.Errorf("get UAA token failed: %s", err)
 	}
 
-	stalePolicies := []store.Policy{}
+	plan := cleanupPlan{
+		stale: Result{
+			Policies:       []store.Policy{},
+			EgressPolicies: []store.EgressPolicy{},
+		},
+		totalPolicies: len(policies) + len(egressPolicies),
+	}
+	checkedAppGUIDs := map[string]bool{}
 
-	appGUIDs := policyAppGUIDs(policies)
+	appGUIDs := policyAppGUIDs(policies, egressPolicies)
 	appGUIDchunks := getChunks(appGUIDs, p.CCAppRequestChunkSize)
 
 	for _, appGUIDchunk := range appGUIDchunks {
 		liveAppGUIDs, err := p.CCClient.GetLiveAppGUIDs(token, appGUIDchunk)
 		if err != nil {
 			p.Logger.Error("cc-get-app-guids-failed", err)
-			return nil, fmt.Errorf("get app guids from Cloud-Controller failed: %s", err)
+			return cleanupPlan{}, fmt.Errorf("get app guids from Cloud-Controller failed: %s", err)
 		}
 
 		staleAppGUIDs := getStaleAppGUIDs(liveAppGUIDs, appGUIDchunk)
-		toDelete := getStalePolicies(policies, staleAppGUIDs)
-		stalePolicies = append(stalePolicies, toDelete...)
+		for _, guid := range appGUIDchunk {
+			_, stale := staleAppGUIDs[guid]
+			checkedAppGUIDs[guid] = !stale
+		}
+
+		stalePolicies := getStalePolicies(policies, staleAppGUIDs)
+		plan.policyChunks = append(plan.policyChunks, stalePolicies)
+		plan.stale.Policies = append(plan.stale.Policies, stalePolicies...)
+
+		staleEgressPolicies := getStaleEgressPolicies(egressPolicies, staleAppGUIDs)
+		plan.egressChunks = append(plan.egressChunks, staleEgressPolicies)
+		plan.stale.EgressPolicies = append(plan.stale.EgressPolicies, staleEgressPolicies...)
+	}
+
+	plan.stale.Tags, err = p.findStaleTags(token, checkedAppGUIDs)
+	if err != nil {
+		return cleanupPlan{}, err
+	}
+
+	return plan, nil
+}
+
+func (p *PolicyCleaner) apply(plan cleanupPlan) (Result, error) {
+	deletedPolicies := []store.Policy{}
+	for _, toDelete := range plan.policyChunks {
+		if len(toDelete) == 0 {
+			continue
+		}
+		deletedPolicies = append(deletedPolicies, toDelete...)
 
 		p.Logger.Info("deleting stale policies:", lager.Data{
-			"total_policies": len(stalePolicies),
-			"stale_policies": stalePolicies,
+			"total_policies": len(deletedPolicies),
+			"stale_policies": deletedPolicies,
 		})
-		err = p.Store.Delete(toDelete)
+		err := p.PolicyCollectionStore.Delete(store.PolicyCollection{Policies: toDelete})
 		if err != nil {
 			p.Logger.Error("store-delete-policies-failed", err)
-			return nil, fmt.Errorf("database write failed: %s", err)
+			return Result{}, fmt.Errorf("database write failed: %s", err)
 		}
 	}
 
-	return stalePolicies, nil
+	deletedEgressPolicies := []store.EgressPolicy{}
+	for _, toDelete := range plan.egressChunks {
+		if len(toDelete) == 0 {
+			continue
+		}
+		deletedEgressPolicies = append(deletedEgressPolicies, toDelete...)
+
+		p.Logger.Info("deleting stale egress policies:", lager.Data{
+			"total_egress_policies": len(deletedEgressPolicies),
+			"stale_egress_policies": deletedEgressPolicies,
+		})
+		err := p.PolicyCollectionStore.Delete(store.PolicyCollection{EgressPolicies: toDelete})
+		if err != nil {
+			p.Logger.Error("store-delete-egress-policies-failed", err)
+			return Result{}, fmt.Errorf("database write failed: %s", err)
+		}
+	}
+
+	// Deleting the last policy of an app already releases its tag, so only
+	// the tags that were still held are reported.
+	releasedTags := []store.Tag{}
+	if len(plan.stale.Tags) > 0 {
+		var err error
+		releasedTags, err = p.TagStore.ReleaseTags(plan.stale.Tags)
+		if err != nil {
+			p.Logger.Error("store-release-tags-failed", err)
+			return Result{}, fmt.Errorf("database write failed: %s", err)
+		}
+		p.Logger.Info("released stale tags:", lager.Data{
+			"total_tags": len(releasedTags),
+			"stale_tags": releasedTags,
+		})
+	}
+
+	return Result{
+		Policies:       deletedPolicies,
+		EgressPolicies: deletedEgressPolicies,
+		Tags:           releasedTags,
+	}, nil
 }
 
-func (p *PolicyCleaner) DeleteStalePoliciesWrapper() error {
-	_, err := p.DeleteStalePolicies()
-	return err
+// findStaleTags returns the tags of apps which no longer exist. Apps
+// already looked up while finding stale policies are not looked up again.
+func (p *PolicyCleaner) findStaleTags(token string, checkedAppGUIDs map[string]bool) ([]store.Tag, error) {
+	tags, err := p.TagStore.Tags()
+	if err != nil {
+		p.Logger.Error("store-list-tags-failed", err)
+		return nil, fmt.Errorf("database read failed: %s", err)
+	}
+
+	appTags := []store.Tag{}
+	uncheckedAppGUIDs := []string{}
+	for _, tag := range tags {
+		if tag.Type != "app" {
+			continue
+		}
+		appTags = append(appTags, tag)
+		if _, ok := checkedAppGUIDs[tag.ID]; !ok {
+			uncheckedAppGUIDs = append(uncheckedAppGUIDs, tag.ID)
+		}
+	}
+
+	for _, appGUIDchunk := range getChunks(uncheckedAppGUIDs, p.CCAppRequestChunkSize) {
+		liveAppGUIDs, err := p.CCClient.GetLiveAppGUIDs(token, appGUIDchunk)
+		if err != nil {
+			p.Logger.Error("cc-get-app-guids-failed", err)
+			return nil, fmt.Errorf("get app guids from Cloud-Controller failed: %s", err)
+		}
+		for _, guid := range appGUIDchunk {
+			_, live := liveAppGUIDs[guid]
+			checkedAppGUIDs[guid] = live
+		}
+	}
+
+	staleTags := []store.Tag{}
+	for _, tag := range appTags {
+		if !checkedAppGUIDs[tag.ID] {
+			staleTags = append(staleTags, tag)
+		}
+	}
+	return staleTags, nil
 }
 
 func getStaleAppGUIDs(liveAppGUIDs map[string]struct{}, appGUIDs []string) map[string]struct{} {
@@ -114,12 +339,25 @@ func getStalePolicies(policyList []store.Policy, staleAppGUIDs map[string]struct
 	return stalePolicies
 }
 
-func policyAppGUIDs(policyList []store.Policy) []string {
+func getStaleEgressPolicies(egressPolicyList []store.EgressPolicy, staleAppGUIDs map[string]struct{}) []store.EgressPolicy {
+	staleEgressPolicies := []store.EgressPolicy{}
+	for _, p := range egressPolicyList {
+		if _, found := staleAppGUIDs[p.Source.ID]; found {
+			staleEgressPolicies = append(staleEgressPolicies, p)
+		}
+	}
+	return staleEgressPolicies
+}
+
+func policyAppGUIDs(policyList []store.Policy, egressPolicyList []store.EgressPolicy) []string {
 	appGUIDset := make(map[string]struct{})
 	for _, p := range policyList {
 		appGUIDset[p.Source.ID] = struct{}{}
 		appGUIDset[p.Destination.ID] = struct{}{}
 	}
+	for _, p := range egressPolicyList {
+		appGUIDset[p.Source.ID] = struct{}{}
+	}
 	var appGUIDs []string
 	for guid, _ := range appGUIDset {
 		appGUIDs = append(appGUIDs, guid)
@@ -16,12 +16,17 @@ import (
 
 var _ = Describe("PolicyCleaner", func() {
 	var (
-		policyCleaner *cleaner.PolicyCleaner
-		fakeStore     *fakes.ListDeleteStore
-		fakeUAAClient *fakes.UAAClient
-		fakeCCClient  *fakes.CCClient
-		logger        *lagertest.TestLogger
-		allPolicies   []store.Policy
+		policyCleaner             *cleaner.PolicyCleaner
+		fakeStore                 *fakes.ListStore
+		fakeEgressStore           *fakes.EgressPolicyStore
+		fakePolicyCollectionStore *fakes.PolicyCollectionStore
+		fakeTagStore              *fakes.TagStore
+		fakeUAAClient             *fakes.UAAClient
+		fakeCCClient              *fakes.CCClient
+		fakeMetricsSender         *fakes.MetricsSender
+		logger                    *lagertest.TestLogger
+		allPolicies               []store.Policy
+		allEgressPolicies         []store.EgressPolicy
 	)
 
 	BeforeEach(func() {
@@ -60,21 +65,47 @@ var _ = Describe("PolicyCleaner", func() {
 			},
 		}}
 
-		fakeStore = &fakes.ListDeleteStore{}
+		allEgressPolicies = []store.EgressPolicy{{
+			Source: store.EgressSource{ID: "live-guid"},
+			Destination: store.EgressDestination{
+				Protocol: "tcp",
+				IPRanges: []store.IPRange{{Start: "10.0.0.1", End: "10.0.0.1"}},
+			},
+		}, {
+			Source: store.EgressSource{ID: "dead-guid"},
+			Destination: store.EgressDestination{
+				Protocol: "udp",
+				IPRanges: []store.IPRange{{Start: "10.0.0.2", End: "10.0.0.3"}},
+			},
+		}}
+
+		fakeStore = &fakes.ListStore{}
+		fakeEgressStore = &fakes.EgressPolicyStore{}
+		fakePolicyCollectionStore = &fakes.PolicyCollectionStore{}
+		fakeTagStore = &fakes.TagStore{}
+		fakeMetricsSender = &fakes.MetricsSender{}
 		fakeUAAClient = &fakes.UAAClient{}
 		fakeCCClient = &fakes.CCClient{}
 		logger = lagertest.NewTestLogger("test")
 
 		policyCleaner = &cleaner.PolicyCleaner{
-			Logger:         logger,
-			Store:          fakeStore,
-			UAAClient:      fakeUAAClient,
-			CCClient:       fakeCCClient,
-			RequestTimeout: 5 * time.Second,
+			Logger:                logger,
+			Store:                 fakeStore,
+			EgressStore:           fakeEgressStore,
+			PolicyCollectionStore: fakePolicyCollectionStore,
+			TagStore:              fakeTagStore,
+			UAAClient:             fakeUAAClient,
+			CCClient:              fakeCCClient,
+			MetricsSender:         fakeMetricsSender,
+			RequestTimeout:        5 * time.Second,
 		}
 
 		fakeUAAClient.GetTokenReturns("valid-token", nil)
 		fakeStore.AllReturns(allPolicies, nil)
+		fakeEgressStore.AllReturns(allEgressPolicies, nil)
+		fakeTagStore.ReleaseTagsStub = func(tags []store.Tag) ([]store.Tag, error) {
+			return tags, nil
+		}
 		fakeCCClient.GetLiveAppGUIDsStub = func(token string, appGUIDs []string) (map[string]struct{}, error) {
 			liveGUIDs := make(map[string]struct{})
 			for _, guid := range appGUIDs {
@@ -87,7 +118,7 @@ var _ = Describe("PolicyCleaner", func() {
 	})
 
 	It("Deletes policies that reference apps that do not exist", func() {
-		policies, err := policyCleaner.DeleteStalePolicies()
+		result, err := policyCleaner.DeleteStalePolicies()
 		Expect(err).NotTo(HaveOccurred())
 
 		Expect(fakeStore.AllCallCount()).To(Equal(1))
@@ -99,12 +130,261 @@ var _ = Describe("PolicyCleaner", func() {
 
 		stalePolicies := allPolicies[1:]
 
-		Expect(fakeStore.DeleteCallCount()).To(Equal(1))
-		Expect(fakeStore.DeleteArgsForCall(0)).To(Equal(stalePolicies))
+		Expect(fakePolicyCollectionStore.DeleteCallCount()).To(Equal(2))
+		Expect(fakePolicyCollectionStore.DeleteArgsForCall(0)).To(Equal(store.PolicyCollection{
+			Policies: stalePolicies,
+		}))
 
 		Expect(logger).To(gbytes.Say("deleting stale policies:.*policies.*dead-guid.*dead-guid.*total_policies\":2"))
 		staleAPIPolicies := allPolicies[1:]
-		Expect(policies).To(Equal(staleAPIPolicies))
+		Expect(result.Policies).To(Equal(staleAPIPolicies))
+	})
+
+	It("Deletes egress policies whose source app does not exist", func() {
+		result, err := policyCleaner.DeleteStalePolicies()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeEgressStore.AllCallCount()).To(Equal(1))
+		Expect(fakePolicyCollectionStore.DeleteCallCount()).To(Equal(2))
+		Expect(fakePolicyCollectionStore.DeleteArgsForCall(1)).To(Equal(store.PolicyCollection{
+			EgressPolicies: allEgressPolicies[1:],
+		}))
+
+		Expect(logger).To(gbytes.Say("deleting stale egress policies:.*stale_egress_policies.*dead-guid.*total_egress_policies\":1"))
+		Expect(result.EgressPolicies).To(Equal(allEgressPolicies[1:]))
+	})
+
+	Context("when there are no stale egress policies", func() {
+		BeforeEach(func() {
+			fakeEgressStore.AllReturns(allEgressPolicies[:1], nil)
+		})
+
+		It("does not delete any egress policies", func() {
+			result, err := policyCleaner.DeleteStalePolicies()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakePolicyCollectionStore.DeleteCallCount()).To(Equal(1))
+			Expect(fakePolicyCollectionStore.DeleteArgsForCall(0).EgressPolicies).To(BeEmpty())
+			Expect(result.EgressPolicies).To(BeEmpty())
+		})
+	})
+
+	Describe("releasing orphaned tags", func() {
+		BeforeEach(func() {
+			fakeTagStore.TagsReturns([]store.Tag{
+				{ID: "live-guid", Tag: "01", Type: "app"},
+				{ID: "dead-guid", Tag: "02", Type: "app"},
+				{ID: "orphaned-guid", Tag: "03", Type: "app"},
+				{ID: "some-router-guid", Tag: "04", Type: "router"},
+			}, nil)
+		})
+
+		It("releases app tags whose guid no longer exists in CC", func() {
+			result, err := policyCleaner.DeleteStalePolicies()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeTagStore.ReleaseTagsCallCount()).To(Equal(1))
+			Expect(fakeTagStore.ReleaseTagsArgsForCall(0)).To(Equal([]store.Tag{
+				{ID: "dead-guid", Tag: "02", Type: "app"},
+				{ID: "orphaned-guid", Tag: "03", Type: "app"},
+			}))
+			Expect(result.Tags).To(Equal([]store.Tag{
+				{ID: "dead-guid", Tag: "02", Type: "app"},
+				{ID: "orphaned-guid", Tag: "03", Type: "app"},
+			}))
+			Expect(logger).To(gbytes.Say("released stale tags:.*stale_tags.*dead-guid.*orphaned-guid.*total_tags\":2"))
+		})
+
+		It("only asks CC about apps that have not been checked already", func() {
+			_, err := policyCleaner.DeleteStalePolicies()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeCCClient.GetLiveAppGUIDsCallCount()).To(Equal(2))
+			_, guids := fakeCCClient.GetLiveAppGUIDsArgsForCall(1)
+			Expect(guids).To(Equal([]string{"orphaned-guid"}))
+		})
+
+		Context("when there are no orphaned tags", func() {
+			BeforeEach(func() {
+				fakeTagStore.TagsReturns([]store.Tag{{ID: "live-guid", Tag: "01", Type: "app"}}, nil)
+			})
+
+			It("does not release any tags", func() {
+				result, err := policyCleaner.DeleteStalePolicies()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeTagStore.ReleaseTagsCallCount()).To(Equal(0))
+				Expect(result.Tags).To(BeEmpty())
+			})
+		})
+
+		Context("when listing the tags fails", func() {
+			BeforeEach(func() {
+				fakeTagStore.TagsReturns(nil, errors.New("potato"))
+			})
+
+			It("returns a meaningful error", func() {
+				result, err := policyCleaner.DeleteStalePolicies()
+				Expect(err).To(MatchError("database read failed: potato"))
+				Expect(result).To(Equal(cleaner.Result{}))
+				Expect(logger).To(gbytes.Say("store-list-tags-failed.*potato"))
+			})
+		})
+
+		Context("when checking the orphaned apps in CC fails", func() {
+			BeforeEach(func() {
+				fakeCCClient.GetLiveAppGUIDsStub = func(token string, appGUIDs []string) (map[string]struct{}, error) {
+					if appGUIDs[0] == "orphaned-guid" {
+						return nil, errors.New("potato")
+					}
+					return map[string]struct{}{"live-guid": {}}, nil
+				}
+			})
+
+			It("returns a meaningful error", func() {
+				_, err := policyCleaner.DeleteStalePolicies()
+				Expect(err).To(MatchError("get app guids from Cloud-Controller failed: potato"))
+				Expect(fakeTagStore.ReleaseTagsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when releasing the tags fails", func() {
+			BeforeEach(func() {
+				fakeTagStore.ReleaseTagsStub = nil
+				fakeTagStore.ReleaseTagsReturns(nil, errors.New("potato"))
+			})
+
+			It("returns a meaningful error", func() {
+				result, err := policyCleaner.DeleteStalePolicies()
+				Expect(err).To(MatchError("database write failed: potato"))
+				Expect(result).To(Equal(cleaner.Result{}))
+				Expect(logger).To(gbytes.Say("store-release-tags-failed.*potato"))
+			})
+		})
+	})
+
+	Describe("FindStalePolicies", func() {
+		BeforeEach(func() {
+			fakeTagStore.TagsReturns([]store.Tag{
+				{ID: "live-guid", Tag: "01", Type: "app"},
+				{ID: "dead-guid", Tag: "02", Type: "app"},
+			}, nil)
+		})
+
+		It("returns what would be cleaned up without deleting anything", func() {
+			result, err := policyCleaner.FindStalePolicies()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result).To(Equal(cleaner.Result{
+				Policies:       allPolicies[1:],
+				EgressPolicies: allEgressPolicies[1:],
+				Tags:           []store.Tag{{ID: "dead-guid", Tag: "02", Type: "app"}},
+			}))
+
+			Expect(fakePolicyCollectionStore.DeleteCallCount()).To(Equal(0))
+			Expect(fakeTagStore.ReleaseTagsCallCount()).To(Equal(0))
+		})
+
+		Context("when getting the apps from the Cloud-Controller fails", func() {
+			BeforeEach(func() {
+				fakeCCClient.GetLiveAppGUIDsStub = nil
+				fakeCCClient.GetLiveAppGUIDsReturns(nil, errors.New("potato"))
+			})
+
+			It("returns a meaningful error", func() {
+				_, err := policyCleaner.FindStalePolicies()
+				Expect(err).To(MatchError("get app guids from Cloud-Controller failed: potato"))
+			})
+		})
+	})
+
+	Describe("DeleteStalePoliciesWrapper", func() {
+		It("deletes stale policies", func() {
+			Expect(policyCleaner.DeleteStalePoliciesWrapper()).To(Succeed())
+
+			Expect(fakePolicyCollectionStore.DeleteCallCount()).To(Equal(2))
+			Expect(fakePolicyCollectionStore.DeleteArgsForCall(0).Policies).To(Equal(allPolicies[1:]))
+			Expect(fakeMetricsSender.IncrementCounterCallCount()).To(Equal(0))
+		})
+
+		Context("when the number of stale policies is above the count limit", func() {
+			BeforeEach(func() {
+				// 2 stale c2c policies and 1 stale egress policy
+				policyCleaner.DeletionLimit = cleaner.DeletionLimit{Count: 2}
+			})
+
+			It("refuses to delete and emits an alert metric", func() {
+				err := policyCleaner.DeleteStalePoliciesWrapper()
+				Expect(err).To(MatchError("refusing to delete 3 of 5 policies: deletion limit exceeded"))
+
+				Expect(fakePolicyCollectionStore.DeleteCallCount()).To(Equal(0))
+				Expect(fakeTagStore.ReleaseTagsCallCount()).To(Equal(0))
+
+				Expect(fakeMetricsSender.IncrementCounterCallCount()).To(Equal(1))
+				Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("PolicyCleanupLimitExceeded"))
+
+				Expect(logger).To(gbytes.Say("deletion-limit-exceeded.*deletion_limit_count\":2"))
+			})
+		})
+
+		Context("when the number of stale policies is at the count limit", func() {
+			BeforeEach(func() {
+				policyCleaner.DeletionLimit = cleaner.DeletionLimit{Count: 3}
+			})
+
+			It("deletes the stale policies", func() {
+				Expect(policyCleaner.DeleteStalePoliciesWrapper()).To(Succeed())
+				Expect(fakePolicyCollectionStore.DeleteCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when the percentage of stale policies is above the percent limit", func() {
+			BeforeEach(func() {
+				policyCleaner.DeletionLimit = cleaner.DeletionLimit{Percent: 50}
+			})
+
+			It("refuses to delete and emits an alert metric", func() {
+				err := policyCleaner.DeleteStalePoliciesWrapper()
+				Expect(err).To(MatchError("refusing to delete 3 of 5 policies: deletion limit exceeded"))
+
+				Expect(fakePolicyCollectionStore.DeleteCallCount()).To(Equal(0))
+				Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("PolicyCleanupLimitExceeded"))
+			})
+		})
+
+		Context("when the percentage of stale policies is at the percent limit", func() {
+			BeforeEach(func() {
+				policyCleaner.DeletionLimit = cleaner.DeletionLimit{Percent: 60}
+			})
+
+			It("deletes the stale policies", func() {
+				Expect(policyCleaner.DeleteStalePoliciesWrapper()).To(Succeed())
+				Expect(fakePolicyCollectionStore.DeleteCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when finding the stale policies fails", func() {
+			BeforeEach(func() {
+				fakeStore.AllReturns(nil, errors.New("potato"))
+			})
+
+			It("returns the error", func() {
+				err := policyCleaner.DeleteStalePoliciesWrapper()
+				Expect(err).To(MatchError("database read failed: potato"))
+			})
+		})
+	})
+
+	Context("when a deletion limit is configured", func() {
+		BeforeEach(func() {
+			policyCleaner.DeletionLimit = cleaner.DeletionLimit{Count: 1}
+		})
+
+		It("is not applied to explicit cleanup requests", func() {
+			result, err := policyCleaner.DeleteStalePolicies()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Policies).To(Equal(allPolicies[1:]))
+		})
 	})
 
 	Context("when there are more apps with policies than the CC chunk size", func() {
@@ -112,6 +392,9 @@ var _ = Describe("PolicyCleaner", func() {
 			policyCleaner = &cleaner.PolicyCleaner{
 				Logger:                logger,
 				Store:                 fakeStore,
+				EgressStore:           fakeEgressStore,
+				PolicyCollectionStore: fakePolicyCollectionStore,
+				TagStore:              fakeTagStore,
 				UAAClient:             fakeUAAClient,
 				CCClient:              fakeCCClient,
 				CCAppRequestChunkSize: 1,
@@ -119,7 +402,7 @@ var _ = Describe("PolicyCleaner", func() {
 			}
 		})
 		It("Calls the CC server multiple times to check which policies to delete", func() {
-			policies, err := policyCleaner.DeleteStalePolicies()
+			result, err := policyCleaner.DeleteStalePolicies()
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(fakeStore.AllCallCount()).To(Equal(1))
@@ -135,19 +418,15 @@ var _ = Describe("PolicyCleaner", func() {
 			))
 
 			stalePolicies := allPolicies[1:]
-			Expect(fakeStore.DeleteCallCount()).To(Equal(2))
-
-			var deleted [][]store.Policy
-			deletedPolicies := fakeStore.DeleteArgsForCall(0)
-			deleted = append(deleted, deletedPolicies)
-			deletedPolicies = fakeStore.DeleteArgsForCall(1)
-			deleted = append(deleted, deletedPolicies)
-			Expect(deleted).To(ConsistOf(stalePolicies, []store.Policy{}))
+			Expect(fakePolicyCollectionStore.DeleteCallCount()).To(Equal(2))
+			Expect(fakePolicyCollectionStore.DeleteArgsForCall(0)).To(Equal(store.PolicyCollection{
+				Policies: stalePolicies,
+			}))
 
 			Expect(logger).To(gbytes.Say("deleting stale policies:.*policies.*dead-guid.*dead-guid.*total_policies\":2"))
 
 			staleAPIPolicies := allPolicies[1:]
-			Expect(policies).To(ConsistOf(staleAPIPolicies[0], staleAPIPolicies[1]))
+			Expect(result.Policies).To(ConsistOf(staleAPIPolicies[0], staleAPIPolicies[1]))
 		})
 	})
 
@@ -157,9 +436,9 @@ var _ = Describe("PolicyCleaner", func() {
 		})
 
 		It("returns a meaningful error", func() {
-			policies, err := policyCleaner.DeleteStalePolicies()
+			result, err := policyCleaner.DeleteStalePolicies()
 			Expect(err).To(MatchError("database read failed: potato"))
-			Expect(policies).To(BeNil())
+			Expect(result).To(Equal(cleaner.Result{}))
 		})
 
 		It("logs the error", func() {
@@ -174,9 +453,9 @@ var _ = Describe("PolicyCleaner", func() {
 		})
 
 		It("returns a meaningful error", func() {
-			policies, err := policyCleaner.DeleteStalePolicies()
+			result, err := policyCleaner.DeleteStalePolicies()
 			Expect(err).To(MatchError("get UAA token failed: potato"))
-			Expect(policies).To(BeNil())
+			Expect(result).To(Equal(cleaner.Result{}))
 		})
 
 		It("logs the full error", func() {
@@ -191,9 +470,9 @@ var _ = Describe("PolicyCleaner", func() {
 		})
 
 		It("returns a meaningful error", func() {
-			policies, err := policyCleaner.DeleteStalePolicies()
+			result, err := policyCleaner.DeleteStalePolicies()
 			Expect(err).To(MatchError("get app guids from Cloud-Controller failed: potato"))
-			Expect(policies).To(BeNil())
+			Expect(result).To(Equal(cleaner.Result{}))
 		})
 
 		It("logs the full error", func() {
@@ -204,13 +483,13 @@ var _ = Describe("PolicyCleaner", func() {
 
 	Context("When deleting the policies fails", func() {
 		BeforeEach(func() {
-			fakeStore.DeleteReturns(errors.New("potato"))
+			fakePolicyCollectionStore.DeleteReturnsOnCall(0, errors.New("potato"))
 		})
 
 		It("returns a meaningful error", func() {
-			policies, err := policyCleaner.DeleteStalePolicies()
+			result, err := policyCleaner.DeleteStalePolicies()
 			Expect(err).To(MatchError("database write failed: potato"))
-			Expect(policies).To(BeNil())
+			Expect(result).To(Equal(cleaner.Result{}))
 		})
 
 		It("logs the full error", func() {
@@ -219,6 +498,40 @@ var _ = Describe("PolicyCleaner", func() {
 		})
 	})
 
+	Context("When retrieving egress policies from the db fails", func() {
+		BeforeEach(func() {
+			fakeEgressStore.AllReturns(nil, errors.New("potato"))
+		})
+
+		It("returns a meaningful error", func() {
+			result, err := policyCleaner.DeleteStalePolicies()
+			Expect(err).To(MatchError("database read failed: potato"))
+			Expect(result).To(Equal(cleaner.Result{}))
+		})
+
+		It("logs the error", func() {
+			policyCleaner.DeleteStalePolicies()
+			Expect(logger).To(gbytes.Say("store-list-egress-policies-failed.*potato"))
+		})
+	})
+
+	Context("When deleting the egress policies fails", func() {
+		BeforeEach(func() {
+			fakePolicyCollectionStore.DeleteReturnsOnCall(1, errors.New("potato"))
+		})
+
+		It("returns a meaningful error", func() {
+			result, err := policyCleaner.DeleteStalePolicies()
+			Expect(err).To(MatchError("database write failed: potato"))
+			Expect(result).To(Equal(cleaner.Result{}))
+		})
+
+		It("logs the full error", func() {
+			policyCleaner.DeleteStalePolicies()
+			Expect(logger).To(gbytes.Say("store-delete-egress-policies-failed.*potato"))
+		})
+	})
+
 	Context("when the context times out", func() {
 		//TODO
 	})
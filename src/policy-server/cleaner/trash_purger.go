@@ -0,0 +1,44 @@
+package cleaner
+
+import (
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+//go:generate counterfeiter -o fakes/trash.go --fake-name Trash . trash
+type trash interface {
+	Purge(before time.Time) (int, error)
+}
+
+// TrashPurger permanently removes deleted policies once they are older than
+// the retention period.
+type TrashPurger struct {
+	Logger    lager.Logger
+	Trash     trash
+	Retention time.Duration
+	Now       func() time.Time
+}
+
+func NewTrashPurger(logger lager.Logger, trash trash, retention time.Duration) *TrashPurger {
+	return &TrashPurger{
+		Logger:    logger,
+		Trash:     trash,
+		Retention: retention,
+		Now:       time.Now,
+	}
+}
+
+func (t *TrashPurger) PurgeExpired() error {
+	count, err := t.Trash.Purge(t.Now().Add(-t.Retention))
+	if err != nil {
+		t.Logger.Error("purge-deleted-policies-failed", err)
+		return fmt.Errorf("purge deleted policies: %s", err)
+	}
+
+	if count > 0 {
+		t.Logger.Info("purged-deleted-policies", lager.Data{"count": count})
+	}
+	return nil
+}
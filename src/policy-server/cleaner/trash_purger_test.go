@@ -0,0 +1,52 @@
+package cleaner_test
+
+import (
+	"errors"
+	"policy-server/cleaner"
+	"policy-server/cleaner/fakes"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("TrashPurger", func() {
+	var (
+		purger    *cleaner.TrashPurger
+		fakeTrash *fakes.Trash
+		logger    *lagertest.TestLogger
+		now       time.Time
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		fakeTrash = &fakes.Trash{}
+		now = time.Date(2017, time.July, 14, 2, 40, 0, 0, time.UTC)
+
+		purger = cleaner.NewTrashPurger(logger, fakeTrash, 48*time.Hour)
+		purger.Now = func() time.Time { return now }
+	})
+
+	It("purges policies deleted before the retention period", func() {
+		fakeTrash.PurgeReturns(3, nil)
+
+		Expect(purger.PurgeExpired()).To(Succeed())
+
+		Expect(fakeTrash.PurgeCallCount()).To(Equal(1))
+		Expect(fakeTrash.PurgeArgsForCall(0)).To(Equal(now.Add(-48 * time.Hour)))
+		Expect(logger).To(gbytes.Say("purged-deleted-policies.*count\":3"))
+	})
+
+	Context("when purging fails", func() {
+		BeforeEach(func() {
+			fakeTrash.PurgeReturns(0, errors.New("potato"))
+		})
+
+		It("returns and logs the error", func() {
+			Expect(purger.PurgeExpired()).To(MatchError("purge deleted policies: potato"))
+			Expect(logger).To(gbytes.Say("purge-deleted-policies-failed.*potato"))
+		})
+	})
+})
@@ -3,13 +3,19 @@ package common
 import (
 	"crypto/tls"
 	"fmt"
+	"lib/poller"
+	"lib/tracing"
+	"net/http"
 	"os"
+	"policy-server/config"
 	"policy-server/server_metrics"
 	"policy-server/store"
+	"policy-server/store/migrations"
 	"strings"
 	"time"
 
 	"code.cloudfoundry.org/cf-networking-helpers/metrics"
+	"code.cloudfoundry.org/debugserver"
 	"code.cloudfoundry.org/lager"
 	"github.com/tedsuo/ifrit"
 	"github.com/tedsuo/ifrit/http_server"
@@ -22,6 +28,8 @@ const (
 	ERROR        = "error"
 	FATAL        = "fatal"
 	emitInterval = 30 * time.Second
+
+	schemaCheckInterval = 30 * time.Second
 )
 
 func InitLoggerSink(logger lager.Logger, level string) *lager.ReconfigurableSink {
@@ -42,10 +50,76 @@ func InitLoggerSink(logger lager.Logger, level string) *lager.ReconfigurableSink
 	return lager.NewReconfigurableSink(w, logLevel)
 }
 
-func InitMetricsEmitter(logger lager.Logger, wrappedStore *store.MetricsWrapper) *metrics.MetricsEmitter {
+func InitMetricSources(wrappedStore *store.MetricsWrapper) []metrics.MetricSource {
 	totalPoliciesSource := server_metrics.NewTotalPoliciesSource(wrappedStore)
+	tagsUsedSource := server_metrics.NewTagsUsedSource(wrappedStore)
+	tagsFreeSource := server_metrics.NewTagsFreeSource(wrappedStore)
 	uptimeSource := metrics.NewUptimeSource()
-	return metrics.NewMetricsEmitter(logger, emitInterval, uptimeSource, totalPoliciesSource)
+	return []metrics.MetricSource{uptimeSource, totalPoliciesSource, tagsUsedSource, tagsFreeSource}
+}
+
+func InitMetricsEmitter(logger lager.Logger, sources []metrics.MetricSource) *metrics.MetricsEmitter {
+	return metrics.NewMetricsEmitter(logger, emitInterval, sources...)
+}
+
+// InitDebugServer serves the debug endpoints, and the metrics in registry on
+// /metrics.
+func InitDebugServer(host string, port int, sink debugserver.ReconfigurableSinkInterface, registry *server_metrics.Registry) ifrit.Runner {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", registry)
+	mux.Handle("/", debugserver.Handler(sink))
+	return http_server.New(fmt.Sprintf("%s:%d", host, port), mux)
+}
+
+// InitTracer builds the tracer for service from conf, and the runner that
+// sends its spans. With tracing off the tracer starts no spans and the runner
+// only waits to be signaled.
+func InitTracer(logger lager.Logger, service string, conf config.Tracing) (*tracing.Tracer, ifrit.Runner) {
+	tracer := &tracing.Tracer{Service: service}
+	switch conf.Exporter {
+	case config.TracingExporterStdout:
+		tracer.Exporter = &tracing.WriterExporter{Writer: os.Stdout}
+	case config.TracingExporterCollector:
+		exporter := tracing.NewCollectorExporter(logger.Session("tracing"), &http.Client{Timeout: 10 * time.Second}, conf.CollectorURL)
+		tracer.Exporter = exporter
+		return tracer, exporter
+	}
+
+	return tracer, ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+		close(ready)
+		<-signals
+		return nil
+	})
+}
+
+// InitSchemaChecker checks that the migrations this binary needs are applied.
+// Pending migrations are an error unless degraded is set, in which case they
+// are only logged and the health check fails until a later Check passes.
+func InitSchemaChecker(logger lager.Logger, migrationDb migrations.MigrationDb, degraded bool) (*migrations.SchemaChecker, error) {
+	schemaChecker := &migrations.SchemaChecker{
+		Migrator: &migrations.Migrator{MigrateAdapter: &migrations.MigrateAdapter{}},
+		DB:       migrationDb,
+	}
+
+	err := schemaChecker.Check()
+	if err != nil {
+		if !degraded {
+			return nil, err
+		}
+		logger.Error("starting-with-incompatible-schema", err)
+	}
+	return schemaChecker, nil
+}
+
+// InitSchemaCheckPoller checks the schema again every 30 seconds, so that the
+// health check of a server started in degraded mode passes once the pending
+// migrations are applied.
+func InitSchemaCheckPoller(logger lager.Logger, schemaChecker *migrations.SchemaChecker) *poller.Poller {
+	return &poller.Poller{
+		Logger:          logger.Session("schema-check-poller"),
+		PollInterval:    schemaCheckInterval,
+		SingleCycleFunc: schemaChecker.Check,
+	}
 }
 
 func InitServer(logger lager.Logger, tlsConfig *tls.Config, host string, port int, handlers rata.Handlers, routes rata.Routes) ifrit.Runner {
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"code.cloudfoundry.org/lager"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"policy-server/cmd/common"
+	"policy-server/config"
+	"policy-server/db"
+	"policy-server/store"
+)
+
+const (
+	jobPrefix = "policy-server-expand-tags"
+	logPrefix = "cfnetworking"
+
+	// VXLAN GBP carries the tag in a 16 bit field, so longer tags cannot
+	// reach the agents.
+	maxTagLength = 2
+)
+
+// expand-tags grows the groups table to the configured tag_length. Existing
+// tags keep their values, so it can be run against a live database after the
+// policy servers have been redeployed with the larger tag_length.
+func main() {
+	err := mainWithError()
+	if err != nil {
+		fmt.Printf("fatal error occured, %s", err)
+		os.Exit(1)
+	}
+}
+
+func mainWithError() error {
+	configFilePath := flag.String("config-file", "", "path to policy-server config file")
+	batchSize := flag.Int("batch-size", 1000, "number of rows to insert per statement")
+	flag.Parse()
+
+	conf, err := config.New(*configFilePath)
+	if err != nil {
+		log.Fatalf("%s.%s: could not read config file: %s", logPrefix, jobPrefix, err)
+	}
+
+	if conf.TagLength > maxTagLength {
+		return fmt.Errorf("tag_length %d is too large to expand online, must be at most %d", conf.TagLength, maxTagLength)
+	}
+
+	logger := lager.NewLogger(fmt.Sprintf("%s.%s", logPrefix, jobPrefix))
+	logger.RegisterSink(common.InitLoggerSink(logger, "DEBUG"))
+
+	dbConn := db.NewConnectionPool(
+		conf.Database,
+		conf.MaxOpenConnections,
+		conf.MaxIdleConnections,
+		logPrefix,
+		jobPrefix,
+		logger,
+	)
+	defer dbConn.Close()
+
+	logger.Info("expanding groups table", lager.Data{"tag-length": conf.TagLength, "batch-size": *batchSize})
+
+	tagPopulator := &store.TagPopulator{DBConnection: dbConn}
+	added, err := tagPopulator.ExpandTables(conf.TagLength, *batchSize)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("finished expanding groups table", lager.Data{"rows-added": added})
+	return nil
+}
@@ -11,7 +11,10 @@ import (
 	"policy-server/db"
 	"policy-server/store"
 	"policy-server/store/migrations"
+	"text/tabwriter"
 	"time"
+
+	"github.com/cf-container-networking/sql-migrate"
 )
 
 const (
@@ -28,10 +31,20 @@ func main() {
 }
 
 func mainWithError() error {
-	conf := parseConfig()
+	conf, opts := parseConfig()
 	c := make(chan error, 1)
 	go func() {
-		err := migrateAndPopulateGroupsTable(conf)
+		var err error
+		switch opts.command {
+		case "up":
+			err = migrateAndPopulateGroupsTable(conf, opts)
+		case "status":
+			err = printStatus(conf)
+		case "down":
+			err = rollbackDb(conf, opts)
+		default:
+			err = fmt.Errorf("unknown command %q, must be one of up, status or down", opts.command)
+		}
 		c <- err
 	}()
 
@@ -44,11 +57,21 @@ func mainWithError() error {
 	}
 }
 
-func migrateAndPopulateGroupsTable(conf *config.Config) error {
+type options struct {
+	command string
+	dryRun  bool
+	steps   int
+}
+
+func migrateAndPopulateGroupsTable(conf *config.Config, opts options) error {
 
 	logger := logger()
 	dbConn := dbConnection(conf, logger)
 
+	if opts.dryRun {
+		return printPlannedMigrations(dbConn)
+	}
+
 	err := migrateDb(dbConn, logger)
 	if err != nil {
 		return fmt.Errorf("perform migrations: %s", err)
@@ -57,6 +80,85 @@ func migrateAndPopulateGroupsTable(conf *config.Config) error {
 	return populateGroupsTable(dbConn, conf.TagLength, logger)
 }
 
+func printStatus(conf *config.Config) error {
+	dbConn := dbConnection(conf, logger())
+
+	statuses, err := newMigrator().Status(dbConn.DriverName(), dbConn)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSTATUS\tAPPLIED AT")
+	for _, status := range statuses {
+		if status.Applied {
+			fmt.Fprintf(w, "%s\tapplied\t%s\n", status.Id, status.AppliedAt.UTC().Format(time.RFC3339))
+		} else {
+			fmt.Fprintf(w, "%s\tpending\t-\n", status.Id)
+		}
+	}
+	return w.Flush()
+}
+
+func printPlannedMigrations(dbConn *db.ConnWrapper) error {
+	planned, err := newMigrator().PlanMigrations(dbConn.DriverName(), dbConn, 0)
+	if err != nil {
+		return err
+	}
+
+	printMigrations(planned, migrate.Up)
+	return nil
+}
+
+func rollbackDb(conf *config.Config, opts options) error {
+	logger := logger()
+	dbConn := dbConnection(conf, logger)
+	migrator := newMigrator()
+
+	if opts.dryRun {
+		planned, err := migrator.PlanRollback(dbConn.DriverName(), dbConn, opts.steps)
+		if err != nil {
+			return err
+		}
+		printMigrations(planned, migrate.Down)
+		return nil
+	}
+
+	logger.Info("rolling back migrations", lager.Data{"steps": opts.steps})
+	numRolledBack, err := migrator.RollbackMigrations(dbConn.DriverName(), dbConn, opts.steps)
+	if err != nil {
+		return fmt.Errorf("roll back migrations: %s", err)
+	}
+
+	logger.Info("finished rolling back migrations", lager.Data{
+		"num-migrations-rolled-back": numRolledBack,
+	})
+	return nil
+}
+
+func printMigrations(planned []*migrate.Migration, dir migrate.MigrationDirection) {
+	if len(planned) == 0 {
+		fmt.Println("-- nothing to do")
+		return
+	}
+
+	for _, migration := range planned {
+		statements := migration.Up
+		if dir == migrate.Down {
+			statements = migration.Down
+		}
+
+		fmt.Printf("-- migration %s\n", migration.Id)
+		for _, statement := range statements {
+			fmt.Println(statement)
+		}
+	}
+}
+
+func newMigrator() *migrations.Migrator {
+	return &migrations.Migrator{MigrateAdapter: &migrations.MigrateAdapter{}}
+}
+
 func logger() lager.Logger {
 	logger := lager.NewLogger(fmt.Sprintf("%s.%s", logPrefix, jobPrefix))
 	logger.RegisterSink(common.InitLoggerSink(logger, "DEBUG"))
@@ -79,8 +181,7 @@ func dbConnection(conf *config.Config, logger lager.Logger) *db.ConnWrapper {
 
 func migrateDb(dbConn *db.ConnWrapper, logger lager.Logger) error {
 	logger.Info("running migrations", lager.Data{})
-	migrator := &migrations.Migrator{MigrateAdapter: &migrations.MigrateAdapter{}}
-	numMigrationsRun, err := migrator.PerformMigrations(dbConn.DriverName(), dbConn, 0)
+	numMigrationsRun, err := newMigrator().PerformMigrations(dbConn.DriverName(), dbConn, 0)
 	if err != nil {
 		return err
 	}
@@ -101,8 +202,14 @@ func populateGroupsTable(dbConn *db.ConnWrapper, tagLength int, logger lager.Log
 	return err
 }
 
-func parseConfig() *config.Config {
+func parseConfig() (*config.Config, options) {
 	configFilePath := flag.String("config-file", "", "path to config file")
+	dryRun := flag.Bool("dry-run", false, "print the SQL that would be run instead of running it")
+	steps := flag.Int("steps", 1, "number of migrations to roll back with the down command")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [flags] [up|status|down]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
 	flag.Parse()
 
 	conf, err := config.New(*configFilePath)
@@ -110,5 +217,14 @@ func parseConfig() *config.Config {
 		log.Fatalf("%s.%s: could not read config file: %s", logPrefix, jobPrefix, err)
 	}
 
-	return conf
+	opts := options{
+		command: "up",
+		dryRun:  *dryRun,
+		steps:   *steps,
+	}
+	if flag.NArg() > 0 {
+		opts.command = flag.Arg(0)
+	}
+
+	return conf, opts
 }
@@ -9,12 +9,16 @@ import (
 	"os"
 	"time"
 
+	"lib/poller"
+
 	"policy-server/adapter"
 	"policy-server/api"
 	"policy-server/api/api_v0_internal"
 	"policy-server/cmd/common"
 	"policy-server/config"
 	"policy-server/handlers"
+	"policy-server/rpc"
+	"policy-server/server_metrics"
 	"policy-server/store"
 
 	"policy-server/db"
@@ -25,7 +29,6 @@ import (
 	"code.cloudfoundry.org/cf-networking-helpers/middleware"
 	middlewareAdapter "code.cloudfoundry.org/cf-networking-helpers/middleware/adapter"
 	"code.cloudfoundry.org/cf-networking-helpers/mutualtls"
-	"code.cloudfoundry.org/debugserver"
 	"code.cloudfoundry.org/lager"
 	"github.com/cloudfoundry/dropsonde"
 	"github.com/tedsuo/ifrit"
@@ -36,6 +39,9 @@ import (
 
 const (
 	jobPrefix = "policy-server-internal"
+
+	replicaHealthCheckInterval = 5 * time.Second
+	grpcWatchInterval          = time.Second
 )
 
 var (
@@ -68,9 +74,17 @@ func main() {
 		logger,
 	)
 
+	schemaChecker, err := common.InitSchemaChecker(logger, connectionPool, conf.DegradeOnSchemaMismatch)
+	if err != nil {
+		log.Fatalf("%s.%s: checking database schema: %s", logPrefix, jobPrefix, err)
+	}
+
+	tagQuarantine := time.Duration(conf.TagQuarantine) * time.Second
+	groupTable := &store.GroupTable{Quarantine: tagQuarantine}
+
 	dataStore := store.New(
 		connectionPool,
-		&store.GroupTable{},
+		groupTable,
 		&store.DestinationTable{},
 		&store.PolicyTable{},
 		conf.TagLength,
@@ -82,10 +96,17 @@ func main() {
 		},
 	}
 
-	tagDataStore := store.NewTagStore(connectionPool, &store.GroupTable{}, conf.TagLength)
+	tagDataStore := store.NewTagStore(connectionPool, groupTable, conf.TagLength, tagQuarantine)
 
-	metricsSender := &metrics.MetricsSender{
-		Logger: logger.Session("time-metric-emitter"),
+	metricsRegistry := &server_metrics.Registry{
+		Namespace: "policy_server_internal",
+		Logger:    logger.Session("metrics-registry"),
+	}
+	metricsSender := server_metrics.Senders{
+		&metrics.MetricsSender{
+			Logger: logger.Session("time-metric-emitter"),
+		},
+		metricsRegistry,
 	}
 
 	wrappedStore := &store.MetricsWrapper{
@@ -93,26 +114,74 @@ func main() {
 		TagStore:      tagDataStore,
 		MetricsSender: metricsSender,
 	}
+	metricsRegistry.Sources = common.InitMetricSources(wrappedStore)
 
 	wrappedEgressStore := &store.EgressPolicyMetricsWrapper{
 		Store:         egressDataStore,
 		MetricsSender: metricsSender,
 	}
 
-	errorResponse := &httperror.ErrorResponse{
+	replicaSet, replicaConnections := initReadReplicas(logger, conf, groupTable)
+	policiesStore := &store.ReplicaStore{
+		Store:         wrappedStore,
+		Replicas:      replicaSet,
+		MetricsSender: metricsSender,
+	}
+	policiesEgressStore := &store.ReplicaEgressStore{
+		Primary:       wrappedEgressStore,
+		Replicas:      replicaSet,
 		MetricsSender: metricsSender,
 	}
+
+	snapshotCache := &store.SnapshotCache{
+		Revisions:   &store.RevisionTable{Conn: connectionPool},
+		Store:       policiesStore,
+		EgressStore: policiesEgressStore,
+	}
+	if len(replicaSet.Replicas) > 0 {
+		snapshotCache.MaxAge = replicaSet.MaxLag
+	}
+
+	errorResponse := &handlers.ErrorResponse{
+		ErrorResponse: &httperror.ErrorResponse{
+			MetricsSender: metricsSender,
+		},
+	}
 	payloadValidator := &api.PayloadValidator{PolicyValidator: &api.Validator{}, EgressPolicyValidator: &api.EgressValidator{}}
 	policyMapperV0Internal := api_v0_internal.NewMapper(marshal.UnmarshalFunc(json.Unmarshal), marshal.MarshalFunc(json.Marshal))
 	policyMapperV1 := api.NewMapper(marshal.UnmarshalFunc(json.Unmarshal), marshal.MarshalFunc(json.Marshal), payloadValidator)
 
-	internalPoliciesHandlerV0 := handlers.NewPoliciesIndexInternal(logger, wrappedStore,
-		wrappedEgressStore, policyMapperV0Internal, errorResponse)
-	internalPoliciesHandlerV1 := handlers.NewPoliciesIndexInternal(logger, wrappedStore,
-		wrappedEgressStore, policyMapperV1, errorResponse)
+	tracer, tracerRunner := common.InitTracer(logger, jobPrefix, conf.Tracing)
+	tracedStore := &store.TracingWrapper{Store: policiesStore, Tracer: tracer}
+	tracedEgressStore := &store.EgressPolicyTracingWrapper{Store: policiesEgressStore, Tracer: tracer}
+	tracedSnapshots := &store.SnapshotTracingWrapper{Snapshots: snapshotCache, Tracer: tracer}
+	tracedTagStore := &store.TagTracingWrapper{Store: wrappedStore, Tracer: tracer}
+
+	internalPoliciesHandlerV0 := handlers.NewPoliciesIndexInternal(logger, tracedStore,
+		tracedEgressStore, policyMapperV0Internal, errorResponse)
+	internalPoliciesHandlerV1 := handlers.NewPoliciesIndexInternal(logger, tracedStore,
+		tracedEgressStore, policyMapperV1, errorResponse)
+	internalPoliciesHandlerV0.Snapshots = tracedSnapshots
+	internalPoliciesHandlerV1.Snapshots = tracedSnapshots
+	internalPoliciesHandlerV1.ProtobufMapper = api.NewProtobufMapper()
 
 	createTagsHandlerV1 := &handlers.TagsCreate{
-		Store:         wrappedStore,
+		Store:         tracedTagStore,
+		ErrorResponse: errorResponse,
+	}
+	showTagHandlerV1 := &handlers.TagsShow{
+		Store:         tracedTagStore,
+		RataAdapter:   adapter.RataAdapter{},
+		ErrorResponse: errorResponse,
+	}
+	deleteTagHandlerV1 := &handlers.TagsDelete{
+		Store:         tracedTagStore,
+		RataAdapter:   adapter.RataAdapter{},
+		ErrorResponse: errorResponse,
+	}
+
+	bodyLimiter := &handlers.BodyLimiter{
+		MaxBytes:      conf.MaxRequestBodySize,
 		ErrorResponse: errorResponse,
 	}
 
@@ -126,7 +195,7 @@ func main() {
 			Name:          name,
 			MetricsSender: metricsSender,
 		}
-		return metricsWrapper.Wrap(handler)
+		return metricsWrapper.Wrap(tracer.Wrap(name, handler))
 	}
 
 	logWrapper := middleware.LogWrapper{
@@ -138,10 +207,10 @@ func main() {
 	}
 
 	versionWrap := func(v1Handler, v0Handler http.Handler) http.Handler {
-		return checkVersionWrapper.CheckVersion(map[string]http.Handler{
+		return tracer.Wrap("check-version", checkVersionWrapper.CheckVersion(map[string]http.Handler{
 			"v1": v1Handler,
 			"v0": v0Handler,
-		})
+		}))
 	}
 
 	err = dropsonde.Initialize(conf.MetronAddress, jobPrefix)
@@ -149,17 +218,21 @@ func main() {
 		log.Fatalf("%s.%s: initializing dropsonde: %s", logPrefix, jobPrefix, err)
 	}
 
-	metricsEmitter := common.InitMetricsEmitter(logger, wrappedStore)
+	metricsEmitter := common.InitMetricsEmitter(logger, metricsRegistry.Sources)
 
 	internalRoutes := rata.Routes{
 		{Name: "internal_policies", Method: "GET", Path: "/networking/:version/internal/policies"},
 		{Name: "create_tags", Method: "PUT", Path: "/networking/v1/internal/tags"},
+		{Name: "show_tag", Method: "GET", Path: "/networking/v1/internal/tags/:guid"},
+		{Name: "delete_tag", Method: "DELETE", Path: "/networking/v1/internal/tags/:guid"},
 	}
 	internalHandlers := rata.Handlers{
 		"internal_policies": metricsWrap("InternalPolicies", logWrap(
 			versionWrap(internalPoliciesHandlerV1, internalPoliciesHandlerV0),
 		)),
-		"create_tags": metricsWrap("CreateTags", logWrap(createTagsHandlerV1)),
+		"create_tags": metricsWrap("CreateTags", logWrap(bodyLimiter.Wrap(createTagsHandlerV1))),
+		"show_tag":    metricsWrap("ShowTag", logWrap(showTagHandlerV1)),
+		"delete_tag":  metricsWrap("DeleteTag", logWrap(deleteTagHandlerV1)),
 	}
 
 	tlsConfig, err := mutualtls.NewServerTLSConfig(conf.ServerCertFile, conf.ServerKeyFile, conf.CACertFile)
@@ -168,12 +241,13 @@ func main() {
 	}
 
 	internalServer := common.InitServer(logger, tlsConfig, conf.ListenHost, conf.InternalListenPort, internalHandlers, internalRoutes)
-	debugServer := debugserver.Runner(fmt.Sprintf("%s:%d", conf.DebugServerHost, conf.DebugServerPort), reconfigurableSink)
+	debugServer := common.InitDebugServer(conf.DebugServerHost, conf.DebugServerPort, reconfigurableSink, metricsRegistry)
 
 	uptimeHandler := &handlers.UptimeHandler{
 		StartTime: time.Now(),
 	}
 	healthHandler := handlers.NewHealth(wrappedStore, errorResponse)
+	healthHandler.SchemaChecker = schemaChecker
 
 	healthRoutes := rata.Routes{
 		{Name: "uptime", Method: "GET", Path: "/"},
@@ -189,11 +263,48 @@ func main() {
 		conf.HealthCheckPort, healthHandlers, healthRoutes)
 
 	members := grouper.Members{
+		{"tracer", tracerRunner},
 		{"metrics-emitter", metricsEmitter},
 		{"internal-http-server", internalServer},
 		{"debug-server", debugServer},
 		{"health-check-server", healthCheckServer},
 	}
+	if conf.GRPCListenPort != 0 {
+		snapshotWatcher := &rpc.SnapshotWatcher{
+			Logger:    logger.Session("snapshot-watcher"),
+			Snapshots: snapshotCache,
+		}
+		members = append(members, grouper.Member{"snapshot-watcher", &poller.Poller{
+			Logger:          logger.Session("snapshot-watcher"),
+			PollInterval:    grpcWatchInterval,
+			SingleCycleFunc: snapshotWatcher.Poll,
+		}})
+		members = append(members, grouper.Member{"grpc-server", &rpc.Runner{
+			Logger:    logger.Session("grpc-server"),
+			Address:   fmt.Sprintf("%s:%d", conf.ListenHost, conf.GRPCListenPort),
+			TLSConfig: tlsConfig,
+			Watcher:   snapshotWatcher,
+			Server: &rpc.Server{
+				Logger:          logger.Session("grpc"),
+				Store:           policiesStore,
+				EgressStore:     policiesEgressStore,
+				Snapshots:       snapshotCache,
+				Watcher:         snapshotWatcher,
+				TagStore:        wrappedStore,
+				DatabaseChecker: wrappedStore,
+			},
+		}})
+	}
+	if conf.DegradeOnSchemaMismatch {
+		members = append(members, grouper.Member{"schema-check-poller", common.InitSchemaCheckPoller(logger, schemaChecker)})
+	}
+	if len(replicaSet.Replicas) > 0 {
+		members = append(members, grouper.Member{"replica-health-poller", &poller.Poller{
+			Logger:          logger.Session("replica-health-poller"),
+			PollInterval:    replicaHealthCheckInterval,
+			SingleCycleFunc: replicaSet.CheckHealth,
+		}})
+	}
 
 	logger.Info("starting internal server", lager.Data{"listen-address": conf.ListenHost, "port": conf.InternalListenPort})
 
@@ -204,6 +315,9 @@ func main() {
 	if connectionPool != nil {
 		connectionPool.Close()
 	}
+	for _, replicaConnection := range replicaConnections {
+		replicaConnection.Close()
+	}
 	if err != nil {
 		logger.Error("exited-with-failure", err)
 		os.Exit(1)
@@ -211,3 +325,48 @@ func main() {
 
 	logger.Info("exited")
 }
+
+func initReadReplicas(logger lager.Logger, conf *config.InternalConfig, groupTable *store.GroupTable) (*store.ReplicaSet, []*db.ConnWrapper) {
+	replicas := []*store.ReadReplica{}
+	connections := []*db.ConnWrapper{}
+	for _, replicaConf := range conf.ReadReplicas {
+		name := fmt.Sprintf("%s:%d", replicaConf.Host, replicaConf.Port)
+		// replicas connect on first use, so that an unreachable replica is
+		// only unhealthy instead of stopping the server
+		replicaConn, err := db.NewLazyConnectionPool(
+			replicaConf,
+			conf.MaxOpenConnections,
+			conf.MaxIdleConnections,
+		)
+		if err != nil {
+			log.Fatalf("%s.%s: read replica %s: %s", logPrefix, jobPrefix, name, err)
+		}
+		connections = append(connections, replicaConn)
+
+		replicas = append(replicas, &store.ReadReplica{
+			Name: name,
+			Conn: replicaConn,
+			Store: store.New(
+				replicaConn,
+				groupTable,
+				&store.DestinationTable{},
+				&store.PolicyTable{},
+				conf.TagLength,
+			),
+			EgressStore: &store.EgressPolicyStore{
+				EgressPolicyRepo: &store.EgressPolicyTable{
+					Conn: replicaConn,
+				},
+			},
+		})
+	}
+
+	replicaSet := store.NewReplicaSet(replicas, time.Duration(conf.ReplicaMaxLag)*time.Second)
+	if len(replicas) > 0 {
+		err := replicaSet.CheckHealth()
+		if err != nil {
+			logger.Error("read-replicas-unhealthy", err)
+		}
+	}
+	return replicaSet, connections
+}
@@ -20,8 +20,11 @@ import (
 	"policy-server/cleaner"
 	"policy-server/cmd/common"
 	"policy-server/config"
+	"policy-server/graph"
 	"policy-server/handlers"
 	psmiddleware "policy-server/middleware"
+	"policy-server/redundancy"
+	"policy-server/server_metrics"
 	"policy-server/store"
 	"policy-server/uaa_client"
 
@@ -33,7 +36,6 @@ import (
 	"code.cloudfoundry.org/cf-networking-helpers/metrics"
 	"code.cloudfoundry.org/cf-networking-helpers/middleware"
 	middlewareAdapter "code.cloudfoundry.org/cf-networking-helpers/middleware/adapter"
-	"code.cloudfoundry.org/debugserver"
 	"code.cloudfoundry.org/lager"
 	"github.com/cloudfoundry/dropsonde"
 	"github.com/tedsuo/ifrit"
@@ -45,6 +47,9 @@ import (
 const (
 	jobPrefix       = "policy-server"
 	dropsondeOrigin = "policy-server"
+
+	minTokenKeyRefreshInterval = 30 * time.Second
+	maxTokenKeyAge             = 10 * time.Minute
 )
 
 var (
@@ -85,7 +90,7 @@ func main() {
 		},
 	}
 
-	uaaClient := &uaa_client.Client{
+	rawUAAClient := &uaa_client.Client{
 		BaseURL:    fmt.Sprintf("%s:%d", conf.UAAURL, conf.UAAPort),
 		Name:       conf.UAAClient,
 		Secret:     conf.UAAClientSecret,
@@ -101,7 +106,8 @@ func main() {
 		StartTime: time.Now(),
 	}
 
-	storeGroup := &store.GroupTable{}
+	tagQuarantine := time.Duration(conf.TagQuarantine) * time.Second
+	storeGroup := &store.GroupTable{Quarantine: tagQuarantine}
 	destination := &store.DestinationTable{}
 	policy := &store.PolicyTable{}
 
@@ -116,6 +122,11 @@ func main() {
 	)
 	logger.Info("db connection retrieved", lager.Data{})
 
+	schemaChecker, err := common.InitSchemaChecker(logger, connectionPool, conf.DegradeOnSchemaMismatch)
+	if err != nil {
+		log.Fatalf("%s.%s: checking database schema: %s", logPrefix, jobPrefix, err)
+	}
+
 	egressDataStore := &store.EgressPolicyStore{
 		EgressPolicyRepo: &store.EgressPolicyTable{
 			Conn: connectionPool,
@@ -134,10 +145,17 @@ func main() {
 		log.Fatalf("%s.%s: failed to construct datastore: %s", logPrefix, jobPrefix, err) // not tested
 	}
 
-	tagDataStore := store.NewTagStore(connectionPool, &store.GroupTable{}, conf.TagLength)
+	tagDataStore := store.NewTagStore(connectionPool, storeGroup, conf.TagLength, tagQuarantine)
 
-	metricsSender := &metrics.MetricsSender{
-		Logger: logger.Session("time-metric-emitter"),
+	metricsRegistry := &server_metrics.Registry{
+		Namespace: "policy_server",
+		Logger:    logger.Session("metrics-registry"),
+	}
+	metricsSender := server_metrics.Senders{
+		&metrics.MetricsSender{
+			Logger: logger.Session("time-metric-emitter"),
+		},
+		metricsRegistry,
 	}
 
 	wrappedStore := &store.MetricsWrapper{
@@ -145,11 +163,26 @@ func main() {
 		TagStore:      tagDataStore,
 		MetricsSender: metricsSender,
 	}
+	metricsRegistry.Sources = common.InitMetricSources(wrappedStore)
+
+	uaaClient := &uaa_client.MetricsWrapper{
+		Client:        rawUAAClient,
+		MetricsSender: metricsSender,
+	}
+
+	trashStore := store.NewTrashStore(connectionPool, dataStore)
+
+	var policyTrash store.TrashStore
+	if conf.DeletedPolicyRetentionDays > 0 {
+		policyTrash = trashStore
+	}
 
 	policyCollectionStore := &store.PolicyCollectionStore{
 		Conn:              connectionPool,
 		PolicyStore:       dataStore,
 		EgressPolicyStore: egressDataStore,
+		Trash:             policyTrash,
+		DeleteReason:      store.DeleteReasonUser,
 	}
 
 	wrappedPolicyCollectionStore := &store.PolicyCollectionMetricsWrapper{
@@ -157,44 +190,109 @@ func main() {
 		MetricsSender: metricsSender,
 	}
 
-	errorResponse := &httperror.ErrorResponse{
+	cleanerPolicyCollectionStore := &store.PolicyCollectionMetricsWrapper{
+		Store: &store.PolicyCollectionStore{
+			Conn:              connectionPool,
+			PolicyStore:       dataStore,
+			EgressPolicyStore: egressDataStore,
+			Trash:             policyTrash,
+			DeleteReason:      store.DeleteReasonCleaner,
+		},
 		MetricsSender: metricsSender,
 	}
 
-	ccClient := &cc_client.Client{
-		JSONClient: json_client.New(logger.Session("cc-json-client"), httpClient, conf.CCURL),
-		Logger:     logger,
+	errorResponse := &handlers.ErrorResponse{
+		ErrorResponse: &httperror.ErrorResponse{
+			MetricsSender: metricsSender,
+		},
+	}
+
+	ccClient := &cc_client.CachingClient{
+		Client: &cc_client.MetricsWrapper{
+			Client: &cc_client.Client{
+				JSONClient: json_client.New(logger.Session("cc-json-client"), httpClient, conf.CCURL),
+				Logger:     logger,
+			},
+			MetricsSender: metricsSender,
+		},
+		TTL:           time.Duration(conf.CCCacheTTL) * time.Second,
+		MetricsSender: metricsSender,
 	}
 
-	policyGuard := handlers.NewPolicyGuard(uaaClient, ccClient)
-	quotaGuard := handlers.NewQuotaGuard(wrappedStore, conf.MaxPolicies)
-	policyFilter := handlers.NewPolicyFilter(uaaClient, ccClient, 100)
+	tracer, tracerRunner := common.InitTracer(logger, jobPrefix, conf.Tracing)
+	tracedUAAClient := &uaa_client.TracingWrapper{Client: uaaClient, Tracer: tracer}
+	tracedCCClient := &cc_client.TracingWrapper{Client: ccClient, Tracer: tracer}
+	tracedStore := &store.TracingWrapper{Store: wrappedStore, Tracer: tracer}
+	tracedPolicyCollectionStore := &store.PolicyCollectionTracingWrapper{Store: wrappedPolicyCollectionStore, Tracer: tracer}
+	tracedEgressStore := &store.EgressPolicyTracingWrapper{Store: egressDataStore, Tracer: tracer}
+
+	var tokenChecker handlers.UAAClient = tracedUAAClient
+	if conf.LocalTokenValidation {
+		tokenValidator := &uaa_client.TokenValidator{
+			Keys:                  uaaClient,
+			Issuer:                conf.UAAIssuer,
+			MinKeyRefreshInterval: minTokenKeyRefreshInterval,
+			MaxKeyAge:             maxTokenKeyAge,
+			Logger:                logger.Session("token-validator"),
+		}
+		if conf.CheckTokenFallback {
+			tokenValidator.Fallback = uaaClient
+		}
+		tokenChecker = &uaa_client.TokenCheckerTracingWrapper{Checker: tokenValidator, Tracer: tracer}
+	}
+
+	authz := conf.Authorization
+	serviceAccounts := newServiceAccounts(conf.ServiceAccounts)
+	createPolicyGuard := handlers.NewPolicyGuard(tracedUAAClient, tracedCCClient, permission(authz.CreateC2C), permission(authz.CreateEgress), serviceAccounts)
+	deletePolicyGuard := handlers.NewPolicyGuard(tracedUAAClient, tracedCCClient, permission(authz.Delete), permission(authz.CreateEgress), serviceAccounts)
+	quotaGuard := handlers.NewQuotaGuard(tracedStore, conf.MaxPolicies)
+	policyFilter := handlers.NewPolicyFilter(tracedUAAClient, tracedCCClient, 100, permission(authz.Read), serviceAccounts)
 
 	payloadValidator := &api.PayloadValidator{PolicyValidator: &api.Validator{}, EgressPolicyValidator: &api.EgressValidator{}}
 	policyMapperV0 := api_v0.NewMapper(marshal.UnmarshalFunc(json.Unmarshal), marshal.MarshalFunc(json.Marshal), &api_v0.Validator{})
 	policyMapperV1 := api.NewMapper(marshal.UnmarshalFunc(json.Unmarshal), marshal.MarshalFunc(json.Marshal), payloadValidator)
 
-	createPolicyHandlerV1 := handlers.NewPoliciesCreate(wrappedPolicyCollectionStore, policyMapperV1,
-		policyGuard, quotaGuard, errorResponse)
-	createPolicyHandlerV0 := handlers.NewPoliciesCreate(wrappedPolicyCollectionStore, policyMapperV0,
-		policyGuard, quotaGuard, errorResponse)
+	createPolicyHandlerV1 := handlers.NewPoliciesCreate(tracedPolicyCollectionStore, policyMapperV1,
+		createPolicyGuard, quotaGuard, errorResponse)
+	createPolicyHandlerV0 := handlers.NewPoliciesCreate(tracedPolicyCollectionStore, policyMapperV0,
+		createPolicyGuard, quotaGuard, errorResponse)
+
+	deletePolicyHandlerV1 := handlers.NewPoliciesDelete(tracedPolicyCollectionStore, policyMapperV1,
+		deletePolicyGuard, errorResponse)
+	deletePolicyHandlerV0 := handlers.NewPoliciesDelete(tracedPolicyCollectionStore, policyMapperV0,
+		deletePolicyGuard, errorResponse)
+
+	policiesIndexHandlerV1 := handlers.NewPoliciesIndex(tracedStore, tracedEgressStore, policyMapperV1, policyFilter, errorResponse)
+	policiesIndexHandlerV0 := handlers.NewPoliciesIndex(tracedStore, tracedEgressStore, policyMapperV0, policyFilter, errorResponse)
 
-	deletePolicyHandlerV1 := handlers.NewPoliciesDelete(wrappedPolicyCollectionStore, policyMapperV1,
-		policyGuard, errorResponse)
-	deletePolicyHandlerV0 := handlers.NewPoliciesDelete(wrappedPolicyCollectionStore, policyMapperV0,
-		policyGuard, errorResponse)
+	policyCleaner := cleaner.NewPolicyCleaner(logger.Session("policy-cleaner"), wrappedStore, egressDataStore,
+		cleanerPolicyCollectionStore, wrappedStore, uaaClient, ccClient, metricsSender, 100, time.Duration(5)*time.Second,
+		cleaner.DeletionLimit{
+			Count:   conf.CleanupMaxDeletions,
+			Percent: conf.CleanupMaxDeletionsPercent,
+		})
+
+	policiesCleanupHandler := handlers.NewPoliciesCleanup(marshal.MarshalFunc(json.Marshal), policyCleaner, errorResponse)
+
+	tagsIndexHandler := handlers.NewTagsIndex(wrappedStore, policyFilter, marshal.MarshalFunc(json.Marshal), errorResponse)
 
-	policiesIndexHandlerV1 := handlers.NewPoliciesIndex(wrappedStore, egressDataStore, policyMapperV1, policyFilter, errorResponse)
-	policiesIndexHandlerV0 := handlers.NewPoliciesIndex(wrappedStore, egressDataStore, policyMapperV0, policyFilter, errorResponse)
+	graphBuilder := graph.NewBuilder(uaaClient, ccClient, 100)
+	policiesGraphHandler := handlers.NewPoliciesGraph(wrappedStore, tracedEgressStore, graphBuilder,
+		marshal.MarshalFunc(json.Marshal), errorResponse)
 
-	policyCleaner := cleaner.NewPolicyCleaner(logger.Session("policy-cleaner"), wrappedStore, uaaClient,
-		ccClient, 100, time.Duration(5)*time.Second)
+	policiesRedundancyHandler := handlers.NewPoliciesRedundancy(wrappedStore, marshal.MarshalFunc(json.Marshal), errorResponse)
 
-	policiesCleanupHandler := handlers.NewPoliciesCleanup(policyMapperV1, policyCleaner, errorResponse)
+	policyCompactor := redundancy.NewCompactor(connectionPool, wrappedStore)
+	policiesCompactHandler := handlers.NewPoliciesCompact(policyCompactor, marshal.MarshalFunc(json.Marshal), errorResponse)
 
-	tagsIndexHandler := handlers.NewTagsIndex(wrappedStore, marshal.MarshalFunc(json.Marshal), errorResponse)
+	policiesDeletedIndexHandler := handlers.NewPoliciesDeletedIndex(trashStore, marshal.MarshalFunc(json.Marshal), errorResponse)
+	policiesRestoreHandler := handlers.NewPoliciesRestore(trashStore, marshal.MarshalFunc(json.Marshal), errorResponse)
+
+	trashPurger := cleaner.NewTrashPurger(logger.Session("deleted-policies-purger"), trashStore,
+		time.Duration(conf.DeletedPolicyRetentionDays)*24*time.Hour)
 
 	healthHandler := handlers.NewHealth(wrappedStore, errorResponse)
+	healthHandler.SchemaChecker = schemaChecker
 
 	checkVersionWrapper := &handlers.CheckVersionWrapper{
 		ErrorResponse: errorResponse,
@@ -206,7 +304,7 @@ func main() {
 			Name:          name,
 			MetricsSender: metricsSender,
 		}
-		return metricsWrapper.Wrap(handler)
+		return metricsWrapper.Wrap(tracer.Wrap(name, handler))
 	}
 
 	logWrapper := middleware.LogWrapper{
@@ -218,32 +316,88 @@ func main() {
 	}
 
 	versionWrap := func(v1Handler, v0Handler http.Handler) http.Handler {
-		return checkVersionWrapper.CheckVersion(map[string]http.Handler{
+		return tracer.Wrap("check-version", checkVersionWrapper.CheckVersion(map[string]http.Handler{
 			"v1": v1Handler,
 			"v0": v0Handler,
-		})
+		}))
+	}
+
+	rateLimiter := &handlers.RateLimiter{
+		UserLimit:     handlers.RateLimit{Rate: conf.UserRateLimit, Burst: conf.UserRateLimitBurst},
+		GlobalLimit:   handlers.RateLimit{Rate: conf.GlobalRateLimit, Burst: conf.GlobalRateLimitBurst},
+		MetricsSender: metricsSender,
+		ErrorResponse: errorResponse,
+	}
+	bodyLimiter := &handlers.BodyLimiter{
+		MaxBytes:      conf.MaxRequestBodySize,
+		ErrorResponse: errorResponse,
 	}
 
 	authAdminWrap := func(handler http.Handler) http.Handler {
 		networkAdminAuthenticator := handlers.Authenticator{
-			Client:        uaaClient,
+			Client:        tokenChecker,
 			Scopes:        []string{"network.admin"},
 			ErrorResponse: errorResponse,
 			ScopeChecking: true,
+			Tracer:        tracer,
 		}
-		return networkAdminAuthenticator.Wrap(handler)
+		return rateLimiter.WrapGlobal(networkAdminAuthenticator.Wrap(rateLimiter.Wrap(bodyLimiter.Wrap(tracer.Wrap("handler", handler)))))
 	}
 
-	authWriteWrap := func(handler http.Handler) http.Handler {
+	// Users who are only allowed by a cc role also need network.write, unless
+	// space developer self service is enabled. Service accounts need neither.
+	authWriteWrap := func(scopes []string, handler http.Handler) http.Handler {
 		networkWriteAuthenticator := handlers.Authenticator{
-			Client:        uaaClient,
-			Scopes:        []string{"network.admin", "network.write"},
+			Client:          tokenChecker,
+			Scopes:          append([]string{"network.write"}, scopes...),
+			ErrorResponse:   errorResponse,
+			ScopeChecking:   !conf.EnableSpaceDeveloperSelfService,
+			ServiceAccounts: serviceAccounts,
+			Tracer:          tracer,
+		}
+		return rateLimiter.WrapGlobal(networkWriteAuthenticator.Wrap(rateLimiter.Wrap(bodyLimiter.Wrap(tracer.Wrap("handler", handler)))))
+	}
+
+	// network.read allows the same reads as network.write, but no writes.
+	authReadWrap := func(handler http.Handler) http.Handler {
+		networkReadAuthenticator := handlers.Authenticator{
+			Client:          tokenChecker,
+			Scopes:          append([]string{"network.read", "network.write"}, authz.Read.Scopes...),
+			ErrorResponse:   errorResponse,
+			ScopeChecking:   !conf.EnableSpaceDeveloperSelfService,
+			ServiceAccounts: serviceAccounts,
+			Tracer:          tracer,
+		}
+		return rateLimiter.WrapGlobal(networkReadAuthenticator.Wrap(rateLimiter.Wrap(bodyLimiter.Wrap(tracer.Wrap("handler", handler)))))
+	}
+
+	// Tags and whoami were admin only before network.read existed, so they
+	// keep checking scopes even with space developer self service.
+	authReadScopeWrap := func(handler http.Handler) http.Handler {
+		networkReadScopeAuthenticator := handlers.Authenticator{
+			Client:        tokenChecker,
+			Scopes:        []string{"network.read", "network.admin"},
+			ErrorResponse: errorResponse,
+			ScopeChecking: true,
+			Tracer:        tracer,
+		}
+		return rateLimiter.WrapGlobal(networkReadScopeAuthenticator.Wrap(rateLimiter.Wrap(bodyLimiter.Wrap(tracer.Wrap("handler", handler)))))
+	}
+
+	authCleanupWrap := func(handler http.Handler) http.Handler {
+		cleanupAuthenticator := handlers.Authenticator{
+			Client:        tokenChecker,
+			Scopes:        authz.Cleanup.Scopes,
 			ErrorResponse: errorResponse,
-			ScopeChecking: !conf.EnableSpaceDeveloperSelfService,
+			ScopeChecking: true,
+			Tracer:        tracer,
 		}
-		return networkWriteAuthenticator.Wrap(handler)
+		return rateLimiter.WrapGlobal(cleanupAuthenticator.Wrap(rateLimiter.Wrap(bodyLimiter.Wrap(tracer.Wrap("handler", handler)))))
 	}
 
+	createScopes := append(append([]string{}, authz.CreateC2C.Scopes...), authz.CreateEgress.Scopes...)
+	deleteScopes := append(append([]string{}, authz.Delete.Scopes...), authz.CreateEgress.Scopes...)
+
 	externalRoutes := rata.Routes{
 		{Name: "uptime", Method: "GET", Path: "/"},
 		{Name: "uptime", Method: "GET", Path: "/networking"},
@@ -253,8 +407,19 @@ func main() {
 		{Name: "delete_policies", Method: "POST", Path: "/networking/:version/external/policies/delete"},
 		{Name: "policies_index", Method: "GET", Path: "/networking/:version/external/policies"},
 		{Name: "cleanup", Method: "POST", Path: "/networking/:version/external/policies/cleanup"},
+		{Name: "policies_graph", Method: "GET", Path: "/networking/:version/external/policies/graph"},
+		{Name: "policies_redundant", Method: "GET", Path: "/networking/:version/external/policies/redundant"},
+		{Name: "policies_compact", Method: "POST", Path: "/networking/:version/external/policies/compact"},
 		{Name: "tags_index", Method: "GET", Path: "/networking/:version/external/tags"},
 	}
+	// Without a retention period no deleted policies are kept, so the routes
+	// that list and restore them are left out and answer 404.
+	if conf.DeletedPolicyRetentionDays > 0 {
+		externalRoutes = append(externalRoutes,
+			rata.Route{Name: "policies_deleted", Method: "GET", Path: "/networking/:version/external/policies/deleted"},
+			rata.Route{Name: "policies_restore", Method: "POST", Path: "/networking/:version/external/policies/restore"},
+		)
+	}
 
 	corsMiddleware := psmiddleware.CORS{}
 	externalRoutesWithOptions := corsMiddleware.AddOptionsRoutes("options", externalRoutes)
@@ -274,22 +439,38 @@ func main() {
 		"health": corsOptionsWrapper(metricsWrap("Health", logWrap(healthHandler))),
 
 		"create_policies": corsOptionsWrapper(metricsWrap("CreatePolicies",
-			logWrap(versionWrap(authWriteWrap(createPolicyHandlerV1), authWriteWrap(createPolicyHandlerV0))))),
+			logWrap(versionWrap(authWriteWrap(createScopes, createPolicyHandlerV1), authWriteWrap(createScopes, createPolicyHandlerV0))))),
 
 		"delete_policies": corsOptionsWrapper(metricsWrap("DeletePolicies",
-			logWrap(versionWrap(authWriteWrap(deletePolicyHandlerV1), authWriteWrap(deletePolicyHandlerV0))))),
+			logWrap(versionWrap(authWriteWrap(deleteScopes, deletePolicyHandlerV1), authWriteWrap(deleteScopes, deletePolicyHandlerV0))))),
 
 		"policies_index": corsOptionsWrapper(metricsWrap("PoliciesIndex",
-			logWrap(versionWrap(authWriteWrap(policiesIndexHandlerV1), authWriteWrap(policiesIndexHandlerV0))))),
+			logWrap(versionWrap(authReadWrap(policiesIndexHandlerV1), authReadWrap(policiesIndexHandlerV0))))),
 
 		"cleanup": corsOptionsWrapper(metricsWrap("Cleanup",
-			logWrap(versionWrap(authAdminWrap(policiesCleanupHandler), authAdminWrap(policiesCleanupHandler))))),
+			logWrap(versionWrap(authCleanupWrap(policiesCleanupHandler), authCleanupWrap(policiesCleanupHandler))))),
+
+		"policies_graph": corsOptionsWrapper(metricsWrap("PoliciesGraph",
+			logWrap(versionWrap(authAdminWrap(policiesGraphHandler), authAdminWrap(policiesGraphHandler))))),
+
+		"policies_redundant": corsOptionsWrapper(metricsWrap("PoliciesRedundant",
+			logWrap(versionWrap(authAdminWrap(policiesRedundancyHandler), authAdminWrap(policiesRedundancyHandler))))),
+
+		"policies_compact": corsOptionsWrapper(metricsWrap("PoliciesCompact",
+			logWrap(versionWrap(authAdminWrap(policiesCompactHandler), authAdminWrap(policiesCompactHandler))))),
 
 		"tags_index": corsOptionsWrapper(metricsWrap("TagsIndex",
-			logWrap(versionWrap(authAdminWrap(tagsIndexHandler), authAdminWrap(tagsIndexHandler))))),
+			logWrap(versionWrap(authReadScopeWrap(tagsIndexHandler), authReadScopeWrap(tagsIndexHandler))))),
 
 		"whoami": corsOptionsWrapper(metricsWrap("WhoAmI",
-			logWrap(versionWrap(authAdminWrap(whoamiHandler), authAdminWrap(whoamiHandler))))),
+			logWrap(versionWrap(authReadScopeWrap(whoamiHandler), authReadScopeWrap(whoamiHandler))))),
+	}
+	if conf.DeletedPolicyRetentionDays > 0 {
+		externalHandlers["policies_deleted"] = corsOptionsWrapper(metricsWrap("PoliciesDeleted",
+			logWrap(versionWrap(authAdminWrap(policiesDeletedIndexHandler), authAdminWrap(policiesDeletedIndexHandler)))))
+
+		externalHandlers["policies_restore"] = corsOptionsWrapper(metricsWrap("PoliciesRestore",
+			logWrap(versionWrap(authAdminWrap(policiesRestoreHandler), authAdminWrap(policiesRestoreHandler)))))
 	}
 
 	err = dropsonde.Initialize(conf.MetronAddress, dropsondeOrigin)
@@ -297,17 +478,23 @@ func main() {
 		log.Fatalf("%s.%s: initializing dropsonde: %s", logPrefix, jobPrefix, err)
 	}
 
-	metricsEmitter := common.InitMetricsEmitter(logger, wrappedStore)
+	metricsEmitter := common.InitMetricsEmitter(logger, metricsRegistry.Sources)
 	externalServer := common.InitServer(logger, nil, conf.ListenHost, conf.ListenPort, externalHandlers, externalRoutesWithOptions)
 	poller := initPoller(logger, conf, policyCleaner)
-	debugServer := debugserver.Runner(fmt.Sprintf("%s:%d", conf.DebugServerHost, conf.DebugServerPort), reconfigurableSink)
+	purgePoller := initPurgePoller(logger, conf, trashPurger)
+	debugServer := common.InitDebugServer(conf.DebugServerHost, conf.DebugServerPort, reconfigurableSink, metricsRegistry)
 
 	members := grouper.Members{
+		{"tracer", tracerRunner},
 		{"metrics_emitter", metricsEmitter},
 		{"http_server", externalServer},
 		{"policy-cleaner-poller", poller},
+		{"deleted-policies-purger-poller", purgePoller},
 		{"debug-server", debugServer},
 	}
+	if conf.DegradeOnSchemaMismatch {
+		members = append(members, grouper.Member{"schema-check-poller", common.InitSchemaCheckPoller(logger, schemaChecker)})
+	}
 
 	logger.Info("starting external server", lager.Data{"listen-address": conf.ListenHost, "port": conf.ListenPort})
 
@@ -326,6 +513,25 @@ func main() {
 	logger.Info("exited")
 }
 
+func permission(p config.Permission) handlers.Permission {
+	return handlers.Permission{
+		Scopes:  p.Scopes,
+		CCRoles: p.CCRoles,
+	}
+}
+
+func newServiceAccounts(accounts []config.ServiceAccount) handlers.ServiceAccounts {
+	serviceAccounts := []handlers.ServiceAccount{}
+	for _, account := range accounts {
+		serviceAccounts = append(serviceAccounts, handlers.ServiceAccount{
+			ClientID:   account.ClientID,
+			OrgGUIDs:   account.OrgGUIDs,
+			SpaceGUIDs: account.SpaceGUIDs,
+		})
+	}
+	return handlers.NewServiceAccounts(serviceAccounts)
+}
+
 func initPoller(logger lager.Logger, conf *config.Config, policyCleaner *cleaner.PolicyCleaner) ifrit.Runner {
 	pollInterval := time.Duration(conf.CleanupInterval) * time.Second
 
@@ -335,3 +541,13 @@ func initPoller(logger lager.Logger, conf *config.Config, policyCleaner *cleaner
 		SingleCycleFunc: policyCleaner.DeleteStalePoliciesWrapper,
 	}
 }
+
+func initPurgePoller(logger lager.Logger, conf *config.Config, trashPurger *cleaner.TrashPurger) ifrit.Runner {
+	pollInterval := time.Duration(conf.CleanupInterval) * time.Second
+
+	return &poller.Poller{
+		Logger:          logger.Session("deleted-policies-purger-poller"),
+		PollInterval:    pollInterval,
+		SingleCycleFunc: trashPurger.PurgeExpired,
+	}
+}
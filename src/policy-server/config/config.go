@@ -2,8 +2,10 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"policy-server/cc_client"
 
 	validator "gopkg.in/validator.v2"
 
@@ -21,14 +23,23 @@ type Config struct {
 	UAACA                           string    `json:"uaa_ca"`
 	UAAURL                          string    `json:"uaa_url" validate:"nonzero"`
 	UAAPort                         int       `json:"uaa_port" validate:"nonzero"`
+	UAAIssuer                       string    `json:"uaa_issuer"`
+	LocalTokenValidation            bool      `json:"local_token_validation"`
+	CheckTokenFallback              bool      `json:"check_token_fallback"`
 	CCURL                           string    `json:"cc_url" validate:"nonzero"`
+	CCCacheTTL                      int       `json:"cc_cache_ttl" validate:"min=0"`
+	CCPolicyRoles                   []string  `json:"cc_policy_roles"`
 	SkipSSLValidation               bool      `json:"skip_ssl_validation"`
 	Database                        db.Config `json:"database" validate:"nonzero"`
 	DatabaseMigrationTimeout        int       `json:"database_migration_timeout" validate:"min=1"`
 	TagLength                       int       `json:"tag_length" validate:"nonzero"`
+	TagQuarantine                   int       `json:"tag_quarantine" validate:"min=0"`
 	MetronAddress                   string    `json:"metron_address" validate:"nonzero"`
 	LogLevel                        string    `json:"log_level"`
 	CleanupInterval                 int       `json:"cleanup_interval" validate:"min=1"`
+	CleanupMaxDeletions             int       `json:"cleanup_max_deletions" validate:"min=0"`
+	CleanupMaxDeletionsPercent      int       `json:"cleanup_max_deletions_percent" validate:"min=0,max=100"`
+	DeletedPolicyRetentionDays      int       `json:"deleted_policy_retention_days" validate:"min=0"`
 	CCAppRequestChunkSize           int       `json:"cc_app_request_chunk_size"`
 	RequestTimeout                  int       `json:"request_timeout" validate:"min=1"`
 	MaxPolicies                     int       `json:"max_policies" validate:"min=1"`
@@ -36,10 +47,113 @@ type Config struct {
 	AllowedCORSDomains              []string  `json:"allowed_cors_domains"`
 	MaxIdleConnections              int       `json:"max_idle_connections" validate:"min=0"`
 	MaxOpenConnections              int       `json:"max_open_connections" validate:"min=0"`
+	DegradeOnSchemaMismatch         bool      `json:"degrade_on_schema_mismatch"`
+	UserRateLimit                   int       `json:"user_rate_limit" validate:"min=0"`
+	UserRateLimitBurst              int       `json:"user_rate_limit_burst" validate:"min=0"`
+	GlobalRateLimit                 int       `json:"global_rate_limit" validate:"min=0"`
+	GlobalRateLimitBurst            int       `json:"global_rate_limit_burst" validate:"min=0"`
+	MaxRequestBodySize              int64     `json:"max_request_body_size" validate:"min=0"`
+
+	Authorization   Authorization    `json:"authorization"`
+	ServiceAccounts []ServiceAccount `json:"service_accounts"`
+	Tracing         Tracing          `json:"tracing"`
+}
+
+// ServiceAccount lets the UAA client ClientID manage policies for apps in the
+// listed orgs and spaces with its client credentials tokens.
+type ServiceAccount struct {
+	ClientID   string   `json:"client_id" validate:"nonzero"`
+	OrgGUIDs   []string `json:"org_guids"`
+	SpaceGUIDs []string `json:"space_guids"`
+}
+
+// Permission allows an action to users with any of Scopes for all apps, and
+// to users with any of CCRoles for the apps in spaces where they hold the role.
+type Permission struct {
+	Scopes  []string `json:"scopes"`
+	CCRoles []string `json:"cc_roles"`
+}
+
+// Authorization maps each policy management action to who may perform it.
+// Lists left out of the config get the defaults in setDefaults; an empty list
+// grants nothing.
+type Authorization struct {
+	Read         Permission `json:"read"`
+	CreateC2C    Permission `json:"create_c2c"`
+	Delete       Permission `json:"delete"`
+	CreateEgress Permission `json:"create_egress"`
+	Cleanup      Permission `json:"cleanup"`
+}
+
+// setDefaults keeps the behaviour from before authorization was configurable:
+// network.admin may do everything, and users with one of ccPolicyRoles may
+// read, create and delete c2c policies in their spaces.
+func (a *Authorization) setDefaults(ccPolicyRoles []string) {
+	if len(ccPolicyRoles) == 0 {
+		ccPolicyRoles = []string{cc_client.RoleSpaceDeveloper}
+	}
+
+	for _, p := range []*Permission{&a.Read, &a.CreateC2C, &a.Delete, &a.CreateEgress, &a.Cleanup} {
+		if p.Scopes == nil {
+			p.Scopes = []string{"network.admin"}
+		}
+	}
+	for _, p := range []*Permission{&a.Read, &a.CreateC2C, &a.Delete} {
+		if p.CCRoles == nil {
+			p.CCRoles = ccPolicyRoles
+		}
+	}
+}
+
+func (a *Authorization) validate() error {
+	permissions := map[string]Permission{
+		"Read":         a.Read,
+		"CreateC2C":    a.CreateC2C,
+		"Delete":       a.Delete,
+		"CreateEgress": a.CreateEgress,
+		"Cleanup":      a.Cleanup,
+	}
+	for name, p := range permissions {
+		for _, role := range p.CCRoles {
+			if !cc_client.IsValidRole(role) {
+				return fmt.Errorf("Authorization.%s: unknown role type: %s", name, role)
+			}
+		}
+	}
+
+	if len(a.Cleanup.CCRoles) > 0 {
+		return errors.New("Authorization.Cleanup: cc_roles are not supported")
+	}
+	return nil
 }
 
 func (c *Config) Validate() error {
-	return validator.Validate(c)
+	err := validator.Validate(c)
+	if err != nil {
+		return err
+	}
+
+	if c.LocalTokenValidation && c.UAAIssuer == "" {
+		return errors.New("UAAIssuer: required for local token validation")
+	}
+
+	for _, role := range c.CCPolicyRoles {
+		if !cc_client.IsValidRole(role) {
+			return fmt.Errorf("CCPolicyRoles: unknown role type: %s", role)
+		}
+	}
+
+	for _, account := range c.ServiceAccounts {
+		if len(account.OrgGUIDs) == 0 && len(account.SpaceGUIDs) == 0 {
+			return fmt.Errorf("ServiceAccounts: %s: needs org_guids or space_guids", account.ClientID)
+		}
+	}
+
+	if err := c.Tracing.validate(); err != nil {
+		return err
+	}
+
+	return c.Authorization.validate()
 }
 
 func New(path string) (*Config, error) {
@@ -53,6 +167,7 @@ func New(path string) (*Config, error) {
 	if err != nil {
 		return nil, fmt.Errorf("parsing config: %s", err)
 	}
+	cfg.Authorization.setDefaults(cfg.CCPolicyRoles)
 
 	if err := cfg.Validate(); err != nil {
 		return &cfg, fmt.Errorf("invalid config: %s", err)
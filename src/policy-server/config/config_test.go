@@ -38,7 +38,12 @@ var _ = Describe("Config", func() {
 					"uaa_url": "http://uaa.example.com",
 					"uaa_port": 8888,
 					"uaa_ca": "some/uaa/ca/file",
+					"uaa_issuer": "https://uaa.example.com/oauth/token",
+					"local_token_validation": true,
+					"check_token_fallback": true,
 					"cc_url": "http://ccapi.example.com",
+					"cc_cache_ttl": 30,
+					"cc_policy_roles": ["space_developer", "organization_manager"],
 					"skip_ssl_validation": true,
 					"database": {
 						"type": "mysql",
@@ -55,13 +60,27 @@ var _ = Describe("Config", func() {
 					"max_idle_connections": 4,
 					"max_open_connections": 5,
 					"tag_length": 2,
+					"tag_quarantine": 600,
+					"degrade_on_schema_mismatch": true,
+					"user_rate_limit": 10,
+					"user_rate_limit_burst": 20,
+					"global_rate_limit": 100,
+					"global_rate_limit_burst": 200,
+					"max_request_body_size": 2048,
 					"metron_address": "http://1.2.3.4:9999",
 					"log_level": "debug",
 					"cleanup_interval": 2,
+					"cleanup_max_deletions": 100,
+					"cleanup_max_deletions_percent": 25,
+					"deleted_policy_retention_days": 7,
 					"request_timeout": 5,
 					"max_policies": 3,
 					"enable_space_developer_self_service": true,
-					"allowed_cors_domains": ["https://foo.bar", "https://bar.foo"]
+					"allowed_cors_domains": ["https://foo.bar", "https://bar.foo"],
+					"authorization": {
+						"read": {"scopes": ["network.admin"], "cc_roles": ["space_developer", "space_auditor"]},
+						"create_egress": {"scopes": ["network.admin"], "cc_roles": ["space_manager"]}
+					}
 				}`)
 				c, err := config.New(file.Name())
 				Expect(err).NotTo(HaveOccurred())
@@ -75,7 +94,12 @@ var _ = Describe("Config", func() {
 				Expect(c.UAAURL).To(Equal("http://uaa.example.com"))
 				Expect(c.UAAPort).To(Equal(8888))
 				Expect(c.UAACA).To(Equal("some/uaa/ca/file"))
+				Expect(c.UAAIssuer).To(Equal("https://uaa.example.com/oauth/token"))
+				Expect(c.LocalTokenValidation).To(BeTrue())
+				Expect(c.CheckTokenFallback).To(BeTrue())
 				Expect(c.CCURL).To(Equal("http://ccapi.example.com"))
+				Expect(c.CCCacheTTL).To(Equal(30))
+				Expect(c.CCPolicyRoles).To(Equal([]string{"space_developer", "organization_manager"}))
 				Expect(c.SkipSSLValidation).To(Equal(true))
 				Expect(c.Database.Type).To(Equal("mysql"))
 				Expect(c.Database.User).To(Equal("root"))
@@ -90,9 +114,19 @@ var _ = Describe("Config", func() {
 				Expect(c.MaxIdleConnections).To(Equal(4))
 				Expect(c.MaxOpenConnections).To(Equal(5))
 				Expect(c.TagLength).To(Equal(2))
+				Expect(c.TagQuarantine).To(Equal(600))
+				Expect(c.DegradeOnSchemaMismatch).To(BeTrue())
+				Expect(c.UserRateLimit).To(Equal(10))
+				Expect(c.UserRateLimitBurst).To(Equal(20))
+				Expect(c.GlobalRateLimit).To(Equal(100))
+				Expect(c.GlobalRateLimitBurst).To(Equal(200))
+				Expect(c.MaxRequestBodySize).To(Equal(int64(2048)))
 				Expect(c.MetronAddress).To(Equal("http://1.2.3.4:9999"))
 				Expect(c.LogLevel).To(Equal("debug"))
 				Expect(c.CleanupInterval).To(Equal(2))
+				Expect(c.CleanupMaxDeletions).To(Equal(100))
+				Expect(c.CleanupMaxDeletionsPercent).To(Equal(25))
+				Expect(c.DeletedPolicyRetentionDays).To(Equal(7))
 				Expect(c.RequestTimeout).To(Equal(5))
 				Expect(c.MaxPolicies).To(Equal(3))
 				Expect(c.EnableSpaceDeveloperSelfService).To(BeTrue())
@@ -100,6 +134,14 @@ var _ = Describe("Config", func() {
 					"https://foo.bar",
 					"https://bar.foo",
 				}))
+				Expect(c.Authorization.Read).To(Equal(config.Permission{
+					Scopes:  []string{"network.admin"},
+					CCRoles: []string{"space_developer", "space_auditor"},
+				}))
+				Expect(c.Authorization.CreateEgress).To(Equal(config.Permission{
+					Scopes:  []string{"network.admin"},
+					CCRoles: []string{"space_manager"},
+				}))
 			})
 		})
 
@@ -186,6 +228,289 @@ var _ = Describe("Config", func() {
 			Entry("missing database migration timeout", "database_migration_timeout", "DatabaseMigrationTimeout: less than min"),
 		)
 
+		Context("when the cleanup max deletions percent is over 100", func() {
+			It("returns a meaningful error", func() {
+				allData := map[string]interface{}{
+					"listen_host":       "http://1.2.3.4",
+					"listen_port":       1234,
+					"log_prefix":        "cfnetworking",
+					"debug_server_host": "http://4.4.4.4",
+					"debug_server_port": 3333,
+					"uaa_client":        "some-uaa-client",
+					"uaa_client_secret": "some-uaa-client-secret",
+					"uaa_url":           "http://uaa.example.com",
+					"uaa_port":          5555,
+					"cc_url":            "http://ccapi.example.com",
+					"database": map[string]interface{}{
+						"type":          "mysql",
+						"user":          "root",
+						"password":      "password",
+						"host":          "127.0.0.1",
+						"port":          3306,
+						"timeout":       5,
+						"database_name": "network_policy",
+					},
+					"database_migration_timeout":    88,
+					"tag_length":                    2,
+					"metron_address":                "http://1.2.3.4:9999",
+					"cleanup_interval":              2,
+					"cleanup_max_deletions_percent": 101,
+					"request_timeout":               5,
+					"max_policies":                  3,
+				}
+				Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+
+				_, err = config.New(file.Name())
+				Expect(err).To(MatchError("invalid config: CleanupMaxDeletionsPercent: greater than max"))
+			})
+		})
+
+		Context("when local token validation is enabled without a uaa issuer", func() {
+			It("returns a meaningful error", func() {
+				allData := map[string]interface{}{
+					"listen_host":       "http://1.2.3.4",
+					"listen_port":       1234,
+					"log_prefix":        "cfnetworking",
+					"debug_server_host": "http://4.4.4.4",
+					"debug_server_port": 3333,
+					"uaa_client":        "some-uaa-client",
+					"uaa_client_secret": "some-uaa-client-secret",
+					"uaa_url":           "http://uaa.example.com",
+					"uaa_port":          5555,
+					"cc_url":            "http://ccapi.example.com",
+					"database": map[string]interface{}{
+						"type":          "mysql",
+						"user":          "root",
+						"password":      "password",
+						"host":          "127.0.0.1",
+						"port":          3306,
+						"timeout":       5,
+						"database_name": "network_policy",
+					},
+					"database_migration_timeout": 88,
+					"tag_length":                 2,
+					"metron_address":             "http://1.2.3.4:9999",
+					"cleanup_interval":           2,
+					"request_timeout":            5,
+					"max_policies":               3,
+					"local_token_validation":     true,
+				}
+				Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+
+				_, err = config.New(file.Name())
+				Expect(err).To(MatchError("invalid config: UAAIssuer: required for local token validation"))
+			})
+		})
+
+		Context("when a policy role is not a cloud controller role type", func() {
+			It("returns a meaningful error", func() {
+				allData := map[string]interface{}{
+					"listen_host":       "http://1.2.3.4",
+					"listen_port":       1234,
+					"log_prefix":        "cfnetworking",
+					"debug_server_host": "http://4.4.4.4",
+					"debug_server_port": 3333,
+					"uaa_client":        "some-uaa-client",
+					"uaa_client_secret": "some-uaa-client-secret",
+					"uaa_url":           "http://uaa.example.com",
+					"uaa_port":          5555,
+					"cc_url":            "http://ccapi.example.com",
+					"database": map[string]interface{}{
+						"type":          "mysql",
+						"user":          "root",
+						"password":      "password",
+						"host":          "127.0.0.1",
+						"port":          3306,
+						"timeout":       5,
+						"database_name": "network_policy",
+					},
+					"database_migration_timeout": 88,
+					"tag_length":                 2,
+					"metron_address":             "http://1.2.3.4:9999",
+					"cleanup_interval":           2,
+					"request_timeout":            5,
+					"max_policies":               3,
+					"cc_policy_roles":            []string{"space_developer", "potato"},
+				}
+				Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+
+				_, err = config.New(file.Name())
+				Expect(err).To(MatchError("invalid config: CCPolicyRoles: unknown role type: potato"))
+			})
+		})
+
+		Describe("authorization", func() {
+			var allData map[string]interface{}
+
+			BeforeEach(func() {
+				allData = map[string]interface{}{
+					"listen_host":       "http://1.2.3.4",
+					"listen_port":       1234,
+					"log_prefix":        "cfnetworking",
+					"debug_server_host": "http://4.4.4.4",
+					"debug_server_port": 3333,
+					"uaa_client":        "some-uaa-client",
+					"uaa_client_secret": "some-uaa-client-secret",
+					"uaa_url":           "http://uaa.example.com",
+					"uaa_port":          5555,
+					"cc_url":            "http://ccapi.example.com",
+					"database": map[string]interface{}{
+						"type":          "mysql",
+						"user":          "root",
+						"password":      "password",
+						"host":          "127.0.0.1",
+						"port":          3306,
+						"timeout":       5,
+						"database_name": "network_policy",
+					},
+					"database_migration_timeout": 88,
+					"tag_length":                 2,
+					"metron_address":             "http://1.2.3.4:9999",
+					"cleanup_interval":           2,
+					"request_timeout":            5,
+					"max_policies":               3,
+				}
+			})
+
+			It("defaults to network.admin for everything and space developers for c2c policies", func() {
+				Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+
+				c, err := config.New(file.Name())
+				Expect(err).NotTo(HaveOccurred())
+
+				spaceDeveloper := config.Permission{Scopes: []string{"network.admin"}, CCRoles: []string{"space_developer"}}
+				adminOnly := config.Permission{Scopes: []string{"network.admin"}}
+				Expect(c.Authorization).To(Equal(config.Authorization{
+					Read:         spaceDeveloper,
+					CreateC2C:    spaceDeveloper,
+					Delete:       spaceDeveloper,
+					CreateEgress: adminOnly,
+					Cleanup:      adminOnly,
+				}))
+			})
+
+			It("uses cc_policy_roles as the default roles", func() {
+				allData["cc_policy_roles"] = []string{"space_manager"}
+				Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+
+				c, err := config.New(file.Name())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(c.Authorization.CreateC2C.CCRoles).To(Equal([]string{"space_manager"}))
+			})
+
+			It("keeps lists that are set to empty", func() {
+				allData["authorization"] = map[string]interface{}{
+					"delete": map[string]interface{}{"cc_roles": []string{}},
+				}
+				Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+
+				c, err := config.New(file.Name())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(c.Authorization.Delete.Scopes).To(Equal([]string{"network.admin"}))
+				Expect(c.Authorization.Delete.CCRoles).To(BeEmpty())
+			})
+
+			Context("when a role is not a cloud controller role type", func() {
+				It("returns a meaningful error", func() {
+					allData["authorization"] = map[string]interface{}{
+						"read": map[string]interface{}{"cc_roles": []string{"potato"}},
+					}
+					Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+
+					_, err = config.New(file.Name())
+					Expect(err).To(MatchError("invalid config: Authorization.Read: unknown role type: potato"))
+				})
+			})
+
+			Context("when cleanup is given cc roles", func() {
+				It("returns a meaningful error", func() {
+					allData["authorization"] = map[string]interface{}{
+						"cleanup": map[string]interface{}{"cc_roles": []string{"space_developer"}},
+					}
+					Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+
+					_, err = config.New(file.Name())
+					Expect(err).To(MatchError("invalid config: Authorization.Cleanup: cc_roles are not supported"))
+				})
+			})
+
+			It("parses service accounts", func() {
+				allData["service_accounts"] = []map[string]interface{}{{
+					"client_id":   "some-pipeline",
+					"org_guids":   []string{"some-org-guid"},
+					"space_guids": []string{"some-space-guid"},
+				}}
+				Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+
+				c, err := config.New(file.Name())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(c.ServiceAccounts).To(Equal([]config.ServiceAccount{{
+					ClientID:   "some-pipeline",
+					OrgGUIDs:   []string{"some-org-guid"},
+					SpaceGUIDs: []string{"some-space-guid"},
+				}}))
+			})
+
+			Context("when a service account has no orgs or spaces", func() {
+				It("returns a meaningful error", func() {
+					allData["service_accounts"] = []map[string]interface{}{{
+						"client_id": "some-pipeline",
+					}}
+					Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+
+					_, err = config.New(file.Name())
+					Expect(err).To(MatchError("invalid config: ServiceAccounts: some-pipeline: needs org_guids or space_guids"))
+				})
+			})
+
+			Context("when a service account has no client id", func() {
+				It("returns a meaningful error", func() {
+					allData["service_accounts"] = []map[string]interface{}{{
+						"space_guids": []string{"some-space-guid"},
+					}}
+					Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+
+					_, err = config.New(file.Name())
+					Expect(err).To(MatchError(ContainSubstring("ClientID: zero value")))
+				})
+			})
+
+			It("parses tracing", func() {
+				allData["tracing"] = map[string]interface{}{
+					"exporter":      "collector",
+					"collector_url": "http://127.0.0.1:4318/v1/traces",
+				}
+				Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+
+				c, err := config.New(file.Name())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(c.Tracing).To(Equal(config.Tracing{
+					Exporter:     "collector",
+					CollectorURL: "http://127.0.0.1:4318/v1/traces",
+				}))
+			})
+
+			Context("when the tracing exporter is unknown", func() {
+				It("returns a meaningful error", func() {
+					allData["tracing"] = map[string]interface{}{"exporter": "zipkin"}
+					Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+
+					_, err = config.New(file.Name())
+					Expect(err).To(MatchError("invalid config: Tracing: unknown exporter: zipkin"))
+				})
+			})
+
+			Context("when the collector exporter has no collector url", func() {
+				It("returns a meaningful error", func() {
+					allData["tracing"] = map[string]interface{}{"exporter": "collector"}
+					Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+
+					_, err = config.New(file.Name())
+					Expect(err).To(MatchError("invalid config: Tracing: collector_url is required for the collector exporter"))
+				})
+			})
+		})
+
 		Describe("database config", func() {
 			var allData map[string]interface{}
 			BeforeEach(func() {
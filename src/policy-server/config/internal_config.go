@@ -11,26 +11,38 @@ import (
 )
 
 type InternalConfig struct {
-	LogPrefix          string    `json:"log_prefix" validate:"nonzero"`
-	ListenHost         string    `json:"listen_host" validate:"nonzero"`
-	InternalListenPort int       `json:"internal_listen_port" validate:"nonzero"`
-	DebugServerHost    string    `json:"debug_server_host" validate:"nonzero"`
-	DebugServerPort    int       `json:"debug_server_port" validate:"nonzero"`
-	HealthCheckPort    int       `json:"health_check_port" validate:"nonzero"`
-	CACertFile         string    `json:"ca_cert_file" validate:"nonzero"`
-	ServerCertFile     string    `json:"server_cert_file" validate:"nonzero"`
-	ServerKeyFile      string    `json:"server_key_file" validate:"nonzero"`
-	Database           db.Config `json:"database" validate:"nonzero"`
-	TagLength          int       `json:"tag_length" validate:"nonzero"`
-	MetronAddress      string    `json:"metron_address" validate:"nonzero"`
-	LogLevel           string    `json:"log_level"`
-	RequestTimeout     int       `json:"request_timeout" validate:"min=1"`
-	MaxIdleConnections int       `json:"max_idle_connections" validate:"min=0"`
-	MaxOpenConnections int       `json:"max_open_connections" validate:"min=0"`
+	LogPrefix               string      `json:"log_prefix" validate:"nonzero"`
+	ListenHost              string      `json:"listen_host" validate:"nonzero"`
+	InternalListenPort      int         `json:"internal_listen_port" validate:"nonzero"`
+	DebugServerHost         string      `json:"debug_server_host" validate:"nonzero"`
+	DebugServerPort         int         `json:"debug_server_port" validate:"nonzero"`
+	HealthCheckPort         int         `json:"health_check_port" validate:"nonzero"`
+	CACertFile              string      `json:"ca_cert_file" validate:"nonzero"`
+	ServerCertFile          string      `json:"server_cert_file" validate:"nonzero"`
+	ServerKeyFile           string      `json:"server_key_file" validate:"nonzero"`
+	Database                db.Config   `json:"database" validate:"nonzero"`
+	TagLength               int         `json:"tag_length" validate:"nonzero"`
+	TagQuarantine           int         `json:"tag_quarantine" validate:"min=0"`
+	MetronAddress           string      `json:"metron_address" validate:"nonzero"`
+	LogLevel                string      `json:"log_level"`
+	RequestTimeout          int         `json:"request_timeout" validate:"min=1"`
+	MaxIdleConnections      int         `json:"max_idle_connections" validate:"min=0"`
+	MaxOpenConnections      int         `json:"max_open_connections" validate:"min=0"`
+	DegradeOnSchemaMismatch bool        `json:"degrade_on_schema_mismatch"`
+	ReadReplicas            []db.Config `json:"read_replicas"`
+	ReplicaMaxLag           int         `json:"replica_max_lag" validate:"min=0"`
+	GRPCListenPort          int         `json:"grpc_listen_port" validate:"min=0"`
+	MaxRequestBodySize      int64       `json:"max_request_body_size" validate:"min=0"`
+	Tracing                 Tracing     `json:"tracing"`
 }
 
 func (c *InternalConfig) Validate() error {
-	return validator.Validate(c)
+	err := validator.Validate(c)
+	if err != nil {
+		return err
+	}
+
+	return c.Tracing.validate()
 }
 
 func NewInternal(path string) (*InternalConfig, error) {
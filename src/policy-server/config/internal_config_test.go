@@ -48,6 +48,20 @@ var _ = Describe("InternalConfig", func() {
 					"max_idle_connections": 4,
 					"max_open_connections": 5,
 					"tag_length": 2,
+					"tag_quarantine": 600,
+					"degrade_on_schema_mismatch": true,
+					"read_replicas": [{
+						"type": "mysql",
+						"user": "root",
+						"password": "password",
+						"host": "127.0.0.2",
+						"port": 3306,
+						"timeout": 5,
+						"database_name": "network_policy"
+					}],
+					"replica_max_lag": 15,
+					"grpc_listen_port": 3333,
+					"max_request_body_size": 1024,
 					"metron_address": "http://1.2.3.4:9999",
 					"log_level": "debug",
 					"request_timeout": 5
@@ -71,6 +85,13 @@ var _ = Describe("InternalConfig", func() {
 				Expect(c.Database.Timeout).To(Equal(5))
 				Expect(c.Database.DatabaseName).To(Equal("network_policy"))
 				Expect(c.TagLength).To(Equal(2))
+				Expect(c.TagQuarantine).To(Equal(600))
+				Expect(c.DegradeOnSchemaMismatch).To(BeTrue())
+				Expect(c.ReadReplicas).To(HaveLen(1))
+				Expect(c.ReadReplicas[0].Host).To(Equal("127.0.0.2"))
+				Expect(c.ReplicaMaxLag).To(Equal(15))
+				Expect(c.GRPCListenPort).To(Equal(3333))
+				Expect(c.MaxRequestBodySize).To(Equal(int64(1024)))
 				Expect(c.MetronAddress).To(Equal("http://1.2.3.4:9999"))
 				Expect(c.LogLevel).To(Equal("debug"))
 				Expect(c.RequestTimeout).To(Equal(5))
@@ -153,6 +174,39 @@ var _ = Describe("InternalConfig", func() {
 			Entry("missing request timeout", "request_timeout", "RequestTimeout: less than min"),
 		)
 
+		Context("when the tracing exporter is unknown", func() {
+			It("returns a meaningful error", func() {
+				allData := map[string]interface{}{
+					"log_prefix":           "cfnetworking",
+					"listen_host":          "http://1.2.3.4",
+					"internal_listen_port": 2222,
+					"debug_server_host":    "http://4.4.4.4",
+					"debug_server_port":    3333,
+					"health_check_port":    4444,
+					"ca_cert_file":         "some/ca/cert/file",
+					"server_cert_file":     "some/server/cert/file",
+					"server_key_file":      "some/server/key/file",
+					"database": map[string]interface{}{
+						"type":          "mysql",
+						"user":          "root",
+						"password":      "password",
+						"host":          "127.0.0.1",
+						"port":          3306,
+						"timeout":       5,
+						"database_name": "network_policy",
+					},
+					"tag_length":      2,
+					"metron_address":  "http://1.2.3.4:9999",
+					"request_timeout": 5,
+					"tracing":         map[string]interface{}{"exporter": "zipkin"},
+				}
+				Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+
+				_, err = config.NewInternal(file.Name())
+				Expect(err).To(MatchError("invalid config: Tracing: unknown exporter: zipkin"))
+			})
+		})
+
 		Describe("database config", func() {
 			var allData map[string]interface{}
 			BeforeEach(func() {
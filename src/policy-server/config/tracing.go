@@ -0,0 +1,33 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	TracingExporterStdout    = "stdout"
+	TracingExporterCollector = "collector"
+)
+
+// Tracing selects where request spans are sent. Tracing is off when Exporter
+// is empty; "stdout" writes spans as JSON lines and "collector" posts them in
+// batches to the OTLP/HTTP endpoint at CollectorURL.
+type Tracing struct {
+	Exporter     string `json:"exporter"`
+	CollectorURL string `json:"collector_url"`
+}
+
+func (t *Tracing) validate() error {
+	switch t.Exporter {
+	case "", TracingExporterStdout:
+		return nil
+	case TracingExporterCollector:
+		if t.CollectorURL == "" {
+			return errors.New("Tracing: collector_url is required for the collector exporter")
+		}
+		return nil
+	default:
+		return fmt.Errorf("Tracing: unknown exporter: %s", t.Exporter)
+	}
+}
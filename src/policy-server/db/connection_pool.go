@@ -2,6 +2,7 @@ package db
 
 import (
 	"database/sql"
+	"fmt"
 	"log"
 	"time"
 
@@ -18,6 +19,7 @@ type ConnWrapper struct {
 type Transaction interface {
 	Exec(query string, args ...interface{}) (sql.Result, error)
 	QueryRow(query string, args ...interface{}) *sql.Row
+	Query(query string, args ...interface{}) (*sql.Rows, error)
 	Commit() error
 	Rollback() error
 	Rebind(string) string
@@ -69,8 +71,14 @@ func (c *ConnWrapper) Close() error {
 }
 
 func NewConnectionPool(conf db.Config, maxOpenConnections int, maxIdleConnections int, logPrefix string, jobPrefix string, logger lager.Logger) *ConnWrapper {
+	connector := db.GetConnectionPool
+	if conf.Type == SQLite {
+		connector = getSQLiteConnectionPool
+		maxOpenConnections, maxIdleConnections = 1, 1
+	}
+
 	retriableConnector := db.RetriableConnector{
-		Connector:     db.GetConnectionPool,
+		Connector:     connector,
 		Sleeper:       db.SleeperFunc(time.Sleep),
 		RetryInterval: time.Duration(3) * time.Second,
 		MaxRetries:    10,
@@ -105,3 +113,31 @@ func NewConnectionPool(conf db.Config, maxOpenConnections int, maxIdleConnection
 
 	return &ConnWrapper{sqlxDB: connectionPool}
 }
+
+// NewLazyConnectionPool opens a connection pool without connecting to the
+// database, so that an unreachable database fails queries rather than the
+// process. It is meant for read replicas, whose health is checked before they
+// are read from.
+func NewLazyConnectionPool(conf db.Config, maxOpenConnections int, maxIdleConnections int) (*ConnWrapper, error) {
+	var connectionString string
+	var err error
+	if conf.Type == SQLite {
+		connectionString = conf.DatabaseName
+		maxOpenConnections, maxIdleConnections = 1, 1
+	} else {
+		connectionString, err = conf.ConnectionString()
+		if err != nil {
+			return nil, fmt.Errorf("connection string: %s", err)
+		}
+	}
+
+	connectionPool, err := sqlx.Open(conf.Type, connectionString)
+	if err != nil {
+		return nil, fmt.Errorf("opening connection pool: %s", err)
+	}
+
+	connectionPool.SetMaxOpenConns(maxOpenConnections)
+	connectionPool.SetMaxIdleConns(maxIdleConnections)
+
+	return &ConnWrapper{sqlxDB: connectionPool}, nil
+}
@@ -0,0 +1,42 @@
+package db
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/cf-networking-helpers/db"
+	"github.com/jmoiron/sqlx"
+)
+
+// SQLite is the database type and driver name of SQLite databases. The driver
+// is only linked into binaries built with the sqlite build tag.
+//
+// SQLite is meant for development and testing. The database_name is the path
+// of the database file, or ":memory:" for an in-memory database. Only one
+// connection is opened, and it is kept open while idle, since SQLite allows a
+// single writer and every connection to ":memory:" gets its own database.
+const SQLite = "sqlite3"
+
+func getSQLiteConnectionPool(conf db.Config) (*sqlx.DB, error) {
+	if conf.DatabaseName == "" {
+		return nil, fmt.Errorf("sqlite database_name must be a file path or :memory:")
+	}
+
+	conn, err := sqlx.Open(SQLite, conf.DatabaseName)
+	if err != nil {
+		return nil, fmt.Errorf("opening sqlite database: %s", err)
+	}
+
+	err = conn.Ping()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("opening sqlite database: %s", err)
+	}
+
+	_, err = conn.Exec("PRAGMA foreign_keys = ON")
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("enabling sqlite foreign keys: %s", err)
+	}
+
+	return conn, nil
+}
@@ -0,0 +1,215 @@
+package graph
+
+import (
+	"fmt"
+	"policy-server/api"
+	"policy-server/store"
+	"sort"
+)
+
+//go:generate counterfeiter -o fakes/uaa_client.go --fake-name UAAClient . uaaClient
+type uaaClient interface {
+	GetToken() (string, error)
+}
+
+//go:generate counterfeiter -o fakes/cc_client.go --fake-name CCClient . ccClient
+type ccClient interface {
+	GetApps(token string, appGUIDs []string) (map[string]api.App, error)
+	GetSpace(token, spaceGUID string) (*api.Space, error)
+}
+
+type Scope struct {
+	OrgGUID   string
+	SpaceGUID string
+}
+
+func (s Scope) includes(node Node) bool {
+	if s.SpaceGUID != "" && node.SpaceGUID != s.SpaceGUID {
+		return false
+	}
+	if s.OrgGUID != "" && node.OrgGUID != s.OrgGUID {
+		return false
+	}
+	return true
+}
+
+type Builder struct {
+	UAAClient uaaClient
+	CCClient  ccClient
+	ChunkSize int
+}
+
+func NewBuilder(uaaClient uaaClient, ccClient ccClient, chunkSize int) *Builder {
+	return &Builder{
+		UAAClient: uaaClient,
+		CCClient:  ccClient,
+		ChunkSize: chunkSize,
+	}
+}
+
+// Build resolves the apps referenced by the given policies against CC and
+// returns the policy graph. A c2c policy is kept when either end is in scope;
+// an egress policy is kept when its source is in scope.
+func (b *Builder) Build(policies []store.Policy, egressPolicies []store.EgressPolicy, scope Scope) (Graph, error) {
+	token, err := b.UAAClient.GetToken()
+	if err != nil {
+		return Graph{}, fmt.Errorf("getting token: %s", err)
+	}
+
+	appNodes, err := b.appNodes(token, appGUIDs(policies, egressPolicies))
+	if err != nil {
+		return Graph{}, err
+	}
+
+	nodes := map[string]Node{}
+	edges := []Edge{}
+
+	for _, policy := range policies {
+		source := appNode(appNodes, policy.Source.ID)
+		destination := appNode(appNodes, policy.Destination.ID)
+		if !scope.includes(source) && !scope.includes(destination) {
+			continue
+		}
+		nodes[source.ID] = source
+		nodes[destination.ID] = destination
+
+		ports := api.Ports{
+			Start: policy.Destination.Ports.Start,
+			End:   policy.Destination.Ports.End,
+		}
+		if ports.Start == 0 {
+			ports = api.Ports{Start: policy.Destination.Port, End: policy.Destination.Port}
+		}
+		edges = append(edges, Edge{
+			Source:      source.ID,
+			Destination: destination.ID,
+			Protocol:    policy.Destination.Protocol,
+			Ports:       &ports,
+		})
+	}
+
+	for _, egressPolicy := range egressPolicies {
+		source := appNode(appNodes, egressPolicy.Source.ID)
+		if !scope.includes(source) {
+			continue
+		}
+		nodes[source.ID] = source
+
+		for _, ipRange := range egressPolicy.Destination.IPRanges {
+			destination := ipRangeNode(ipRange)
+			nodes[destination.ID] = destination
+			edges = append(edges, Edge{
+				Source:      source.ID,
+				Destination: destination.ID,
+				Protocol:    egressPolicy.Destination.Protocol,
+			})
+		}
+	}
+
+	graph := Graph{
+		Nodes: make([]Node, 0, len(nodes)),
+		Edges: edges,
+	}
+	for _, node := range nodes {
+		graph.Nodes = append(graph.Nodes, node)
+	}
+	sort.Slice(graph.Nodes, func(i, j int) bool {
+		return graph.Nodes[i].ID < graph.Nodes[j].ID
+	})
+	sort.SliceStable(graph.Edges, func(i, j int) bool {
+		if graph.Edges[i].Source != graph.Edges[j].Source {
+			return graph.Edges[i].Source < graph.Edges[j].Source
+		}
+		return graph.Edges[i].Destination < graph.Edges[j].Destination
+	})
+
+	return graph, nil
+}
+
+func (b *Builder) appNodes(token string, guids []string) (map[string]Node, error) {
+	apps := map[string]api.App{}
+	for _, chunk := range getChunks(guids, b.ChunkSize) {
+		chunkApps, err := b.CCClient.GetApps(token, chunk)
+		if err != nil {
+			return nil, fmt.Errorf("getting apps: %s", err)
+		}
+		for guid, app := range chunkApps {
+			apps[guid] = app
+		}
+	}
+
+	spaces := map[string]*api.Space{}
+	for _, app := range apps {
+		if _, ok := spaces[app.SpaceGUID]; ok {
+			continue
+		}
+		space, err := b.CCClient.GetSpace(token, app.SpaceGUID)
+		if err != nil {
+			return nil, fmt.Errorf("getting space %s: %s", app.SpaceGUID, err)
+		}
+		spaces[app.SpaceGUID] = space
+	}
+
+	nodes := map[string]Node{}
+	for guid, app := range apps {
+		node := Node{
+			ID:        guid,
+			Type:      NodeTypeApp,
+			Name:      app.Name,
+			SpaceGUID: app.SpaceGUID,
+		}
+		if space := spaces[app.SpaceGUID]; space != nil {
+			node.SpaceName = space.Name
+			node.OrgGUID = space.OrgGUID
+		}
+		nodes[guid] = node
+	}
+	return nodes, nil
+}
+
+func appNode(appNodes map[string]Node, guid string) Node {
+	if node, ok := appNodes[guid]; ok {
+		return node
+	}
+	return Node{ID: guid, Type: NodeTypeApp}
+}
+
+func ipRangeNode(ipRange store.IPRange) Node {
+	id := ipRange.Start
+	if ipRange.End != ipRange.Start {
+		id = fmt.Sprintf("%s-%s", ipRange.Start, ipRange.End)
+	}
+	return Node{ID: id, Type: NodeTypeIPRange}
+}
+
+func appGUIDs(policies []store.Policy, egressPolicies []store.EgressPolicy) []string {
+	set := map[string]struct{}{}
+	for _, policy := range policies {
+		set[policy.Source.ID] = struct{}{}
+		set[policy.Destination.ID] = struct{}{}
+	}
+	for _, egressPolicy := range egressPolicies {
+		set[egressPolicy.Source.ID] = struct{}{}
+	}
+	guids := make([]string, 0, len(set))
+	for guid := range set {
+		guids = append(guids, guid)
+	}
+	sort.Strings(guids)
+	return guids
+}
+
+func getChunks(guids []string, chunkSize int) [][]string {
+	if chunkSize <= 0 {
+		return [][]string{guids}
+	}
+	chunks := [][]string{}
+	for i := 0; i < len(guids); i += chunkSize {
+		end := i + chunkSize
+		if end > len(guids) {
+			end = len(guids)
+		}
+		chunks = append(chunks, guids[i:end])
+	}
+	return chunks
+}
@@ -0,0 +1,193 @@
+package graph_test
+
+import (
+	"errors"
+	"policy-server/api"
+	"policy-server/graph"
+	"policy-server/graph/fakes"
+	"policy-server/store"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Builder", func() {
+	var (
+		builder        *graph.Builder
+		fakeUAAClient  *fakes.UAAClient
+		fakeCCClient   *fakes.CCClient
+		policies       []store.Policy
+		egressPolicies []store.EgressPolicy
+	)
+
+	BeforeEach(func() {
+		fakeUAAClient = &fakes.UAAClient{}
+		fakeCCClient = &fakes.CCClient{}
+		builder = graph.NewBuilder(fakeUAAClient, fakeCCClient, 2)
+
+		policies = []store.Policy{{
+			Source: store.Source{ID: "app-a", Tag: "01"},
+			Destination: store.Destination{
+				ID:       "app-b",
+				Tag:      "02",
+				Protocol: "tcp",
+				Ports:    store.Ports{Start: 8080, End: 8080},
+			},
+		}, {
+			Source: store.Source{ID: "app-c", Tag: "03"},
+			Destination: store.Destination{
+				ID:       "app-a",
+				Tag:      "01",
+				Protocol: "udp",
+				Port:     5000,
+			},
+		}}
+		egressPolicies = []store.EgressPolicy{{
+			Source: store.EgressSource{ID: "app-b"},
+			Destination: store.EgressDestination{
+				Protocol: "tcp",
+				IPRanges: []store.IPRange{
+					{Start: "10.0.0.1", End: "10.0.0.1"},
+					{Start: "10.0.0.2", End: "10.0.0.9"},
+				},
+			},
+		}}
+
+		fakeUAAClient.GetTokenReturns("some-token", nil)
+		fakeCCClient.GetAppsStub = func(token string, appGUIDs []string) (map[string]api.App, error) {
+			all := map[string]api.App{
+				"app-a": {Name: "app-a-name", SpaceGUID: "space-1"},
+				"app-b": {Name: "app-b-name", SpaceGUID: "space-1"},
+				"app-c": {Name: "app-c-name", SpaceGUID: "space-2"},
+			}
+			apps := map[string]api.App{}
+			for _, guid := range appGUIDs {
+				apps[guid] = all[guid]
+			}
+			return apps, nil
+		}
+		fakeCCClient.GetSpaceStub = func(token, spaceGUID string) (*api.Space, error) {
+			switch spaceGUID {
+			case "space-1":
+				return &api.Space{Name: "space-1-name", OrgGUID: "org-1"}, nil
+			case "space-2":
+				return &api.Space{Name: "space-2-name", OrgGUID: "org-2"}, nil
+			}
+			return nil, nil
+		}
+	})
+
+	It("returns the graph of all policies", func() {
+		g, err := builder.Build(policies, egressPolicies, graph.Scope{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(g.Nodes).To(Equal([]graph.Node{
+			{ID: "10.0.0.1", Type: "ip_range"},
+			{ID: "10.0.0.2-10.0.0.9", Type: "ip_range"},
+			{ID: "app-a", Type: "app", Name: "app-a-name", SpaceGUID: "space-1", SpaceName: "space-1-name", OrgGUID: "org-1"},
+			{ID: "app-b", Type: "app", Name: "app-b-name", SpaceGUID: "space-1", SpaceName: "space-1-name", OrgGUID: "org-1"},
+			{ID: "app-c", Type: "app", Name: "app-c-name", SpaceGUID: "space-2", SpaceName: "space-2-name", OrgGUID: "org-2"},
+		}))
+		Expect(g.Edges).To(Equal([]graph.Edge{
+			{Source: "app-a", Destination: "app-b", Protocol: "tcp", Ports: &api.Ports{Start: 8080, End: 8080}},
+			{Source: "app-b", Destination: "10.0.0.1", Protocol: "tcp"},
+			{Source: "app-b", Destination: "10.0.0.2-10.0.0.9", Protocol: "tcp"},
+			{Source: "app-c", Destination: "app-a", Protocol: "udp", Ports: &api.Ports{Start: 5000, End: 5000}},
+		}))
+	})
+
+	It("looks up apps in chunks and each space once", func() {
+		_, err := builder.Build(policies, egressPolicies, graph.Scope{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeCCClient.GetAppsCallCount()).To(Equal(2))
+		token, guids := fakeCCClient.GetAppsArgsForCall(0)
+		Expect(token).To(Equal("some-token"))
+		Expect(guids).To(Equal([]string{"app-a", "app-b"}))
+		_, guids = fakeCCClient.GetAppsArgsForCall(1)
+		Expect(guids).To(Equal([]string{"app-c"}))
+
+		Expect(fakeCCClient.GetSpaceCallCount()).To(Equal(2))
+	})
+
+	Context("when scoped to a space", func() {
+		It("returns only policies with an end in that space", func() {
+			g, err := builder.Build(policies, egressPolicies, graph.Scope{SpaceGUID: "space-2"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(g.Edges).To(Equal([]graph.Edge{
+				{Source: "app-c", Destination: "app-a", Protocol: "udp", Ports: &api.Ports{Start: 5000, End: 5000}},
+			}))
+			Expect(g.Nodes).To(HaveLen(2))
+		})
+	})
+
+	Context("when scoped to an org", func() {
+		It("returns only policies with an end in that org", func() {
+			g, err := builder.Build(policies, egressPolicies, graph.Scope{OrgGUID: "org-1"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(g.Edges).To(HaveLen(4))
+		})
+
+		It("excludes egress policies whose source is outside the org", func() {
+			g, err := builder.Build(nil, egressPolicies, graph.Scope{OrgGUID: "org-2"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(g.Nodes).To(BeEmpty())
+			Expect(g.Edges).To(BeEmpty())
+		})
+	})
+
+	Context("when an app is not found in CC", func() {
+		BeforeEach(func() {
+			fakeCCClient.GetAppsReturns(map[string]api.App{}, nil)
+			fakeCCClient.GetAppsStub = nil
+		})
+
+		It("still includes the app by guid", func() {
+			g, err := builder.Build(policies[:1], nil, graph.Scope{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(g.Nodes).To(Equal([]graph.Node{
+				{ID: "app-a", Type: "app"},
+				{ID: "app-b", Type: "app"},
+			}))
+		})
+	})
+
+	Context("when getting the token fails", func() {
+		BeforeEach(func() {
+			fakeUAAClient.GetTokenReturns("", errors.New("potato"))
+		})
+
+		It("returns a helpful error", func() {
+			_, err := builder.Build(policies, egressPolicies, graph.Scope{})
+			Expect(err).To(MatchError("getting token: potato"))
+		})
+	})
+
+	Context("when getting apps fails", func() {
+		BeforeEach(func() {
+			fakeCCClient.GetAppsStub = nil
+			fakeCCClient.GetAppsReturns(nil, errors.New("potato"))
+		})
+
+		It("returns a helpful error", func() {
+			_, err := builder.Build(policies, egressPolicies, graph.Scope{})
+			Expect(err).To(MatchError("getting apps: potato"))
+		})
+	})
+
+	Context("when getting a space fails", func() {
+		BeforeEach(func() {
+			fakeCCClient.GetSpaceStub = nil
+			fakeCCClient.GetSpaceReturns(nil, errors.New("potato"))
+		})
+
+		It("returns a helpful error", func() {
+			_, err := builder.Build(policies[:1], nil, graph.Scope{})
+			Expect(err).To(MatchError("getting space space-1: potato"))
+		})
+	})
+})
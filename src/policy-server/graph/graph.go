@@ -0,0 +1,110 @@
+package graph
+
+import (
+	"bytes"
+	"fmt"
+	"policy-server/api"
+	"sort"
+	"strings"
+)
+
+const (
+	NodeTypeApp     = "app"
+	NodeTypeIPRange = "ip_range"
+)
+
+type Graph struct {
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+}
+
+type Node struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Name      string `json:"name,omitempty"`
+	SpaceGUID string `json:"space_id,omitempty"`
+	SpaceName string `json:"space_name,omitempty"`
+	OrgGUID   string `json:"org_id,omitempty"`
+}
+
+type Edge struct {
+	Source      string     `json:"source"`
+	Destination string     `json:"destination"`
+	Protocol    string     `json:"protocol"`
+	Ports       *api.Ports `json:"ports,omitempty"`
+}
+
+func (e Edge) Label() string {
+	if e.Ports == nil {
+		return e.Protocol
+	}
+	if e.Ports.Start == e.Ports.End {
+		return fmt.Sprintf("%s:%d", e.Protocol, e.Ports.Start)
+	}
+	return fmt.Sprintf("%s:%d-%d", e.Protocol, e.Ports.Start, e.Ports.End)
+}
+
+// DOT renders the graph in Graphviz DOT format. App nodes are grouped into
+// one cluster per space so that cross-space policies stand out.
+func (g Graph) DOT() []byte {
+	var b bytes.Buffer
+	b.WriteString("digraph policies {\n")
+
+	clusters := map[string][]Node{}
+	var unclustered []Node
+	for _, node := range g.Nodes {
+		if node.SpaceGUID == "" {
+			unclustered = append(unclustered, node)
+			continue
+		}
+		clusters[node.SpaceGUID] = append(clusters[node.SpaceGUID], node)
+	}
+
+	spaceGUIDs := make([]string, 0, len(clusters))
+	for spaceGUID := range clusters {
+		spaceGUIDs = append(spaceGUIDs, spaceGUID)
+	}
+	sort.Strings(spaceGUIDs)
+
+	for _, spaceGUID := range spaceGUIDs {
+		nodes := clusters[spaceGUID]
+		label := nodes[0].SpaceName
+		if label == "" {
+			label = spaceGUID
+		}
+		fmt.Fprintf(&b, "\tsubgraph %s {\n", quote("cluster_"+spaceGUID))
+		fmt.Fprintf(&b, "\t\tlabel=%s;\n", quote(label))
+		for _, node := range nodes {
+			fmt.Fprintf(&b, "\t\t%s;\n", dotNode(node))
+		}
+		b.WriteString("\t}\n")
+	}
+
+	for _, node := range unclustered {
+		fmt.Fprintf(&b, "\t%s;\n", dotNode(node))
+	}
+
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&b, "\t%s -> %s [label=%s];\n", quote(edge.Source), quote(edge.Destination), quote(edge.Label()))
+	}
+
+	b.WriteString("}\n")
+	return b.Bytes()
+}
+
+func dotNode(node Node) string {
+	label := node.Name
+	if label == "" {
+		label = node.ID
+	}
+	if node.Type == NodeTypeIPRange {
+		return fmt.Sprintf("%s [label=%s, shape=box]", quote(node.ID), quote(label))
+	}
+	return fmt.Sprintf("%s [label=%s]", quote(node.ID), quote(label))
+}
+
+func quote(s string) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	s = strings.Replace(s, `"`, `\"`, -1)
+	return `"` + s + `"`
+}
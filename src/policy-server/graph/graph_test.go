@@ -0,0 +1,43 @@
+package graph_test
+
+import (
+	"policy-server/api"
+	"policy-server/graph"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Graph", func() {
+	Describe("DOT", func() {
+		It("renders apps clustered by space with labelled edges", func() {
+			g := graph.Graph{
+				Nodes: []graph.Node{
+					{ID: "10.0.0.1-10.0.0.9", Type: "ip_range"},
+					{ID: "app-a", Type: "app", Name: "app-a-name", SpaceGUID: "space-1", SpaceName: "space-1-name"},
+					{ID: "app-b", Type: "app", Name: `app "b"`, SpaceGUID: "space-1", SpaceName: "space-1-name"},
+					{ID: "app-c", Type: "app"},
+				},
+				Edges: []graph.Edge{
+					{Source: "app-a", Destination: "app-b", Protocol: "tcp", Ports: &api.Ports{Start: 8080, End: 8080}},
+					{Source: "app-a", Destination: "app-c", Protocol: "udp", Ports: &api.Ports{Start: 5000, End: 5010}},
+					{Source: "app-b", Destination: "10.0.0.1-10.0.0.9", Protocol: "icmp"},
+				},
+			}
+
+			Expect(string(g.DOT())).To(Equal(`digraph policies {
+	subgraph "cluster_space-1" {
+		label="space-1-name";
+		"app-a" [label="app-a-name"];
+		"app-b" [label="app \"b\""];
+	}
+	"10.0.0.1-10.0.0.9" [label="10.0.0.1-10.0.0.9", shape=box];
+	"app-c" [label="app-c"];
+	"app-a" -> "app-b" [label="tcp:8080"];
+	"app-a" -> "app-c" [label="udp:5000-5010"];
+	"app-b" -> "10.0.0.1-10.0.0.9" [label="icmp"];
+}
+`))
+		})
+	})
+})
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"lib/tracing"
 	"net/http"
 	"policy-server/uaa_client"
 	"strings"
@@ -23,15 +24,21 @@ type http_handler interface {
 	http.Handler
 }
 
+//go:generate counterfeiter -o fakes/token_checker.go --fake-name TokenChecker . UAAClient
 type UAAClient interface {
-	CheckToken(token string) (uaa_client.CheckTokenResponse, error)
+	CheckToken(ctx context.Context, token string) (uaa_client.CheckTokenResponse, error)
 }
 
+// Authenticator checks the token on each request. Tokens of ServiceAccounts
+// skip the scope check; what they may do is left to the handler. The checks
+// run in an auth span, which ends before the request is handed on.
 type Authenticator struct {
-	Client        UAAClient
-	Scopes        []string
-	ErrorResponse errorResponse
-	ScopeChecking bool
+	Client          UAAClient
+	Scopes          []string
+	ErrorResponse   errorResponse
+	ScopeChecking   bool
+	ServiceAccounts ServiceAccounts
+	Tracer          *tracing.Tracer
 }
 
 func getLogger(req *http.Request) lager.Logger {
@@ -57,9 +64,12 @@ func (a *Authenticator) Wrap(handle http.Handler) http.Handler {
 		logger := getLogger(req)
 		logger = logger.Session("authentication")
 
+		ctx, span := a.Tracer.Start(req.Context(), "auth")
+
 		authorization := req.Header["Authorization"]
 		if len(authorization) < 1 {
 			err := errors.New("no auth header")
+			span.End(err)
 			a.ErrorResponse.Unauthorized(logger, w, err, "missing authorization header")
 			return
 		}
@@ -67,19 +77,21 @@ func (a *Authenticator) Wrap(handle http.Handler) http.Handler {
 		token := authorization[0]
 		token = strings.TrimPrefix(token, "Bearer ")
 		token = strings.TrimPrefix(token, "bearer ")
-		tokenData, err := a.Client.CheckToken(token)
+		tokenData, err := a.Client.CheckToken(ctx, token)
 		if err != nil {
+			span.End(err)
 			a.ErrorResponse.Forbidden(logger, w, err, "failed to verify token with uaa")
 			return
 		}
 
-		if a.ScopeChecking && !isAuthorized(tokenData.Scope, a.Scopes) {
+		_, isServiceAccount := a.ServiceAccounts.lookup(tokenData)
+		if a.ScopeChecking && !isServiceAccount && !isAuthorized(tokenData.Scope, a.Scopes) {
 			err := errors.New(fmt.Sprintf("provided scopes %s do not include allowed scopes %s", tokenData.Scope, a.Scopes))
+			span.End(err)
 			a.ErrorResponse.Forbidden(logger, w, err, err.Error())
 			return
 		}
-
-		req.Body = http.MaxBytesReader(w, req.Body, MAX_REQ_BODY_SIZE)
+		span.End(nil)
 
 		contextWithTokenData := context.WithValue(req.Context(), TokenDataKey, tokenData)
 		req = req.WithContext(contextWithTokenData)
@@ -97,21 +109,3 @@ func isAuthorized(scopes, allowedScopes []string) bool {
 	}
 	return false
 }
-
-func isNetworkAdmin(scopes []string) bool {
-	for _, scope := range scopes {
-		if scope == "network.admin" {
-			return true
-		}
-	}
-	return false
-}
-
-func isNetworkWrite(scopes []string) bool {
-	for _, scope := range scopes {
-		if scope == "network.write" {
-			return true
-		}
-	}
-	return false
-}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	libfakes "lib/fakes"
+	"lib/tracing"
 	"net/http"
 	"net/http/httptest"
 	"policy-server/handlers"
@@ -25,7 +27,7 @@ var _ = Describe("Authentication middleware", func() {
 		authenticator *handlers.Authenticator
 
 		resp                 *httptest.ResponseRecorder
-		uaaClient            *fakes.UAAClient
+		uaaClient            *fakes.TokenChecker
 		logger               *lagertest.TestLogger
 		expectedLogger       lager.Logger
 		tokenResponse        uaa_client.CheckTokenResponse
@@ -40,7 +42,7 @@ var _ = Describe("Authentication middleware", func() {
 		request.Header.Set("Authorization", "Bearer correct-token")
 		request.RemoteAddr = "some-host:some-ip"
 
-		uaaClient = &fakes.UAAClient{}
+		uaaClient = &fakes.TokenChecker{}
 		logger = lagertest.NewTestLogger("test")
 
 		expectedLogger = lager.NewLogger("test").Session("authentication")
@@ -99,7 +101,8 @@ var _ = Describe("Authentication middleware", func() {
 		Expect(unprotectedCallCount).To(Equal(1))
 
 		Expect(uaaClient.CheckTokenCallCount()).To(Equal(1))
-		Expect(uaaClient.CheckTokenArgsForCall(0)).To(Equal("correct-token"))
+		_, token := uaaClient.CheckTokenArgsForCall(0)
+		Expect(token).To(Equal("correct-token"))
 	})
 
 	Context("when the logger isn't on the request", func() {
@@ -147,11 +150,52 @@ var _ = Describe("Authentication middleware", func() {
 			Expect(unprotectedCallCount).To(Equal(1))
 
 			Expect(uaaClient.CheckTokenCallCount()).To(Equal(1))
-			Expect(uaaClient.CheckTokenArgsForCall(0)).To(Equal("correct-token"))
+			_, token := uaaClient.CheckTokenArgsForCall(0)
+			Expect(token).To(Equal("correct-token"))
 
 		})
 	})
 
+	Context("when there is a tracer", func() {
+		var (
+			fakeExporter *libfakes.SpanExporter
+			handlerSpans int
+		)
+
+		BeforeEach(func() {
+			fakeExporter = &libfakes.SpanExporter{}
+			authenticator.Tracer = &tracing.Tracer{Service: "some-service", Exporter: fakeExporter}
+			protected = authenticator.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				handlerSpans = fakeExporter.ExportSpanCallCount()
+				Expect(tracing.SpanContextFromContext(r.Context()).IsValid()).To(BeFalse())
+			}))
+		})
+
+		It("checks the token in an auth span that ends before the handler runs", func() {
+			makeRequest()
+			Expect(handlerSpans).To(Equal(1))
+
+			span := fakeExporter.ExportSpanArgsForCall(0)
+			Expect(span.Name).To(Equal("auth"))
+			Expect(span.Error).To(BeEmpty())
+
+			ctx, _ := uaaClient.CheckTokenArgsForCall(0)
+			Expect(tracing.SpanContextFromContext(ctx).SpanID).To(Equal(span.SpanID))
+		})
+
+		Context("when the token check fails", func() {
+			BeforeEach(func() {
+				uaaClient.CheckTokenReturns(uaa_client.CheckTokenResponse{}, errors.New("potato"))
+			})
+
+			It("records the error on the auth span", func() {
+				makeRequest()
+				Expect(fakeExporter.ExportSpanCallCount()).To(Equal(1))
+				Expect(fakeExporter.ExportSpanArgsForCall(0).Error).To(Equal("potato"))
+			})
+		})
+	})
+
 	Context("when the header does not have any authorization header", func() {
 		BeforeEach(func() {
 			var err error
@@ -217,4 +261,35 @@ var _ = Describe("Authentication middleware", func() {
 			Expect(description).To(Equal("provided scopes [wrong.scope] do not include allowed scopes [network.admin network.write]"))
 		})
 	})
+
+	Context("when the token is a client credentials token without any of the allowed scopes", func() {
+		BeforeEach(func() {
+			tokenResponse = uaa_client.CheckTokenResponse{
+				Scope:    []string{"uaa.none"},
+				ClientID: "some-pipeline",
+			}
+			uaaClient.CheckTokenReturns(tokenResponse, nil)
+		})
+
+		It("calls the forbidden error handler", func() {
+			makeRequest()
+			Expect(unprotectedCallCount).To(Equal(0))
+			Expect(fakeErrorResponse.ForbiddenCallCount()).To(Equal(1))
+		})
+
+		Context("when the client is a service account", func() {
+			BeforeEach(func() {
+				authenticator.ServiceAccounts = handlers.NewServiceAccounts([]handlers.ServiceAccount{{
+					ClientID:   "some-pipeline",
+					SpaceGUIDs: []string{"some-space-guid"},
+				}})
+			})
+
+			It("calls the unprotected handler", func() {
+				makeRequest()
+				Expect(unprotectedCallCount).To(Equal(1))
+				Expect(fakeErrorResponse.ForbiddenCallCount()).To(Equal(0))
+			})
+		})
+	})
 })
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"policy-server/api"
+	"policy-server/store"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// BodyLimiter limits request bodies to MaxBytes, or MAX_REQ_BODY_SIZE when
+// zero. A request whose Content-Length is over the limit is rejected with a
+// 413 right away; otherwise reading past the limit fails with a
+// *bodyTooLargeError, and the handler responds with a 413 through
+// writeBodyReadError.
+type BodyLimiter struct {
+	MaxBytes      int64
+	ErrorResponse errorResponse
+}
+
+func (b *BodyLimiter) Wrap(handle http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		maxBytes := b.maxBytes()
+		if req.ContentLength > maxBytes {
+			logger := getLogger(req)
+			logger = logger.Session("body-limit")
+			b.ErrorResponse.RequestEntityTooLarge(logger, w, nil, bodyTooLargeDescription(maxBytes))
+			return
+		}
+
+		req.Body = &limitedBody{ReadCloser: req.Body, remaining: maxBytes, limit: maxBytes}
+		handle.ServeHTTP(w, req)
+	})
+}
+
+func (b *BodyLimiter) maxBytes() int64 {
+	if b.MaxBytes < 1 {
+		return MAX_REQ_BODY_SIZE
+	}
+	return b.MaxBytes
+}
+
+func bodyTooLargeDescription(maxBytes int64) string {
+	return fmt.Sprintf("request body too large: the limit is %d bytes", maxBytes)
+}
+
+// bodyTooLargeError is returned from reading more than limit bytes of a
+// request body.
+type bodyTooLargeError struct {
+	limit int64
+}
+
+func (e *bodyTooLargeError) Error() string {
+	return bodyTooLargeDescription(e.limit)
+}
+
+// limitedBody returns the first limit bytes of the body, and fails with a
+// *bodyTooLargeError if there are more.
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+	limit     int64
+	err       error
+}
+
+func (l *limitedBody) Read(p []byte) (int, error) {
+	if l.err != nil {
+		return 0, l.err
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	// read one byte more than allowed to tell a body of exactly the limit
+	// from a longer one
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.ReadCloser.Read(p)
+	if int64(n) <= l.remaining {
+		l.remaining -= int64(n)
+		return n, err
+	}
+
+	n = int(l.remaining)
+	l.remaining = 0
+	l.err = &bodyTooLargeError{limit: l.limit}
+	return n, l.err
+}
+
+// bodyReadError is an error reading the request body, as opposed to an error
+// in what was read.
+type bodyReadError struct {
+	err error
+}
+
+func (e *bodyReadError) Error() string {
+	return e.err.Error()
+}
+
+// errorReader remembers the first error other than io.EOF from reading r.
+type errorReader struct {
+	r   io.Reader
+	err error
+}
+
+func (e *errorReader) Read(p []byte) (int, error) {
+	n, err := e.r.Read(p)
+	if err != nil && err != io.EOF && e.err == nil {
+		e.err = err
+	}
+	return n, err
+}
+
+// mapRequestPolicies maps a request body with mapper. Mappers that can decode
+// as they read are given the body itself, so that large payloads are not
+// buffered. Failures to read the body are returned as a *bodyReadError.
+func mapRequestPolicies(mapper api.PolicyMapper, body io.Reader) (store.PolicyCollection, error) {
+	streamMapper, ok := mapper.(api.PolicyStreamMapper)
+	if !ok {
+		bodyBytes, err := ioutil.ReadAll(body)
+		if err != nil {
+			return store.PolicyCollection{}, &bodyReadError{err: err}
+		}
+		return mapper.AsStorePolicy(bodyBytes)
+	}
+
+	reader := &errorReader{r: body}
+	policies, err := streamMapper.AsStorePolicyFromReader(reader)
+	if reader.err != nil {
+		return store.PolicyCollection{}, &bodyReadError{err: reader.err}
+	}
+	return policies, err
+}
+
+// writeBodyReadError responds with a 413 when the body was over the limit, and
+// with a bad request and description otherwise.
+func writeBodyReadError(logger lager.Logger, w http.ResponseWriter, errorResponse errorResponse, err error, description string) {
+	if readErr, ok := err.(*bodyReadError); ok {
+		err = readErr.err
+	}
+	if tooLargeErr, ok := err.(*bodyTooLargeError); ok {
+		errorResponse.RequestEntityTooLarge(logger, w, nil, tooLargeErr.Error())
+		return
+	}
+	errorResponse.BadRequest(logger, w, err, description)
+}
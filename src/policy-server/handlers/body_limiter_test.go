@@ -0,0 +1,104 @@
+package handlers_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"policy-server/handlers"
+	"policy-server/handlers/fakes"
+
+	"code.cloudfoundry.org/lager"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BodyLimiter", func() {
+	var (
+		bodyLimiter       *handlers.BodyLimiter
+		fakeErrorResponse *fakes.ErrorResponse
+		limited           http.Handler
+		resp              *httptest.ResponseRecorder
+		readBody          []byte
+		readErr           error
+		handlerCalls      int
+	)
+
+	BeforeEach(func() {
+		fakeErrorResponse = &fakes.ErrorResponse{}
+		fakeErrorResponse.RequestEntityTooLargeStub = func(logger lager.Logger, w http.ResponseWriter, err error, description string) {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+		}
+		bodyLimiter = &handlers.BodyLimiter{MaxBytes: 10, ErrorResponse: fakeErrorResponse}
+		handlerCalls = 0
+		limited = bodyLimiter.Wrap(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			handlerCalls++
+			readBody, readErr = ioutil.ReadAll(req.Body)
+		}))
+		resp = httptest.NewRecorder()
+	})
+
+	It("lets bodies up to the limit through", func() {
+		request, err := http.NewRequest("POST", "/some/path", bytes.NewBufferString("0123456789"))
+		Expect(err).NotTo(HaveOccurred())
+
+		limited.ServeHTTP(resp, request)
+		Expect(handlerCalls).To(Equal(1))
+		Expect(readErr).NotTo(HaveOccurred())
+		Expect(readBody).To(Equal([]byte("0123456789")))
+	})
+
+	Context("when the content length is over the limit", func() {
+		It("responds with a 413 without calling the handler", func() {
+			request, err := http.NewRequest("POST", "/some/path", bytes.NewBufferString("0123456789a"))
+			Expect(err).NotTo(HaveOccurred())
+
+			limited.ServeHTTP(resp, request)
+			Expect(handlerCalls).To(Equal(0))
+			Expect(resp.Code).To(Equal(http.StatusRequestEntityTooLarge))
+
+			Expect(fakeErrorResponse.RequestEntityTooLargeCallCount()).To(Equal(1))
+			_, _, err, description := fakeErrorResponse.RequestEntityTooLargeArgsForCall(0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(description).To(Equal("request body too large: the limit is 10 bytes"))
+		})
+	})
+
+	Context("when the body has no content length", func() {
+		It("fails reading past the limit", func() {
+			request, err := http.NewRequest("POST", "/some/path", ioutil.NopCloser(bytes.NewBufferString("0123456789a")))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(request.ContentLength).To(BeZero())
+
+			limited.ServeHTTP(resp, request)
+			Expect(handlerCalls).To(Equal(1))
+			Expect(readErr).To(MatchError("request body too large: the limit is 10 bytes"))
+			Expect(readBody).To(Equal([]byte("0123456789")))
+		})
+
+		It("reads a body of exactly the limit", func() {
+			request, err := http.NewRequest("POST", "/some/path", ioutil.NopCloser(bytes.NewBufferString("0123456789")))
+			Expect(err).NotTo(HaveOccurred())
+
+			limited.ServeHTTP(resp, request)
+			Expect(readErr).NotTo(HaveOccurred())
+			Expect(readBody).To(Equal([]byte("0123456789")))
+		})
+	})
+
+	Context("when the limit is zero", func() {
+		BeforeEach(func() {
+			bodyLimiter.MaxBytes = 0
+		})
+
+		It("uses the default limit", func() {
+			request, err := http.NewRequest("POST", "/some/path", bytes.NewBufferString("0123456789a"))
+			Expect(err).NotTo(HaveOccurred())
+			request.ContentLength = handlers.MAX_REQ_BODY_SIZE + 1
+
+			limited.ServeHTTP(resp, request)
+			Expect(handlerCalls).To(Equal(0))
+			Expect(resp.Code).To(Equal(http.StatusRequestEntityTooLarge))
+		})
+	})
+})
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/cf-networking-helpers/httperror"
+	"code.cloudfoundry.org/lager"
+)
+
+// httpErrorMetric is the counter httperror.ErrorResponse increments for
+// every error it writes.
+const httpErrorMetric = "http_error"
+
+// ErrorResponse adds the statuses the policy server needs on top of
+// httperror.ErrorResponse, writing the same body and emitting the same
+// metric.
+type ErrorResponse struct {
+	*httperror.ErrorResponse
+}
+
+func (e *ErrorResponse) NotFound(logger lager.Logger, w http.ResponseWriter, err error, description string) {
+	e.write(logger, w, http.StatusNotFound, err, description)
+}
+
+func (e *ErrorResponse) Conflict(logger lager.Logger, w http.ResponseWriter, err error, description string) {
+	e.write(logger, w, http.StatusConflict, err, description)
+}
+
+func (e *ErrorResponse) TooManyRequests(logger lager.Logger, w http.ResponseWriter, err error, description string) {
+	e.write(logger, w, http.StatusTooManyRequests, err, description)
+}
+
+func (e *ErrorResponse) RequestEntityTooLarge(logger lager.Logger, w http.ResponseWriter, err error, description string) {
+	e.write(logger, w, http.StatusRequestEntityTooLarge, err, description)
+}
+
+func (e *ErrorResponse) ServiceUnavailable(logger lager.Logger, w http.ResponseWriter, err error, description string) {
+	e.write(logger, w, http.StatusServiceUnavailable, err, description)
+}
+
+func (e *ErrorResponse) InsufficientStorage(logger lager.Logger, w http.ResponseWriter, err error, description string) {
+	e.write(logger, w, http.StatusInsufficientStorage, err, description)
+}
+
+func (e *ErrorResponse) write(logger lager.Logger, w http.ResponseWriter, status int, err error, description string) {
+	if err != nil {
+		logger.Error(description, err)
+	} else {
+		logger.Info(description)
+	}
+	body, _ := json.Marshal(map[string]string{"error": description})
+	w.WriteHeader(status)
+	w.Write(body)
+	if e.MetricsSender != nil {
+		e.MetricsSender.IncrementCounter(httpErrorMetric)
+	}
+}
@@ -0,0 +1,96 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"policy-server/handlers"
+	storeFakes "policy-server/store/fakes"
+
+	"code.cloudfoundry.org/cf-networking-helpers/httperror"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("ErrorResponse", func() {
+	var (
+		errorResponse     *handlers.ErrorResponse
+		fakeMetricsSender *storeFakes.MetricsSender
+		logger            *lagertest.TestLogger
+		resp              *httptest.ResponseRecorder
+	)
+
+	BeforeEach(func() {
+		fakeMetricsSender = &storeFakes.MetricsSender{}
+		errorResponse = &handlers.ErrorResponse{
+			ErrorResponse: &httperror.ErrorResponse{MetricsSender: fakeMetricsSender},
+		}
+		logger = lagertest.NewTestLogger("test")
+		resp = httptest.NewRecorder()
+	})
+
+	Describe("NotFound", func() {
+		It("responds with 404 and counts the error", func() {
+			errorResponse.NotFound(logger, resp, nil, "tag not found")
+
+			Expect(resp.Code).To(Equal(http.StatusNotFound))
+			Expect(resp.Body).To(MatchJSON(`{"error": "tag not found"}`))
+			Expect(fakeMetricsSender.IncrementCounterCallCount()).To(Equal(1))
+			Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("http_error"))
+			Expect(logger).To(gbytes.Say("tag not found"))
+		})
+	})
+
+	Describe("Conflict", func() {
+		It("responds with 409, logs the error and counts it", func() {
+			errorResponse.Conflict(logger, resp, errors.New("banana"), "tag is in use by a policy")
+
+			Expect(resp.Code).To(Equal(http.StatusConflict))
+			Expect(resp.Body).To(MatchJSON(`{"error": "tag is in use by a policy"}`))
+			Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("http_error"))
+			Expect(logger).To(gbytes.Say("tag is in use by a policy.*banana"))
+		})
+	})
+
+	Describe("TooManyRequests", func() {
+		It("responds with 429 and counts the error", func() {
+			errorResponse.TooManyRequests(logger, resp, nil, "rate limit exceeded")
+
+			Expect(resp.Code).To(Equal(http.StatusTooManyRequests))
+			Expect(resp.Body).To(MatchJSON(`{"error": "rate limit exceeded"}`))
+			Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("http_error"))
+		})
+	})
+
+	Describe("RequestEntityTooLarge", func() {
+		It("responds with 413 and counts the error", func() {
+			errorResponse.RequestEntityTooLarge(logger, resp, nil, "request body too large: the limit is 10 bytes")
+
+			Expect(resp.Code).To(Equal(http.StatusRequestEntityTooLarge))
+			Expect(resp.Body).To(MatchJSON(`{"error": "request body too large: the limit is 10 bytes"}`))
+			Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("http_error"))
+		})
+	})
+
+	Describe("ServiceUnavailable", func() {
+		It("responds with 503 and counts the error", func() {
+			errorResponse.ServiceUnavailable(logger, resp, nil, "try again later")
+
+			Expect(resp.Code).To(Equal(http.StatusServiceUnavailable))
+			Expect(resp.Body).To(MatchJSON(`{"error": "try again later"}`))
+			Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("http_error"))
+		})
+	})
+
+	Describe("InsufficientStorage", func() {
+		It("responds with 507 and counts the error", func() {
+			errorResponse.InsufficientStorage(logger, resp, nil, "tag space exhausted")
+
+			Expect(resp.Code).To(Equal(http.StatusInsufficientStorage))
+			Expect(resp.Body).To(MatchJSON(`{"error": "tag space exhausted"}`))
+			Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("http_error"))
+		})
+	})
+})
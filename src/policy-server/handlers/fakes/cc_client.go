@@ -2,14 +2,16 @@
 package fakes
 
 import (
+	"context"
 	"policy-server/api"
 	"sync"
 )
 
 type CCClient struct {
-	GetAppSpacesStub        func(token string, appGUIDs []string) (map[string]string, error)
+	GetAppSpacesStub        func(ctx context.Context, token string, appGUIDs []string) (map[string]string, error)
 	getAppSpacesMutex       sync.RWMutex
 	getAppSpacesArgsForCall []struct {
+		ctx      context.Context
 		token    string
 		appGUIDs []string
 	}
@@ -21,9 +23,10 @@ type CCClient struct {
 		result1 map[string]string
 		result2 error
 	}
-	GetSpaceStub        func(token, spaceGUID string) (*api.Space, error)
+	GetSpaceStub        func(ctx context.Context, token, spaceGUID string) (*api.Space, error)
 	getSpaceMutex       sync.RWMutex
 	getSpaceArgsForCall []struct {
+		ctx       context.Context
 		token     string
 		spaceGUID string
 	}
@@ -35,9 +38,10 @@ type CCClient struct {
 		result1 *api.Space
 		result2 error
 	}
-	GetSpaceGUIDsStub        func(token string, appGUIDs []string) ([]string, error)
+	GetSpaceGUIDsStub        func(ctx context.Context, token string, appGUIDs []string) ([]string, error)
 	getSpaceGUIDsMutex       sync.RWMutex
 	getSpaceGUIDsArgsForCall []struct {
+		ctx      context.Context
 		token    string
 		appGUIDs []string
 	}
@@ -49,12 +53,14 @@ type CCClient struct {
 		result1 []string
 		result2 error
 	}
-	GetUserSpaceStub        func(token, userGUID string, spaces api.Space) (*api.Space, error)
+	GetUserSpaceStub        func(ctx context.Context, token, userGUID string, spaces api.Space, roles []string) (*api.Space, error)
 	getUserSpaceMutex       sync.RWMutex
 	getUserSpaceArgsForCall []struct {
+		ctx      context.Context
 		token    string
 		userGUID string
 		spaces   api.Space
+		roles    []string
 	}
 	getUserSpaceReturns struct {
 		result1 *api.Space
@@ -64,11 +70,13 @@ type CCClient struct {
 		result1 *api.Space
 		result2 error
 	}
-	GetUserSpacesStub        func(token, userGUID string) (map[string]struct{}, error)
+	GetUserSpacesStub        func(ctx context.Context, token, userGUID string, roles []string) (map[string]struct{}, error)
 	getUserSpacesMutex       sync.RWMutex
 	getUserSpacesArgsForCall []struct {
+		ctx      context.Context
 		token    string
 		userGUID string
+		roles    []string
 	}
 	getUserSpacesReturns struct {
 		result1 map[string]struct{}
@@ -82,7 +90,7 @@ type CCClient struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *CCClient) GetAppSpaces(token string, appGUIDs []string) (map[string]string, error) {
+func (fake *CCClient) GetAppSpaces(ctx context.Context, token string, appGUIDs []string) (map[string]string, error) {
 	var appGUIDsCopy []string
 	if appGUIDs != nil {
 		appGUIDsCopy = make([]string, len(appGUIDs))
@@ -91,13 +99,14 @@ func (fake *CCClient) GetAppSpaces(token string, appGUIDs []string) (map[string]
 	fake.getAppSpacesMutex.Lock()
 	ret, specificReturn := fake.getAppSpacesReturnsOnCall[len(fake.getAppSpacesArgsForCall)]
 	fake.getAppSpacesArgsForCall = append(fake.getAppSpacesArgsForCall, struct {
+		ctx      context.Context
 		token    string
 		appGUIDs []string
-	}{token, appGUIDsCopy})
-	fake.recordInvocation("GetAppSpaces", []interface{}{token, appGUIDsCopy})
+	}{ctx, token, appGUIDsCopy})
+	fake.recordInvocation("GetAppSpaces", []interface{}{ctx, token, appGUIDsCopy})
 	fake.getAppSpacesMutex.Unlock()
 	if fake.GetAppSpacesStub != nil {
-		return fake.GetAppSpacesStub(token, appGUIDs)
+		return fake.GetAppSpacesStub(ctx, token, appGUIDs)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -111,10 +120,10 @@ func (fake *CCClient) GetAppSpacesCallCount() int {
 	return len(fake.getAppSpacesArgsForCall)
 }
 
-func (fake *CCClient) GetAppSpacesArgsForCall(i int) (string, []string) {
+func (fake *CCClient) GetAppSpacesArgsForCall(i int) (context.Context, string, []string) {
 	fake.getAppSpacesMutex.RLock()
 	defer fake.getAppSpacesMutex.RUnlock()
-	return fake.getAppSpacesArgsForCall[i].token, fake.getAppSpacesArgsForCall[i].appGUIDs
+	return fake.getAppSpacesArgsForCall[i].ctx, fake.getAppSpacesArgsForCall[i].token, fake.getAppSpacesArgsForCall[i].appGUIDs
 }
 
 func (fake *CCClient) GetAppSpacesReturns(result1 map[string]string, result2 error) {
@@ -139,17 +148,18 @@ func (fake *CCClient) GetAppSpacesReturnsOnCall(i int, result1 map[string]string
 	}{result1, result2}
 }
 
-func (fake *CCClient) GetSpace(token string, spaceGUID string) (*api.Space, error) {
+func (fake *CCClient) GetSpace(ctx context.Context, token string, spaceGUID string) (*api.Space, error) {
 	fake.getSpaceMutex.Lock()
 	ret, specificReturn := fake.getSpaceReturnsOnCall[len(fake.getSpaceArgsForCall)]
 	fake.getSpaceArgsForCall = append(fake.getSpaceArgsForCall, struct {
+		ctx       context.Context
 		token     string
 		spaceGUID string
-	}{token, spaceGUID})
-	fake.recordInvocation("GetSpace", []interface{}{token, spaceGUID})
+	}{ctx, token, spaceGUID})
+	fake.recordInvocation("GetSpace", []interface{}{ctx, token, spaceGUID})
 	fake.getSpaceMutex.Unlock()
 	if fake.GetSpaceStub != nil {
-		return fake.GetSpaceStub(token, spaceGUID)
+		return fake.GetSpaceStub(ctx, token, spaceGUID)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -163,10 +173,10 @@ func (fake *CCClient) GetSpaceCallCount() int {
 	return len(fake.getSpaceArgsForCall)
 }
 
-func (fake *CCClient) GetSpaceArgsForCall(i int) (string, string) {
+func (fake *CCClient) GetSpaceArgsForCall(i int) (context.Context, string, string) {
 	fake.getSpaceMutex.RLock()
 	defer fake.getSpaceMutex.RUnlock()
-	return fake.getSpaceArgsForCall[i].token, fake.getSpaceArgsForCall[i].spaceGUID
+	return fake.getSpaceArgsForCall[i].ctx, fake.getSpaceArgsForCall[i].token, fake.getSpaceArgsForCall[i].spaceGUID
 }
 
 func (fake *CCClient) GetSpaceReturns(result1 *api.Space, result2 error) {
@@ -191,7 +201,7 @@ func (fake *CCClient) GetSpaceReturnsOnCall(i int, result1 *api.Space, result2 e
 	}{result1, result2}
 }
 
-func (fake *CCClient) GetSpaceGUIDs(token string, appGUIDs []string) ([]string, error) {
+func (fake *CCClient) GetSpaceGUIDs(ctx context.Context, token string, appGUIDs []string) ([]string, error) {
 	var appGUIDsCopy []string
 	if appGUIDs != nil {
 		appGUIDsCopy = make([]string, len(appGUIDs))
@@ -200,13 +210,14 @@ func (fake *CCClient) GetSpaceGUIDs(token string, appGUIDs []string) ([]string,
 	fake.getSpaceGUIDsMutex.Lock()
 	ret, specificReturn := fake.getSpaceGUIDsReturnsOnCall[len(fake.getSpaceGUIDsArgsForCall)]
 	fake.getSpaceGUIDsArgsForCall = append(fake.getSpaceGUIDsArgsForCall, struct {
+		ctx      context.Context
 		token    string
 		appGUIDs []string
-	}{token, appGUIDsCopy})
-	fake.recordInvocation("GetSpaceGUIDs", []interface{}{token, appGUIDsCopy})
+	}{ctx, token, appGUIDsCopy})
+	fake.recordInvocation("GetSpaceGUIDs", []interface{}{ctx, token, appGUIDsCopy})
 	fake.getSpaceGUIDsMutex.Unlock()
 	if fake.GetSpaceGUIDsStub != nil {
-		return fake.GetSpaceGUIDsStub(token, appGUIDs)
+		return fake.GetSpaceGUIDsStub(ctx, token, appGUIDs)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -220,10 +231,10 @@ func (fake *CCClient) GetSpaceGUIDsCallCount() int {
 	return len(fake.getSpaceGUIDsArgsForCall)
 }
 
-func (fake *CCClient) GetSpaceGUIDsArgsForCall(i int) (string, []string) {
+func (fake *CCClient) GetSpaceGUIDsArgsForCall(i int) (context.Context, string, []string) {
 	fake.getSpaceGUIDsMutex.RLock()
 	defer fake.getSpaceGUIDsMutex.RUnlock()
-	return fake.getSpaceGUIDsArgsForCall[i].token, fake.getSpaceGUIDsArgsForCall[i].appGUIDs
+	return fake.getSpaceGUIDsArgsForCall[i].ctx, fake.getSpaceGUIDsArgsForCall[i].token, fake.getSpaceGUIDsArgsForCall[i].appGUIDs
 }
 
 func (fake *CCClient) GetSpaceGUIDsReturns(result1 []string, result2 error) {
@@ -248,18 +259,25 @@ func (fake *CCClient) GetSpaceGUIDsReturnsOnCall(i int, result1 []string, result
 	}{result1, result2}
 }
 
-func (fake *CCClient) GetUserSpace(token string, userGUID string, spaces api.Space) (*api.Space, error) {
+func (fake *CCClient) GetUserSpace(ctx context.Context, token string, userGUID string, spaces api.Space, roles []string) (*api.Space, error) {
+	var rolesCopy []string
+	if roles != nil {
+		rolesCopy = make([]string, len(roles))
+		copy(rolesCopy, roles)
+	}
 	fake.getUserSpaceMutex.Lock()
 	ret, specificReturn := fake.getUserSpaceReturnsOnCall[len(fake.getUserSpaceArgsForCall)]
 	fake.getUserSpaceArgsForCall = append(fake.getUserSpaceArgsForCall, struct {
+		ctx      context.Context
 		token    string
 		userGUID string
 		spaces   api.Space
-	}{token, userGUID, spaces})
-	fake.recordInvocation("GetUserSpace", []interface{}{token, userGUID, spaces})
+		roles    []string
+	}{ctx, token, userGUID, spaces, rolesCopy})
+	fake.recordInvocation("GetUserSpace", []interface{}{ctx, token, userGUID, spaces, rolesCopy})
 	fake.getUserSpaceMutex.Unlock()
 	if fake.GetUserSpaceStub != nil {
-		return fake.GetUserSpaceStub(token, userGUID, spaces)
+		return fake.GetUserSpaceStub(ctx, token, userGUID, spaces, roles)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -273,10 +291,10 @@ func (fake *CCClient) GetUserSpaceCallCount() int {
 	return len(fake.getUserSpaceArgsForCall)
 }
 
-func (fake *CCClient) GetUserSpaceArgsForCall(i int) (string, string, api.Space) {
+func (fake *CCClient) GetUserSpaceArgsForCall(i int) (context.Context, string, string, api.Space, []string) {
 	fake.getUserSpaceMutex.RLock()
 	defer fake.getUserSpaceMutex.RUnlock()
-	return fake.getUserSpaceArgsForCall[i].token, fake.getUserSpaceArgsForCall[i].userGUID, fake.getUserSpaceArgsForCall[i].spaces
+	return fake.getUserSpaceArgsForCall[i].ctx, fake.getUserSpaceArgsForCall[i].token, fake.getUserSpaceArgsForCall[i].userGUID, fake.getUserSpaceArgsForCall[i].spaces, fake.getUserSpaceArgsForCall[i].roles
 }
 
 func (fake *CCClient) GetUserSpaceReturns(result1 *api.Space, result2 error) {
@@ -301,17 +319,24 @@ func (fake *CCClient) GetUserSpaceReturnsOnCall(i int, result1 *api.Space, resul
 	}{result1, result2}
 }
 
-func (fake *CCClient) GetUserSpaces(token string, userGUID string) (map[string]struct{}, error) {
+func (fake *CCClient) GetUserSpaces(ctx context.Context, token string, userGUID string, roles []string) (map[string]struct{}, error) {
+	var rolesCopy []string
+	if roles != nil {
+		rolesCopy = make([]string, len(roles))
+		copy(rolesCopy, roles)
+	}
 	fake.getUserSpacesMutex.Lock()
 	ret, specificReturn := fake.getUserSpacesReturnsOnCall[len(fake.getUserSpacesArgsForCall)]
 	fake.getUserSpacesArgsForCall = append(fake.getUserSpacesArgsForCall, struct {
+		ctx      context.Context
 		token    string
 		userGUID string
-	}{token, userGUID})
-	fake.recordInvocation("GetUserSpaces", []interface{}{token, userGUID})
+		roles    []string
+	}{ctx, token, userGUID, rolesCopy})
+	fake.recordInvocation("GetUserSpaces", []interface{}{ctx, token, userGUID, rolesCopy})
 	fake.getUserSpacesMutex.Unlock()
 	if fake.GetUserSpacesStub != nil {
-		return fake.GetUserSpacesStub(token, userGUID)
+		return fake.GetUserSpacesStub(ctx, token, userGUID, roles)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -325,10 +350,10 @@ func (fake *CCClient) GetUserSpacesCallCount() int {
 	return len(fake.getUserSpacesArgsForCall)
 }
 
-func (fake *CCClient) GetUserSpacesArgsForCall(i int) (string, string) {
+func (fake *CCClient) GetUserSpacesArgsForCall(i int) (context.Context, string, string, []string) {
 	fake.getUserSpacesMutex.RLock()
 	defer fake.getUserSpacesMutex.RUnlock()
-	return fake.getUserSpacesArgsForCall[i].token, fake.getUserSpacesArgsForCall[i].userGUID
+	return fake.getUserSpacesArgsForCall[i].ctx, fake.getUserSpacesArgsForCall[i].token, fake.getUserSpacesArgsForCall[i].userGUID, fake.getUserSpacesArgsForCall[i].roles
 }
 
 func (fake *CCClient) GetUserSpacesReturns(result1 map[string]struct{}, result2 error) {
@@ -2,16 +2,18 @@
 package fakes
 
 import (
+	"context"
 	"policy-server/store"
 	"sync"
 )
 
 type CreateTagDataStore struct {
-	CreateTagStub        func(string, string) (store.Tag, error)
+	CreateTagStub        func(context.Context, string, string) (store.Tag, error)
 	createTagMutex       sync.RWMutex
 	createTagArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
+		arg3 string
 	}
 	createTagReturns struct {
 		result1 store.Tag
@@ -25,17 +27,18 @@ type CreateTagDataStore struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *CreateTagDataStore) CreateTag(arg1 string, arg2 string) (store.Tag, error) {
+func (fake *CreateTagDataStore) CreateTag(arg1 context.Context, arg2 string, arg3 string) (store.Tag, error) {
 	fake.createTagMutex.Lock()
 	ret, specificReturn := fake.createTagReturnsOnCall[len(fake.createTagArgsForCall)]
 	fake.createTagArgsForCall = append(fake.createTagArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
-	fake.recordInvocation("CreateTag", []interface{}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("CreateTag", []interface{}{arg1, arg2, arg3})
 	fake.createTagMutex.Unlock()
 	if fake.CreateTagStub != nil {
-		return fake.CreateTagStub(arg1, arg2)
+		return fake.CreateTagStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -49,10 +52,10 @@ func (fake *CreateTagDataStore) CreateTagCallCount() int {
 	return len(fake.createTagArgsForCall)
 }
 
-func (fake *CreateTagDataStore) CreateTagArgsForCall(i int) (string, string) {
+func (fake *CreateTagDataStore) CreateTagArgsForCall(i int) (context.Context, string, string) {
 	fake.createTagMutex.RLock()
 	defer fake.createTagMutex.RUnlock()
-	return fake.createTagArgsForCall[i].arg1, fake.createTagArgsForCall[i].arg2
+	return fake.createTagArgsForCall[i].arg1, fake.createTagArgsForCall[i].arg2, fake.createTagArgsForCall[i].arg3
 }
 
 func (fake *CreateTagDataStore) CreateTagReturns(result1 store.Tag, result2 error) {
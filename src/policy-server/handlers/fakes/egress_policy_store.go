@@ -2,15 +2,18 @@
 package fakes
 
 import (
+	"context"
 	"policy-server/store"
 	"sync"
 )
 
 type EgressPolicyStore struct {
-	AllStub        func() ([]store.EgressPolicy, error)
+	AllStub        func(ctx context.Context) ([]store.EgressPolicy, error)
 	allMutex       sync.RWMutex
-	allArgsForCall []struct{}
-	allReturns     struct {
+	allArgsForCall []struct {
+		ctx context.Context
+	}
+	allReturns struct {
 		result1 []store.EgressPolicy
 		result2 error
 	}
@@ -18,9 +21,10 @@ type EgressPolicyStore struct {
 		result1 []store.EgressPolicy
 		result2 error
 	}
-	ByGuidsStub        func(ids []string) ([]store.EgressPolicy, error)
+	ByGuidsStub        func(ctx context.Context, ids []string) ([]store.EgressPolicy, error)
 	byGuidsMutex       sync.RWMutex
 	byGuidsArgsForCall []struct {
+		ctx context.Context
 		ids []string
 	}
 	byGuidsReturns struct {
@@ -35,14 +39,16 @@ type EgressPolicyStore struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *EgressPolicyStore) All() ([]store.EgressPolicy, error) {
+func (fake *EgressPolicyStore) All(ctx context.Context) ([]store.EgressPolicy, error) {
 	fake.allMutex.Lock()
 	ret, specificReturn := fake.allReturnsOnCall[len(fake.allArgsForCall)]
-	fake.allArgsForCall = append(fake.allArgsForCall, struct{}{})
-	fake.recordInvocation("All", []interface{}{})
+	fake.allArgsForCall = append(fake.allArgsForCall, struct {
+		ctx context.Context
+	}{ctx})
+	fake.recordInvocation("All", []interface{}{ctx})
 	fake.allMutex.Unlock()
 	if fake.AllStub != nil {
-		return fake.AllStub()
+		return fake.AllStub(ctx)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -56,6 +62,12 @@ func (fake *EgressPolicyStore) AllCallCount() int {
 	return len(fake.allArgsForCall)
 }
 
+func (fake *EgressPolicyStore) AllArgsForCall(i int) context.Context {
+	fake.allMutex.RLock()
+	defer fake.allMutex.RUnlock()
+	return fake.allArgsForCall[i].ctx
+}
+
 func (fake *EgressPolicyStore) AllReturns(result1 []store.EgressPolicy, result2 error) {
 	fake.AllStub = nil
 	fake.allReturns = struct {
@@ -78,7 +90,7 @@ func (fake *EgressPolicyStore) AllReturnsOnCall(i int, result1 []store.EgressPol
 	}{result1, result2}
 }
 
-func (fake *EgressPolicyStore) ByGuids(ids []string) ([]store.EgressPolicy, error) {
+func (fake *EgressPolicyStore) ByGuids(ctx context.Context, ids []string) ([]store.EgressPolicy, error) {
 	var idsCopy []string
 	if ids != nil {
 		idsCopy = make([]string, len(ids))
@@ -87,12 +99,13 @@ func (fake *EgressPolicyStore) ByGuids(ids []string) ([]store.EgressPolicy, erro
 	fake.byGuidsMutex.Lock()
 	ret, specificReturn := fake.byGuidsReturnsOnCall[len(fake.byGuidsArgsForCall)]
 	fake.byGuidsArgsForCall = append(fake.byGuidsArgsForCall, struct {
+		ctx context.Context
 		ids []string
-	}{idsCopy})
-	fake.recordInvocation("ByGuids", []interface{}{idsCopy})
+	}{ctx, idsCopy})
+	fake.recordInvocation("ByGuids", []interface{}{ctx, idsCopy})
 	fake.byGuidsMutex.Unlock()
 	if fake.ByGuidsStub != nil {
-		return fake.ByGuidsStub(ids)
+		return fake.ByGuidsStub(ctx, ids)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -106,10 +119,10 @@ func (fake *EgressPolicyStore) ByGuidsCallCount() int {
 	return len(fake.byGuidsArgsForCall)
 }
 
-func (fake *EgressPolicyStore) ByGuidsArgsForCall(i int) []string {
+func (fake *EgressPolicyStore) ByGuidsArgsForCall(i int) (context.Context, []string) {
 	fake.byGuidsMutex.RLock()
 	defer fake.byGuidsMutex.RUnlock()
-	return fake.byGuidsArgsForCall[i].ids
+	return fake.byGuidsArgsForCall[i].ctx, fake.byGuidsArgsForCall[i].ids
 }
 
 func (fake *EgressPolicyStore) ByGuidsReturns(result1 []store.EgressPolicy, result2 error) {
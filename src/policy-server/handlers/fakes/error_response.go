@@ -49,6 +49,54 @@ type ErrorResponse struct {
 		arg3 error
 		arg4 string
 	}
+	NotFoundStub        func(lager.Logger, http.ResponseWriter, error, string)
+	notFoundMutex       sync.RWMutex
+	notFoundArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 http.ResponseWriter
+		arg3 error
+		arg4 string
+	}
+	ConflictStub        func(lager.Logger, http.ResponseWriter, error, string)
+	conflictMutex       sync.RWMutex
+	conflictArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 http.ResponseWriter
+		arg3 error
+		arg4 string
+	}
+	TooManyRequestsStub        func(lager.Logger, http.ResponseWriter, error, string)
+	tooManyRequestsMutex       sync.RWMutex
+	tooManyRequestsArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 http.ResponseWriter
+		arg3 error
+		arg4 string
+	}
+	RequestEntityTooLargeStub        func(lager.Logger, http.ResponseWriter, error, string)
+	requestEntityTooLargeMutex       sync.RWMutex
+	requestEntityTooLargeArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 http.ResponseWriter
+		arg3 error
+		arg4 string
+	}
+	ServiceUnavailableStub        func(lager.Logger, http.ResponseWriter, error, string)
+	serviceUnavailableMutex       sync.RWMutex
+	serviceUnavailableArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 http.ResponseWriter
+		arg3 error
+		arg4 string
+	}
+	InsufficientStorageStub        func(lager.Logger, http.ResponseWriter, error, string)
+	insufficientStorageMutex       sync.RWMutex
+	insufficientStorageArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 http.ResponseWriter
+		arg3 error
+		arg4 string
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -188,6 +236,168 @@ func (fake *ErrorResponse) UnauthorizedArgsForCall(i int) (lager.Logger, http.Re
 	return fake.unauthorizedArgsForCall[i].arg1, fake.unauthorizedArgsForCall[i].arg2, fake.unauthorizedArgsForCall[i].arg3, fake.unauthorizedArgsForCall[i].arg4
 }
 
+func (fake *ErrorResponse) NotFound(arg1 lager.Logger, arg2 http.ResponseWriter, arg3 error, arg4 string) {
+	fake.notFoundMutex.Lock()
+	fake.notFoundArgsForCall = append(fake.notFoundArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 http.ResponseWriter
+		arg3 error
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("NotFound", []interface{}{arg1, arg2, arg3, arg4})
+	fake.notFoundMutex.Unlock()
+	if fake.NotFoundStub != nil {
+		fake.NotFoundStub(arg1, arg2, arg3, arg4)
+	}
+}
+
+func (fake *ErrorResponse) NotFoundCallCount() int {
+	fake.notFoundMutex.RLock()
+	defer fake.notFoundMutex.RUnlock()
+	return len(fake.notFoundArgsForCall)
+}
+
+func (fake *ErrorResponse) NotFoundArgsForCall(i int) (lager.Logger, http.ResponseWriter, error, string) {
+	fake.notFoundMutex.RLock()
+	defer fake.notFoundMutex.RUnlock()
+	return fake.notFoundArgsForCall[i].arg1, fake.notFoundArgsForCall[i].arg2, fake.notFoundArgsForCall[i].arg3, fake.notFoundArgsForCall[i].arg4
+}
+
+func (fake *ErrorResponse) Conflict(arg1 lager.Logger, arg2 http.ResponseWriter, arg3 error, arg4 string) {
+	fake.conflictMutex.Lock()
+	fake.conflictArgsForCall = append(fake.conflictArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 http.ResponseWriter
+		arg3 error
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("Conflict", []interface{}{arg1, arg2, arg3, arg4})
+	fake.conflictMutex.Unlock()
+	if fake.ConflictStub != nil {
+		fake.ConflictStub(arg1, arg2, arg3, arg4)
+	}
+}
+
+func (fake *ErrorResponse) ConflictCallCount() int {
+	fake.conflictMutex.RLock()
+	defer fake.conflictMutex.RUnlock()
+	return len(fake.conflictArgsForCall)
+}
+
+func (fake *ErrorResponse) ConflictArgsForCall(i int) (lager.Logger, http.ResponseWriter, error, string) {
+	fake.conflictMutex.RLock()
+	defer fake.conflictMutex.RUnlock()
+	return fake.conflictArgsForCall[i].arg1, fake.conflictArgsForCall[i].arg2, fake.conflictArgsForCall[i].arg3, fake.conflictArgsForCall[i].arg4
+}
+
+func (fake *ErrorResponse) TooManyRequests(arg1 lager.Logger, arg2 http.ResponseWriter, arg3 error, arg4 string) {
+	fake.tooManyRequestsMutex.Lock()
+	fake.tooManyRequestsArgsForCall = append(fake.tooManyRequestsArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 http.ResponseWriter
+		arg3 error
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("TooManyRequests", []interface{}{arg1, arg2, arg3, arg4})
+	fake.tooManyRequestsMutex.Unlock()
+	if fake.TooManyRequestsStub != nil {
+		fake.TooManyRequestsStub(arg1, arg2, arg3, arg4)
+	}
+}
+
+func (fake *ErrorResponse) TooManyRequestsCallCount() int {
+	fake.tooManyRequestsMutex.RLock()
+	defer fake.tooManyRequestsMutex.RUnlock()
+	return len(fake.tooManyRequestsArgsForCall)
+}
+
+func (fake *ErrorResponse) TooManyRequestsArgsForCall(i int) (lager.Logger, http.ResponseWriter, error, string) {
+	fake.tooManyRequestsMutex.RLock()
+	defer fake.tooManyRequestsMutex.RUnlock()
+	return fake.tooManyRequestsArgsForCall[i].arg1, fake.tooManyRequestsArgsForCall[i].arg2, fake.tooManyRequestsArgsForCall[i].arg3, fake.tooManyRequestsArgsForCall[i].arg4
+}
+
+func (fake *ErrorResponse) RequestEntityTooLarge(arg1 lager.Logger, arg2 http.ResponseWriter, arg3 error, arg4 string) {
+	fake.requestEntityTooLargeMutex.Lock()
+	fake.requestEntityTooLargeArgsForCall = append(fake.requestEntityTooLargeArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 http.ResponseWriter
+		arg3 error
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("RequestEntityTooLarge", []interface{}{arg1, arg2, arg3, arg4})
+	fake.requestEntityTooLargeMutex.Unlock()
+	if fake.RequestEntityTooLargeStub != nil {
+		fake.RequestEntityTooLargeStub(arg1, arg2, arg3, arg4)
+	}
+}
+
+func (fake *ErrorResponse) RequestEntityTooLargeCallCount() int {
+	fake.requestEntityTooLargeMutex.RLock()
+	defer fake.requestEntityTooLargeMutex.RUnlock()
+	return len(fake.requestEntityTooLargeArgsForCall)
+}
+
+func (fake *ErrorResponse) RequestEntityTooLargeArgsForCall(i int) (lager.Logger, http.ResponseWriter, error, string) {
+	fake.requestEntityTooLargeMutex.RLock()
+	defer fake.requestEntityTooLargeMutex.RUnlock()
+	return fake.requestEntityTooLargeArgsForCall[i].arg1, fake.requestEntityTooLargeArgsForCall[i].arg2, fake.requestEntityTooLargeArgsForCall[i].arg3, fake.requestEntityTooLargeArgsForCall[i].arg4
+}
+
+func (fake *ErrorResponse) ServiceUnavailable(arg1 lager.Logger, arg2 http.ResponseWriter, arg3 error, arg4 string) {
+	fake.serviceUnavailableMutex.Lock()
+	fake.serviceUnavailableArgsForCall = append(fake.serviceUnavailableArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 http.ResponseWriter
+		arg3 error
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("ServiceUnavailable", []interface{}{arg1, arg2, arg3, arg4})
+	fake.serviceUnavailableMutex.Unlock()
+	if fake.ServiceUnavailableStub != nil {
+		fake.ServiceUnavailableStub(arg1, arg2, arg3, arg4)
+	}
+}
+
+func (fake *ErrorResponse) ServiceUnavailableCallCount() int {
+	fake.serviceUnavailableMutex.RLock()
+	defer fake.serviceUnavailableMutex.RUnlock()
+	return len(fake.serviceUnavailableArgsForCall)
+}
+
+func (fake *ErrorResponse) ServiceUnavailableArgsForCall(i int) (lager.Logger, http.ResponseWriter, error, string) {
+	fake.serviceUnavailableMutex.RLock()
+	defer fake.serviceUnavailableMutex.RUnlock()
+	return fake.serviceUnavailableArgsForCall[i].arg1, fake.serviceUnavailableArgsForCall[i].arg2, fake.serviceUnavailableArgsForCall[i].arg3, fake.serviceUnavailableArgsForCall[i].arg4
+}
+
+func (fake *ErrorResponse) InsufficientStorage(arg1 lager.Logger, arg2 http.ResponseWriter, arg3 error, arg4 string) {
+	fake.insufficientStorageMutex.Lock()
+	fake.insufficientStorageArgsForCall = append(fake.insufficientStorageArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 http.ResponseWriter
+		arg3 error
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("InsufficientStorage", []interface{}{arg1, arg2, arg3, arg4})
+	fake.insufficientStorageMutex.Unlock()
+	if fake.InsufficientStorageStub != nil {
+		fake.InsufficientStorageStub(arg1, arg2, arg3, arg4)
+	}
+}
+
+func (fake *ErrorResponse) InsufficientStorageCallCount() int {
+	fake.insufficientStorageMutex.RLock()
+	defer fake.insufficientStorageMutex.RUnlock()
+	return len(fake.insufficientStorageArgsForCall)
+}
+
+func (fake *ErrorResponse) InsufficientStorageArgsForCall(i int) (lager.Logger, http.ResponseWriter, error, string) {
+	fake.insufficientStorageMutex.RLock()
+	defer fake.insufficientStorageMutex.RUnlock()
+	return fake.insufficientStorageArgsForCall[i].arg1, fake.insufficientStorageArgsForCall[i].arg2, fake.insufficientStorageArgsForCall[i].arg3, fake.insufficientStorageArgsForCall[i].arg4
+}
+
 func (fake *ErrorResponse) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -201,6 +411,18 @@ func (fake *ErrorResponse) Invocations() map[string][][]interface{} {
 	defer fake.forbiddenMutex.RUnlock()
 	fake.unauthorizedMutex.RLock()
 	defer fake.unauthorizedMutex.RUnlock()
+	fake.notFoundMutex.RLock()
+	defer fake.notFoundMutex.RUnlock()
+	fake.conflictMutex.RLock()
+	defer fake.conflictMutex.RUnlock()
+	fake.tooManyRequestsMutex.RLock()
+	defer fake.tooManyRequestsMutex.RUnlock()
+	fake.requestEntityTooLargeMutex.RLock()
+	defer fake.requestEntityTooLargeMutex.RUnlock()
+	fake.serviceUnavailableMutex.RLock()
+	defer fake.serviceUnavailableMutex.RUnlock()
+	fake.insufficientStorageMutex.RLock()
+	defer fake.insufficientStorageMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -2,27 +2,38 @@
 package fakes
 
 import (
-	"policy-server/store"
+	"policy-server/cleaner"
 	"sync"
 )
 
 type PolicyCleaner struct {
-	DeleteStalePoliciesStub        func() ([]store.Policy, error)
+	DeleteStalePoliciesStub        func() (cleaner.Result, error)
 	deleteStalePoliciesMutex       sync.RWMutex
 	deleteStalePoliciesArgsForCall []struct{}
 	deleteStalePoliciesReturns     struct {
-		result1 []store.Policy
+		result1 cleaner.Result
 		result2 error
 	}
 	deleteStalePoliciesReturnsOnCall map[int]struct {
-		result1 []store.Policy
+		result1 cleaner.Result
+		result2 error
+	}
+	FindStalePoliciesStub        func() (cleaner.Result, error)
+	findStalePoliciesMutex       sync.RWMutex
+	findStalePoliciesArgsForCall []struct{}
+	findStalePoliciesReturns     struct {
+		result1 cleaner.Result
+		result2 error
+	}
+	findStalePoliciesReturnsOnCall map[int]struct {
+		result1 cleaner.Result
 		result2 error
 	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *PolicyCleaner) DeleteStalePolicies() ([]store.Policy, error) {
+func (fake *PolicyCleaner) DeleteStalePolicies() (cleaner.Result, error) {
 	fake.deleteStalePoliciesMutex.Lock()
 	ret, specificReturn := fake.deleteStalePoliciesReturnsOnCall[len(fake.deleteStalePoliciesArgsForCall)]
 	fake.deleteStalePoliciesArgsForCall = append(fake.deleteStalePoliciesArgsForCall, struct{}{})
@@ -43,24 +54,67 @@ func (fake *PolicyCleaner) DeleteStalePoliciesCallCount() int {
 	return len(fake.deleteStalePoliciesArgsForCall)
 }
 
-func (fake *PolicyCleaner) DeleteStalePoliciesReturns(result1 []store.Policy, result2 error) {
+func (fake *PolicyCleaner) DeleteStalePoliciesReturns(result1 cleaner.Result, result2 error) {
 	fake.DeleteStalePoliciesStub = nil
 	fake.deleteStalePoliciesReturns = struct {
-		result1 []store.Policy
+		result1 cleaner.Result
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *PolicyCleaner) DeleteStalePoliciesReturnsOnCall(i int, result1 []store.Policy, result2 error) {
+func (fake *PolicyCleaner) DeleteStalePoliciesReturnsOnCall(i int, result1 cleaner.Result, result2 error) {
 	fake.DeleteStalePoliciesStub = nil
 	if fake.deleteStalePoliciesReturnsOnCall == nil {
 		fake.deleteStalePoliciesReturnsOnCall = make(map[int]struct {
-			result1 []store.Policy
+			result1 cleaner.Result
 			result2 error
 		})
 	}
 	fake.deleteStalePoliciesReturnsOnCall[i] = struct {
-		result1 []store.Policy
+		result1 cleaner.Result
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PolicyCleaner) FindStalePolicies() (cleaner.Result, error) {
+	fake.findStalePoliciesMutex.Lock()
+	ret, specificReturn := fake.findStalePoliciesReturnsOnCall[len(fake.findStalePoliciesArgsForCall)]
+	fake.findStalePoliciesArgsForCall = append(fake.findStalePoliciesArgsForCall, struct{}{})
+	fake.recordInvocation("FindStalePolicies", []interface{}{})
+	fake.findStalePoliciesMutex.Unlock()
+	if fake.FindStalePoliciesStub != nil {
+		return fake.FindStalePoliciesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.findStalePoliciesReturns.result1, fake.findStalePoliciesReturns.result2
+}
+
+func (fake *PolicyCleaner) FindStalePoliciesCallCount() int {
+	fake.findStalePoliciesMutex.RLock()
+	defer fake.findStalePoliciesMutex.RUnlock()
+	return len(fake.findStalePoliciesArgsForCall)
+}
+
+func (fake *PolicyCleaner) FindStalePoliciesReturns(result1 cleaner.Result, result2 error) {
+	fake.FindStalePoliciesStub = nil
+	fake.findStalePoliciesReturns = struct {
+		result1 cleaner.Result
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PolicyCleaner) FindStalePoliciesReturnsOnCall(i int, result1 cleaner.Result, result2 error) {
+	fake.FindStalePoliciesStub = nil
+	if fake.findStalePoliciesReturnsOnCall == nil {
+		fake.findStalePoliciesReturnsOnCall = make(map[int]struct {
+			result1 cleaner.Result
+			result2 error
+		})
+	}
+	fake.findStalePoliciesReturnsOnCall[i] = struct {
+		result1 cleaner.Result
 		result2 error
 	}{result1, result2}
 }
@@ -70,6 +124,8 @@ func (fake *PolicyCleaner) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.deleteStalePoliciesMutex.RLock()
 	defer fake.deleteStalePoliciesMutex.RUnlock()
+	fake.findStalePoliciesMutex.RLock()
+	defer fake.findStalePoliciesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -2,15 +2,17 @@
 package fakes
 
 import (
+	"context"
 	"policy-server/store"
 	"sync"
 )
 
 type PolicyCollectionStore struct {
-	CreateStub        func(store.PolicyCollection) error
+	CreateStub        func(context.Context, store.PolicyCollection) error
 	createMutex       sync.RWMutex
 	createArgsForCall []struct {
-		arg1 store.PolicyCollection
+		arg1 context.Context
+		arg2 store.PolicyCollection
 	}
 	createReturns struct {
 		result1 error
@@ -18,10 +20,11 @@ type PolicyCollectionStore struct {
 	createReturnsOnCall map[int]struct {
 		result1 error
 	}
-	DeleteStub        func(store.PolicyCollection) error
+	DeleteStub        func(context.Context, store.PolicyCollection) error
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
-		arg1 store.PolicyCollection
+		arg1 context.Context
+		arg2 store.PolicyCollection
 	}
 	deleteReturns struct {
 		result1 error
@@ -33,16 +36,17 @@ type PolicyCollectionStore struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *PolicyCollectionStore) Create(arg1 store.PolicyCollection) error {
+func (fake *PolicyCollectionStore) Create(arg1 context.Context, arg2 store.PolicyCollection) error {
 	fake.createMutex.Lock()
 	ret, specificReturn := fake.createReturnsOnCall[len(fake.createArgsForCall)]
 	fake.createArgsForCall = append(fake.createArgsForCall, struct {
-		arg1 store.PolicyCollection
-	}{arg1})
-	fake.recordInvocation("Create", []interface{}{arg1})
+		arg1 context.Context
+		arg2 store.PolicyCollection
+	}{arg1, arg2})
+	fake.recordInvocation("Create", []interface{}{arg1, arg2})
 	fake.createMutex.Unlock()
 	if fake.CreateStub != nil {
-		return fake.CreateStub(arg1)
+		return fake.CreateStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -56,10 +60,10 @@ func (fake *PolicyCollectionStore) CreateCallCount() int {
 	return len(fake.createArgsForCall)
 }
 
-func (fake *PolicyCollectionStore) CreateArgsForCall(i int) store.PolicyCollection {
+func (fake *PolicyCollectionStore) CreateArgsForCall(i int) (context.Context, store.PolicyCollection) {
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
-	return fake.createArgsForCall[i].arg1
+	return fake.createArgsForCall[i].arg1, fake.createArgsForCall[i].arg2
 }
 
 func (fake *PolicyCollectionStore) CreateReturns(result1 error) {
@@ -81,16 +85,17 @@ func (fake *PolicyCollectionStore) CreateReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *PolicyCollectionStore) Delete(arg1 store.PolicyCollection) error {
+func (fake *PolicyCollectionStore) Delete(arg1 context.Context, arg2 store.PolicyCollection) error {
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
 	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
-		arg1 store.PolicyCollection
-	}{arg1})
-	fake.recordInvocation("Delete", []interface{}{arg1})
+		arg1 context.Context
+		arg2 store.PolicyCollection
+	}{arg1, arg2})
+	fake.recordInvocation("Delete", []interface{}{arg1, arg2})
 	fake.deleteMutex.Unlock()
 	if fake.DeleteStub != nil {
-		return fake.DeleteStub(arg1)
+		return fake.DeleteStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -104,10 +109,10 @@ func (fake *PolicyCollectionStore) DeleteCallCount() int {
 	return len(fake.deleteArgsForCall)
 }
 
-func (fake *PolicyCollectionStore) DeleteArgsForCall(i int) store.PolicyCollection {
+func (fake *PolicyCollectionStore) DeleteArgsForCall(i int) (context.Context, store.PolicyCollection) {
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
-	return fake.deleteArgsForCall[i].arg1
+	return fake.deleteArgsForCall[i].arg1, fake.deleteArgsForCall[i].arg2
 }
 
 func (fake *PolicyCollectionStore) DeleteReturns(result1 error) {
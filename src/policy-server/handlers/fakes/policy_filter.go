@@ -2,15 +2,17 @@
 package fakes
 
 import (
+	"context"
 	"policy-server/store"
 	"policy-server/uaa_client"
 	"sync"
 )
 
 type PolicyFilter struct {
-	FilterPoliciesStub        func(policies []store.Policy, userToken uaa_client.CheckTokenResponse) ([]store.Policy, error)
+	FilterPoliciesStub        func(ctx context.Context, policies []store.Policy, userToken uaa_client.CheckTokenResponse) ([]store.Policy, error)
 	filterPoliciesMutex       sync.RWMutex
 	filterPoliciesArgsForCall []struct {
+		ctx       context.Context
 		policies  []store.Policy
 		userToken uaa_client.CheckTokenResponse
 	}
@@ -22,11 +24,22 @@ type PolicyFilter struct {
 		result1 []store.Policy
 		result2 error
 	}
+	CheckEgressPolicyListAccessStub        func(userToken uaa_client.CheckTokenResponse) bool
+	checkEgressPolicyListAccessMutex       sync.RWMutex
+	checkEgressPolicyListAccessArgsForCall []struct {
+		userToken uaa_client.CheckTokenResponse
+	}
+	checkEgressPolicyListAccessReturns struct {
+		result1 bool
+	}
+	checkEgressPolicyListAccessReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *PolicyFilter) FilterPolicies(policies []store.Policy, userToken uaa_client.CheckTokenResponse) ([]store.Policy, error) {
+func (fake *PolicyFilter) FilterPolicies(ctx context.Context, policies []store.Policy, userToken uaa_client.CheckTokenResponse) ([]store.Policy, error) {
 	var policiesCopy []store.Policy
 	if policies != nil {
 		policiesCopy = make([]store.Policy, len(policies))
@@ -35,13 +48,14 @@ func (fake *PolicyFilter) FilterPolicies(policies []store.Policy, userToken uaa_
 	fake.filterPoliciesMutex.Lock()
 	ret, specificReturn := fake.filterPoliciesReturnsOnCall[len(fake.filterPoliciesArgsForCall)]
 	fake.filterPoliciesArgsForCall = append(fake.filterPoliciesArgsForCall, struct {
+		ctx       context.Context
 		policies  []store.Policy
 		userToken uaa_client.CheckTokenResponse
-	}{policiesCopy, userToken})
-	fake.recordInvocation("FilterPolicies", []interface{}{policiesCopy, userToken})
+	}{ctx, policiesCopy, userToken})
+	fake.recordInvocation("FilterPolicies", []interface{}{ctx, policiesCopy, userToken})
 	fake.filterPoliciesMutex.Unlock()
 	if fake.FilterPoliciesStub != nil {
-		return fake.FilterPoliciesStub(policies, userToken)
+		return fake.FilterPoliciesStub(ctx, policies, userToken)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -55,10 +69,10 @@ func (fake *PolicyFilter) FilterPoliciesCallCount() int {
 	return len(fake.filterPoliciesArgsForCall)
 }
 
-func (fake *PolicyFilter) FilterPoliciesArgsForCall(i int) ([]store.Policy, uaa_client.CheckTokenResponse) {
+func (fake *PolicyFilter) FilterPoliciesArgsForCall(i int) (context.Context, []store.Policy, uaa_client.CheckTokenResponse) {
 	fake.filterPoliciesMutex.RLock()
 	defer fake.filterPoliciesMutex.RUnlock()
-	return fake.filterPoliciesArgsForCall[i].policies, fake.filterPoliciesArgsForCall[i].userToken
+	return fake.filterPoliciesArgsForCall[i].ctx, fake.filterPoliciesArgsForCall[i].policies, fake.filterPoliciesArgsForCall[i].userToken
 }
 
 func (fake *PolicyFilter) FilterPoliciesReturns(result1 []store.Policy, result2 error) {
@@ -83,11 +97,61 @@ func (fake *PolicyFilter) FilterPoliciesReturnsOnCall(i int, result1 []store.Pol
 	}{result1, result2}
 }
 
+func (fake *PolicyFilter) CheckEgressPolicyListAccess(userToken uaa_client.CheckTokenResponse) bool {
+	fake.checkEgressPolicyListAccessMutex.Lock()
+	ret, specificReturn := fake.checkEgressPolicyListAccessReturnsOnCall[len(fake.checkEgressPolicyListAccessArgsForCall)]
+	fake.checkEgressPolicyListAccessArgsForCall = append(fake.checkEgressPolicyListAccessArgsForCall, struct {
+		userToken uaa_client.CheckTokenResponse
+	}{userToken})
+	fake.recordInvocation("CheckEgressPolicyListAccess", []interface{}{userToken})
+	fake.checkEgressPolicyListAccessMutex.Unlock()
+	if fake.CheckEgressPolicyListAccessStub != nil {
+		return fake.CheckEgressPolicyListAccessStub(userToken)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.checkEgressPolicyListAccessReturns.result1
+}
+
+func (fake *PolicyFilter) CheckEgressPolicyListAccessCallCount() int {
+	fake.checkEgressPolicyListAccessMutex.RLock()
+	defer fake.checkEgressPolicyListAccessMutex.RUnlock()
+	return len(fake.checkEgressPolicyListAccessArgsForCall)
+}
+
+func (fake *PolicyFilter) CheckEgressPolicyListAccessArgsForCall(i int) uaa_client.CheckTokenResponse {
+	fake.checkEgressPolicyListAccessMutex.RLock()
+	defer fake.checkEgressPolicyListAccessMutex.RUnlock()
+	return fake.checkEgressPolicyListAccessArgsForCall[i].userToken
+}
+
+func (fake *PolicyFilter) CheckEgressPolicyListAccessReturns(result1 bool) {
+	fake.CheckEgressPolicyListAccessStub = nil
+	fake.checkEgressPolicyListAccessReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *PolicyFilter) CheckEgressPolicyListAccessReturnsOnCall(i int, result1 bool) {
+	fake.CheckEgressPolicyListAccessStub = nil
+	if fake.checkEgressPolicyListAccessReturnsOnCall == nil {
+		fake.checkEgressPolicyListAccessReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.checkEgressPolicyListAccessReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *PolicyFilter) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.filterPoliciesMutex.RLock()
 	defer fake.filterPoliciesMutex.RUnlock()
+	fake.checkEgressPolicyListAccessMutex.RLock()
+	defer fake.checkEgressPolicyListAccessMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -2,15 +2,17 @@
 package fakes
 
 import (
+	"context"
 	"policy-server/store"
 	"policy-server/uaa_client"
 	"sync"
 )
 
 type PolicyGuard struct {
-	CheckAccessStub        func(policyCollection store.PolicyCollection, tokenData uaa_client.CheckTokenResponse) (bool, error)
+	CheckAccessStub        func(ctx context.Context, policyCollection store.PolicyCollection, tokenData uaa_client.CheckTokenResponse) (bool, error)
 	checkAccessMutex       sync.RWMutex
 	checkAccessArgsForCall []struct {
+		ctx              context.Context
 		policyCollection store.PolicyCollection
 		tokenData        uaa_client.CheckTokenResponse
 	}
@@ -22,32 +24,22 @@ type PolicyGuard struct {
 		result1 bool
 		result2 error
 	}
-	CheckEgressPolicyListAccessStub        func(userToken uaa_client.CheckTokenResponse) bool
-	checkEgressPolicyListAccessMutex       sync.RWMutex
-	checkEgressPolicyListAccessArgsForCall []struct {
-		userToken uaa_client.CheckTokenResponse
-	}
-	checkEgressPolicyListAccessReturns struct {
-		result1 bool
-	}
-	checkEgressPolicyListAccessReturnsOnCall map[int]struct {
-		result1 bool
-	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *PolicyGuard) CheckAccess(policyCollection store.PolicyCollection, tokenData uaa_client.CheckTokenResponse) (bool, error) {
+func (fake *PolicyGuard) CheckAccess(ctx context.Context, policyCollection store.PolicyCollection, tokenData uaa_client.CheckTokenResponse) (bool, error) {
 	fake.checkAccessMutex.Lock()
 	ret, specificReturn := fake.checkAccessReturnsOnCall[len(fake.checkAccessArgsForCall)]
 	fake.checkAccessArgsForCall = append(fake.checkAccessArgsForCall, struct {
+		ctx              context.Context
 		policyCollection store.PolicyCollection
 		tokenData        uaa_client.CheckTokenResponse
-	}{policyCollection, tokenData})
-	fake.recordInvocation("CheckAccess", []interface{}{policyCollection, tokenData})
+	}{ctx, policyCollection, tokenData})
+	fake.recordInvocation("CheckAccess", []interface{}{ctx, policyCollection, tokenData})
 	fake.checkAccessMutex.Unlock()
 	if fake.CheckAccessStub != nil {
-		return fake.CheckAccessStub(policyCollection, tokenData)
+		return fake.CheckAccessStub(ctx, policyCollection, tokenData)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -61,10 +53,10 @@ func (fake *PolicyGuard) CheckAccessCallCount() int {
 	return len(fake.checkAccessArgsForCall)
 }
 
-func (fake *PolicyGuard) CheckAccessArgsForCall(i int) (store.PolicyCollection, uaa_client.CheckTokenResponse) {
+func (fake *PolicyGuard) CheckAccessArgsForCall(i int) (context.Context, store.PolicyCollection, uaa_client.CheckTokenResponse) {
 	fake.checkAccessMutex.RLock()
 	defer fake.checkAccessMutex.RUnlock()
-	return fake.checkAccessArgsForCall[i].policyCollection, fake.checkAccessArgsForCall[i].tokenData
+	return fake.checkAccessArgsForCall[i].ctx, fake.checkAccessArgsForCall[i].policyCollection, fake.checkAccessArgsForCall[i].tokenData
 }
 
 func (fake *PolicyGuard) CheckAccessReturns(result1 bool, result2 error) {
@@ -89,61 +81,11 @@ func (fake *PolicyGuard) CheckAccessReturnsOnCall(i int, result1 bool, result2 e
 	}{result1, result2}
 }
 
-func (fake *PolicyGuard) CheckEgressPolicyListAccess(userToken uaa_client.CheckTokenResponse) bool {
-	fake.checkEgressPolicyListAccessMutex.Lock()
-	ret, specificReturn := fake.checkEgressPolicyListAccessReturnsOnCall[len(fake.checkEgressPolicyListAccessArgsForCall)]
-	fake.checkEgressPolicyListAccessArgsForCall = append(fake.checkEgressPolicyListAccessArgsForCall, struct {
-		userToken uaa_client.CheckTokenResponse
-	}{userToken})
-	fake.recordInvocation("CheckEgressPolicyListAccess", []interface{}{userToken})
-	fake.checkEgressPolicyListAccessMutex.Unlock()
-	if fake.CheckEgressPolicyListAccessStub != nil {
-		return fake.CheckEgressPolicyListAccessStub(userToken)
-	}
-	if specificReturn {
-		return ret.result1
-	}
-	return fake.checkEgressPolicyListAccessReturns.result1
-}
-
-func (fake *PolicyGuard) CheckEgressPolicyListAccessCallCount() int {
-	fake.checkEgressPolicyListAccessMutex.RLock()
-	defer fake.checkEgressPolicyListAccessMutex.RUnlock()
-	return len(fake.checkEgressPolicyListAccessArgsForCall)
-}
-
-func (fake *PolicyGuard) CheckEgressPolicyListAccessArgsForCall(i int) uaa_client.CheckTokenResponse {
-	fake.checkEgressPolicyListAccessMutex.RLock()
-	defer fake.checkEgressPolicyListAccessMutex.RUnlock()
-	return fake.checkEgressPolicyListAccessArgsForCall[i].userToken
-}
-
-func (fake *PolicyGuard) CheckEgressPolicyListAccessReturns(result1 bool) {
-	fake.CheckEgressPolicyListAccessStub = nil
-	fake.checkEgressPolicyListAccessReturns = struct {
-		result1 bool
-	}{result1}
-}
-
-func (fake *PolicyGuard) CheckEgressPolicyListAccessReturnsOnCall(i int, result1 bool) {
-	fake.CheckEgressPolicyListAccessStub = nil
-	if fake.checkEgressPolicyListAccessReturnsOnCall == nil {
-		fake.checkEgressPolicyListAccessReturnsOnCall = make(map[int]struct {
-			result1 bool
-		})
-	}
-	fake.checkEgressPolicyListAccessReturnsOnCall[i] = struct {
-		result1 bool
-	}{result1}
-}
-
 func (fake *PolicyGuard) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.checkAccessMutex.RLock()
 	defer fake.checkAccessMutex.RUnlock()
-	fake.checkEgressPolicyListAccessMutex.RLock()
-	defer fake.checkEgressPolicyListAccessMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
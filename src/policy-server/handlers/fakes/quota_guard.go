@@ -2,15 +2,17 @@
 package fakes
 
 import (
+	"context"
 	"policy-server/store"
 	"policy-server/uaa_client"
 	"sync"
 )
 
 type QuotaGuard struct {
-	CheckAccessStub        func(policyCollection store.PolicyCollection, tokenData uaa_client.CheckTokenResponse) (bool, error)
+	CheckAccessStub        func(ctx context.Context, policyCollection store.PolicyCollection, tokenData uaa_client.CheckTokenResponse) (bool, error)
 	checkAccessMutex       sync.RWMutex
 	checkAccessArgsForCall []struct {
+		ctx              context.Context
 		policyCollection store.PolicyCollection
 		tokenData        uaa_client.CheckTokenResponse
 	}
@@ -26,17 +28,18 @@ type QuotaGuard struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *QuotaGuard) CheckAccess(policyCollection store.PolicyCollection, tokenData uaa_client.CheckTokenResponse) (bool, error) {
+func (fake *QuotaGuard) CheckAccess(ctx context.Context, policyCollection store.PolicyCollection, tokenData uaa_client.CheckTokenResponse) (bool, error) {
 	fake.checkAccessMutex.Lock()
 	ret, specificReturn := fake.checkAccessReturnsOnCall[len(fake.checkAccessArgsForCall)]
 	fake.checkAccessArgsForCall = append(fake.checkAccessArgsForCall, struct {
+		ctx              context.Context
 		policyCollection store.PolicyCollection
 		tokenData        uaa_client.CheckTokenResponse
-	}{policyCollection, tokenData})
-	fake.recordInvocation("CheckAccess", []interface{}{policyCollection, tokenData})
+	}{ctx, policyCollection, tokenData})
+	fake.recordInvocation("CheckAccess", []interface{}{ctx, policyCollection, tokenData})
 	fake.checkAccessMutex.Unlock()
 	if fake.CheckAccessStub != nil {
-		return fake.CheckAccessStub(policyCollection, tokenData)
+		return fake.CheckAccessStub(ctx, policyCollection, tokenData)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -50,10 +53,10 @@ func (fake *QuotaGuard) CheckAccessCallCount() int {
 	return len(fake.checkAccessArgsForCall)
 }
 
-func (fake *QuotaGuard) CheckAccessArgsForCall(i int) (store.PolicyCollection, uaa_client.CheckTokenResponse) {
+func (fake *QuotaGuard) CheckAccessArgsForCall(i int) (context.Context, store.PolicyCollection, uaa_client.CheckTokenResponse) {
 	fake.checkAccessMutex.RLock()
 	defer fake.checkAccessMutex.RUnlock()
-	return fake.checkAccessArgsForCall[i].policyCollection, fake.checkAccessArgsForCall[i].tokenData
+	return fake.checkAccessArgsForCall[i].ctx, fake.checkAccessArgsForCall[i].policyCollection, fake.checkAccessArgsForCall[i].tokenData
 }
 
 func (fake *QuotaGuard) CheckAccessReturns(result1 bool, result2 error) {
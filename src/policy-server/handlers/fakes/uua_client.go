@@ -2,15 +2,17 @@
 package fakes
 
 import (
-	"policy-server/uaa_client"
+	"context"
 	"sync"
 )
 
 type UAAClient struct {
-	GetTokenStub        func() (string, error)
+	GetTokenStub        func(ctx context.Context) (string, error)
 	getTokenMutex       sync.RWMutex
-	getTokenArgsForCall []struct{}
-	getTokenReturns     struct {
+	getTokenArgsForCall []struct {
+		ctx context.Context
+	}
+	getTokenReturns struct {
 		result1 string
 		result2 error
 	}
@@ -18,31 +20,20 @@ type UAAClient struct {
 		result1 string
 		result2 error
 	}
-	CheckTokenStub        func(string) (uaa_client.CheckTokenResponse, error)
-	checkTokenMutex       sync.RWMutex
-	checkTokenArgsForCall []struct {
-		arg1 string
-	}
-	checkTokenReturns struct {
-		result1 uaa_client.CheckTokenResponse
-		result2 error
-	}
-	checkTokenReturnsOnCall map[int]struct {
-		result1 uaa_client.CheckTokenResponse
-		result2 error
-	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *UAAClient) GetToken() (string, error) {
+func (fake *UAAClient) GetToken(ctx context.Context) (string, error) {
 	fake.getTokenMutex.Lock()
 	ret, specificReturn := fake.getTokenReturnsOnCall[len(fake.getTokenArgsForCall)]
-	fake.getTokenArgsForCall = append(fake.getTokenArgsForCall, struct{}{})
-	fake.recordInvocation("GetToken", []interface{}{})
+	fake.getTokenArgsForCall = append(fake.getTokenArgsForCall, struct {
+		ctx context.Context
+	}{ctx})
+	fake.recordInvocation("GetToken", []interface{}{ctx})
 	fake.getTokenMutex.Unlock()
 	if fake.GetTokenStub != nil {
-		return fake.GetTokenStub()
+		return fake.GetTokenStub(ctx)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -56,6 +47,12 @@ func (fake *UAAClient) GetTokenCallCount() int {
 	return len(fake.getTokenArgsForCall)
 }
 
+func (fake *UAAClient) GetTokenArgsForCall(i int) context.Context {
+	fake.getTokenMutex.RLock()
+	defer fake.getTokenMutex.RUnlock()
+	return fake.getTokenArgsForCall[i].ctx
+}
+
 func (fake *UAAClient) GetTokenReturns(result1 string, result2 error) {
 	fake.GetTokenStub = nil
 	fake.getTokenReturns = struct {
@@ -78,64 +75,11 @@ func (fake *UAAClient) GetTokenReturnsOnCall(i int, result1 string, result2 erro
 	}{result1, result2}
 }
 
-func (fake *UAAClient) CheckToken(arg1 string) (uaa_client.CheckTokenResponse, error) {
-	fake.checkTokenMutex.Lock()
-	ret, specificReturn := fake.checkTokenReturnsOnCall[len(fake.checkTokenArgsForCall)]
-	fake.checkTokenArgsForCall = append(fake.checkTokenArgsForCall, struct {
-		arg1 string
-	}{arg1})
-	fake.recordInvocation("CheckToken", []interface{}{arg1})
-	fake.checkTokenMutex.Unlock()
-	if fake.CheckTokenStub != nil {
-		return fake.CheckTokenStub(arg1)
-	}
-	if specificReturn {
-		return ret.result1, ret.result2
-	}
-	return fake.checkTokenReturns.result1, fake.checkTokenReturns.result2
-}
-
-func (fake *UAAClient) CheckTokenCallCount() int {
-	fake.checkTokenMutex.RLock()
-	defer fake.checkTokenMutex.RUnlock()
-	return len(fake.checkTokenArgsForCall)
-}
-
-func (fake *UAAClient) CheckTokenArgsForCall(i int) string {
-	fake.checkTokenMutex.RLock()
-	defer fake.checkTokenMutex.RUnlock()
-	return fake.checkTokenArgsForCall[i].arg1
-}
-
-func (fake *UAAClient) CheckTokenReturns(result1 uaa_client.CheckTokenResponse, result2 error) {
-	fake.CheckTokenStub = nil
-	fake.checkTokenReturns = struct {
-		result1 uaa_client.CheckTokenResponse
-		result2 error
-	}{result1, result2}
-}
-
-func (fake *UAAClient) CheckTokenReturnsOnCall(i int, result1 uaa_client.CheckTokenResponse, result2 error) {
-	fake.CheckTokenStub = nil
-	if fake.checkTokenReturnsOnCall == nil {
-		fake.checkTokenReturnsOnCall = make(map[int]struct {
-			result1 uaa_client.CheckTokenResponse
-			result2 error
-		})
-	}
-	fake.checkTokenReturnsOnCall[i] = struct {
-		result1 uaa_client.CheckTokenResponse
-		result2 error
-	}{result1, result2}
-}
-
 func (fake *UAAClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.getTokenMutex.RLock()
 	defer fake.getTokenMutex.RUnlock()
-	fake.checkTokenMutex.RLock()
-	defer fake.checkTokenMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
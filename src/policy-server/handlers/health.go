@@ -5,8 +5,17 @@ import (
 	"policy-server/store"
 )
 
+//go:generate counterfeiter -o fakes/schema_checker.go --fake-name SchemaChecker . schemaChecker
+type schemaChecker interface {
+	Err() error
+}
+
+// Health fails when the database cannot be reached or, if a SchemaChecker is
+// set, when its last check found migrations this binary needs that are not
+// applied yet.
 type Health struct {
 	Store         store.Store
+	SchemaChecker schemaChecker
 	ErrorResponse errorResponse
 }
 
@@ -25,4 +34,12 @@ func (h *Health) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		h.ErrorResponse.InternalServerError(logger, w, err, "check database failed")
 		return
 	}
+
+	if h.SchemaChecker != nil {
+		err = h.SchemaChecker.Err()
+		if err != nil {
+			h.ErrorResponse.InternalServerError(logger, w, err, "check schema failed")
+			return
+		}
+	}
 }
@@ -82,4 +82,48 @@ var _ = Describe("Health handler", func() {
 			Expect(description).To(Equal("check database failed"))
 		})
 	})
+
+	Context("when a schema checker is set", func() {
+		var fakeSchemaChecker *fakes.SchemaChecker
+
+		BeforeEach(func() {
+			fakeSchemaChecker = &fakes.SchemaChecker{}
+			handler.SchemaChecker = fakeSchemaChecker
+		})
+
+		It("looks at the last schema check and returns a 200", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeSchemaChecker.ErrCallCount()).To(Equal(1))
+			Expect(resp.Code).To(Equal(http.StatusOK))
+		})
+
+		Context("when the schema is incompatible", func() {
+			BeforeEach(func() {
+				fakeSchemaChecker.ErrReturns(errors.New("kiwi"))
+			})
+
+			It("calls the internal server error handler", func() {
+				MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+				Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+				l, w, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+				Expect(l).To(Equal(expectedLogger))
+				Expect(w).To(Equal(resp))
+				Expect(err).To(MatchError("kiwi"))
+				Expect(description).To(Equal("check schema failed"))
+			})
+		})
+
+		Context("when the database returns an error", func() {
+			BeforeEach(func() {
+				fakeStore.CheckDatabaseReturns(errors.New("pineapple"))
+			})
+
+			It("does not check the schema", func() {
+				MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+				Expect(fakeSchemaChecker.ErrCallCount()).To(Equal(0))
+			})
+		})
+	})
 })
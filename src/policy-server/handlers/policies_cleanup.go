@@ -3,14 +3,16 @@ package handlers
 import (
 	"net/http"
 	"policy-server/api"
-	"policy-server/store"
+	"policy-server/cleaner"
 
+	"code.cloudfoundry.org/cf-networking-helpers/marshal"
 	"code.cloudfoundry.org/lager"
 )
 
 //go:generate counterfeiter -o fakes/policy_cleaner.go --fake-name PolicyCleaner . policyCleaner
 type policyCleaner interface {
-	DeleteStalePolicies() ([]store.Policy, error)
+	DeleteStalePolicies() (cleaner.Result, error)
+	FindStalePolicies() (cleaner.Result, error)
 }
 
 //go:generate counterfeiter -o fakes/error_response.go --fake-name ErrorResponse . errorResponse
@@ -20,17 +22,33 @@ type errorResponse interface {
 	NotAcceptable(lager.Logger, http.ResponseWriter, error, string)
 	Forbidden(lager.Logger, http.ResponseWriter, error, string)
 	Unauthorized(lager.Logger, http.ResponseWriter, error, string)
+	NotFound(lager.Logger, http.ResponseWriter, error, string)
+	Conflict(lager.Logger, http.ResponseWriter, error, string)
+	TooManyRequests(lager.Logger, http.ResponseWriter, error, string)
+	RequestEntityTooLarge(lager.Logger, http.ResponseWriter, error, string)
+	ServiceUnavailable(lager.Logger, http.ResponseWriter, error, string)
+	InsufficientStorage(lager.Logger, http.ResponseWriter, error, string)
+}
+
+type cleanupResponse struct {
+	TotalPolicies       int                `json:"total_policies"`
+	Policies            []api.Policy       `json:"policies"`
+	TotalEgressPolicies int                `json:"total_egress_policies,omitempty"`
+	EgressPolicies      []api.EgressPolicy `json:"egress_policies,omitempty"`
+	TotalTags           int                `json:"total_tags,omitempty"`
+	Tags                []api.Tag          `json:"tags,omitempty"`
+	DryRun              bool               `json:"dry_run,omitempty"`
 }
 
 type PoliciesCleanup struct {
-	Mapper        api.PolicyMapper
+	Marshaler     marshal.Marshaler
 	PolicyCleaner policyCleaner
 	ErrorResponse errorResponse
 }
 
-func NewPoliciesCleanup(mapper api.PolicyMapper, policyCleaner policyCleaner, errorResponse errorResponse) *PoliciesCleanup {
+func NewPoliciesCleanup(marshaler marshal.Marshaler, policyCleaner policyCleaner, errorResponse errorResponse) *PoliciesCleanup {
 	return &PoliciesCleanup{
-		Mapper:        mapper,
+		Marshaler:     marshaler,
 		PolicyCleaner: policyCleaner,
 		ErrorResponse: errorResponse,
 	}
@@ -40,18 +58,36 @@ func (h *PoliciesCleanup) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	logger := getLogger(req)
 	logger = logger.Session("cleanup-policies")
 
-	policies, err := h.PolicyCleaner.DeleteStalePolicies()
+	dryRun := req.URL.Query().Get("dry_run") == "true"
+
+	var result cleaner.Result
+	var err error
+	if dryRun {
+		result, err = h.PolicyCleaner.FindStalePolicies()
+	} else {
+		result, err = h.PolicyCleaner.DeleteStalePolicies()
+	}
 	if err != nil {
 		h.ErrorResponse.InternalServerError(logger, w, err, "policies cleanup failed")
 		return
 	}
 
-	for i, _ := range policies {
-		policies[i].Source.Tag = ""
-		policies[i].Destination.Tag = ""
+	egressPolicies := []api.EgressPolicy{}
+	for _, egressPolicy := range result.EgressPolicies {
+		egressPolicies = append(egressPolicies, api.MapStoreEgressPolicy(egressPolicy))
+	}
+
+	response := cleanupResponse{
+		TotalPolicies:       len(result.Policies),
+		Policies:            mapUntaggedPolicies(result.Policies),
+		TotalEgressPolicies: len(egressPolicies),
+		EgressPolicies:      egressPolicies,
+		TotalTags:           len(result.Tags),
+		Tags:                api.MapStoreTags(result.Tags),
+		DryRun:              dryRun,
 	}
 
-	bytes, err := h.Mapper.AsBytes(policies, []store.EgressPolicy{})
+	bytes, err := h.Marshaler.Marshal(response)
 	if err != nil {
 		h.ErrorResponse.InternalServerError(logger, w, err, "map policy as bytes failed")
 		return
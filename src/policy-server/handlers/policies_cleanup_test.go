@@ -1,15 +1,16 @@
 package handlers_test
 
 import (
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"policy-server/cleaner"
 	"policy-server/handlers"
 	"policy-server/handlers/fakes"
 	"policy-server/store"
 
-	apifakes "policy-server/api/fakes"
-
+	hfakes "code.cloudfoundry.org/cf-networking-helpers/fakes"
 	"code.cloudfoundry.org/lager"
 
 	"code.cloudfoundry.org/lager/lagertest"
@@ -25,7 +26,7 @@ var _ = Describe("PoliciesCleanup", func() {
 		logger            *lagertest.TestLogger
 		expectedLogger    lager.Logger
 		fakePolicyCleaner *fakes.PolicyCleaner
-		fakeMapper        *apifakes.PolicyMapper
+		fakeMarshaler     *hfakes.Marshaler
 		fakeErrorResponse *fakes.ErrorResponse
 		policies          []store.Policy
 	)
@@ -51,18 +52,18 @@ var _ = Describe("PoliciesCleanup", func() {
 		expectedLogger.RegisterSink(testSink)
 		expectedLogger.RegisterSink(lager.NewWriterSink(GinkgoWriter, lager.DEBUG))
 
-		fakeMapper = &apifakes.PolicyMapper{}
+		fakeMarshaler = &hfakes.Marshaler{}
+		fakeMarshaler.MarshalStub = json.Marshal
 		fakePolicyCleaner = &fakes.PolicyCleaner{}
 		fakeErrorResponse = &fakes.ErrorResponse{}
 
 		handler = &handlers.PoliciesCleanup{
-			Mapper:        fakeMapper,
+			Marshaler:     fakeMarshaler,
 			PolicyCleaner: fakePolicyCleaner,
 			ErrorResponse: fakeErrorResponse,
 		}
 
-		fakePolicyCleaner.DeleteStalePoliciesReturns(policies, nil)
-		fakeMapper.AsBytesReturns([]byte("some-bytes"), nil)
+		fakePolicyCleaner.DeleteStalePoliciesReturns(cleaner.Result{Policies: policies}, nil)
 		resp = httptest.NewRecorder()
 		request, _ = http.NewRequest("POST", "/networking/v0/external/policies/cleanup", nil)
 	})
@@ -71,27 +72,101 @@ var _ = Describe("PoliciesCleanup", func() {
 		MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
 
 		Expect(fakePolicyCleaner.DeleteStalePoliciesCallCount()).To(Equal(1))
-		Expect(fakeMapper.AsBytesCallCount()).To(Equal(1))
 
-		policyArg, _ := fakeMapper.AsBytesArgsForCall(0)
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(resp.Body).To(MatchJSON(`{
+			"total_policies": 1,
+			"policies": [
+				{ "source": { "id": "live-guid" }, "destination": { "id": "dead-guid", "protocol": "tcp", "ports": { "start": 8080, "end": 8080 } } }
+			]
+		}`))
+	})
 
-		Expect(policyArg).To(Equal(policies))
+	Context("when stale egress policies and orphaned tags are cleaned up", func() {
+		BeforeEach(func() {
+			fakePolicyCleaner.DeleteStalePoliciesReturns(cleaner.Result{
+				Policies: policies,
+				EgressPolicies: []store.EgressPolicy{{
+					Source: store.EgressSource{ID: "dead-guid"},
+					Destination: store.EgressDestination{
+						Protocol: "udp",
+						IPRanges: []store.IPRange{{Start: "10.0.0.1", End: "10.0.0.2"}},
+					},
+				}},
+				Tags: []store.Tag{{ID: "orphaned-guid", Tag: "0003", Type: "app"}},
+			}, nil)
+		})
 
-		Expect(resp.Code).To(Equal(http.StatusOK))
-		Expect(resp.Body.String()).To(Equal(`some-bytes`))
+		It("reports them", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Body).To(MatchJSON(`{
+				"total_policies": 1,
+				"policies": [
+					{ "source": { "id": "live-guid" }, "destination": { "id": "dead-guid", "protocol": "tcp", "ports": { "start": 8080, "end": 8080 } } }
+				],
+				"total_egress_policies": 1,
+				"egress_policies": [
+					{ "source": { "id": "dead-guid" }, "destination": { "protocol": "udp", "ips": [{ "start": "10.0.0.1", "end": "10.0.0.2" }] } }
+				],
+				"total_tags": 1,
+				"tags": [
+					{ "id": "orphaned-guid", "tag": "0003", "type": "app" }
+				]
+			}`))
+		})
+	})
+
+	Context("when dry_run is requested", func() {
+		BeforeEach(func() {
+			request.URL.RawQuery = "dry_run=true"
+			fakePolicyCleaner.FindStalePoliciesReturns(cleaner.Result{Policies: policies}, nil)
+		})
+
+		It("reports the stale policies without deleting them", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakePolicyCleaner.FindStalePoliciesCallCount()).To(Equal(1))
+			Expect(fakePolicyCleaner.DeleteStalePoliciesCallCount()).To(Equal(0))
+
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Body).To(MatchJSON(`{
+				"total_policies": 1,
+				"policies": [
+					{ "source": { "id": "live-guid" }, "destination": { "id": "dead-guid", "protocol": "tcp", "ports": { "start": 8080, "end": 8080 } } }
+				],
+				"dry_run": true
+			}`))
+		})
+
+		Context("when finding the stale policies fails", func() {
+			BeforeEach(func() {
+				fakePolicyCleaner.FindStalePoliciesReturns(cleaner.Result{}, errors.New("potato"))
+			})
+
+			It("calls the internal server error handler", func() {
+				MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+				Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+				_, _, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+				Expect(err).To(MatchError("potato"))
+				Expect(description).To(Equal("policies cleanup failed"))
+			})
+		})
 	})
 
 	Context("when the logger isn't on the request context", func() {
 		It("returns all the policies, but does not include the tags", func() {
 			handler.ServeHTTP(resp, request)
 			Expect(resp.Code).To(Equal(http.StatusOK))
-			Expect(resp.Body.String()).To(Equal(`some-bytes`))
+			Expect(resp.Body.String()).NotTo(ContainSubstring(`"tag"`))
 		})
 	})
 
 	Context("When deleting the policies fails", func() {
 		BeforeEach(func() {
-			fakePolicyCleaner.DeleteStalePoliciesReturns(nil, errors.New("potato"))
+			fakePolicyCleaner.DeleteStalePoliciesReturns(cleaner.Result{}, errors.New("potato"))
 		})
 
 		It("calls the internal server error handler", func() {
@@ -109,7 +184,8 @@ var _ = Describe("PoliciesCleanup", func() {
 
 	Context("When mapping the policies to bytes", func() {
 		BeforeEach(func() {
-			fakeMapper.AsBytesReturns(nil, errors.New("potato"))
+			fakeMarshaler.MarshalStub = nil
+			fakeMarshaler.MarshalReturns(nil, errors.New("potato"))
 		})
 
 		It("calls the internal server error handler", func() {
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"net/http"
+	"policy-server/api"
+	"policy-server/redundancy"
+
+	"code.cloudfoundry.org/cf-networking-helpers/marshal"
+	"code.cloudfoundry.org/lager"
+)
+
+//go:generate counterfeiter -o fakes/policy_compactor.go --fake-name PolicyCompactor . policyCompactor
+type policyCompactor interface {
+	Compact() (redundancy.CompactResult, error)
+}
+
+type PoliciesCompact struct {
+	PolicyCompactor policyCompactor
+	Marshaler       marshal.Marshaler
+	ErrorResponse   errorResponse
+}
+
+func NewPoliciesCompact(policyCompactor policyCompactor, marshaler marshal.Marshaler, errorResponse errorResponse) *PoliciesCompact {
+	return &PoliciesCompact{
+		PolicyCompactor: policyCompactor,
+		Marshaler:       marshaler,
+		ErrorResponse:   errorResponse,
+	}
+}
+
+func (h *PoliciesCompact) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	logger := getLogger(req)
+	logger = logger.Session("compact-policies")
+
+	result, err := h.PolicyCompactor.Compact()
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "policies compaction failed")
+		return
+	}
+
+	response := struct {
+		TotalCreated int          `json:"total_created"`
+		Created      []api.Policy `json:"created"`
+		TotalDeleted int          `json:"total_deleted"`
+		Deleted      []api.Policy `json:"deleted"`
+	}{
+		TotalCreated: len(result.Created),
+		Created:      mapUntaggedPolicies(result.Created),
+		TotalDeleted: len(result.Deleted),
+		Deleted:      mapUntaggedPolicies(result.Deleted),
+	}
+
+	responseBytes, err := h.Marshaler.Marshal(response)
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "marshalling compaction result failed")
+		return
+	}
+
+	logger.Info("compacted-policies", lager.Data{"created": len(result.Created), "deleted": len(result.Deleted)})
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
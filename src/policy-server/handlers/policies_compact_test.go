@@ -0,0 +1,133 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"policy-server/handlers"
+	"policy-server/handlers/fakes"
+	"policy-server/redundancy"
+	"policy-server/store"
+
+	hfakes "code.cloudfoundry.org/cf-networking-helpers/fakes"
+	"code.cloudfoundry.org/lager"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PoliciesCompact", func() {
+	var (
+		request             *http.Request
+		handler             *handlers.PoliciesCompact
+		resp                *httptest.ResponseRecorder
+		logger              *lagertest.TestLogger
+		expectedLogger      lager.Logger
+		fakePolicyCompactor *fakes.PolicyCompactor
+		fakeErrorResponse   *fakes.ErrorResponse
+		marshaler           *hfakes.Marshaler
+	)
+
+	BeforeEach(func() {
+		var err error
+		request, err = http.NewRequest("POST", "/networking/v1/external/policies/compact", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		logger = lagertest.NewTestLogger("test")
+		expectedLogger = lager.NewLogger("test").Session("compact-policies")
+
+		testSink := lagertest.NewTestSink()
+		expectedLogger.RegisterSink(testSink)
+		expectedLogger.RegisterSink(lager.NewWriterSink(GinkgoWriter, lager.DEBUG))
+
+		marshaler = &hfakes.Marshaler{}
+		marshaler.MarshalStub = json.Marshal
+
+		fakePolicyCompactor = &fakes.PolicyCompactor{}
+		fakePolicyCompactor.CompactReturns(redundancy.CompactResult{
+			Created: []store.Policy{{
+				Source: store.Source{ID: "some-app-guid"},
+				Destination: store.Destination{
+					ID:       "some-other-app-guid",
+					Protocol: "tcp",
+					Ports:    store.Ports{Start: 8080, End: 8095},
+				},
+			}},
+			Deleted: []store.Policy{{
+				Source: store.Source{ID: "some-app-guid", Tag: "01"},
+				Destination: store.Destination{
+					ID:       "some-other-app-guid",
+					Tag:      "02",
+					Protocol: "tcp",
+					Ports:    store.Ports{Start: 8080, End: 8090},
+				},
+			}, {
+				Source: store.Source{ID: "some-app-guid", Tag: "01"},
+				Destination: store.Destination{
+					ID:       "some-other-app-guid",
+					Tag:      "02",
+					Protocol: "tcp",
+					Ports:    store.Ports{Start: 8085, End: 8095},
+				},
+			}},
+		}, nil)
+		fakeErrorResponse = &fakes.ErrorResponse{}
+
+		handler = handlers.NewPoliciesCompact(fakePolicyCompactor, marshaler, fakeErrorResponse)
+		resp = httptest.NewRecorder()
+	})
+
+	It("compacts the policies and returns what changed", func() {
+		MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+		Expect(fakePolicyCompactor.CompactCallCount()).To(Equal(1))
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(resp.Body).To(MatchJSON(`{
+			"total_created": 1,
+			"created": [
+				{ "source": { "id": "some-app-guid" }, "destination": { "id": "some-other-app-guid", "protocol": "tcp", "ports": { "start": 8080, "end": 8095 } } }
+			],
+			"total_deleted": 2,
+			"deleted": [
+				{ "source": { "id": "some-app-guid" }, "destination": { "id": "some-other-app-guid", "protocol": "tcp", "ports": { "start": 8080, "end": 8090 } } },
+				{ "source": { "id": "some-app-guid" }, "destination": { "id": "some-other-app-guid", "protocol": "tcp", "ports": { "start": 8085, "end": 8095 } } }
+			]
+		}`))
+	})
+
+	Context("when compaction fails", func() {
+		BeforeEach(func() {
+			fakePolicyCompactor.CompactReturns(redundancy.CompactResult{}, errors.New("potato"))
+		})
+
+		It("calls the internal server error handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+			l, w, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+			Expect(l).To(Equal(expectedLogger))
+			Expect(w).To(Equal(resp))
+			Expect(err).To(MatchError("potato"))
+			Expect(description).To(Equal("policies compaction failed"))
+		})
+	})
+
+	Context("when marshalling fails", func() {
+		BeforeEach(func() {
+			marshaler.MarshalStub = func(interface{}) ([]byte, error) {
+				return nil, errors.New("potato")
+			}
+		})
+
+		It("calls the internal server error handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+			_, _, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+			Expect(err).To(MatchError("potato"))
+			Expect(description).To(Equal("marshalling compaction result failed"))
+		})
+	})
+})
@@ -1,9 +1,9 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 	"policy-server/api"
 	"policy-server/store"
@@ -14,19 +14,18 @@ import (
 
 //go:generate counterfeiter -o fakes/policy_guard.go --fake-name PolicyGuard . policyGuard
 type policyGuard interface {
-	CheckAccess(policyCollection store.PolicyCollection, tokenData uaa_client.CheckTokenResponse) (bool, error)
-	CheckEgressPolicyListAccess(userToken uaa_client.CheckTokenResponse) bool
+	CheckAccess(ctx context.Context, policyCollection store.PolicyCollection, tokenData uaa_client.CheckTokenResponse) (bool, error)
 }
 
 //go:generate counterfeiter -o fakes/quota_guard.go --fake-name QuotaGuard . quotaGuard
 type quotaGuard interface {
-	CheckAccess(policyCollection store.PolicyCollection, tokenData uaa_client.CheckTokenResponse) (bool, error)
+	CheckAccess(ctx context.Context, policyCollection store.PolicyCollection, tokenData uaa_client.CheckTokenResponse) (bool, error)
 }
 
 //go:generate counterfeiter -o fakes/policy_collection_store.go --fake-name PolicyCollectionStore . policyCollectionStore
 type policyCollectionStore interface {
-	Create(store.PolicyCollection) error
-	Delete(store.PolicyCollection) error
+	Create(context.Context, store.PolicyCollection) error
+	Delete(context.Context, store.PolicyCollection) error
 }
 
 type PoliciesCreate struct {
@@ -53,19 +52,17 @@ func (h *PoliciesCreate) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	logger = logger.Session("create-policies")
 	tokenData := getTokenData(req)
 
-	bodyBytes, err := ioutil.ReadAll(req.Body)
-	if err != nil {
-		h.ErrorResponse.BadRequest(logger, w, err, "failed reading request body")
+	policies, err := mapRequestPolicies(h.Mapper, req.Body)
+	if _, ok := err.(*bodyReadError); ok {
+		writeBodyReadError(logger, w, h.ErrorResponse, err, "failed reading request body")
 		return
 	}
-
-	policies, err := h.Mapper.AsStorePolicy(bodyBytes)
 	if err != nil {
 		h.ErrorResponse.BadRequest(logger, w, err, fmt.Sprintf("mapper: %s", err))
 		return
 	}
 
-	authorized, err := h.PolicyGuard.CheckAccess(policies, tokenData)
+	authorized, err := h.PolicyGuard.CheckAccess(req.Context(), policies, tokenData)
 	if err != nil {
 		h.ErrorResponse.InternalServerError(logger, w, err, "check access failed")
 		return
@@ -76,7 +73,7 @@ func (h *PoliciesCreate) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	authorized, err = h.QuotaGuard.CheckAccess(policies, tokenData)
+	authorized, err = h.QuotaGuard.CheckAccess(req.Context(), policies, tokenData)
 	if err != nil {
 		h.ErrorResponse.InternalServerError(logger, w, err, "check quota failed")
 		return
@@ -87,7 +84,15 @@ func (h *PoliciesCreate) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	err = h.Store.Create(policies)
+	err = h.Store.Create(req.Context(), policies)
+	if err == store.ErrTagSpaceExhausted {
+		h.ErrorResponse.InsufficientStorage(logger, w, err, "database create failed: tag space exhausted")
+		return
+	}
+	if err == store.ErrTagsQuarantined {
+		h.ErrorResponse.ServiceUnavailable(logger, w, err, "database create failed: all free tags are quarantined, try again later")
+		return
+	}
 	if err != nil {
 		h.ErrorResponse.InternalServerError(logger, w, err, "database create failed")
 		return
@@ -2,16 +2,19 @@ package handlers_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"policy-server/api"
 	"policy-server/handlers"
 	"policy-server/handlers/fakes"
 	"policy-server/uaa_client"
 
 	apifakes "policy-server/api/fakes"
 
+	"code.cloudfoundry.org/cf-networking-helpers/marshal"
 	"code.cloudfoundry.org/cf-networking-helpers/testsupport"
 	"code.cloudfoundry.org/lager"
 
@@ -106,11 +109,12 @@ var _ = Describe("PoliciesCreate", func() {
 			Expect(fakeMapper.AsStorePolicyArgsForCall(0)).To(Equal([]byte(requestBody)))
 
 			Expect(fakePolicyGuard.CheckAccessCallCount()).To(Equal(1))
-			policies, token := fakePolicyGuard.CheckAccessArgsForCall(0)
+			_, policies, token := fakePolicyGuard.CheckAccessArgsForCall(0)
 			Expect(policies).To(Equal(expectedPolicyCollection))
 			Expect(token).To(Equal(tokenData))
 			Expect(fakeStore.CreateCallCount()).To(Equal(1))
-			Expect(fakeStore.CreateArgsForCall(0)).To(Equal(expectedPolicyCollection))
+			_, created := fakeStore.CreateArgsForCall(0)
+			Expect(created).To(Equal(expectedPolicyCollection))
 			Expect(resp.Code).To(Equal(http.StatusOK))
 			Expect(resp.Body.String()).To(MatchJSON("{}"))
 		}
@@ -290,6 +294,34 @@ var _ = Describe("PoliciesCreate", func() {
 			Expect(err).To(MatchError("banana"))
 			Expect(description).To(Equal("database create failed"))
 		})
+
+		Context("when there are no tags left to allocate", func() {
+			BeforeEach(func() {
+				fakeStore.CreateReturns(store.ErrTagSpaceExhausted)
+			})
+
+			It("says so in the error description", func() {
+				MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, tokenData)
+
+				Expect(fakeErrorResponse.InsufficientStorageCallCount()).To(Equal(1))
+				_, _, _, description := fakeErrorResponse.InsufficientStorageArgsForCall(0)
+				Expect(description).To(Equal("database create failed: tag space exhausted"))
+			})
+		})
+
+		Context("when every free tag is still in quarantine", func() {
+			BeforeEach(func() {
+				fakeStore.CreateReturns(store.ErrTagsQuarantined)
+			})
+
+			It("asks the caller to try again later", func() {
+				MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, tokenData)
+
+				Expect(fakeErrorResponse.ServiceUnavailableCallCount()).To(Equal(1))
+				_, _, _, description := fakeErrorResponse.ServiceUnavailableArgsForCall(0)
+				Expect(description).To(Equal("database create failed: all free tags are quarantined, try again later"))
+			})
+		})
 	})
 
 	Context("when there are errors reading the body bytes", func() {
@@ -309,5 +341,70 @@ var _ = Describe("PoliciesCreate", func() {
 			Expect(description).To(Equal("failed reading request body"))
 		})
 	})
+	Context("when the body is larger than the limit", func() {
+		BeforeEach(func() {
+			request.ContentLength = -1
+		})
+
+		It("responds with a 413", func() {
+			bodyLimiter := &handlers.BodyLimiter{MaxBytes: 4, ErrorResponse: fakeErrorResponse}
+			MakeRequestWithLoggerAndAuth(bodyLimiter.Wrap(handler).ServeHTTP, resp, request, logger, tokenData)
+
+			Expect(fakeErrorResponse.RequestEntityTooLargeCallCount()).To(Equal(1))
+			_, w, _, description := fakeErrorResponse.RequestEntityTooLargeArgsForCall(0)
+			Expect(w).To(Equal(resp))
+			Expect(description).To(Equal("request body too large: the limit is 4 bytes"))
+			Expect(fakeErrorResponse.BadRequestCallCount()).To(Equal(0))
+			Expect(fakeStore.CreateCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the mapper can decode the body as it is read", func() {
+		BeforeEach(func() {
+			var err error
+			requestBody = `{"policies": [{"source": {"id": "some-app-guid"}, "destination": {"id": "some-other-app-guid", "protocol": "tcp", "ports": {"start": 8080, "end": 9090}}}]}`
+			request, err = http.NewRequest("POST", "/networking/v1/external/policies", bytes.NewBuffer([]byte(requestBody)))
+			Expect(err).NotTo(HaveOccurred())
 
+			handler.Mapper = api.NewMapper(marshal.UnmarshalFunc(json.Unmarshal), marshal.MarshalFunc(json.Marshal), &apifakes.PayloadValidator{})
+		})
+
+		It("creates the policies", func() {
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, tokenData)
+
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(fakeStore.CreateCallCount()).To(Equal(1))
+			_, created := fakeStore.CreateArgsForCall(0)
+			Expect(created.Policies).To(Equal(expectedPolicyCollection.Policies[:1]))
+		})
+
+		Context("when the body is larger than the limit", func() {
+			BeforeEach(func() {
+				request.ContentLength = -1
+			})
+
+			It("responds with a 413", func() {
+				bodyLimiter := &handlers.BodyLimiter{MaxBytes: 100, ErrorResponse: fakeErrorResponse}
+				MakeRequestWithLoggerAndAuth(bodyLimiter.Wrap(handler).ServeHTTP, resp, request, logger, tokenData)
+
+				Expect(fakeErrorResponse.RequestEntityTooLargeCallCount()).To(Equal(1))
+				Expect(fakeErrorResponse.BadRequestCallCount()).To(Equal(0))
+				Expect(fakeStore.CreateCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the body is not valid", func() {
+			BeforeEach(func() {
+				request.Body = ioutil.NopCloser(bytes.NewBufferString(`{"policies": [`))
+			})
+
+			It("calls the bad request handler", func() {
+				MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, tokenData)
+
+				Expect(fakeErrorResponse.BadRequestCallCount()).To(Equal(1))
+				_, _, err, _ := fakeErrorResponse.BadRequestArgsForCall(0)
+				Expect(err).To(MatchError(HavePrefix("unmarshal json: ")))
+			})
+		})
+	})
 })
@@ -3,7 +3,6 @@ package handlers
 import (
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 	"policy-server/api"
 
@@ -32,19 +31,17 @@ func (h *PoliciesDelete) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	logger = logger.Session("delete-policies")
 	tokenData := getTokenData(req)
 
-	bodyBytes, err := ioutil.ReadAll(req.Body)
-	if err != nil {
-		h.ErrorResponse.BadRequest(logger, w, err, "invalid request body")
+	policies, err := mapRequestPolicies(h.Mapper, req.Body)
+	if _, ok := err.(*bodyReadError); ok {
+		writeBodyReadError(logger, w, h.ErrorResponse, err, "invalid request body")
 		return
 	}
-
-	policies, err := h.Mapper.AsStorePolicy(bodyBytes)
 	if err != nil {
 		h.ErrorResponse.BadRequest(logger, w, err, fmt.Sprintf("mapper: %s", err))
 		return
 	}
 
-	authorized, err := h.PolicyGuard.CheckAccess(policies, tokenData)
+	authorized, err := h.PolicyGuard.CheckAccess(req.Context(), policies, tokenData)
 	if err != nil {
 		h.ErrorResponse.InternalServerError(logger, w, err, "check access failed")
 		return
@@ -55,7 +52,7 @@ func (h *PoliciesDelete) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	err = h.Store.Delete(policies)
+	err = h.Store.Delete(req.Context(), policies)
 	if err != nil {
 		h.ErrorResponse.InternalServerError(logger, w, err, "database delete failed")
 		return
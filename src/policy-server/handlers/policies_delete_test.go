@@ -94,11 +94,12 @@ var _ = Describe("PoliciesDelete", func() {
 		Expect(fakeMapper.AsStorePolicyArgsForCall(0)).To(Equal([]byte(requestBody)))
 
 		Expect(fakePolicyGuard.CheckAccessCallCount()).To(Equal(1))
-		policies, token := fakePolicyGuard.CheckAccessArgsForCall(0)
+		_, policies, token := fakePolicyGuard.CheckAccessArgsForCall(0)
 		Expect(policies).To(Equal(expectedPolicyCollection))
 		Expect(token).To(Equal(tokenData))
 		Expect(fakeStore.DeleteCallCount()).To(Equal(1))
-		Expect(fakeStore.DeleteArgsForCall(0)).To(Equal(expectedPolicyCollection))
+		_, deleted := fakeStore.DeleteArgsForCall(0)
+		Expect(deleted).To(Equal(expectedPolicyCollection))
 		Expect(resp.Code).To(Equal(http.StatusOK))
 		Expect(resp.Body.String()).To(MatchJSON("{}"))
 	})
@@ -154,7 +155,7 @@ var _ = Describe("PoliciesDelete", func() {
 			Expect(resp.Code).To(Equal(http.StatusOK))
 			Expect(resp.Body.String()).To(MatchJSON("{}"))
 
-			_, token := fakePolicyGuard.CheckAccessArgsForCall(0)
+			_, _, token := fakePolicyGuard.CheckAccessArgsForCall(0)
 			Expect(token).To(Equal(tokenData))
 		})
 	})
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"policy-server/graph"
+	"policy-server/store"
+
+	"code.cloudfoundry.org/cf-networking-helpers/marshal"
+)
+
+//go:generate counterfeiter -o fakes/graph_builder.go --fake-name GraphBuilder . graphBuilder
+type graphBuilder interface {
+	Build(policies []store.Policy, egressPolicies []store.EgressPolicy, scope graph.Scope) (graph.Graph, error)
+}
+
+type PoliciesGraph struct {
+	Store         store.Store
+	EgressStore   egressPolicyStore
+	GraphBuilder  graphBuilder
+	Marshaler     marshal.Marshaler
+	ErrorResponse errorResponse
+}
+
+func NewPoliciesGraph(store store.Store, egressStore egressPolicyStore, graphBuilder graphBuilder,
+	marshaler marshal.Marshaler, errorResponse errorResponse) *PoliciesGraph {
+	return &PoliciesGraph{
+		Store:         store,
+		EgressStore:   egressStore,
+		GraphBuilder:  graphBuilder,
+		Marshaler:     marshaler,
+		ErrorResponse: errorResponse,
+	}
+}
+
+func (h *PoliciesGraph) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	logger := getLogger(req)
+	logger = logger.Session("graph-policies")
+	queryValues := req.URL.Query()
+
+	format := queryValues.Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "dot" {
+		h.ErrorResponse.BadRequest(logger, w, errors.New("invalid format: "+format), "format must be json or dot")
+		return
+	}
+
+	scope := graph.Scope{
+		OrgGUID:   queryValues.Get("org_id"),
+		SpaceGUID: queryValues.Get("space_id"),
+	}
+
+	policies, err := h.Store.All()
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "database read failed")
+		return
+	}
+
+	egressPolicies, err := h.EgressStore.All(req.Context())
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "egress database read failed")
+		return
+	}
+
+	policyGraph, err := h.GraphBuilder.Build(policies, egressPolicies, scope)
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "building policy graph failed")
+		return
+	}
+
+	if format == "dot" {
+		w.Header().Set("Content-Type", "text/vnd.graphviz")
+		w.WriteHeader(http.StatusOK)
+		w.Write(policyGraph.DOT())
+		return
+	}
+
+	responseBytes, err := h.Marshaler.Marshal(policyGraph)
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "marshalling policy graph failed")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
@@ -0,0 +1,221 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"policy-server/api"
+	"policy-server/graph"
+	"policy-server/handlers"
+	"policy-server/handlers/fakes"
+	"policy-server/store"
+	storeFakes "policy-server/store/fakes"
+
+	hfakes "code.cloudfoundry.org/cf-networking-helpers/fakes"
+	"code.cloudfoundry.org/lager"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PoliciesGraph", func() {
+	var (
+		request               *http.Request
+		handler               *handlers.PoliciesGraph
+		resp                  *httptest.ResponseRecorder
+		logger                *lagertest.TestLogger
+		expectedLogger        lager.Logger
+		fakeStore             *storeFakes.Store
+		fakeEgressPolicyStore *fakes.EgressPolicyStore
+		fakeGraphBuilder      *fakes.GraphBuilder
+		fakeErrorResponse     *fakes.ErrorResponse
+		marshaler             *hfakes.Marshaler
+		policies              []store.Policy
+		egressPolicies        []store.EgressPolicy
+	)
+
+	BeforeEach(func() {
+		policies = []store.Policy{{
+			Source: store.Source{ID: "some-app-guid", Tag: "some-tag"},
+			Destination: store.Destination{
+				ID:       "some-other-app-guid",
+				Tag:      "some-other-tag",
+				Protocol: "tcp",
+				Ports:    store.Ports{Start: 8080, End: 8080},
+			},
+		}}
+		egressPolicies = []store.EgressPolicy{{
+			Source: store.EgressSource{ID: "some-app-guid"},
+			Destination: store.EgressDestination{
+				Protocol: "udp",
+				IPRanges: []store.IPRange{{Start: "10.0.0.1", End: "10.0.0.1"}},
+			},
+		}}
+
+		var err error
+		request, err = http.NewRequest("GET", "/networking/v1/external/policies/graph", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		logger = lagertest.NewTestLogger("test")
+		expectedLogger = lager.NewLogger("test").Session("graph-policies")
+
+		testSink := lagertest.NewTestSink()
+		expectedLogger.RegisterSink(testSink)
+		expectedLogger.RegisterSink(lager.NewWriterSink(GinkgoWriter, lager.DEBUG))
+
+		marshaler = &hfakes.Marshaler{}
+		marshaler.MarshalStub = json.Marshal
+
+		fakeStore = &storeFakes.Store{}
+		fakeStore.AllReturns(policies, nil)
+		fakeEgressPolicyStore = &fakes.EgressPolicyStore{}
+		fakeEgressPolicyStore.AllReturns(egressPolicies, nil)
+		fakeGraphBuilder = &fakes.GraphBuilder{}
+		fakeGraphBuilder.BuildReturns(graph.Graph{
+			Nodes: []graph.Node{
+				{ID: "some-app-guid", Type: "app", Name: "some-app", SpaceGUID: "some-space-guid", SpaceName: "some-space", OrgGUID: "some-org-guid"},
+				{ID: "some-other-app-guid", Type: "app"},
+			},
+			Edges: []graph.Edge{
+				{Source: "some-app-guid", Destination: "some-other-app-guid", Protocol: "tcp", Ports: &api.Ports{Start: 8080, End: 8080}},
+			},
+		}, nil)
+		fakeErrorResponse = &fakes.ErrorResponse{}
+
+		handler = handlers.NewPoliciesGraph(fakeStore, fakeEgressPolicyStore, fakeGraphBuilder, marshaler, fakeErrorResponse)
+		resp = httptest.NewRecorder()
+	})
+
+	It("returns the policy graph as json", func() {
+		MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+		Expect(fakeGraphBuilder.BuildCallCount()).To(Equal(1))
+		policiesArg, egressPoliciesArg, scope := fakeGraphBuilder.BuildArgsForCall(0)
+		Expect(policiesArg).To(Equal(policies))
+		Expect(egressPoliciesArg).To(Equal(egressPolicies))
+		Expect(scope).To(Equal(graph.Scope{}))
+
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(resp.Body).To(MatchJSON(`{
+			"nodes": [
+				{ "id": "some-app-guid", "type": "app", "name": "some-app", "space_id": "some-space-guid", "space_name": "some-space", "org_id": "some-org-guid" },
+				{ "id": "some-other-app-guid", "type": "app" }
+			],
+			"edges": [
+				{ "source": "some-app-guid", "destination": "some-other-app-guid", "protocol": "tcp", "ports": { "start": 8080, "end": 8080 } }
+			]
+		}`))
+	})
+
+	Context("when the dot format is requested", func() {
+		BeforeEach(func() {
+			request.URL.RawQuery = "format=dot"
+		})
+
+		It("returns the policy graph in DOT format", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Header().Get("Content-Type")).To(Equal("text/vnd.graphviz"))
+			Expect(resp.Body.String()).To(HavePrefix("digraph policies {"))
+			Expect(resp.Body.String()).To(ContainSubstring(`"some-app-guid" -> "some-other-app-guid" [label="tcp:8080"];`))
+		})
+	})
+
+	Context("when an org and space are provided", func() {
+		BeforeEach(func() {
+			request.URL.RawQuery = "org_id=some-org-guid&space_id=some-space-guid"
+		})
+
+		It("scopes the graph", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			_, _, scope := fakeGraphBuilder.BuildArgsForCall(0)
+			Expect(scope).To(Equal(graph.Scope{OrgGUID: "some-org-guid", SpaceGUID: "some-space-guid"}))
+		})
+	})
+
+	Context("when the format is invalid", func() {
+		BeforeEach(func() {
+			request.URL.RawQuery = "format=png"
+		})
+
+		It("calls the bad request handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.BadRequestCallCount()).To(Equal(1))
+			l, w, err, description := fakeErrorResponse.BadRequestArgsForCall(0)
+			Expect(l).To(Equal(expectedLogger))
+			Expect(w).To(Equal(resp))
+			Expect(err).To(MatchError("invalid format: png"))
+			Expect(description).To(Equal("format must be json or dot"))
+			Expect(fakeStore.AllCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the store fails", func() {
+		BeforeEach(func() {
+			fakeStore.AllReturns(nil, errors.New("potato"))
+		})
+
+		It("calls the internal server error handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+			l, w, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+			Expect(l).To(Equal(expectedLogger))
+			Expect(w).To(Equal(resp))
+			Expect(err).To(MatchError("potato"))
+			Expect(description).To(Equal("database read failed"))
+		})
+	})
+
+	Context("when the egress store fails", func() {
+		BeforeEach(func() {
+			fakeEgressPolicyStore.AllReturns(nil, errors.New("potato"))
+		})
+
+		It("calls the internal server error handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+			_, _, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+			Expect(err).To(MatchError("potato"))
+			Expect(description).To(Equal("egress database read failed"))
+		})
+	})
+
+	Context("when building the graph fails", func() {
+		BeforeEach(func() {
+			fakeGraphBuilder.BuildReturns(graph.Graph{}, errors.New("potato"))
+		})
+
+		It("calls the internal server error handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+			_, _, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+			Expect(err).To(MatchError("potato"))
+			Expect(description).To(Equal("building policy graph failed"))
+		})
+	})
+
+	Context("when marshalling fails", func() {
+		BeforeEach(func() {
+			marshaler.MarshalStub = func(interface{}) ([]byte, error) {
+				return nil, errors.New("potato")
+			}
+		})
+
+		It("calls the internal server error handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+			_, _, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+			Expect(err).To(MatchError("potato"))
+			Expect(description).To(Equal("marshalling policy graph failed"))
+		})
+	})
+})
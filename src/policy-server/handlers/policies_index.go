@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 	"policy-server/api"
@@ -13,13 +14,14 @@ import (
 
 //go:generate counterfeiter -o fakes/policy_filter.go --fake-name PolicyFilter . policyFilter
 type policyFilter interface {
-	FilterPolicies(policies []store.Policy, userToken uaa_client.CheckTokenResponse) ([]store.Policy, error)
+	FilterPolicies(ctx context.Context, policies []store.Policy, userToken uaa_client.CheckTokenResponse) ([]store.Policy, error)
+	CheckEgressPolicyListAccess(userToken uaa_client.CheckTokenResponse) bool
 }
 
 //go:generate counterfeiter -o fakes/egress_policy_store.go --fake-name EgressPolicyStore . egressPolicyStore
 type egressPolicyStore interface {
-	All() ([]store.EgressPolicy, error)
-	ByGuids(ids []string) ([]store.EgressPolicy, error)
+	All(ctx context.Context) ([]store.EgressPolicy, error)
+	ByGuids(ctx context.Context, ids []string) ([]store.EgressPolicy, error)
 }
 
 //go:generate counterfeiter -o fakes/database.go --fake-name Db . database
@@ -28,14 +30,14 @@ type database interface {
 }
 
 type PoliciesIndex struct {
-	Store         store.Store
+	Store         policyStore
 	EgressStore   egressPolicyStore
 	Mapper        api.PolicyMapper
 	PolicyFilter  policyFilter
 	ErrorResponse errorResponse
 }
 
-func NewPoliciesIndex(store store.Store, egressStore egressPolicyStore,
+func NewPoliciesIndex(store policyStore, egressStore egressPolicyStore,
 	mapper api.PolicyMapper, policyFilter policyFilter, errorResponse errorResponse) *PoliciesIndex {
 	return &PoliciesIndex{
 		Store:         store,
@@ -58,15 +60,15 @@ func (h *PoliciesIndex) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	var storePolicies []store.Policy
 	var err error
 	if len(ids) > 0 {
-		storePolicies, err = h.Store.ByGuids(ids, ids, false)
+		storePolicies, err = h.Store.ByGuids(req.Context(), ids, ids, false)
 	} else if len(sourceIDs) > 0 && len(destIDs) > 0 {
-		storePolicies, err = h.Store.ByGuids(sourceIDs, destIDs, true)
+		storePolicies, err = h.Store.ByGuids(req.Context(), sourceIDs, destIDs, true)
 	} else if len(sourceIDs) > 0 {
-		storePolicies, err = h.Store.ByGuids(sourceIDs, []string{}, false)
+		storePolicies, err = h.Store.ByGuids(req.Context(), sourceIDs, []string{}, false)
 	} else if len(destIDs) > 0 {
-		storePolicies, err = h.Store.ByGuids([]string{}, destIDs, false)
+		storePolicies, err = h.Store.ByGuids(req.Context(), []string{}, destIDs, false)
 	} else {
-		storePolicies, err = h.Store.All()
+		storePolicies, err = h.Store.All(req.Context())
 	}
 
 	if err != nil {
@@ -74,7 +76,7 @@ func (h *PoliciesIndex) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	policies, err := h.PolicyFilter.FilterPolicies(storePolicies, userToken)
+	policies, err := h.PolicyFilter.FilterPolicies(req.Context(), storePolicies, userToken)
 	if err != nil {
 		h.ErrorResponse.InternalServerError(logger, w, err, "filter policies failed")
 		return
@@ -87,9 +89,8 @@ func (h *PoliciesIndex) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 
 	var egressPolicies []store.EgressPolicy
 
-	if policyGuard.CheckEgressPolicyListAccess(&PolicyGuard{}, userToken) {
-
-		egressPolicies, err = h.EgressStore.All()
+	if h.PolicyFilter.CheckEgressPolicyListAccess(userToken) {
+		egressPolicies, err = h.EgressStore.All(req.Context())
 		if err != nil {
 			h.ErrorResponse.InternalServerError(logger, w, err, "getting egress policies failed")
 			return
@@ -1,24 +1,56 @@
 package handlers
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha1"
+	"fmt"
 	"net/http"
 	"net/url"
 	"policy-server/api"
 	"policy-server/store"
 	"strings"
+	"sync"
 
 	"code.cloudfoundry.org/lager"
 )
 
+//go:generate counterfeiter -o fakes/policy_snapshots.go --fake-name PolicySnapshots . policySnapshots
+type policySnapshots interface {
+	Snapshot(context.Context) (*store.PolicySnapshot, error)
+}
+
 type PoliciesIndexInternal struct {
 	Logger        lager.Logger
-	Store         store.Store
+	Store         policyStore
 	Mapper        api.PolicyMapper
 	ErrorResponse errorResponse
 	EgressStore   egressPolicyStore
+	// ProtobufMapper, when set, is used for requests that accept
+	// application/x-protobuf.
+	ProtobufMapper api.PolicyMapper
+	// Snapshots, when set, serves every request from memory, filtering the
+	// snapshot for requests by id.
+	Snapshots policySnapshots
+
+	renderMutex sync.Mutex
+	rendered    *store.PolicySnapshot
+	renderings  map[representation]*rendering
+}
+
+// representation is the content type and encoding of a response body.
+type representation struct {
+	contentType string
+	gzip        bool
+}
+
+type rendering struct {
+	body []byte
+	etag string
 }
 
-func NewPoliciesIndexInternal(logger lager.Logger, store store.Store, egressStore egressPolicyStore,
+func NewPoliciesIndexInternal(logger lager.Logger, store policyStore, egressStore egressPolicyStore,
 	mapper api.PolicyMapper, errorResponse errorResponse) *PoliciesIndexInternal {
 	return &PoliciesIndexInternal{
 		Logger:        logger,
@@ -36,39 +68,161 @@ func (h *PoliciesIndexInternal) ServeHTTP(w http.ResponseWriter, req *http.Reque
 	queryValues := req.URL.Query()
 	ids := parseIds(queryValues)
 
+	mapper, rep := h.negotiate(req)
+
+	var r *rendering
+	var err error
+	if h.Snapshots != nil {
+		r, err = h.renderSnapshot(req.Context(), logger, w, mapper, rep, ids)
+	} else {
+		r, err = h.renderPolicies(req.Context(), logger, w, mapper, rep, ids)
+	}
+	if err != nil {
+		return
+	}
+
+	w.Header().Set("Content-Type", rep.contentType)
+	w.Header().Set("Vary", "Accept, Accept-Encoding")
+	w.Header().Set("ETag", r.etag)
+	if etagMatches(req.Header.Get("If-None-Match"), r.etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	if rep.gzip {
+		w.Header().Set("Content-Encoding", "gzip")
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(r.body)
+}
+
+func (h *PoliciesIndexInternal) negotiate(req *http.Request) (api.PolicyMapper, representation) {
+	rep := representation{
+		contentType: "application/json",
+		gzip:        accepts(req.Header.Get("Accept-Encoding"), "gzip"),
+	}
+	if h.ProtobufMapper != nil && accepts(req.Header.Get("Accept"), api.ProtobufContentType) {
+		rep.contentType = api.ProtobufContentType
+		return h.ProtobufMapper, rep
+	}
+	return h.Mapper, rep
+}
+
+func (h *PoliciesIndexInternal) renderPolicies(ctx context.Context, logger lager.Logger, w http.ResponseWriter, mapper api.PolicyMapper, rep representation, ids []string) (*rendering, error) {
 	var policies []store.Policy
 	var err error
 	if len(ids) == 0 {
-		policies, err = h.Store.All()
+		policies, err = h.Store.All(ctx)
 	} else {
-		policies, err = h.Store.ByGuids(ids, ids, false)
+		policies, err = h.Store.ByGuids(ctx, ids, ids, false)
 	}
 
 	if err != nil {
 		h.ErrorResponse.InternalServerError(logger, w, err, "database read failed")
-		return
+		return nil, err
 	}
 
 	var egressPolicies []store.EgressPolicy
 	if len(ids) == 0 {
-		egressPolicies, err = h.EgressStore.All()
+		egressPolicies, err = h.EgressStore.All(ctx)
 	} else {
-		egressPolicies, err = h.EgressStore.ByGuids(ids)
+		egressPolicies, err = h.EgressStore.ByGuids(ctx, ids)
 	}
 
 	if err != nil {
 		h.ErrorResponse.InternalServerError(logger, w, err, "egress database read failed")
-		return
+		return nil, err
 	}
 
-	bytes, err := h.Mapper.AsBytes(policies, egressPolicies)
+	return h.render(logger, w, mapper, rep, policies, egressPolicies)
+}
+
+// renderSnapshot renders the current snapshot, filtered by ids. Renderings of
+// the whole snapshot are reused while the snapshot has not changed.
+func (h *PoliciesIndexInternal) renderSnapshot(ctx context.Context, logger lager.Logger, w http.ResponseWriter, mapper api.PolicyMapper, rep representation, ids []string) (*rendering, error) {
+	snapshot, err := h.Snapshots.Snapshot(ctx)
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "database read failed")
+		return nil, err
+	}
+
+	if len(ids) > 0 {
+		policies, egressPolicies := snapshot.Filter(ids)
+		return h.render(logger, w, mapper, rep, policies, egressPolicies)
+	}
+
+	h.renderMutex.Lock()
+	defer h.renderMutex.Unlock()
+
+	if snapshot != h.rendered {
+		h.rendered = snapshot
+		h.renderings = map[representation]*rendering{}
+	}
+	if r, ok := h.renderings[rep]; ok {
+		return r, nil
+	}
+
+	r, err := h.render(logger, w, mapper, rep, snapshot.Policies, snapshot.EgressPolicies)
+	if err != nil {
+		return nil, err
+	}
+	h.renderings[rep] = r
+	return r, nil
+}
+
+func (h *PoliciesIndexInternal) render(logger lager.Logger, w http.ResponseWriter, mapper api.PolicyMapper, rep representation,
+	policies []store.Policy, egressPolicies []store.EgressPolicy) (*rendering, error) {
+	body, err := mapper.AsBytes(policies, egressPolicies)
 	if err != nil {
 		h.ErrorResponse.InternalServerError(logger, w, err, "map policy as bytes failed")
-		return
+		return nil, err
 	}
 
-	w.WriteHeader(http.StatusOK)
-	w.Write(bytes)
+	hash := fmt.Sprintf("%x", sha1.Sum(body))
+	if !rep.gzip {
+		return &rendering{body: body, etag: `"` + hash + `"`}, nil
+	}
+
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	_, err = writer.Write(body)
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "compress response failed")
+		return nil, err
+	}
+	return &rendering{body: compressed.Bytes(), etag: `"` + hash + `-gzip"`}, nil
+}
+
+// accepts reports whether an Accept or Accept-Encoding header lists value
+// without a zero quality.
+func accepts(header, value string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		params := strings.Split(candidate, ";")
+		if !strings.EqualFold(strings.TrimSpace(params[0]), value) {
+			continue
+		}
+		for _, param := range params[1:] {
+			param = strings.Replace(param, " ", "", -1)
+			if param == "q=0" || param == "q=0.0" || param == "q=0.00" || param == "q=0.000" {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
 }
 
 func parseIds(queryValues url.Values) []string {
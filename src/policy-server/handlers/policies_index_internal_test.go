@@ -1,12 +1,16 @@
 package handlers_test
 
 import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha1"
 	"errors"
+	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"policy-server/handlers"
 	"policy-server/handlers/fakes"
-	storeFakes "policy-server/store/fakes"
 
 	apifakes "policy-server/api/fakes"
 
@@ -23,7 +27,7 @@ var _ = Describe("PoliciesIndexInternal", func() {
 	var (
 		handler              *handlers.PoliciesIndexInternal
 		resp                 *httptest.ResponseRecorder
-		fakeStore            *storeFakes.Store
+		fakeStore            *fakes.PolicyStore
 		fakeEgressStore      *fakes.EgressPolicyStore
 		fakeErrorResponse    *fakes.ErrorResponse
 		logger               *lagertest.TestLogger
@@ -79,7 +83,7 @@ var _ = Describe("PoliciesIndexInternal", func() {
 		expectedResponseBody = []byte("some-response")
 
 		fakeMapper = &apifakes.PolicyMapper{}
-		fakeStore = &storeFakes.Store{}
+		fakeStore = &fakes.PolicyStore{}
 		fakeStore.AllReturns(allPolicies, nil)
 		fakeEgressStore = &fakes.EgressPolicyStore{}
 		fakeEgressStore.ByGuidsReturns(allEgressPolicies, nil)
@@ -109,7 +113,7 @@ var _ = Describe("PoliciesIndexInternal", func() {
 
 		Expect(fakeStore.ByGuidsCallCount()).To(Equal(1))
 		Expect(fakeEgressStore.ByGuidsCallCount()).To(Equal(1))
-		srcGuids, dstGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
+		_, srcGuids, dstGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
 		Expect(srcGuids).To(Equal([]string{"some-app-guid"}))
 		Expect(dstGuids).To(Equal([]string{"some-app-guid"}))
 		Expect(inSourceAndDest).To(BeFalse())
@@ -141,6 +145,219 @@ var _ = Describe("PoliciesIndexInternal", func() {
 		})
 	})
 
+	It("sets an ETag computed from the response body", func() {
+		request, err := http.NewRequest("GET", "/networking/v1/internal/policies", nil)
+		Expect(err).NotTo(HaveOccurred())
+		MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(resp.Header().Get("ETag")).To(Equal(fmt.Sprintf(`"%x"`, sha1.Sum(expectedResponseBody))))
+	})
+
+	Context("when the request has a matching If-None-Match header", func() {
+		It("returns 304 without a body", func() {
+			etag := fmt.Sprintf(`"%x"`, sha1.Sum(expectedResponseBody))
+			request, err := http.NewRequest("GET", "/networking/v1/internal/policies", nil)
+			Expect(err).NotTo(HaveOccurred())
+			request.Header.Set("If-None-Match", `"some-other-etag", W/`+etag)
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(resp.Code).To(Equal(http.StatusNotModified))
+			Expect(resp.Header().Get("ETag")).To(Equal(etag))
+			Expect(resp.Body.Len()).To(Equal(0))
+		})
+	})
+
+	Context("when the request has a stale If-None-Match header", func() {
+		It("returns the policies", func() {
+			request, err := http.NewRequest("GET", "/networking/v1/internal/policies", nil)
+			Expect(err).NotTo(HaveOccurred())
+			request.Header.Set("If-None-Match", `"some-other-etag"`)
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Body.Bytes()).To(Equal(expectedResponseBody))
+		})
+	})
+
+	It("responds with json", func() {
+		request, err := http.NewRequest("GET", "/networking/v1/internal/policies", nil)
+		Expect(err).NotTo(HaveOccurred())
+		MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+		Expect(resp.Header().Get("Content-Type")).To(Equal("application/json"))
+		Expect(resp.Header().Get("Vary")).To(Equal("Accept, Accept-Encoding"))
+		Expect(resp.Header().Get("Content-Encoding")).To(BeEmpty())
+	})
+
+	Context("when the request accepts gzip", func() {
+		It("compresses the response", func() {
+			request, err := http.NewRequest("GET", "/networking/v1/internal/policies", nil)
+			Expect(err).NotTo(HaveOccurred())
+			request.Header.Set("Accept-Encoding", "deflate, gzip")
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Header().Get("Content-Encoding")).To(Equal("gzip"))
+			Expect(resp.Header().Get("ETag")).To(Equal(fmt.Sprintf(`"%x-gzip"`, sha1.Sum(expectedResponseBody))))
+
+			reader, err := gzip.NewReader(bytes.NewReader(resp.Body.Bytes()))
+			Expect(err).NotTo(HaveOccurred())
+			body, err := ioutil.ReadAll(reader)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(body).To(Equal(expectedResponseBody))
+		})
+
+		It("does not compress when gzip has a zero quality", func() {
+			request, err := http.NewRequest("GET", "/networking/v1/internal/policies", nil)
+			Expect(err).NotTo(HaveOccurred())
+			request.Header.Set("Accept-Encoding", "gzip;q=0")
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(resp.Header().Get("Content-Encoding")).To(BeEmpty())
+			Expect(resp.Body.Bytes()).To(Equal(expectedResponseBody))
+		})
+	})
+
+	Context("when the request accepts protobuf", func() {
+		var fakeProtobufMapper *apifakes.PolicyMapper
+
+		BeforeEach(func() {
+			fakeProtobufMapper = &apifakes.PolicyMapper{}
+			fakeProtobufMapper.AsBytesReturns([]byte("some-protobuf-response"), nil)
+			handler.ProtobufMapper = fakeProtobufMapper
+		})
+
+		It("uses the protobuf mapper", func() {
+			request, err := http.NewRequest("GET", "/networking/v1/internal/policies", nil)
+			Expect(err).NotTo(HaveOccurred())
+			request.Header.Set("Accept", "application/x-protobuf, application/json;q=0.5")
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Header().Get("Content-Type")).To(Equal("application/x-protobuf"))
+			Expect(resp.Body.String()).To(Equal("some-protobuf-response"))
+			Expect(fakeMapper.AsBytesCallCount()).To(Equal(0))
+		})
+
+		Context("when there is no protobuf mapper", func() {
+			BeforeEach(func() {
+				handler.ProtobufMapper = nil
+			})
+
+			It("falls back to json", func() {
+				request, err := http.NewRequest("GET", "/networking/v0/internal/policies", nil)
+				Expect(err).NotTo(HaveOccurred())
+				request.Header.Set("Accept", "application/x-protobuf")
+				MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+				Expect(resp.Header().Get("Content-Type")).To(Equal("application/json"))
+				Expect(resp.Body.Bytes()).To(Equal(expectedResponseBody))
+			})
+		})
+	})
+
+	Context("when a snapshot cache is set", func() {
+		var (
+			fakeSnapshots *fakes.PolicySnapshots
+			snapshot      *store.PolicySnapshot
+		)
+
+		BeforeEach(func() {
+			snapshot = &store.PolicySnapshot{
+				Revision:       4,
+				Policies:       []store.Policy{{Source: store.Source{ID: "snapshot-app-guid"}}},
+				EgressPolicies: []store.EgressPolicy{{Source: store.EgressSource{ID: "snapshot-egress-app-guid"}}},
+			}
+			fakeSnapshots = &fakes.PolicySnapshots{}
+			fakeSnapshots.SnapshotReturns(snapshot, nil)
+			handler.Snapshots = fakeSnapshots
+		})
+
+		It("serves all policies from the snapshot", func() {
+			request, err := http.NewRequest("GET", "/networking/v1/internal/policies", nil)
+			Expect(err).NotTo(HaveOccurred())
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Body.Bytes()).To(Equal(expectedResponseBody))
+			Expect(fakeStore.AllCallCount()).To(Equal(0))
+			Expect(fakeEgressStore.AllCallCount()).To(Equal(0))
+
+			policies, egressPolicies := fakeMapper.AsBytesArgsForCall(0)
+			Expect(policies).To(Equal(snapshot.Policies))
+			Expect(egressPolicies).To(Equal(snapshot.EgressPolicies))
+		})
+
+		It("renders the same snapshot only once", func() {
+			for i := 0; i < 3; i++ {
+				request, err := http.NewRequest("GET", "/networking/v1/internal/policies", nil)
+				Expect(err).NotTo(HaveOccurred())
+				resp = httptest.NewRecorder()
+				MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+				Expect(resp.Body.Bytes()).To(Equal(expectedResponseBody))
+			}
+			Expect(fakeMapper.AsBytesCallCount()).To(Equal(1))
+
+			fakeSnapshots.SnapshotReturns(&store.PolicySnapshot{Revision: 5}, nil)
+			request, err := http.NewRequest("GET", "/networking/v1/internal/policies", nil)
+			Expect(err).NotTo(HaveOccurred())
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+			Expect(fakeMapper.AsBytesCallCount()).To(Equal(2))
+		})
+
+		It("renders each representation of a snapshot separately", func() {
+			fakeProtobufMapper := &apifakes.PolicyMapper{}
+			fakeProtobufMapper.AsBytesReturns([]byte("some-protobuf-response"), nil)
+			handler.ProtobufMapper = fakeProtobufMapper
+
+			for _, accept := range []string{"application/json", "application/x-protobuf", "application/json"} {
+				request, err := http.NewRequest("GET", "/networking/v1/internal/policies", nil)
+				Expect(err).NotTo(HaveOccurred())
+				request.Header.Set("Accept", accept)
+				resp = httptest.NewRecorder()
+				MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+				Expect(resp.Header().Get("Content-Type")).To(Equal(accept))
+			}
+
+			Expect(fakeMapper.AsBytesCallCount()).To(Equal(1))
+			Expect(fakeProtobufMapper.AsBytesCallCount()).To(Equal(1))
+		})
+
+		It("serves policies by guid from the snapshot", func() {
+			snapshot.Policies = append(snapshot.Policies, store.Policy{Source: store.Source{ID: "other-app-guid"}})
+			request, err := http.NewRequest("GET", "/networking/v1/internal/policies?id=snapshot-app-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Header().Get("ETag")).NotTo(BeEmpty())
+			Expect(fakeStore.ByGuidsCallCount()).To(Equal(0))
+			Expect(fakeEgressStore.ByGuidsCallCount()).To(Equal(0))
+
+			policies, egressPolicies := fakeMapper.AsBytesArgsForCall(0)
+			Expect(policies).To(Equal(snapshot.Policies[:1]))
+			Expect(egressPolicies).To(BeEmpty())
+		})
+
+		Context("when getting the snapshot fails", func() {
+			BeforeEach(func() {
+				fakeSnapshots.SnapshotReturns(nil, errors.New("banana"))
+			})
+
+			It("calls the internal server error handler", func() {
+				request, err := http.NewRequest("GET", "/networking/v1/internal/policies", nil)
+				Expect(err).NotTo(HaveOccurred())
+				MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+				Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+				_, _, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+				Expect(err).To(MatchError("banana"))
+				Expect(description).To(Equal("database read failed"))
+			})
+		})
+	})
+
 	Context("when rendering the policies as bytes fails", func() {
 		BeforeEach(func() {
 			fakeMapper.AsBytesReturns(nil, errors.New("banana"))
@@ -1,12 +1,12 @@
 package handlers_test
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"policy-server/handlers"
 	"policy-server/handlers/fakes"
-	storeFakes "policy-server/store/fakes"
 
 	"policy-server/uaa_client"
 
@@ -32,7 +32,7 @@ var _ = Describe("Policies index handler", func() {
 		request               *http.Request
 		handler               *handlers.PoliciesIndex
 		resp                  *httptest.ResponseRecorder
-		fakeStore             *storeFakes.Store
+		fakeStore             *fakes.PolicyStore
 		fakeEgressPolicyStore *fakes.EgressPolicyStore
 		fakePolicyFilter      *fakes.PolicyFilter
 		fakeErrorResponse     *fakes.ErrorResponse
@@ -148,7 +148,7 @@ var _ = Describe("Policies index handler", func() {
 		request, err = http.NewRequest("GET", "/networking/v0/external/policies", nil)
 		Expect(err).NotTo(HaveOccurred())
 
-		fakeStore = &storeFakes.Store{}
+		fakeStore = &fakes.PolicyStore{}
 		fakeStore.AllReturns(allPolicies, nil)
 		fakeStore.ByGuidsReturns(byGuidsPolicies, nil)
 
@@ -157,7 +157,7 @@ var _ = Describe("Policies index handler", func() {
 
 		fakeErrorResponse = &fakes.ErrorResponse{}
 		fakePolicyFilter = &fakes.PolicyFilter{}
-		fakePolicyFilter.FilterPoliciesStub = func(policies []store.Policy, userToken uaa_client.CheckTokenResponse) ([]store.Policy, error) {
+		fakePolicyFilter.FilterPoliciesStub = func(_ context.Context, policies []store.Policy, userToken uaa_client.CheckTokenResponse) ([]store.Policy, error) {
 			return filteredPolicies, nil
 		}
 		fakeMapper = &apifakes.PolicyMapper{}
@@ -195,18 +195,16 @@ var _ = Describe("Policies index handler", func() {
 	})
 
 	Context("when there are egress policies", func() {
-		Context("when the user is a network admin", func() {
+		Context("when the user may list egress policies", func() {
 			BeforeEach(func() {
-				token = uaa_client.CheckTokenResponse{
-					Scope:    []string{"some-scope", "network.admin"},
-					UserID:   "some-user-id",
-					UserName: "some-user",
-				}
+				fakePolicyFilter.CheckEgressPolicyListAccessReturns(true)
 			})
 
 			It("returns all egress policies", func() {
 				MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
 
+				Expect(fakePolicyFilter.CheckEgressPolicyListAccessCallCount()).To(Equal(1))
+				Expect(fakePolicyFilter.CheckEgressPolicyListAccessArgsForCall(0)).To(Equal(token))
 				Expect(fakeEgressPolicyStore.AllCallCount()).To(Equal(1))
 				_, egressPolicies := fakeMapper.AsBytesArgsForCall(0)
 				Expect(egressPolicies).To(Equal(allEgressPolicies))
@@ -233,7 +231,7 @@ var _ = Describe("Policies index handler", func() {
 			})
 		})
 
-		Context("when the user is not a network admin", func() {
+		Context("when the user may not list egress policies", func() {
 			It("does not return any egress policies", func() {
 				MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
 
@@ -262,7 +260,7 @@ var _ = Describe("Policies index handler", func() {
 			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
 
 			Expect(fakePolicyFilter.FilterPoliciesCallCount()).To(Equal(1))
-			_, filterToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
+			_, _, filterToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
 			Expect(filterToken).To(Equal(uaa_client.CheckTokenResponse{}))
 			Expect(resp.Code).To(Equal(http.StatusOK))
 			Expect(resp.Body.Bytes()).To(Equal(expectedResponseBody))
@@ -302,12 +300,12 @@ var _ = Describe("Policies index handler", func() {
 			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
 
 			Expect(fakeStore.ByGuidsCallCount()).To(Equal(1))
-			srcGuids, destGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
+			_, srcGuids, destGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
 			Expect(srcGuids).To(ConsistOf([]string{"some-app-guid", "yet-another-app-guid"}))
 			Expect(destGuids).To(ConsistOf([]string{"some-app-guid", "yet-another-app-guid"}))
 			Expect(inSourceAndDest).To(BeFalse())
 			Expect(fakePolicyFilter.FilterPoliciesCallCount()).To(Equal(1))
-			policies, userToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
+			_, policies, userToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
 			Expect(policies).To(Equal(byGuidsAPIPolicies))
 			Expect(userToken).To(Equal(token))
 			Expect(resp.Code).To(Equal(http.StatusOK))
@@ -321,12 +319,12 @@ var _ = Describe("Policies index handler", func() {
 
 				MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
 				Expect(fakeStore.ByGuidsCallCount()).To(Equal(1))
-				srcGuids, destGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
+				_, srcGuids, destGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
 				Expect(srcGuids).To(Equal([]string{""}))
 				Expect(destGuids).To(Equal([]string{""}))
 				Expect(inSourceAndDest).To(BeFalse())
 				Expect(fakePolicyFilter.FilterPoliciesCallCount()).To(Equal(1))
-				policies, userToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
+				_, policies, userToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
 				Expect(policies).To(Equal(byGuidsAPIPolicies))
 				Expect(userToken).To(Equal(token))
 
@@ -346,12 +344,12 @@ var _ = Describe("Policies index handler", func() {
 			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
 
 			Expect(fakeStore.ByGuidsCallCount()).To(Equal(1))
-			srcGuids, destGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
+			_, srcGuids, destGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
 			Expect(srcGuids).To(ConsistOf([]string{}))
 			Expect(destGuids).To(ConsistOf([]string{"not-a-real-app-guid", "some-other-app-guid"}))
 			Expect(inSourceAndDest).To(BeFalse())
 			Expect(fakePolicyFilter.FilterPoliciesCallCount()).To(Equal(1))
-			policies, userToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
+			_, policies, userToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
 			Expect(policies).To(Equal(byGuidsAPIPolicies))
 			Expect(userToken).To(Equal(token))
 			Expect(resp.Code).To(Equal(http.StatusOK))
@@ -365,12 +363,12 @@ var _ = Describe("Policies index handler", func() {
 
 				MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
 				Expect(fakeStore.ByGuidsCallCount()).To(Equal(1))
-				srcGuids, destGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
+				_, srcGuids, destGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
 				Expect(srcGuids).To(Equal([]string{}))
 				Expect(destGuids).To(Equal([]string{""}))
 				Expect(inSourceAndDest).To(BeFalse())
 				Expect(fakePolicyFilter.FilterPoliciesCallCount()).To(Equal(1))
-				policies, userToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
+				_, policies, userToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
 				Expect(policies).To(Equal(byGuidsAPIPolicies))
 				Expect(userToken).To(Equal(token))
 				Expect(resp.Code).To(Equal(http.StatusOK))
@@ -389,12 +387,12 @@ var _ = Describe("Policies index handler", func() {
 			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
 
 			Expect(fakeStore.ByGuidsCallCount()).To(Equal(1))
-			srcGuids, destGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
+			_, srcGuids, destGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
 			Expect(srcGuids).To(ConsistOf([]string{"some-app-guid", "yet-another-app-guid", "some-other-app-guid"}))
 			Expect(destGuids).To(ConsistOf([]string{}))
 			Expect(inSourceAndDest).To(BeFalse())
 			Expect(fakePolicyFilter.FilterPoliciesCallCount()).To(Equal(1))
-			policies, userToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
+			_, policies, userToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
 			Expect(policies).To(Equal(byGuidsAPIPolicies))
 			Expect(userToken).To(Equal(token))
 			Expect(resp.Code).To(Equal(http.StatusOK))
@@ -408,12 +406,12 @@ var _ = Describe("Policies index handler", func() {
 
 				MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
 				Expect(fakeStore.ByGuidsCallCount()).To(Equal(1))
-				srcGuids, destGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
+				_, srcGuids, destGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
 				Expect(srcGuids).To(Equal([]string{""}))
 				Expect(destGuids).To(Equal([]string{}))
 				Expect(inSourceAndDest).To(BeFalse())
 				Expect(fakePolicyFilter.FilterPoliciesCallCount()).To(Equal(1))
-				policies, userToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
+				_, policies, userToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
 				Expect(policies).To(Equal(byGuidsAPIPolicies))
 				Expect(userToken).To(Equal(token))
 
@@ -433,12 +431,12 @@ var _ = Describe("Policies index handler", func() {
 			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
 
 			Expect(fakeStore.ByGuidsCallCount()).To(Equal(1))
-			srcGuids, destGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
+			_, srcGuids, destGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
 			Expect(srcGuids).To(ConsistOf([]string{"some-app-guid", "meow"}))
 			Expect(destGuids).To(ConsistOf([]string{"not-a-real-app-guid", "some-other-app-guid"}))
 			Expect(inSourceAndDest).To(BeTrue())
 			Expect(fakePolicyFilter.FilterPoliciesCallCount()).To(Equal(1))
-			policies, userToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
+			_, policies, userToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
 			Expect(policies).To(Equal(byGuidsAPIPolicies))
 			Expect(userToken).To(Equal(token))
 			Expect(resp.Code).To(Equal(http.StatusOK))
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"net/http"
+	"policy-server/api"
+	"policy-server/redundancy"
+	"policy-server/store"
+
+	"code.cloudfoundry.org/cf-networking-helpers/marshal"
+)
+
+type finding struct {
+	Policy        api.Policy `json:"policy"`
+	RelatedPolicy api.Policy `json:"related_policy"`
+}
+
+type redundancyReport struct {
+	TotalDuplicates  int       `json:"total_duplicates"`
+	Duplicates       []finding `json:"duplicates"`
+	TotalShadowed    int       `json:"total_shadowed"`
+	Shadowed         []finding `json:"shadowed"`
+	TotalOverlapping int       `json:"total_overlapping"`
+	Overlapping      []finding `json:"overlapping"`
+}
+
+type PoliciesRedundancy struct {
+	Store         store.Store
+	Marshaler     marshal.Marshaler
+	ErrorResponse errorResponse
+}
+
+func NewPoliciesRedundancy(store store.Store, marshaler marshal.Marshaler, errorResponse errorResponse) *PoliciesRedundancy {
+	return &PoliciesRedundancy{
+		Store:         store,
+		Marshaler:     marshaler,
+		ErrorResponse: errorResponse,
+	}
+}
+
+func (h *PoliciesRedundancy) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	logger := getLogger(req)
+	logger = logger.Session("redundant-policies")
+
+	policies, err := h.Store.All()
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "database read failed")
+		return
+	}
+
+	report := redundancy.Analyze(policies)
+	response := redundancyReport{
+		TotalDuplicates:  len(report.Duplicates),
+		Duplicates:       mapFindings(report.Duplicates),
+		TotalShadowed:    len(report.Shadowed),
+		Shadowed:         mapFindings(report.Shadowed),
+		TotalOverlapping: len(report.Overlapping),
+		Overlapping:      mapFindings(report.Overlapping),
+	}
+
+	responseBytes, err := h.Marshaler.Marshal(response)
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "marshalling redundancy report failed")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+func mapFindings(findings []redundancy.Finding) []finding {
+	mapped := []finding{}
+	for _, f := range findings {
+		mapped = append(mapped, finding{
+			Policy:        mapUntaggedPolicy(f.Policy),
+			RelatedPolicy: mapUntaggedPolicy(f.Related),
+		})
+	}
+	return mapped
+}
+
+func mapUntaggedPolicies(policies []store.Policy) []api.Policy {
+	mapped := []api.Policy{}
+	for _, policy := range policies {
+		mapped = append(mapped, mapUntaggedPolicy(policy))
+	}
+	return mapped
+}
+
+func mapUntaggedPolicy(policy store.Policy) api.Policy {
+	policy.Source.Tag = ""
+	policy.Destination.Tag = ""
+	return api.MapStorePolicy(policy)
+}
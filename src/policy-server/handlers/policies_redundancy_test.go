@@ -0,0 +1,129 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"policy-server/handlers"
+	"policy-server/handlers/fakes"
+	"policy-server/store"
+	storeFakes "policy-server/store/fakes"
+
+	hfakes "code.cloudfoundry.org/cf-networking-helpers/fakes"
+	"code.cloudfoundry.org/lager"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PoliciesRedundancy", func() {
+	var (
+		request           *http.Request
+		handler           *handlers.PoliciesRedundancy
+		resp              *httptest.ResponseRecorder
+		logger            *lagertest.TestLogger
+		expectedLogger    lager.Logger
+		fakeStore         *storeFakes.Store
+		fakeErrorResponse *fakes.ErrorResponse
+		marshaler         *hfakes.Marshaler
+	)
+
+	BeforeEach(func() {
+		var err error
+		request, err = http.NewRequest("GET", "/networking/v1/external/policies/redundant", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		logger = lagertest.NewTestLogger("test")
+		expectedLogger = lager.NewLogger("test").Session("redundant-policies")
+
+		testSink := lagertest.NewTestSink()
+		expectedLogger.RegisterSink(testSink)
+		expectedLogger.RegisterSink(lager.NewWriterSink(GinkgoWriter, lager.DEBUG))
+
+		marshaler = &hfakes.Marshaler{}
+		marshaler.MarshalStub = json.Marshal
+
+		fakeStore = &storeFakes.Store{}
+		fakeStore.AllReturns([]store.Policy{{
+			Source: store.Source{ID: "some-app-guid", Tag: "01"},
+			Destination: store.Destination{
+				ID:       "some-other-app-guid",
+				Tag:      "02",
+				Protocol: "tcp",
+				Ports:    store.Ports{Start: 8080, End: 8090},
+			},
+		}, {
+			Source: store.Source{ID: "some-app-guid", Tag: "01"},
+			Destination: store.Destination{
+				ID:       "some-other-app-guid",
+				Tag:      "02",
+				Protocol: "tcp",
+				Port:     8085,
+				Ports:    store.Ports{Start: 8085, End: 8085},
+			},
+		}}, nil)
+		fakeErrorResponse = &fakes.ErrorResponse{}
+
+		handler = handlers.NewPoliciesRedundancy(fakeStore, marshaler, fakeErrorResponse)
+		resp = httptest.NewRecorder()
+	})
+
+	It("returns the redundant policies without tags", func() {
+		MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(resp.Body).To(MatchJSON(`{
+			"total_duplicates": 0,
+			"duplicates": [],
+			"total_shadowed": 1,
+			"shadowed": [{
+				"policy": {
+					"source": { "id": "some-app-guid" },
+					"destination": { "id": "some-other-app-guid", "protocol": "tcp", "ports": { "start": 8085, "end": 8085 } }
+				},
+				"related_policy": {
+					"source": { "id": "some-app-guid" },
+					"destination": { "id": "some-other-app-guid", "protocol": "tcp", "ports": { "start": 8080, "end": 8090 } }
+				}
+			}],
+			"total_overlapping": 0,
+			"overlapping": []
+		}`))
+	})
+
+	Context("when the store fails", func() {
+		BeforeEach(func() {
+			fakeStore.AllReturns(nil, errors.New("potato"))
+		})
+
+		It("calls the internal server error handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+			l, w, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+			Expect(l).To(Equal(expectedLogger))
+			Expect(w).To(Equal(resp))
+			Expect(err).To(MatchError("potato"))
+			Expect(description).To(Equal("database read failed"))
+		})
+	})
+
+	Context("when marshalling fails", func() {
+		BeforeEach(func() {
+			marshaler.MarshalStub = func(interface{}) ([]byte, error) {
+				return nil, errors.New("potato")
+			}
+		})
+
+		It("calls the internal server error handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+			_, _, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+			Expect(err).To(MatchError("potato"))
+			Expect(description).To(Equal("marshalling redundancy report failed"))
+		})
+	})
+})
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"policy-server/api"
+	"policy-server/store"
+	"time"
+
+	"code.cloudfoundry.org/cf-networking-helpers/marshal"
+	"code.cloudfoundry.org/lager"
+)
+
+//go:generate counterfeiter -o fakes/policy_trash.go --fake-name PolicyTrash . policyTrash
+type policyTrash interface {
+	All() ([]store.DeletedPolicy, error)
+	Restore([]int) ([]store.Policy, error)
+}
+
+type deletedPolicy struct {
+	ID        int       `json:"id"`
+	Reason    string    `json:"reason"`
+	DeletedAt time.Time `json:"deleted_at"`
+	api.Policy
+}
+
+type PoliciesDeletedIndex struct {
+	PolicyTrash   policyTrash
+	Marshaler     marshal.Marshaler
+	ErrorResponse errorResponse
+}
+
+func NewPoliciesDeletedIndex(policyTrash policyTrash, marshaler marshal.Marshaler, errorResponse errorResponse) *PoliciesDeletedIndex {
+	return &PoliciesDeletedIndex{
+		PolicyTrash:   policyTrash,
+		Marshaler:     marshaler,
+		ErrorResponse: errorResponse,
+	}
+}
+
+func (h *PoliciesDeletedIndex) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	logger := getLogger(req)
+	logger = logger.Session("index-deleted-policies")
+
+	deleted, err := h.PolicyTrash.All()
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "database read failed")
+		return
+	}
+
+	response := struct {
+		TotalPolicies int             `json:"total_policies"`
+		Policies      []deletedPolicy `json:"policies"`
+	}{
+		TotalPolicies: len(deleted),
+		Policies:      []deletedPolicy{},
+	}
+	for _, d := range deleted {
+		response.Policies = append(response.Policies, deletedPolicy{
+			ID:        d.ID,
+			Reason:    d.Reason,
+			DeletedAt: d.DeletedAt,
+			Policy:    mapUntaggedPolicy(d.Policy),
+		})
+	}
+
+	responseBytes, err := h.Marshaler.Marshal(response)
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "marshal response failed")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+type PoliciesRestore struct {
+	PolicyTrash   policyTrash
+	Marshaler     marshal.Marshaler
+	ErrorResponse errorResponse
+}
+
+func NewPoliciesRestore(policyTrash policyTrash, marshaler marshal.Marshaler, errorResponse errorResponse) *PoliciesRestore {
+	return &PoliciesRestore{
+		PolicyTrash:   policyTrash,
+		Marshaler:     marshaler,
+		ErrorResponse: errorResponse,
+	}
+}
+
+func (h *PoliciesRestore) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	logger := getLogger(req)
+	logger = logger.Session("restore-policies")
+	tokenData := getTokenData(req)
+
+	bodyBytes, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		writeBodyReadError(logger, w, h.ErrorResponse, err, "invalid request body")
+		return
+	}
+
+	var payload struct {
+		IDs []int `json:"ids"`
+	}
+	err = json.Unmarshal(bodyBytes, &payload)
+	if err != nil {
+		h.ErrorResponse.BadRequest(logger, w, err, "invalid values passed to API")
+		return
+	}
+	if len(payload.IDs) == 0 {
+		err = errors.New("missing ids")
+		h.ErrorResponse.BadRequest(logger, w, err, err.Error())
+		return
+	}
+
+	restored, err := h.PolicyTrash.Restore(payload.IDs)
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "database restore failed")
+		return
+	}
+
+	response := struct {
+		TotalPolicies int          `json:"total_policies"`
+		Policies      []api.Policy `json:"policies"`
+	}{
+		TotalPolicies: len(restored),
+		Policies:      mapUntaggedPolicies(restored),
+	}
+
+	responseBytes, err := h.Marshaler.Marshal(response)
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "marshal response failed")
+		return
+	}
+
+	logger.Info("restored-policies", lager.Data{"policies": restored, "userName": tokenData.UserName})
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
@@ -0,0 +1,218 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"policy-server/handlers"
+	"policy-server/handlers/fakes"
+	"policy-server/store"
+	"time"
+
+	hfakes "code.cloudfoundry.org/cf-networking-helpers/fakes"
+	"code.cloudfoundry.org/lager"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Policy trash", func() {
+	var (
+		resp              *httptest.ResponseRecorder
+		logger            *lagertest.TestLogger
+		fakePolicyTrash   *fakes.PolicyTrash
+		fakeErrorResponse *fakes.ErrorResponse
+		marshaler         *hfakes.Marshaler
+		deletedPolicies   []store.DeletedPolicy
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		marshaler = &hfakes.Marshaler{}
+		marshaler.MarshalStub = json.Marshal
+		fakeErrorResponse = &fakes.ErrorResponse{}
+		resp = httptest.NewRecorder()
+
+		deletedPolicies = []store.DeletedPolicy{{
+			ID: 7,
+			Policy: store.Policy{
+				Source: store.Source{ID: "some-app-guid"},
+				Destination: store.Destination{
+					ID:       "some-other-app-guid",
+					Protocol: "tcp",
+					Ports:    store.Ports{Start: 8080, End: 8090},
+				},
+			},
+			Reason:    "cleaner",
+			DeletedAt: time.Date(2017, time.July, 14, 2, 40, 0, 0, time.UTC),
+		}}
+
+		fakePolicyTrash = &fakes.PolicyTrash{}
+		fakePolicyTrash.AllReturns(deletedPolicies, nil)
+		fakePolicyTrash.RestoreReturns([]store.Policy{deletedPolicies[0].Policy}, nil)
+	})
+
+	Describe("PoliciesDeletedIndex", func() {
+		var (
+			request        *http.Request
+			handler        *handlers.PoliciesDeletedIndex
+			expectedLogger lager.Logger
+		)
+
+		BeforeEach(func() {
+			var err error
+			request, err = http.NewRequest("GET", "/networking/v1/external/policies/deleted", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expectedLogger = lager.NewLogger("test").Session("index-deleted-policies")
+			testSink := lagertest.NewTestSink()
+			expectedLogger.RegisterSink(testSink)
+			expectedLogger.RegisterSink(lager.NewWriterSink(GinkgoWriter, lager.DEBUG))
+
+			handler = handlers.NewPoliciesDeletedIndex(fakePolicyTrash, marshaler, fakeErrorResponse)
+		})
+
+		It("returns the deleted policies", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakePolicyTrash.AllCallCount()).To(Equal(1))
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Body).To(MatchJSON(`{
+				"total_policies": 1,
+				"policies": [{
+					"id": 7,
+					"reason": "cleaner",
+					"deleted_at": "2017-07-14T02:40:00Z",
+					"source": { "id": "some-app-guid" },
+					"destination": { "id": "some-other-app-guid", "protocol": "tcp", "ports": { "start": 8080, "end": 8090 } }
+				}]
+			}`))
+		})
+
+		Context("when the trash is empty", func() {
+			BeforeEach(func() {
+				fakePolicyTrash.AllReturns(nil, nil)
+			})
+
+			It("returns an empty list", func() {
+				MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+				Expect(resp.Body).To(MatchJSON(`{ "total_policies": 0, "policies": [] }`))
+			})
+		})
+
+		Context("when listing the trash fails", func() {
+			BeforeEach(func() {
+				fakePolicyTrash.AllReturns(nil, errors.New("potato"))
+			})
+
+			It("calls the internal server error handler", func() {
+				MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+				Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+				l, w, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+				Expect(l).To(Equal(expectedLogger))
+				Expect(w).To(Equal(resp))
+				Expect(err).To(MatchError("potato"))
+				Expect(description).To(Equal("database read failed"))
+			})
+		})
+
+		Context("when marshalling fails", func() {
+			BeforeEach(func() {
+				marshaler.MarshalStub = func(interface{}) ([]byte, error) {
+					return nil, errors.New("potato")
+				}
+			})
+
+			It("calls the internal server error handler", func() {
+				MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+				Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+				_, _, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+				Expect(err).To(MatchError("potato"))
+				Expect(description).To(Equal("marshal response failed"))
+			})
+		})
+	})
+
+	Describe("PoliciesRestore", func() {
+		var (
+			handler        *handlers.PoliciesRestore
+			expectedLogger lager.Logger
+		)
+
+		newRequest := func(body string) *http.Request {
+			request, err := http.NewRequest("POST", "/networking/v1/external/policies/restore", bytes.NewBufferString(body))
+			Expect(err).NotTo(HaveOccurred())
+			return request
+		}
+
+		BeforeEach(func() {
+			expectedLogger = lager.NewLogger("test").Session("restore-policies")
+			testSink := lagertest.NewTestSink()
+			expectedLogger.RegisterSink(testSink)
+			expectedLogger.RegisterSink(lager.NewWriterSink(GinkgoWriter, lager.DEBUG))
+
+			handler = handlers.NewPoliciesRestore(fakePolicyTrash, marshaler, fakeErrorResponse)
+		})
+
+		It("restores the requested policies", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, newRequest(`{"ids": [7, 8]}`), logger)
+
+			Expect(fakePolicyTrash.RestoreCallCount()).To(Equal(1))
+			Expect(fakePolicyTrash.RestoreArgsForCall(0)).To(Equal([]int{7, 8}))
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Body).To(MatchJSON(`{
+				"total_policies": 1,
+				"policies": [
+					{ "source": { "id": "some-app-guid" }, "destination": { "id": "some-other-app-guid", "protocol": "tcp", "ports": { "start": 8080, "end": 8090 } } }
+				]
+			}`))
+			Expect(logger).To(gbytes.Say("restored-policies"))
+		})
+
+		Context("when the body is not valid json", func() {
+			It("calls the bad request handler", func() {
+				MakeRequestWithLogger(handler.ServeHTTP, resp, newRequest(`{`), logger)
+
+				Expect(fakeErrorResponse.BadRequestCallCount()).To(Equal(1))
+				l, w, _, description := fakeErrorResponse.BadRequestArgsForCall(0)
+				Expect(l).To(Equal(expectedLogger))
+				Expect(w).To(Equal(resp))
+				Expect(description).To(Equal("invalid values passed to API"))
+				Expect(fakePolicyTrash.RestoreCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when no ids are given", func() {
+			It("calls the bad request handler", func() {
+				MakeRequestWithLogger(handler.ServeHTTP, resp, newRequest(`{"ids": []}`), logger)
+
+				Expect(fakeErrorResponse.BadRequestCallCount()).To(Equal(1))
+				_, _, err, description := fakeErrorResponse.BadRequestArgsForCall(0)
+				Expect(err).To(MatchError("missing ids"))
+				Expect(description).To(Equal("missing ids"))
+				Expect(fakePolicyTrash.RestoreCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when restoring fails", func() {
+			BeforeEach(func() {
+				fakePolicyTrash.RestoreReturns(nil, errors.New("potato"))
+			})
+
+			It("calls the internal server error handler", func() {
+				MakeRequestWithLogger(handler.ServeHTTP, resp, newRequest(`{"ids": [7]}`), logger)
+
+				Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+				_, _, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+				Expect(err).To(MatchError("potato"))
+				Expect(description).To(Equal("database restore failed"))
+			})
+		})
+	})
+})
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
 	"policy-server/api"
 	"policy-server/store"
@@ -9,67 +10,148 @@ import (
 
 //go:generate counterfeiter -o fakes/uua_client.go --fake-name UAAClient . uaaClient
 type uaaClient interface {
-	GetToken() (string, error)
-	CheckToken(string) (uaa_client.CheckTokenResponse, error)
+	GetToken(ctx context.Context) (string, error)
 }
 
 //go:generate counterfeiter -o fakes/cc_client.go --fake-name CCClient . ccClient
 type ccClient interface {
-	GetAppSpaces(token string, appGUIDs []string) (map[string]string, error)
-	GetSpace(token, spaceGUID string) (*api.Space, error)
-	GetSpaceGUIDs(token string, appGUIDs []string) ([]string, error)
-	GetUserSpace(token, userGUID string, spaces api.Space) (*api.Space, error)
-	GetUserSpaces(token, userGUID string) (map[string]struct{}, error)
+	GetAppSpaces(ctx context.Context, token string, appGUIDs []string) (map[string]string, error)
+	GetSpace(ctx context.Context, token, spaceGUID string) (*api.Space, error)
+	GetSpaceGUIDs(ctx context.Context, token string, appGUIDs []string) ([]string, error)
+	GetUserSpace(ctx context.Context, token, userGUID string, spaces api.Space, roles []string) (*api.Space, error)
+	GetUserSpaces(ctx context.Context, token, userGUID string, roles []string) (map[string]struct{}, error)
 }
 
+// PolicyFilter limits the policies a user may read to those allowed by Read.
+// A service account may read policies of apps in its orgs and spaces.
 type PolicyFilter struct {
-	CCClient  ccClient
-	UAAClient uaaClient
-	ChunkSize int
+	CCClient        ccClient
+	UAAClient       uaaClient
+	ChunkSize       int
+	Read            Permission
+	ServiceAccounts ServiceAccounts
 }
 
-func NewPolicyFilter(uaaClient uaaClient, ccClient ccClient, chunkSize int) *PolicyFilter {
+func NewPolicyFilter(uaaClient uaaClient, ccClient ccClient, chunkSize int, read Permission, serviceAccounts ServiceAccounts) *PolicyFilter {
 	return &PolicyFilter{
-		CCClient:  ccClient,
-		UAAClient: uaaClient,
-		ChunkSize: chunkSize,
+		CCClient:        ccClient,
+		UAAClient:       uaaClient,
+		ChunkSize:       chunkSize,
+		Read:            read,
+		ServiceAccounts: serviceAccounts,
 	}
 }
 
-func (f *PolicyFilter) FilterPolicies(policies []store.Policy, userToken uaa_client.CheckTokenResponse) ([]store.Policy, error) {
-	for _, scope := range userToken.Scope {
-		if scope == "network.admin" {
-			return policies, nil
+func (f *PolicyFilter) FilterPolicies(ctx context.Context, policies []store.Policy, userToken uaa_client.CheckTokenResponse) ([]store.Policy, error) {
+	if f.Read.grantedByScope(userToken) {
+		return policies, nil
+	}
+	if len(f.Read.CCRoles) == 0 {
+		return []store.Policy{}, nil
+	}
+
+	appSpaces, userSpaces, err := f.spaces(ctx, uniqueAppGUIDs(policies), userToken)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := filter(policies, appSpaces, userSpaces)
+
+	return filtered, nil
+}
+
+// FilterTags limits tags to those of apps whose policies the user may read.
+func (f *PolicyFilter) FilterTags(ctx context.Context, tags []store.Tag, userToken uaa_client.CheckTokenResponse) ([]store.Tag, error) {
+	if f.Read.grantedByScope(userToken) {
+		return tags, nil
+	}
+	if len(f.Read.CCRoles) == 0 {
+		return []store.Tag{}, nil
+	}
+
+	appGUIDs := []string{}
+	for _, tag := range tags {
+		if tag.Type == "app" {
+			appGUIDs = append(appGUIDs, tag.ID)
+		}
+	}
+
+	appSpaces, userSpaces, err := f.spaces(ctx, appGUIDs, userToken)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := []store.Tag{}
+	for _, tag := range tags {
+		if tag.Type != "app" {
+			continue
+		}
+		if _, ok := userSpaces[appSpaces[tag.ID]]; ok {
+			filtered = append(filtered, tag)
 		}
 	}
+	return filtered, nil
+}
 
-	token, err := f.UAAClient.GetToken()
+// spaces looks up the spaces of the apps, and the spaces in which the user
+// has one of the Read roles, or for a service account those of the app spaces
+// it is allowed.
+func (f *PolicyFilter) spaces(ctx context.Context, appGuids []string, userToken uaa_client.CheckTokenResponse) (map[string]string, map[string]struct{}, error) {
+	account, isServiceAccount := f.ServiceAccounts.lookup(userToken)
+	if !isServiceAccount && isClientToken(userToken) {
+		return map[string]string{}, map[string]struct{}{}, nil
+	}
+
+	token, err := f.UAAClient.GetToken(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("getting token: %s", err)
+		return nil, nil, fmt.Errorf("getting token: %s", err)
 	}
 
-	appGuids := uniqueAppGUIDs(policies)
 	appGuidChunks := getChunks(appGuids, f.ChunkSize)
 
 	appSpacesList := []map[string]string{}
 	for _, chunk := range appGuidChunks {
-		spaces, err := f.CCClient.GetAppSpaces(token, chunk)
+		spaces, err := f.CCClient.GetAppSpaces(ctx, token, chunk)
 		if err != nil {
-			return nil, fmt.Errorf("getting app spaces: %s", err)
+			return nil, nil, fmt.Errorf("getting app spaces: %s", err)
 		}
 		appSpacesList = append(appSpacesList, spaces)
 	}
 
 	appSpaces := flatten(appSpacesList)
 
-	userSpaces, err := f.CCClient.GetUserSpaces(token, userToken.UserID)
+	if isServiceAccount {
+		userSpaces, err := account.allowedSpaces(ctx, f.CCClient, token, uniqueSpaceGUIDs(appSpaces))
+		if err != nil {
+			return nil, nil, err
+		}
+		return appSpaces, userSpaces, nil
+	}
+
+	userSpaces, err := f.CCClient.GetUserSpaces(ctx, token, userToken.UserID, f.Read.CCRoles)
 	if err != nil {
-		return nil, fmt.Errorf("getting user spaces: %s", err)
+		return nil, nil, fmt.Errorf("getting user spaces: %s", err)
 	}
 
-	filtered := filter(policies, appSpaces, userSpaces)
+	return appSpaces, userSpaces, nil
+}
 
-	return filtered, nil
+// CheckEgressPolicyListAccess reports whether the user may list egress
+// policies, which are only shown to users granted Read by scope.
+func (f *PolicyFilter) CheckEgressPolicyListAccess(userToken uaa_client.CheckTokenResponse) bool {
+	return f.Read.grantedByScope(userToken)
+}
+
+func uniqueSpaceGUIDs(appSpaces map[string]string) []string {
+	set := map[string]struct{}{}
+	for _, spaceGUID := range appSpaces {
+		set[spaceGUID] = struct{}{}
+	}
+	spaceGUIDs := make([]string, 0, len(set))
+	for guid := range set {
+		spaceGUIDs = append(spaceGUIDs, guid)
+	}
+	return spaceGUIDs
 }
 
 func flatten(list []map[string]string) map[string]string {
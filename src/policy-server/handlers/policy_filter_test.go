@@ -1,7 +1,9 @@
 package handlers_test
 
 import (
+	"context"
 	"errors"
+	"policy-server/api"
 	"policy-server/handlers"
 	"policy-server/handlers/fakes"
 	"policy-server/store"
@@ -27,6 +29,10 @@ var _ = Describe("PolicyFilter", func() {
 			CCClient:  fakeCCClient,
 			UAAClient: fakeUAAClient,
 			ChunkSize: 100,
+			Read: handlers.Permission{
+				Scopes:  []string{"network.admin"},
+				CCRoles: []string{"space_developer", "space_auditor"},
+			},
 		}
 		policies = []store.Policy{
 			{
@@ -72,21 +78,22 @@ var _ = Describe("PolicyFilter", func() {
 
 	Describe("FilterPolicies", func() {
 		It("filters the policies by the spaces the user can access", func() {
-			filteredPolicies, err := policyFilter.FilterPolicies(policies, tokenData)
+			filteredPolicies, err := policyFilter.FilterPolicies(context.Background(), policies, tokenData)
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(fakeUAAClient.GetTokenCallCount()).To(Equal(1))
 			Expect(fakeCCClient.GetAppSpacesCallCount()).To(Equal(1))
 
-			token, appGUIDs := fakeCCClient.GetAppSpacesArgsForCall(0)
+			_, token, appGUIDs := fakeCCClient.GetAppSpacesArgsForCall(0)
 			Expect(token).To(Equal("policy-server-token"))
 			Expect(appGUIDs).To(ConsistOf([]string{"app-guid-1", "app-guid-2", "app-guid-3", "app-guid-4"}))
 
 			Expect(fakeCCClient.GetUserSpacesCallCount()).To(Equal(1))
 
-			token, userGUID := fakeCCClient.GetUserSpacesArgsForCall(0)
+			_, token, userGUID, roles := fakeCCClient.GetUserSpacesArgsForCall(0)
 			Expect(token).To(Equal("policy-server-token"))
 			Expect(userGUID).To(Equal("some-developer-guid"))
+			Expect(roles).To(Equal([]string{"space_developer", "space_auditor"}))
 
 			expected := []store.Policy{
 				{
@@ -107,7 +114,7 @@ var _ = Describe("PolicyFilter", func() {
 			})
 
 			It("returns a non-null, but empty, slice of policies", func() {
-				filteredPolicies, err := policyFilter.FilterPolicies(policies, tokenData)
+				filteredPolicies, err := policyFilter.FilterPolicies(context.Background(), policies, tokenData)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(filteredPolicies).NotTo(BeNil())
 				Expect(filteredPolicies).To(HaveLen(0))
@@ -121,7 +128,7 @@ var _ = Describe("PolicyFilter", func() {
 				}
 			})
 			It("returns all policies without making extra calls to UAA or CC", func() {
-				filtered, err := policyFilter.FilterPolicies(policies, tokenData)
+				filtered, err := policyFilter.FilterPolicies(context.Background(), policies, tokenData)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(fakeUAAClient.GetTokenCallCount()).To(Equal(0))
 				Expect(fakeCCClient.GetAppSpacesCallCount()).To(Equal(0))
@@ -130,12 +137,78 @@ var _ = Describe("PolicyFilter", func() {
 			})
 		})
 
+		Context("when the read permission has no cc roles", func() {
+			BeforeEach(func() {
+				policyFilter.Read.CCRoles = nil
+			})
+
+			It("returns no policies without making calls to UAA or CC", func() {
+				filtered, err := policyFilter.FilterPolicies(context.Background(), policies, tokenData)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(filtered).To(BeEmpty())
+				Expect(fakeUAAClient.GetTokenCallCount()).To(Equal(0))
+				Expect(fakeCCClient.GetUserSpacesCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the token is a client credentials token", func() {
+			BeforeEach(func() {
+				tokenData = uaa_client.CheckTokenResponse{
+					ClientID: "some-pipeline",
+				}
+			})
+
+			It("returns no policies without making calls to UAA or CC", func() {
+				filteredPolicies, err := policyFilter.FilterPolicies(context.Background(), policies, tokenData)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(filteredPolicies).To(BeEmpty())
+				Expect(fakeUAAClient.GetTokenCallCount()).To(Equal(0))
+				Expect(fakeCCClient.GetAppSpacesCallCount()).To(Equal(0))
+			})
+
+			Context("when the client is a service account", func() {
+				BeforeEach(func() {
+					policyFilter.ServiceAccounts = handlers.NewServiceAccounts([]handlers.ServiceAccount{{
+						ClientID:   "some-pipeline",
+						OrgGUIDs:   []string{"org-1"},
+						SpaceGUIDs: []string{"space-1"},
+					}})
+					fakeCCClient.GetSpaceStub = func(_ context.Context, token, spaceGUID string) (*api.Space, error) {
+						if spaceGUID == "space-2" {
+							return &api.Space{Name: "space-2", OrgGUID: "org-1"}, nil
+						}
+						return &api.Space{Name: spaceGUID, OrgGUID: "org-2"}, nil
+					}
+				})
+
+				It("filters the policies by the spaces the service account is allowed", func() {
+					filteredPolicies, err := policyFilter.FilterPolicies(context.Background(), policies, tokenData)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(filteredPolicies).To(Equal(policies[:1]))
+
+					Expect(fakeCCClient.GetUserSpacesCallCount()).To(Equal(0))
+					Expect(fakeCCClient.GetSpaceCallCount()).To(Equal(3))
+				})
+
+				Context("when getting a space fails", func() {
+					BeforeEach(func() {
+						fakeCCClient.GetSpaceReturns(nil, errors.New("banana"))
+					})
+
+					It("returns a useful error", func() {
+						_, err := policyFilter.FilterPolicies(context.Background(), policies, tokenData)
+						Expect(err).To(MatchError(ContainSubstring("banana")))
+					})
+				})
+			})
+		})
+
 		Context("when the getting the app spaces fails", func() {
 			BeforeEach(func() {
 				fakeCCClient.GetAppSpacesReturns(nil, errors.New("banana"))
 			})
 			It("returns a useful error", func() {
-				filtered, err := policyFilter.FilterPolicies(policies, tokenData)
+				filtered, err := policyFilter.FilterPolicies(context.Background(), policies, tokenData)
 				Expect(err).To(MatchError("getting app spaces: banana"))
 				Expect(filtered).To(BeNil())
 			})
@@ -146,7 +219,7 @@ var _ = Describe("PolicyFilter", func() {
 				fakeCCClient.GetUserSpacesReturns(nil, errors.New("banana"))
 			})
 			It("returns a useful error", func() {
-				filtered, err := policyFilter.FilterPolicies(policies, tokenData)
+				filtered, err := policyFilter.FilterPolicies(context.Background(), policies, tokenData)
 				Expect(err).To(MatchError("getting user spaces: banana"))
 				Expect(filtered).To(BeNil())
 			})
@@ -157,7 +230,7 @@ var _ = Describe("PolicyFilter", func() {
 				fakeUAAClient.GetTokenReturns("", errors.New("banana"))
 			})
 			It("returns a useful error", func() {
-				filtered, err := policyFilter.FilterPolicies(policies, tokenData)
+				filtered, err := policyFilter.FilterPolicies(context.Background(), policies, tokenData)
 				Expect(err).To(MatchError("getting token: banana"))
 				Expect(filtered).To(BeNil())
 			})
@@ -168,7 +241,7 @@ var _ = Describe("PolicyFilter", func() {
 				policyFilter.ChunkSize = 1
 			})
 			It("chunks the guids and makes multiple requests to CC", func() {
-				filtered, err := policyFilter.FilterPolicies(policies, tokenData)
+				filtered, err := policyFilter.FilterPolicies(context.Background(), policies, tokenData)
 				Expect(err).NotTo(HaveOccurred())
 
 				expected := []store.Policy{
@@ -186,7 +259,7 @@ var _ = Describe("PolicyFilter", func() {
 
 				var appGUIDs []string
 				for i := 0; i < 4; i++ {
-					_, guids := fakeCCClient.GetAppSpacesArgsForCall(i)
+					_, _, guids := fakeCCClient.GetAppSpacesArgsForCall(i)
 					appGUIDs = append(appGUIDs, guids...)
 				}
 				Expect(appGUIDs).To(ConsistOf("app-guid-1",
@@ -199,4 +272,75 @@ var _ = Describe("PolicyFilter", func() {
 			})
 		})
 	})
+
+	Describe("FilterTags", func() {
+		var tags []store.Tag
+
+		BeforeEach(func() {
+			tags = []store.Tag{
+				{ID: "app-guid-1", Tag: "0001", Type: "app"},
+				{ID: "app-guid-4", Tag: "0002", Type: "app"},
+				{ID: "some-group", Tag: "0003", Type: "group"},
+			}
+		})
+
+		It("returns the tags of apps in spaces the user can access", func() {
+			filtered, err := policyFilter.FilterTags(context.Background(), tags, tokenData)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(filtered).To(Equal([]store.Tag{{ID: "app-guid-1", Tag: "0001", Type: "app"}}))
+
+			_, _, appGUIDs := fakeCCClient.GetAppSpacesArgsForCall(0)
+			Expect(appGUIDs).To(Equal([]string{"app-guid-1", "app-guid-4"}))
+			_, _, userGUID, roles := fakeCCClient.GetUserSpacesArgsForCall(0)
+			Expect(userGUID).To(Equal("some-developer-guid"))
+			Expect(roles).To(Equal([]string{"space_developer", "space_auditor"}))
+		})
+
+		Context("when the token has one of the read scopes", func() {
+			BeforeEach(func() {
+				tokenData.Scope = []string{"network.admin"}
+			})
+
+			It("returns all tags without making calls to UAA or CC", func() {
+				filtered, err := policyFilter.FilterTags(context.Background(), tags, tokenData)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(filtered).To(Equal(tags))
+				Expect(fakeUAAClient.GetTokenCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when getting the user spaces fails", func() {
+			BeforeEach(func() {
+				fakeCCClient.GetUserSpacesReturns(nil, errors.New("banana"))
+			})
+
+			It("returns a useful error", func() {
+				_, err := policyFilter.FilterTags(context.Background(), tags, tokenData)
+				Expect(err).To(MatchError("getting user spaces: banana"))
+			})
+		})
+	})
+
+	Describe("CheckEgressPolicyListAccess", func() {
+		Context("when the user has one of the read scopes", func() {
+			BeforeEach(func() {
+				tokenData = uaa_client.CheckTokenResponse{
+					Scope: []string{"network.admin"},
+				}
+			})
+			It("returns true", func() {
+				authorized := policyFilter.CheckEgressPolicyListAccess(tokenData)
+				Expect(fakeUAAClient.GetTokenCallCount()).To(Equal(0))
+				Expect(authorized).To(BeTrue())
+			})
+		})
+
+		Context("when the user only has a cc role that allows reading", func() {
+			It("returns false", func() {
+				authorized := policyFilter.CheckEgressPolicyListAccess(tokenData)
+				Expect(fakeUAAClient.GetTokenCallCount()).To(Equal(0))
+				Expect(authorized).To(BeFalse())
+			})
+		})
+	})
 })
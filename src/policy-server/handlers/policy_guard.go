@@ -1,52 +1,99 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
 	"policy-server/store"
 	"policy-server/uaa_client"
 )
 
+// Permission allows an action to users with any of Scopes for all apps, and
+// to users with any of CCRoles for the apps in spaces where they hold the role.
+type Permission struct {
+	Scopes  []string
+	CCRoles []string
+}
+
+func (p Permission) grantedByScope(userToken uaa_client.CheckTokenResponse) bool {
+	return isAuthorized(userToken.Scope, p.Scopes)
+}
+
+// PolicyGuard checks that a user may create or delete a collection of
+// policies. C2C applies to the c2c policies in the collection and Egress to
+// the egress policies, whose source app must be accessible. ServiceAccounts
+// stand in for cc roles when the token belongs to a client.
 type PolicyGuard struct {
-	CCClient  ccClient
-	UAAClient uaaClient
+	CCClient        ccClient
+	UAAClient       uaaClient
+	C2C             Permission
+	Egress          Permission
+	ServiceAccounts ServiceAccounts
 }
 
-func NewPolicyGuard(uaaClient uaaClient, ccClient ccClient) *PolicyGuard {
+func NewPolicyGuard(uaaClient uaaClient, ccClient ccClient, c2c, egress Permission, serviceAccounts ServiceAccounts) *PolicyGuard {
 	return &PolicyGuard{
-		CCClient:  ccClient,
-		UAAClient: uaaClient,
+		CCClient:        ccClient,
+		UAAClient:       uaaClient,
+		C2C:             c2c,
+		Egress:          egress,
+		ServiceAccounts: serviceAccounts,
 	}
 }
 
-func (g *PolicyGuard) CheckAccess(policyCollection store.PolicyCollection, userToken uaa_client.CheckTokenResponse) (bool, error) {
-	for _, scope := range userToken.Scope {
-		if scope == "network.admin" {
-			return true, nil
+func (g *PolicyGuard) CheckAccess(ctx context.Context, policyCollection store.PolicyCollection, userToken uaa_client.CheckTokenResponse) (bool, error) {
+	if len(policyCollection.EgressPolicies) > 0 && !g.Egress.grantedByScope(userToken) {
+		authorized, err := g.checkAppAccess(ctx, egressSourceGUIDs(policyCollection.EgressPolicies), userToken, g.Egress.CCRoles)
+		if err != nil || !authorized {
+			return false, err
 		}
 	}
 
-	if len(policyCollection.EgressPolicies) > 0 {
+	if len(policyCollection.Policies) > 0 && !g.C2C.grantedByScope(userToken) {
+		return g.checkAppAccess(ctx, uniqueAppGUIDs(policyCollection.Policies), userToken, g.C2C.CCRoles)
+	}
+	return true, nil
+}
+
+// checkAppAccess checks that the user has one of roles in the spaces of all
+// of the apps. A service account is checked against its orgs and spaces
+// instead.
+func (g *PolicyGuard) checkAppAccess(ctx context.Context, appGUIDs []string, userToken uaa_client.CheckTokenResponse, roles []string) (bool, error) {
+	if len(roles) == 0 {
 		return false, nil
 	}
 
-	token, err := g.UAAClient.GetToken()
+	account, isServiceAccount := g.ServiceAccounts.lookup(userToken)
+	if !isServiceAccount && isClientToken(userToken) {
+		return false, nil
+	}
+
+	token, err := g.UAAClient.GetToken(ctx)
 	if err != nil {
 		return false, fmt.Errorf("getting token: %s", err)
 	}
 
-	spaceGUIDs, err := g.CCClient.GetSpaceGUIDs(token, uniqueAppGUIDs(policyCollection.Policies))
+	spaceGUIDs, err := g.CCClient.GetSpaceGUIDs(ctx, token, appGUIDs)
 	if err != nil {
 		return false, fmt.Errorf("getting space guids: %s", err)
 	}
+
+	if isServiceAccount {
+		allowed, err := account.allowedSpaces(ctx, g.CCClient, token, spaceGUIDs)
+		if err != nil {
+			return false, err
+		}
+		return len(allowed) == len(spaceGUIDs), nil
+	}
+
 	for _, guid := range spaceGUIDs {
-		space, err := g.CCClient.GetSpace(token, guid)
+		space, err := g.CCClient.GetSpace(ctx, token, guid)
 		if err != nil {
 			return false, fmt.Errorf("getting space with guid %s: %s", guid, err)
 		}
 		if space == nil {
 			return false, nil
 		}
-		userSpace, err := g.CCClient.GetUserSpace(token, userToken.UserID, *space)
+		userSpace, err := g.CCClient.GetUserSpace(ctx, token, userToken.UserID, *space, roles)
 		if err != nil {
 			return false, fmt.Errorf("getting space with guid %s: %s", guid, err)
 		}
@@ -56,15 +103,6 @@ func (g *PolicyGuard) CheckAccess(policyCollection store.PolicyCollection, userT
 	}
 	return true, nil
 }
-func (g *PolicyGuard) CheckEgressPolicyListAccess(userToken uaa_client.CheckTokenResponse) bool {
-	for _, scope := range userToken.Scope {
-		if scope == "network.admin" {
-			return true
-		}
-	}
-
-	return false
-}
 
 func uniqueAppGUIDs(policies []store.Policy) []string {
 	var set = make(map[string]struct{})
@@ -78,3 +116,15 @@ func uniqueAppGUIDs(policies []store.Policy) []string {
 	}
 	return appGUIDs
 }
+
+func egressSourceGUIDs(policies []store.EgressPolicy) []string {
+	var set = make(map[string]struct{})
+	for _, policy := range policies {
+		set[policy.Source.ID] = struct{}{}
+	}
+	var appGUIDs = make([]string, 0, len(set))
+	for guid := range set {
+		appGUIDs = append(appGUIDs, guid)
+	}
+	return appGUIDs
+}
@@ -1,6 +1,7 @@
 package handlers_test
 
 import (
+	"context"
 	"errors"
 	"policy-server/api"
 	"policy-server/handlers"
@@ -31,6 +32,13 @@ var _ = Describe("PolicyGuard", func() {
 		policyGuard = &handlers.PolicyGuard{
 			CCClient:  fakeCCClient,
 			UAAClient: fakeUAAClient,
+			C2C: handlers.Permission{
+				Scopes:  []string{"network.admin"},
+				CCRoles: []string{"space_developer"},
+			},
+			Egress: handlers.Permission{
+				Scopes: []string{"network.admin"},
+			},
 		}
 		policyCollection = store.PolicyCollection{
 			Policies: []store.Policy{
@@ -73,7 +81,7 @@ var _ = Describe("PolicyGuard", func() {
 
 		fakeUAAClient.GetTokenReturns("policy-server-token", nil)
 		fakeCCClient.GetSpaceGUIDsReturns(spaceGUIDs, nil)
-		fakeCCClient.GetSpaceStub = func(token, spaceGUID string) (*api.Space, error) {
+		fakeCCClient.GetSpaceStub = func(_ context.Context, token, spaceGUID string) (*api.Space, error) {
 			switch spaceGUID {
 			case "space-guid-1":
 				{
@@ -93,7 +101,7 @@ var _ = Describe("PolicyGuard", func() {
 				}
 			}
 		}
-		fakeCCClient.GetUserSpaceStub = func(token, userGUID string, space api.Space) (*api.Space, error) {
+		fakeCCClient.GetUserSpaceStub = func(_ context.Context, token, userGUID string, space api.Space, roles []string) (*api.Space, error) {
 			switch space {
 			case space1:
 				{
@@ -115,65 +123,37 @@ var _ = Describe("PolicyGuard", func() {
 		}
 	})
 
-	Describe("CheckEgressPolicyListAccess", func() {
-		Context("when the user is network.admin", func() {
-			BeforeEach(func() {
-				tokenData = uaa_client.CheckTokenResponse{
-					Scope: []string{"network.admin"},
-				}
-			})
-			It("returns true", func() {
-				authorized := policyGuard.CheckEgressPolicyListAccess(tokenData)
-				Expect(fakeUAAClient.GetTokenCallCount()).To(Equal(0))
-				Expect(authorized).To(BeTrue())
-			})
-		})
-
-		Context("when the user is not network.admin", func() {
-			BeforeEach(func() {
-				tokenData = uaa_client.CheckTokenResponse{
-					Scope: []string{"not-network.not-admin"},
-				}
-			})
-			It("returns false", func() {
-				authorized := policyGuard.CheckEgressPolicyListAccess(tokenData)
-				Expect(fakeUAAClient.GetTokenCallCount()).To(Equal(0))
-				Expect(authorized).To(BeFalse())
-			})
-		})
-
-	})
-
 	Describe("CheckAccess", func() {
 
 		It("checks that the user can access all apps references in policies", func() {
-			authorized, err := policyGuard.CheckAccess(policyCollection, tokenData)
+			authorized, err := policyGuard.CheckAccess(context.Background(), policyCollection, tokenData)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(fakeUAAClient.GetTokenCallCount()).To(Equal(1))
 			Expect(fakeCCClient.GetSpaceGUIDsCallCount()).To(Equal(1))
-			token, appGUIDs := fakeCCClient.GetSpaceGUIDsArgsForCall(0)
+			_, token, appGUIDs := fakeCCClient.GetSpaceGUIDsArgsForCall(0)
 			Expect(token).To(Equal("policy-server-token"))
 			Expect(appGUIDs).To(ConsistOf([]string{"some-app-guid", "some-other-guid", "yet-another-guid"}))
 			Expect(fakeCCClient.GetSpaceCallCount()).To(Equal(3))
-			token, guid := fakeCCClient.GetSpaceArgsForCall(0)
+			_, token, guid := fakeCCClient.GetSpaceArgsForCall(0)
 			Expect(token).To(Equal("policy-server-token"))
 			Expect(guid).To(Equal("space-guid-1"))
-			token, guid = fakeCCClient.GetSpaceArgsForCall(1)
+			_, token, guid = fakeCCClient.GetSpaceArgsForCall(1)
 			Expect(token).To(Equal("policy-server-token"))
 			Expect(guid).To(Equal("space-guid-2"))
-			token, guid = fakeCCClient.GetSpaceArgsForCall(2)
+			_, token, guid = fakeCCClient.GetSpaceArgsForCall(2)
 			Expect(token).To(Equal("policy-server-token"))
 			Expect(guid).To(Equal("space-guid-3"))
 			Expect(fakeCCClient.GetUserSpaceCallCount()).To(Equal(3))
-			token, userGUID, checkUserSpace := fakeCCClient.GetUserSpaceArgsForCall(0)
+			_, token, userGUID, checkUserSpace, roles := fakeCCClient.GetUserSpaceArgsForCall(0)
 			Expect(token).To(Equal("policy-server-token"))
 			Expect(userGUID).To(Equal("some-developer-guid"))
 			Expect(checkUserSpace).To(Equal(space1))
-			token, userGUID, checkUserSpace = fakeCCClient.GetUserSpaceArgsForCall(1)
+			Expect(roles).To(Equal([]string{"space_developer"}))
+			_, token, userGUID, checkUserSpace, _ = fakeCCClient.GetUserSpaceArgsForCall(1)
 			Expect(token).To(Equal("policy-server-token"))
 			Expect(userGUID).To(Equal("some-developer-guid"))
 			Expect(checkUserSpace).To(Equal(space2))
-			token, userGUID, checkUserSpace = fakeCCClient.GetUserSpaceArgsForCall(2)
+			_, token, userGUID, checkUserSpace, _ = fakeCCClient.GetUserSpaceArgsForCall(2)
 			Expect(token).To(Equal("policy-server-token"))
 			Expect(userGUID).To(Equal("some-developer-guid"))
 			Expect(checkUserSpace).To(Equal(space3))
@@ -202,7 +182,7 @@ var _ = Describe("PolicyGuard", func() {
 				})
 
 				It("returns successfully without making extra calls to UAA or CC", func() {
-					authorized, err := policyGuard.CheckAccess(policyCollection, tokenData)
+					authorized, err := policyGuard.CheckAccess(context.Background(), policyCollection, tokenData)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(fakeUAAClient.GetTokenCallCount()).To(Equal(0))
 					Expect(fakeCCClient.GetSpaceCallCount()).To(Equal(0))
@@ -213,7 +193,7 @@ var _ = Describe("PolicyGuard", func() {
 
 			Context("when the token does not have network.admin scope", func() {
 				It("returns false", func() {
-					authorized, err := policyGuard.CheckAccess(policyCollection, tokenData)
+					authorized, err := policyGuard.CheckAccess(context.Background(), policyCollection, tokenData)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(fakeUAAClient.GetTokenCallCount()).To(Equal(0))
 					Expect(fakeCCClient.GetSpaceGUIDsCallCount()).To(Equal(0))
@@ -221,6 +201,51 @@ var _ = Describe("PolicyGuard", func() {
 					Expect(authorized).To(BeFalse())
 				})
 			})
+
+			Context("when cc roles are allowed to create egress policies", func() {
+				BeforeEach(func() {
+					policyGuard.Egress.CCRoles = []string{"space_manager"}
+				})
+
+				It("checks that the user has one of the roles in the space of the source app", func() {
+					authorized, err := policyGuard.CheckAccess(context.Background(), policyCollection, tokenData)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(authorized).To(BeTrue())
+
+					_, _, appGUIDs := fakeCCClient.GetSpaceGUIDsArgsForCall(0)
+					Expect(appGUIDs).To(Equal([]string{"some-app-guid"}))
+					_, _, _, _, roles := fakeCCClient.GetUserSpaceArgsForCall(0)
+					Expect(roles).To(Equal([]string{"space_manager"}))
+				})
+			})
+		})
+
+		Context("when the permission has no cc roles", func() {
+			BeforeEach(func() {
+				policyGuard.C2C.CCRoles = nil
+			})
+
+			It("returns false without making calls to UAA or CC", func() {
+				authorized, err := policyGuard.CheckAccess(context.Background(), policyCollection, tokenData)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(authorized).To(BeFalse())
+				Expect(fakeUAAClient.GetTokenCallCount()).To(Equal(0))
+				Expect(fakeCCClient.GetSpaceGUIDsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the token has one of the permission's scopes", func() {
+			BeforeEach(func() {
+				policyGuard.C2C.Scopes = []string{"network.admin", "network.policy-manager"}
+				tokenData.Scope = []string{"network.policy-manager"}
+			})
+
+			It("returns true without making calls to UAA or CC", func() {
+				authorized, err := policyGuard.CheckAccess(context.Background(), policyCollection, tokenData)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(authorized).To(BeTrue())
+				Expect(fakeUAAClient.GetTokenCallCount()).To(Equal(0))
+			})
 		})
 
 		Context("when the token has network.admin scope", func() {
@@ -230,7 +255,7 @@ var _ = Describe("PolicyGuard", func() {
 				}
 			})
 			It("returns successfully without making extra calls to UAA or CC", func() {
-				authorized, err := policyGuard.CheckAccess(policyCollection, tokenData)
+				authorized, err := policyGuard.CheckAccess(context.Background(), policyCollection, tokenData)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(fakeUAAClient.GetTokenCallCount()).To(Equal(0))
 				Expect(fakeCCClient.GetSpaceCallCount()).To(Equal(0))
@@ -239,12 +264,96 @@ var _ = Describe("PolicyGuard", func() {
 			})
 		})
 
+		Context("when the token is a client credentials token", func() {
+			BeforeEach(func() {
+				tokenData = uaa_client.CheckTokenResponse{
+					ClientID: "some-pipeline",
+				}
+			})
+
+			It("returns false without making calls to UAA or CC", func() {
+				authorized, err := policyGuard.CheckAccess(context.Background(), policyCollection, tokenData)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(authorized).To(BeFalse())
+				Expect(fakeUAAClient.GetTokenCallCount()).To(Equal(0))
+				Expect(fakeCCClient.GetSpaceGUIDsCallCount()).To(Equal(0))
+			})
+
+			Context("when the client is a service account", func() {
+				BeforeEach(func() {
+					policyGuard.ServiceAccounts = handlers.NewServiceAccounts([]handlers.ServiceAccount{{
+						ClientID:   "some-pipeline",
+						OrgGUIDs:   []string{"org-guid-1", "org-guid-2"},
+						SpaceGUIDs: []string{"space-guid-3"},
+					}})
+				})
+
+				It("checks that the spaces of all apps are allowed by its orgs or spaces", func() {
+					authorized, err := policyGuard.CheckAccess(context.Background(), policyCollection, tokenData)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(authorized).To(BeTrue())
+
+					Expect(fakeCCClient.GetSpaceCallCount()).To(Equal(2))
+					Expect(fakeCCClient.GetUserSpaceCallCount()).To(Equal(0))
+				})
+
+				Context("when one of the spaces is not allowed", func() {
+					BeforeEach(func() {
+						space2.OrgGUID = "some-other-org-guid"
+					})
+
+					It("returns false", func() {
+						authorized, err := policyGuard.CheckAccess(context.Background(), policyCollection, tokenData)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(authorized).To(BeFalse())
+					})
+				})
+
+				Context("when one of the spaces no longer exists", func() {
+					BeforeEach(func() {
+						fakeCCClient.GetSpaceReturns(nil, nil)
+					})
+
+					It("returns false", func() {
+						authorized, err := policyGuard.CheckAccess(context.Background(), policyCollection, tokenData)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(authorized).To(BeFalse())
+					})
+				})
+
+				Context("when getting one of the spaces fails", func() {
+					BeforeEach(func() {
+						fakeCCClient.GetSpaceReturns(nil, errors.New("banana"))
+					})
+
+					It("returns a useful error", func() {
+						authorized, err := policyGuard.CheckAccess(context.Background(), policyCollection, tokenData)
+						Expect(err).To(MatchError(ContainSubstring("getting space with guid space-guid-")))
+						Expect(err).To(MatchError(ContainSubstring("banana")))
+						Expect(authorized).To(BeFalse())
+					})
+				})
+
+				Context("when the permission has no cc roles", func() {
+					BeforeEach(func() {
+						policyGuard.C2C.CCRoles = nil
+					})
+
+					It("returns false", func() {
+						authorized, err := policyGuard.CheckAccess(context.Background(), policyCollection, tokenData)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(authorized).To(BeFalse())
+					})
+				})
+			})
+		})
+
 		Context("when the getting one of the the spaces returns nil", func() {
 			BeforeEach(func() {
 				fakeCCClient.GetSpaceReturns(nil, nil)
 			})
 			It("returns false", func() {
-				authorized, err := policyGuard.CheckAccess(policyCollection, tokenData)
+				authorized, err := policyGuard.CheckAccess(context.Background(), policyCollection, tokenData)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(authorized).To(BeFalse())
 			})
@@ -255,7 +364,7 @@ var _ = Describe("PolicyGuard", func() {
 				fakeCCClient.GetUserSpaceReturns(nil, nil)
 			})
 			It("returns false", func() {
-				authorized, err := policyGuard.CheckAccess(policyCollection, tokenData)
+				authorized, err := policyGuard.CheckAccess(context.Background(), policyCollection, tokenData)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(authorized).To(BeFalse())
 			})
@@ -266,7 +375,7 @@ var _ = Describe("PolicyGuard", func() {
 				fakeUAAClient.GetTokenReturns("", errors.New("banana"))
 			})
 			It("returns a useful error", func() {
-				authorized, err := policyGuard.CheckAccess(policyCollection, tokenData)
+				authorized, err := policyGuard.CheckAccess(context.Background(), policyCollection, tokenData)
 				Expect(err).To(MatchError("getting token: banana"))
 				Expect(authorized).To(BeFalse())
 			})
@@ -277,7 +386,7 @@ var _ = Describe("PolicyGuard", func() {
 				fakeCCClient.GetSpaceGUIDsReturns(nil, errors.New("banana"))
 			})
 			It("returns a useful error", func() {
-				authorized, err := policyGuard.CheckAccess(policyCollection, tokenData)
+				authorized, err := policyGuard.CheckAccess(context.Background(), policyCollection, tokenData)
 				Expect(err).To(MatchError("getting space guids: banana"))
 				Expect(authorized).To(BeFalse())
 			})
@@ -288,7 +397,7 @@ var _ = Describe("PolicyGuard", func() {
 				fakeCCClient.GetSpaceReturns(nil, errors.New("banana"))
 			})
 			It("returns a useful error", func() {
-				authorized, err := policyGuard.CheckAccess(policyCollection, tokenData)
+				authorized, err := policyGuard.CheckAccess(context.Background(), policyCollection, tokenData)
 				Expect(err).To(MatchError("getting space with guid space-guid-1: banana"))
 				Expect(authorized).To(BeFalse())
 			})
@@ -299,7 +408,7 @@ var _ = Describe("PolicyGuard", func() {
 				fakeCCClient.GetUserSpaceReturns(nil, errors.New("banana"))
 			})
 			It("returns a useful error", func() {
-				authorized, err := policyGuard.CheckAccess(policyCollection, tokenData)
+				authorized, err := policyGuard.CheckAccess(context.Background(), policyCollection, tokenData)
 				Expect(err).To(MatchError("getting space with guid space-guid-1: banana"))
 				Expect(authorized).To(BeFalse())
 			})
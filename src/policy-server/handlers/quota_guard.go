@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
 	"policy-server/store"
 	"policy-server/uaa_client"
@@ -11,8 +12,10 @@ type QuotaGuard struct {
 	MaxPolicies int
 }
 
+//go:generate counterfeiter -o fakes/policy_store.go --fake-name PolicyStore . policyStore
 type policyStore interface {
-	ByGuids([]string, []string, bool) ([]store.Policy, error)
+	All(context.Context) ([]store.Policy, error)
+	ByGuids(context.Context, []string, []string, bool) ([]store.Policy, error)
 }
 
 func NewQuotaGuard(store policyStore, maxPolicies int) *QuotaGuard {
@@ -22,7 +25,7 @@ func NewQuotaGuard(store policyStore, maxPolicies int) *QuotaGuard {
 	}
 }
 
-func (g *QuotaGuard) CheckAccess(policyCollection store.PolicyCollection, userToken uaa_client.CheckTokenResponse) (bool, error) {
+func (g *QuotaGuard) CheckAccess(ctx context.Context, policyCollection store.PolicyCollection, userToken uaa_client.CheckTokenResponse) (bool, error) {
 	for _, scope := range userToken.Scope {
 		if scope == "network.admin" {
 			return true, nil
@@ -35,7 +38,7 @@ func (g *QuotaGuard) CheckAccess(policyCollection store.PolicyCollection, userTo
 
 	appGuids := uniqueAppGUIDs(policyCollection.Policies)
 	toAddSourceCounts := sourceCounts(policyCollection.Policies, appGuids)
-	sourcePolicies, err := g.Store.ByGuids(appGuids, []string{}, false)
+	sourcePolicies, err := g.Store.ByGuids(ctx, appGuids, []string{}, false)
 	if err != nil {
 		return false, fmt.Errorf("getting policies: %s", err)
 	}
@@ -1,10 +1,11 @@
 package handlers_test
 
 import (
+	"context"
 	"errors"
 	"policy-server/handlers"
+	"policy-server/handlers/fakes"
 	"policy-server/store"
-	"policy-server/store/fakes"
 	"policy-server/uaa_client"
 
 	. "github.com/onsi/ginkgo"
@@ -14,12 +15,12 @@ import (
 var _ = Describe("QuotaGuard", func() {
 	var (
 		quotaGuard       *handlers.QuotaGuard
-		fakeStore        *fakes.Store
+		fakeStore        *fakes.PolicyStore
 		policyCollection store.PolicyCollection
 		tokenData        uaa_client.CheckTokenResponse
 	)
 	BeforeEach(func() {
-		fakeStore = &fakes.Store{}
+		fakeStore = &fakes.PolicyStore{}
 		quotaGuard = &handlers.QuotaGuard{
 			Store:       fakeStore,
 			MaxPolicies: 2,
@@ -59,7 +60,7 @@ var _ = Describe("QuotaGuard", func() {
 			})
 
 			It("denies policy creation", func() {
-				authorized, err := quotaGuard.CheckAccess(policyCollection, tokenData)
+				authorized, err := quotaGuard.CheckAccess(context.Background(), policyCollection, tokenData)
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(authorized).To(BeFalse())
@@ -68,7 +69,7 @@ var _ = Describe("QuotaGuard", func() {
 
 		Context("when the additional policies do not exceed the quota", func() {
 			It("allows policy creation", func() {
-				authorized, err := quotaGuard.CheckAccess(policyCollection, tokenData)
+				authorized, err := quotaGuard.CheckAccess(context.Background(), policyCollection, tokenData)
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(authorized).To(BeTrue())
@@ -88,7 +89,7 @@ var _ = Describe("QuotaGuard", func() {
 				}, nil)
 			})
 			It("does not allow policy creation", func() {
-				authorized, err := quotaGuard.CheckAccess(policyCollection, tokenData)
+				authorized, err := quotaGuard.CheckAccess(context.Background(), policyCollection, tokenData)
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(authorized).To(BeFalse())
@@ -99,7 +100,7 @@ var _ = Describe("QuotaGuard", func() {
 				fakeStore.ByGuidsReturns([]store.Policy{}, errors.New("banana"))
 			})
 			It("returns an error", func() {
-				_, err := quotaGuard.CheckAccess(policyCollection, tokenData)
+				_, err := quotaGuard.CheckAccess(context.Background(), policyCollection, tokenData)
 				Expect(err).To(MatchError("getting policies: banana"))
 			})
 
@@ -124,7 +125,7 @@ var _ = Describe("QuotaGuard", func() {
 			}, nil)
 		})
 		It("allows policy creation beyond the max policies", func() {
-			authorized, err := quotaGuard.CheckAccess(policyCollection, tokenData)
+			authorized, err := quotaGuard.CheckAccess(context.Background(), policyCollection, tokenData)
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(authorized).To(BeTrue())
@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"policy-server/uaa_client"
+	"strconv"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+//go:generate counterfeiter -o fakes/metrics_sender.go --fake-name MetricsSender . metricsSender
+type metricsSender interface {
+	IncrementCounter(string)
+}
+
+// RateLimit allows Rate requests per second on average, and bursts of up to
+// Burst requests. A zero Rate disables the limit.
+type RateLimit struct {
+	Rate  int
+	Burst int
+}
+
+func (l RateLimit) enabled() bool {
+	return l.Rate > 0
+}
+
+func (l RateLimit) burst() float64 {
+	if l.Burst < 1 {
+		return float64(l.Rate)
+	}
+	return float64(l.Burst)
+}
+
+// RateLimiter limits requests with token buckets, one for each user and one
+// shared by all requests. WrapGlobal takes from the shared bucket and goes in
+// front of the Authenticator, so a flood of requests is rejected without
+// checking their tokens. Wrap takes from the user's bucket and must go behind
+// the Authenticator, since users are told apart by their token data. Client
+// credentials tokens are limited per client.
+//
+// Rejected requests get a 429 with a Retry-After header, and increment the
+// UserRateLimitExceeded or GlobalRateLimitExceeded counter.
+type RateLimiter struct {
+	UserLimit     RateLimit
+	GlobalLimit   RateLimit
+	MetricsSender metricsSender
+	ErrorResponse errorResponse
+	Now           func() time.Time
+
+	mutex     sync.Mutex
+	global    tokenBucket
+	users     map[string]*tokenBucket
+	nextSweep time.Time
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// globalTokenKey marks requests that took a token from the global bucket,
+// so that it can be given back if the user's bucket rejects them.
+const globalTokenKey = Key("globalRateLimitToken")
+
+func (r *RateLimiter) WrapGlobal(handle http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		retryAfter, took := r.takeGlobal()
+		if retryAfter > 0 {
+			r.reject(w, req, "", "GlobalRateLimitExceeded", retryAfter)
+			return
+		}
+
+		if took {
+			req = req.WithContext(context.WithValue(req.Context(), globalTokenKey, true))
+		}
+		handle.ServeHTTP(w, req)
+	})
+}
+
+func (r *RateLimiter) Wrap(handle http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		user := rateLimitKey(getTokenData(req))
+
+		_, tookGlobal := req.Context().Value(globalTokenKey).(bool)
+		retryAfter := r.takeUser(user, tookGlobal)
+		if retryAfter > 0 {
+			r.reject(w, req, user, "UserRateLimitExceeded", retryAfter)
+			return
+		}
+
+		handle.ServeHTTP(w, req)
+	})
+}
+
+func (r *RateLimiter) reject(w http.ResponseWriter, req *http.Request, user, counter string, retryAfter time.Duration) {
+	logger := getLogger(req)
+	logger = logger.Session("rate-limit")
+	logger.Info("request-rejected", lager.Data{"user": user, "limit": counter})
+
+	r.MetricsSender.IncrementCounter(counter)
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	r.ErrorResponse.TooManyRequests(logger, w, nil, "rate limit exceeded")
+}
+
+// takeGlobal takes a token from the global bucket. If it is empty it returns
+// how long until a token is available. It also reports whether a token was
+// taken, which is not the case when the limit is disabled.
+func (r *RateLimiter) takeGlobal() (time.Duration, bool) {
+	if !r.GlobalLimit.enabled() {
+		return 0, false
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := r.now()
+	if r.global.last.IsZero() {
+		r.global = tokenBucket{tokens: r.GlobalLimit.burst(), last: now}
+	}
+	wait := r.global.take(r.GlobalLimit, now)
+	return wait, wait == 0
+}
+
+// takeUser takes a token from the user's bucket. If it is empty it returns
+// how long until a token is available, and gives back the global token the
+// request took, so that a user over their limit does not use up everyone
+// else's requests.
+func (r *RateLimiter) takeUser(user string, tookGlobal bool) time.Duration {
+	if !r.UserLimit.enabled() || user == "" {
+		return 0
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := r.now()
+	r.sweep(now)
+
+	if r.users == nil {
+		r.users = map[string]*tokenBucket{}
+	}
+	userBucket := r.users[user]
+	if userBucket == nil {
+		userBucket = &tokenBucket{tokens: r.UserLimit.burst(), last: now}
+		r.users[user] = userBucket
+	}
+
+	wait := userBucket.take(r.UserLimit, now)
+	if wait > 0 && tookGlobal {
+		r.global.tokens = math.Min(r.GlobalLimit.burst(), r.global.tokens+1)
+	}
+	return wait
+}
+
+// sweep drops the buckets of users that are full again, since a new bucket
+// would be the same.
+func (r *RateLimiter) sweep(now time.Time) {
+	if now.Before(r.nextSweep) {
+		return
+	}
+	for user, bucket := range r.users {
+		bucket.refill(r.UserLimit, now)
+		if bucket.tokens >= r.UserLimit.burst() {
+			delete(r.users, user)
+		}
+	}
+	r.nextSweep = now.Add(time.Minute)
+}
+
+func (r *RateLimiter) now() time.Time {
+	if r.Now != nil {
+		return r.Now()
+	}
+	return time.Now()
+}
+
+func (b *tokenBucket) refill(limit RateLimit, now time.Time) {
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(limit.burst(), b.tokens+elapsed*float64(limit.Rate))
+		b.last = now
+	}
+}
+
+func (b *tokenBucket) take(limit RateLimit, now time.Time) time.Duration {
+	b.refill(limit, now)
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / float64(limit.Rate) * float64(time.Second))
+}
+
+func rateLimitKey(userToken uaa_client.CheckTokenResponse) string {
+	if userToken.UserID != "" {
+		return userToken.UserID
+	}
+	if userToken.ClientID != "" {
+		return "client:" + userToken.ClientID
+	}
+	return ""
+}
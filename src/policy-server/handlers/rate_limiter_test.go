@@ -0,0 +1,162 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"policy-server/handlers"
+	"policy-server/handlers/fakes"
+	"policy-server/uaa_client"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RateLimiter", func() {
+	var (
+		rateLimiter       *handlers.RateLimiter
+		fakeMetricsSender *fakes.MetricsSender
+		fakeErrorResponse *fakes.ErrorResponse
+		limited           http.Handler
+		now               time.Time
+		handlerCallCount  int
+		userLimitCalls    int
+	)
+
+	BeforeEach(func() {
+		now = time.Unix(1500000000, 0)
+		fakeMetricsSender = &fakes.MetricsSender{}
+		fakeErrorResponse = &fakes.ErrorResponse{}
+		fakeErrorResponse.TooManyRequestsStub = func(logger lager.Logger, w http.ResponseWriter, err error, description string) {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+		rateLimiter = &handlers.RateLimiter{
+			UserLimit:     handlers.RateLimit{Rate: 1, Burst: 2},
+			GlobalLimit:   handlers.RateLimit{Rate: 10, Burst: 3},
+			MetricsSender: fakeMetricsSender,
+			ErrorResponse: fakeErrorResponse,
+			Now:           func() time.Time { return now },
+		}
+		handlerCallCount = 0
+		userLimitCalls = 0
+		userLimited := rateLimiter.Wrap(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			handlerCallCount++
+			w.WriteHeader(http.StatusOK)
+		}))
+		// stands in for the Authenticator between the two limits
+		limited = rateLimiter.WrapGlobal(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			userLimitCalls++
+			userLimited.ServeHTTP(w, req)
+		}))
+	})
+
+	makeRequest := func(tokenData uaa_client.CheckTokenResponse) *httptest.ResponseRecorder {
+		request, err := http.NewRequest("GET", "/networking/v1/external/policies", nil)
+		Expect(err).NotTo(HaveOccurred())
+		request = request.WithContext(context.WithValue(request.Context(), handlers.TokenDataKey, tokenData))
+
+		resp := httptest.NewRecorder()
+		limited.ServeHTTP(resp, request)
+		return resp
+	}
+
+	user1 := uaa_client.CheckTokenResponse{UserID: "user-1"}
+	user2 := uaa_client.CheckTokenResponse{UserID: "user-2"}
+
+	It("allows each user a burst of requests, then rejects them with a 429", func() {
+		Expect(makeRequest(user1).Code).To(Equal(http.StatusOK))
+		Expect(makeRequest(user1).Code).To(Equal(http.StatusOK))
+
+		resp := makeRequest(user1)
+		Expect(resp.Code).To(Equal(http.StatusTooManyRequests))
+		Expect(resp.Header().Get("Retry-After")).To(Equal("1"))
+		Expect(handlerCallCount).To(Equal(2))
+
+		Expect(fakeErrorResponse.TooManyRequestsCallCount()).To(Equal(1))
+		_, _, err, description := fakeErrorResponse.TooManyRequestsArgsForCall(0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(description).To(Equal("rate limit exceeded"))
+
+		Expect(fakeMetricsSender.IncrementCounterCallCount()).To(Equal(1))
+		Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("UserRateLimitExceeded"))
+
+		Expect(makeRequest(user2).Code).To(Equal(http.StatusOK))
+	})
+
+	It("refills the buckets over time", func() {
+		makeRequest(user1)
+		makeRequest(user1)
+		Expect(makeRequest(user1).Code).To(Equal(http.StatusTooManyRequests))
+
+		now = now.Add(time.Second)
+		Expect(makeRequest(user1).Code).To(Equal(http.StatusOK))
+		Expect(makeRequest(user1).Code).To(Equal(http.StatusTooManyRequests))
+	})
+
+	It("limits client credentials tokens per client", func() {
+		client := uaa_client.CheckTokenResponse{ClientID: "some-pipeline"}
+		makeRequest(client)
+		makeRequest(client)
+		Expect(makeRequest(client).Code).To(Equal(http.StatusTooManyRequests))
+	})
+
+	Context("when the global limit is reached", func() {
+		It("rejects requests from all users", func() {
+			makeRequest(user1)
+			makeRequest(user2)
+			makeRequest(uaa_client.CheckTokenResponse{UserID: "user-3"})
+
+			resp := makeRequest(uaa_client.CheckTokenResponse{UserID: "user-4"})
+			Expect(resp.Code).To(Equal(http.StatusTooManyRequests))
+			Expect(resp.Header().Get("Retry-After")).To(Equal("1"))
+			Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("GlobalRateLimitExceeded"))
+			Expect(fakeErrorResponse.TooManyRequestsCallCount()).To(Equal(1))
+		})
+
+		It("rejects requests before they reach the authenticator", func() {
+			makeRequest(user1)
+			makeRequest(user2)
+			makeRequest(uaa_client.CheckTokenResponse{UserID: "user-3"})
+			Expect(userLimitCalls).To(Equal(3))
+
+			Expect(makeRequest(uaa_client.CheckTokenResponse{UserID: "user-4"}).Code).To(Equal(http.StatusTooManyRequests))
+			Expect(userLimitCalls).To(Equal(3))
+		})
+
+		It("does not use up the user's requests", func() {
+			makeRequest(user2)
+			makeRequest(user2)
+			makeRequest(user1)
+			Expect(makeRequest(user1).Code).To(Equal(http.StatusTooManyRequests))
+
+			now = now.Add(time.Second / 10)
+			Expect(makeRequest(user1).Code).To(Equal(http.StatusOK))
+		})
+	})
+
+	Context("when a user is over their limit", func() {
+		It("gives the global token back", func() {
+			makeRequest(user1)
+			makeRequest(user1)
+			Expect(makeRequest(user1).Code).To(Equal(http.StatusTooManyRequests))
+
+			Expect(makeRequest(user2).Code).To(Equal(http.StatusOK))
+		})
+	})
+
+	Context("when the limits are zero", func() {
+		BeforeEach(func() {
+			rateLimiter.UserLimit = handlers.RateLimit{}
+			rateLimiter.GlobalLimit = handlers.RateLimit{}
+		})
+
+		It("does not limit requests", func() {
+			for i := 0; i < 10; i++ {
+				Expect(makeRequest(user1).Code).To(Equal(http.StatusOK))
+			}
+			Expect(fakeMetricsSender.IncrementCounterCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"policy-server/uaa_client"
+)
+
+// ServiceAccount lets a UAA client, whose client credentials tokens carry no
+// user id, do what a cc role allows for apps in the listed orgs and spaces.
+type ServiceAccount struct {
+	ClientID   string
+	OrgGUIDs   []string
+	SpaceGUIDs []string
+}
+
+// ServiceAccounts are keyed by client id.
+type ServiceAccounts map[string]ServiceAccount
+
+func NewServiceAccounts(serviceAccounts []ServiceAccount) ServiceAccounts {
+	accounts := ServiceAccounts{}
+	for _, account := range serviceAccounts {
+		accounts[account.ClientID] = account
+	}
+	return accounts
+}
+
+// lookup returns the service account for a client credentials token.
+func (s ServiceAccounts) lookup(userToken uaa_client.CheckTokenResponse) (ServiceAccount, bool) {
+	if !isClientToken(userToken) {
+		return ServiceAccount{}, false
+	}
+	account, ok := s[userToken.ClientID]
+	return account, ok
+}
+
+// allowedSpaces returns those of spaceGUIDs that the service account may
+// manage. Spaces that are not listed directly are looked up to check their org.
+func (a ServiceAccount) allowedSpaces(ctx context.Context, ccClient ccClient, token string, spaceGUIDs []string) (map[string]struct{}, error) {
+	allowed := map[string]struct{}{}
+	for _, guid := range spaceGUIDs {
+		if contains(a.SpaceGUIDs, guid) {
+			allowed[guid] = struct{}{}
+			continue
+		}
+		if len(a.OrgGUIDs) == 0 {
+			continue
+		}
+
+		space, err := ccClient.GetSpace(ctx, token, guid)
+		if err != nil {
+			return nil, fmt.Errorf("getting space with guid %s: %s", guid, err)
+		}
+		if space != nil && contains(a.OrgGUIDs, space.OrgGUID) {
+			allowed[guid] = struct{}{}
+		}
+	}
+	return allowed, nil
+}
+
+func isClientToken(userToken uaa_client.CheckTokenResponse) bool {
+	return userToken.UserID == "" && userToken.ClientID != ""
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
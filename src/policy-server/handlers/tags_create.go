@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -11,7 +12,7 @@ import (
 
 //go:generate counterfeiter -o fakes/create_tag_store.go --fake-name CreateTagDataStore . createTagDataStore
 type createTagDataStore interface {
-	CreateTag(string, string) (store.Tag, error)
+	CreateTag(context.Context, string, string) (store.Tag, error)
 }
 
 type TagsCreate struct {
@@ -29,9 +30,8 @@ func (h *TagsCreate) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	logger = logger.Session("create-tags")
 
 	bodyBytes, err := ioutil.ReadAll(req.Body)
-
 	if err != nil {
-		h.ErrorResponse.BadRequest(logger, w, err, "failed reading request body")
+		writeBodyReadError(logger, w, h.ErrorResponse, err, "failed reading request body")
 		return
 	}
 
@@ -42,7 +42,15 @@ func (h *TagsCreate) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	tag, err := h.Store.CreateTag(grp.GroupGuid, grp.GroupType)
+	tag, err := h.Store.CreateTag(req.Context(), grp.GroupGuid, grp.GroupType)
+	if err == store.ErrTagSpaceExhausted {
+		h.ErrorResponse.InsufficientStorage(logger, w, err, "database create failed: tag space exhausted")
+		return
+	}
+	if err == store.ErrTagsQuarantined {
+		h.ErrorResponse.ServiceUnavailable(logger, w, err, "database create failed: all free tags are quarantined, try again later")
+		return
+	}
 	if err != nil {
 		h.ErrorResponse.InternalServerError(logger, w, err, "database create failed")
 		return
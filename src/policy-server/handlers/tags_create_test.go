@@ -64,7 +64,7 @@ var _ = Describe("Tags index handler", func() {
 		Expect(fakeStore.CreateTagCallCount()).To(Equal(1))
 		Expect(resp.Code).To(Equal(http.StatusOK))
 
-		groupGuid, groupType := fakeStore.CreateTagArgsForCall(0)
+		_, groupGuid, groupType := fakeStore.CreateTagArgsForCall(0)
 		Expect(groupGuid).To(Equal(expectedGroupGuid))
 		Expect(groupType).To(Equal(expectedGroupType))
 
@@ -112,6 +112,21 @@ var _ = Describe("Tags index handler", func() {
 		})
 	})
 
+	Context("when the body is larger than the limit", func() {
+		BeforeEach(func() {
+			request.ContentLength = -1
+		})
+
+		It("responds with a 413", func() {
+			bodyLimiter := &handlers.BodyLimiter{MaxBytes: 4, ErrorResponse: fakeErrorResponse}
+			MakeRequestWithLogger(bodyLimiter.Wrap(handler).ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.RequestEntityTooLargeCallCount()).To(Equal(1))
+			Expect(fakeErrorResponse.BadRequestCallCount()).To(Equal(0))
+			Expect(fakeStore.CreateTagCallCount()).To(Equal(0))
+		})
+	})
+
 	Context("when CreateTag fails", func() {
 		BeforeEach(func() {
 			fakeStore.CreateTagReturns(store.Tag{}, errors.New("meow meow"))
@@ -128,5 +143,33 @@ var _ = Describe("Tags index handler", func() {
 			Expect(err).To(MatchError("meow meow"))
 			Expect(description).To(Equal("database create failed"))
 		})
+
+		Context("when there are no tags left to allocate", func() {
+			BeforeEach(func() {
+				fakeStore.CreateTagReturns(store.Tag{}, store.ErrTagSpaceExhausted)
+			})
+
+			It("says so in the error description", func() {
+				MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+				Expect(fakeErrorResponse.InsufficientStorageCallCount()).To(Equal(1))
+				_, _, _, description := fakeErrorResponse.InsufficientStorageArgsForCall(0)
+				Expect(description).To(Equal("database create failed: tag space exhausted"))
+			})
+		})
+
+		Context("when every free tag is still in quarantine", func() {
+			BeforeEach(func() {
+				fakeStore.CreateTagReturns(store.Tag{}, store.ErrTagsQuarantined)
+			})
+
+			It("asks the caller to try again later", func() {
+				MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+				Expect(fakeErrorResponse.ServiceUnavailableCallCount()).To(Equal(1))
+				_, _, _, description := fakeErrorResponse.ServiceUnavailableArgsForCall(0)
+				Expect(description).To(Equal("database create failed: all free tags are quarantined, try again later"))
+			})
+		})
 	})
 })
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"policy-server/api"
+	"policy-server/store"
+
+	"code.cloudfoundry.org/lager"
+)
+
+//go:generate counterfeiter -o fakes/delete_tag_store.go --fake-name DeleteTagDataStore . deleteTagDataStore
+type deleteTagDataStore interface {
+	TagsByGuids(context.Context, []string) ([]store.Tag, error)
+	ReleaseTags(context.Context, []store.Tag) ([]store.Tag, error)
+}
+
+// TagsDelete releases the tag of a guid so that it can be allocated again. A
+// tag that is still used by a policy is not released.
+type TagsDelete struct {
+	Store         deleteTagDataStore
+	RataAdapter   rataAdapter
+	ErrorResponse errorResponse
+}
+
+func (h *TagsDelete) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	logger := getLogger(req)
+	logger = logger.Session("delete-tag")
+	guid := h.RataAdapter.Param(req, "guid")
+
+	tags, err := h.Store.TagsByGuids(req.Context(), []string{guid})
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "database read failed")
+		return
+	}
+	if len(tags) == 0 {
+		h.ErrorResponse.NotFound(logger, w, nil, "tag not found")
+		return
+	}
+
+	released, err := h.Store.ReleaseTags(req.Context(), tags)
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "database delete failed")
+		return
+	}
+	if len(released) == 0 {
+		h.ErrorResponse.Conflict(logger, w, nil, "tag is in use by a policy")
+		return
+	}
+	if len(released) > 1 {
+		// a guid has a single tag, so releasing more than one means the
+		// groups table holds duplicate guids
+		err := fmt.Errorf("released %d tags for guid %s", len(released), guid)
+		h.ErrorResponse.InternalServerError(logger, w, err, "database delete failed")
+		return
+	}
+
+	tagJSON, err := json.Marshal(api.MapStoreTag(released[0]))
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "marshal response failed")
+		return
+	}
+
+	logger.Info("released-tag", lager.Data{"tag": released[0]})
+	w.WriteHeader(http.StatusOK)
+	w.Write(tagJSON)
+}
@@ -0,0 +1,156 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"policy-server/handlers"
+	"policy-server/handlers/fakes"
+	"policy-server/store"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Tags delete handler", func() {
+	var (
+		request           *http.Request
+		handler           *handlers.TagsDelete
+		resp              *httptest.ResponseRecorder
+		fakeStore         *fakes.DeleteTagDataStore
+		fakeRataAdapter   *fakes.RataAdapter
+		fakeErrorResponse *fakes.ErrorResponse
+		logger            *lagertest.TestLogger
+		expectedLogger    lager.Logger
+		tag               store.Tag
+	)
+
+	BeforeEach(func() {
+		var err error
+		request, err = http.NewRequest("DELETE", "/networking/v1/internal/tags/some-ingress-guid", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		tag = store.Tag{ID: "some-ingress-guid", Tag: "0003", Type: "ingress"}
+		fakeStore = &fakes.DeleteTagDataStore{}
+		fakeStore.TagsByGuidsReturns([]store.Tag{tag}, nil)
+		fakeStore.ReleaseTagsReturns([]store.Tag{tag}, nil)
+		fakeRataAdapter = &fakes.RataAdapter{}
+		fakeRataAdapter.ParamReturns("some-ingress-guid")
+		fakeErrorResponse = &fakes.ErrorResponse{}
+
+		logger = lagertest.NewTestLogger("test")
+		expectedLogger = lager.NewLogger("test").Session("delete-tag")
+		testSink := lagertest.NewTestSink()
+		expectedLogger.RegisterSink(testSink)
+		expectedLogger.RegisterSink(lager.NewWriterSink(GinkgoWriter, lager.DEBUG))
+
+		handler = &handlers.TagsDelete{
+			Store:         fakeStore,
+			RataAdapter:   fakeRataAdapter,
+			ErrorResponse: fakeErrorResponse,
+		}
+		resp = httptest.NewRecorder()
+	})
+
+	It("releases the tag of the guid", func() {
+		MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+		_, name := fakeRataAdapter.ParamArgsForCall(0)
+		Expect(name).To(Equal("guid"))
+		_, guids := fakeStore.TagsByGuidsArgsForCall(0)
+		Expect(guids).To(Equal([]string{"some-ingress-guid"}))
+		_, tags := fakeStore.ReleaseTagsArgsForCall(0)
+		Expect(tags).To(Equal([]store.Tag{tag}))
+
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(resp.Body).To(MatchJSON(`{"id": "some-ingress-guid", "tag": "0003", "type": "ingress"}`))
+		Expect(logger).To(gbytes.Say("released-tag"))
+	})
+
+	Context("when the guid has no tag", func() {
+		BeforeEach(func() {
+			fakeStore.TagsByGuidsReturns([]store.Tag{}, nil)
+		})
+
+		It("calls the not found handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeStore.ReleaseTagsCallCount()).To(Equal(0))
+			Expect(fakeErrorResponse.NotFoundCallCount()).To(Equal(1))
+			l, w, err, description := fakeErrorResponse.NotFoundArgsForCall(0)
+			Expect(l).To(Equal(expectedLogger))
+			Expect(w).To(Equal(resp))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(description).To(Equal("tag not found"))
+		})
+	})
+
+	Context("when the tag is still used by a policy", func() {
+		BeforeEach(func() {
+			fakeStore.ReleaseTagsReturns([]store.Tag{}, nil)
+		})
+
+		It("calls the conflict handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.ConflictCallCount()).To(Equal(1))
+			l, w, err, description := fakeErrorResponse.ConflictArgsForCall(0)
+			Expect(l).To(Equal(expectedLogger))
+			Expect(w).To(Equal(resp))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(description).To(Equal("tag is in use by a policy"))
+		})
+	})
+
+	Context("when more than one tag is released", func() {
+		BeforeEach(func() {
+			fakeStore.ReleaseTagsReturns([]store.Tag{tag, tag}, nil)
+		})
+
+		It("calls the internal server error handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+			l, w, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+			Expect(l).To(Equal(expectedLogger))
+			Expect(w).To(Equal(resp))
+			Expect(err).To(MatchError("released 2 tags for guid some-ingress-guid"))
+			Expect(description).To(Equal("database delete failed"))
+		})
+	})
+
+	Context("when looking up the tag fails", func() {
+		BeforeEach(func() {
+			fakeStore.TagsByGuidsReturns(nil, errors.New("banana"))
+		})
+
+		It("calls the internal server error handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+			l, w, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+			Expect(l).To(Equal(expectedLogger))
+			Expect(w).To(Equal(resp))
+			Expect(err).To(MatchError("banana"))
+			Expect(description).To(Equal("database read failed"))
+		})
+	})
+
+	Context("when releasing the tag fails", func() {
+		BeforeEach(func() {
+			fakeStore.ReleaseTagsReturns(nil, errors.New("banana"))
+		})
+
+		It("calls the internal server error handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+			_, _, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+			Expect(err).To(MatchError("banana"))
+			Expect(description).To(Equal("database delete failed"))
+		})
+	})
+})
@@ -1,23 +1,32 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 
 	"policy-server/api"
 
 	"code.cloudfoundry.org/cf-networking-helpers/marshal"
 	"policy-server/store"
+	"policy-server/uaa_client"
 )
 
+//go:generate counterfeiter -o fakes/tag_filter.go --fake-name TagFilter . tagFilter
+type tagFilter interface {
+	FilterTags(ctx context.Context, tags []store.Tag, userToken uaa_client.CheckTokenResponse) ([]store.Tag, error)
+}
+
 type TagsIndex struct {
 	Store         store.TagStore
+	TagFilter     tagFilter
 	Marshaler     marshal.Marshaler
 	ErrorResponse errorResponse
 }
 
-func NewTagsIndex(store store.TagStore, marshaler marshal.Marshaler, errorResponse errorResponse) *TagsIndex {
+func NewTagsIndex(store store.TagStore, tagFilter tagFilter, marshaler marshal.Marshaler, errorResponse errorResponse) *TagsIndex {
 	return &TagsIndex{
 		Store:         store,
+		TagFilter:     tagFilter,
 		Marshaler:     marshaler,
 		ErrorResponse: errorResponse,
 	}
@@ -26,12 +35,26 @@ func NewTagsIndex(store store.TagStore, marshaler marshal.Marshaler, errorRespon
 func (h *TagsIndex) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	logger := getLogger(req)
 	logger = logger.Session("index-tags")
-	tags, err := h.Store.Tags()
+
+	var tags []store.Tag
+	var err error
+	ids := parseIds(req.URL.Query())
+	if len(ids) > 0 {
+		tags, err = h.Store.TagsByGuids(ids)
+	} else {
+		tags, err = h.Store.Tags()
+	}
 	if err != nil {
 		h.ErrorResponse.InternalServerError(logger, w, err, "database read failed")
 		return
 	}
 
+	tags, err = h.TagFilter.FilterTags(req.Context(), tags, getTokenData(req))
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "filter tags failed")
+		return
+	}
+
 	tagsResponse := struct {
 		Tags []api.Tag `json:"tags"`
 	}{api.MapStoreTags(tags)}
@@ -1,6 +1,7 @@
 package handlers_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -9,6 +10,7 @@ import (
 	"policy-server/handlers/fakes"
 	"policy-server/store"
 	storeFakes "policy-server/store/fakes"
+	"policy-server/uaa_client"
 
 	hfakes "code.cloudfoundry.org/cf-networking-helpers/fakes"
 	"code.cloudfoundry.org/lager"
@@ -29,6 +31,7 @@ var _ = Describe("Tags index handler", func() {
 		logger            *lagertest.TestLogger
 		expectedLogger    lager.Logger
 		marshaler         *hfakes.Marshaler
+		fakeTagFilter     *fakes.TagFilter
 	)
 
 	BeforeEach(func() {
@@ -58,8 +61,13 @@ var _ = Describe("Tags index handler", func() {
 		testSink := lagertest.NewTestSink()
 		expectedLogger.RegisterSink(testSink)
 		expectedLogger.RegisterSink(lager.NewWriterSink(GinkgoWriter, lager.DEBUG))
+		fakeTagFilter = &fakes.TagFilter{}
+		fakeTagFilter.FilterTagsStub = func(_ context.Context, tags []store.Tag, userToken uaa_client.CheckTokenResponse) ([]store.Tag, error) {
+			return tags, nil
+		}
 		handler = &handlers.TagsIndex{
 			Store:         fakeStore,
+			TagFilter:     fakeTagFilter,
 			Marshaler:     marshaler,
 			ErrorResponse: fakeErrorResponse,
 		}
@@ -78,6 +86,78 @@ var _ = Describe("Tags index handler", func() {
 		Expect(resp.Body).To(MatchJSON(expectedResponseJSON))
 	})
 
+	It("only returns the tags the user may see", func() {
+		token := uaa_client.CheckTokenResponse{
+			Scope:  []string{"network.read"},
+			UserID: "some-user-id",
+		}
+		fakeTagFilter.FilterTagsStub = nil
+		fakeTagFilter.FilterTagsReturns(allTags[1:], nil)
+
+		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+		Expect(fakeTagFilter.FilterTagsCallCount()).To(Equal(1))
+		_, tags, userToken := fakeTagFilter.FilterTagsArgsForCall(0)
+		Expect(tags).To(Equal(allTags))
+		Expect(userToken).To(Equal(token))
+		Expect(resp.Body).To(MatchJSON(`{"tags": [
+			{ "id": "some-other-app-guid", "tag": "0002", "type": "app" }
+		]}`))
+	})
+
+	Context("when filtering the tags fails", func() {
+		BeforeEach(func() {
+			fakeTagFilter.FilterTagsStub = nil
+			fakeTagFilter.FilterTagsReturns(nil, errors.New("potato"))
+		})
+
+		It("calls the internal server error handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+			_, _, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+			Expect(err).To(MatchError("potato"))
+			Expect(description).To(Equal("filter tags failed"))
+		})
+	})
+
+	Context("when ids are given", func() {
+		BeforeEach(func() {
+			var err error
+			request, err = http.NewRequest("GET", "/networking/v0/external/tags?id=some-app-guid,some-space-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			fakeStore.TagsByGuidsReturns(allTags[:1], nil)
+		})
+
+		It("returns only the tags of those ids", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeStore.TagsCallCount()).To(Equal(0))
+			Expect(fakeStore.TagsByGuidsCallCount()).To(Equal(1))
+			Expect(fakeStore.TagsByGuidsArgsForCall(0)).To(Equal([]string{"some-app-guid", "some-space-guid"}))
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Body).To(MatchJSON(`{"tags": [
+				{ "id": "some-app-guid", "tag": "0001", "type": "app" }
+			]}`))
+		})
+
+		Context("when the store throws an error", func() {
+			BeforeEach(func() {
+				fakeStore.TagsByGuidsReturns(nil, errors.New("banana"))
+			})
+
+			It("calls the internal server error handler", func() {
+				MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+				Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+				_, _, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+				Expect(err).To(MatchError("banana"))
+				Expect(description).To(Equal("database read failed"))
+			})
+		})
+	})
+
 	Context("when the logger isn't on the request context", func() {
 		BeforeEach(func() {
 			logger = nil
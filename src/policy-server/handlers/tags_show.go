@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"policy-server/api"
+	"policy-server/store"
+)
+
+//go:generate counterfeiter -o fakes/tags_by_guids_store.go --fake-name TagsByGuidsStore . tagsByGuidsStore
+type tagsByGuidsStore interface {
+	TagsByGuids(context.Context, []string) ([]store.Tag, error)
+}
+
+type TagsShow struct {
+	Store         tagsByGuidsStore
+	RataAdapter   rataAdapter
+	ErrorResponse errorResponse
+}
+
+func (h *TagsShow) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	logger := getLogger(req)
+	logger = logger.Session("show-tag")
+	guid := h.RataAdapter.Param(req, "guid")
+
+	tags, err := h.Store.TagsByGuids(req.Context(), []string{guid})
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "database read failed")
+		return
+	}
+	if len(tags) == 0 {
+		h.ErrorResponse.NotFound(logger, w, nil, "tag not found")
+		return
+	}
+
+	tagJSON, err := json.Marshal(api.MapStoreTag(tags[0]))
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "marshal response failed")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(tagJSON)
+}
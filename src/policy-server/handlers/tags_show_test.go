@@ -0,0 +1,99 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"policy-server/handlers"
+	"policy-server/handlers/fakes"
+	"policy-server/store"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tags show handler", func() {
+	var (
+		request           *http.Request
+		handler           *handlers.TagsShow
+		resp              *httptest.ResponseRecorder
+		fakeStore         *fakes.TagsByGuidsStore
+		fakeRataAdapter   *fakes.RataAdapter
+		fakeErrorResponse *fakes.ErrorResponse
+		logger            *lagertest.TestLogger
+		expectedLogger    lager.Logger
+	)
+
+	BeforeEach(func() {
+		var err error
+		request, err = http.NewRequest("GET", "/networking/v1/internal/tags/some-app-guid", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeStore = &fakes.TagsByGuidsStore{}
+		fakeStore.TagsByGuidsReturns([]store.Tag{{ID: "some-app-guid", Tag: "0001", Type: "app"}}, nil)
+		fakeRataAdapter = &fakes.RataAdapter{}
+		fakeRataAdapter.ParamReturns("some-app-guid")
+		fakeErrorResponse = &fakes.ErrorResponse{}
+
+		logger = lagertest.NewTestLogger("test")
+		expectedLogger = lager.NewLogger("test").Session("show-tag")
+		testSink := lagertest.NewTestSink()
+		expectedLogger.RegisterSink(testSink)
+		expectedLogger.RegisterSink(lager.NewWriterSink(GinkgoWriter, lager.DEBUG))
+
+		handler = &handlers.TagsShow{
+			Store:         fakeStore,
+			RataAdapter:   fakeRataAdapter,
+			ErrorResponse: fakeErrorResponse,
+		}
+		resp = httptest.NewRecorder()
+	})
+
+	It("returns the tag of the guid", func() {
+		MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+		_, name := fakeRataAdapter.ParamArgsForCall(0)
+		Expect(name).To(Equal("guid"))
+		_, guids := fakeStore.TagsByGuidsArgsForCall(0)
+		Expect(guids).To(Equal([]string{"some-app-guid"}))
+
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(resp.Body).To(MatchJSON(`{"id": "some-app-guid", "tag": "0001", "type": "app"}`))
+	})
+
+	Context("when the guid has no tag", func() {
+		BeforeEach(func() {
+			fakeStore.TagsByGuidsReturns([]store.Tag{}, nil)
+		})
+
+		It("calls the not found handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.NotFoundCallCount()).To(Equal(1))
+			l, w, err, description := fakeErrorResponse.NotFoundArgsForCall(0)
+			Expect(l).To(Equal(expectedLogger))
+			Expect(w).To(Equal(resp))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(description).To(Equal("tag not found"))
+		})
+	})
+
+	Context("when the store throws an error", func() {
+		BeforeEach(func() {
+			fakeStore.TagsByGuidsReturns(nil, errors.New("banana"))
+		})
+
+		It("calls the internal server error handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+			l, w, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+			Expect(l).To(Equal(expectedLogger))
+			Expect(w).To(Equal(resp))
+			Expect(err).To(MatchError("banana"))
+			Expect(description).To(Equal("database read failed"))
+		})
+	})
+})
@@ -53,18 +53,18 @@ var MockCCServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWrite
 		return
 	}
 
-	if r.URL.Path == "/v2/spaces/space-1-guid" {
+	if r.URL.Path == "/v3/spaces/space-1-guid" {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte(fixtures.Space1))
 		return
 	}
-	if r.URL.Path == "/v2/spaces/space-2-guid" {
+	if r.URL.Path == "/v3/spaces/space-2-guid" {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte(fixtures.Space2))
 		return
 	}
 
-	if r.URL.Path == "/v2/spaces" {
+	if r.URL.Path == "/v3/spaces" {
 		if strings.Contains(r.URL.RawQuery, "space-1") {
 			w.WriteHeader(http.StatusOK)
 			w.Write([]byte(fixtures.UserSpace))
@@ -77,9 +77,13 @@ var MockCCServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWrite
 		}
 	}
 
-	if r.URL.Path == "/v2/users/some-user-id/spaces" {
+	if r.URL.Path == "/v3/roles" {
 		w.WriteHeader(http.StatusOK)
-		w.Write([]byte(fixtures.UserSpaces))
+		if r.URL.Query().Get("space_guids") != "" || r.URL.Query().Get("page") == "2" {
+			w.Write([]byte(fixtures.UserRolesPg2))
+			return
+		}
+		w.Write([]byte(fixtures.UserRoles))
 		return
 	}
 
@@ -0,0 +1,88 @@
+package redundancy
+
+import (
+	"fmt"
+	"policy-server/db"
+	"policy-server/store"
+)
+
+//go:generate counterfeiter -o fakes/database.go --fake-name Db . database
+type database interface {
+	Beginx() (db.Transaction, error)
+}
+
+//go:generate counterfeiter -o fakes/policy_store.go --fake-name PolicyStore . policyStore
+type policyStore interface {
+	AllWithTx(db.Transaction) ([]store.Policy, error)
+	CreateWithTx(db.Transaction, []store.Policy) error
+	DeleteWithTx(db.Transaction, []store.Policy) error
+}
+
+type CompactResult struct {
+	Created []store.Policy
+	Deleted []store.Policy
+}
+
+type Compactor struct {
+	Conn  database
+	Store policyStore
+}
+
+func NewCompactor(conn database, store policyStore) *Compactor {
+	return &Compactor{
+		Conn:  conn,
+		Store: store,
+	}
+}
+
+// Compact merges redundant policies in a single transaction. The policies
+// are read and locked in that transaction, so the plan cannot be invalidated
+// by a concurrent delete. Merged policies are created before the policies they
+// replace are deleted so that the source and destination groups, and
+// therefore their tags, are never released.
+func (c *Compactor) Compact() (CompactResult, error) {
+	tx, err := c.Conn.Beginx()
+	if err != nil {
+		return CompactResult{}, fmt.Errorf("begin transaction: %s", err)
+	}
+
+	policies, err := c.Store.AllWithTx(tx)
+	if err != nil {
+		return CompactResult{}, rollback(tx, fmt.Errorf("listing policies: %s", err))
+	}
+
+	toCreate, toDelete := Plan(policies)
+	if len(toDelete) == 0 {
+		err = tx.Rollback()
+		if err != nil {
+			return CompactResult{}, fmt.Errorf("database rollback: %s", err)
+		}
+		return CompactResult{Created: toCreate, Deleted: toDelete}, nil
+	}
+
+	err = c.Store.CreateWithTx(tx, toCreate)
+	if err != nil {
+		return CompactResult{}, rollback(tx, fmt.Errorf("creating merged policies: %s", err))
+	}
+
+	// DeleteWithTx rolls back the transaction itself on failure
+	err = c.Store.DeleteWithTx(tx, toDelete)
+	if err != nil {
+		return CompactResult{}, fmt.Errorf("deleting redundant policies: %s", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return CompactResult{}, fmt.Errorf("commit transaction: %s", err)
+	}
+
+	return CompactResult{Created: toCreate, Deleted: toDelete}, nil
+}
+
+func rollback(tx db.Transaction, err error) error {
+	txErr := tx.Rollback()
+	if txErr != nil {
+		return fmt.Errorf("database rollback: %s (sql error: %s)", txErr, err)
+	}
+	return err
+}
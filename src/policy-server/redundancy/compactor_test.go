@@ -0,0 +1,162 @@
+package redundancy_test
+
+import (
+	"errors"
+	dbfakes "policy-server/db/fakes"
+	"policy-server/redundancy"
+	"policy-server/redundancy/fakes"
+	"policy-server/store"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Compactor", func() {
+	var (
+		compactor *redundancy.Compactor
+		fakeDb    *fakes.Db
+		fakeStore *fakes.PolicyStore
+		tx        *dbfakes.Transaction
+		wide      store.Policy
+		narrow    store.Policy
+		overlapA  store.Policy
+		overlapB  store.Policy
+	)
+
+	BeforeEach(func() {
+		wide = policy("a", "b", "tcp", 0, 8080, 8090)
+		narrow = policy("a", "b", "tcp", 8085, 8085, 8085)
+		overlapA = policy("b", "c", "udp", 0, 100, 200)
+		overlapB = policy("b", "c", "udp", 0, 150, 300)
+
+		tx = &dbfakes.Transaction{}
+		fakeDb = &fakes.Db{}
+		fakeDb.BeginxReturns(tx, nil)
+		fakeStore = &fakes.PolicyStore{}
+		fakeStore.AllWithTxReturns([]store.Policy{wide, narrow, overlapA, overlapB}, nil)
+
+		compactor = redundancy.NewCompactor(fakeDb, fakeStore)
+	})
+
+	It("creates merged policies and deletes redundant ones in one transaction", func() {
+		result, err := compactor.Compact()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeStore.AllWithTxCallCount()).To(Equal(1))
+		Expect(fakeStore.AllWithTxArgsForCall(0)).To(Equal(tx))
+
+		merged := policy("b", "c", "udp", 0, 100, 300)
+		Expect(result.Created).To(Equal([]store.Policy{merged}))
+		Expect(result.Deleted).To(ConsistOf(narrow, overlapA, overlapB))
+
+		Expect(fakeStore.CreateWithTxCallCount()).To(Equal(1))
+		createTx, created := fakeStore.CreateWithTxArgsForCall(0)
+		Expect(createTx).To(Equal(tx))
+		Expect(created).To(Equal([]store.Policy{merged}))
+
+		Expect(fakeStore.DeleteWithTxCallCount()).To(Equal(1))
+		deleteTx, deleted := fakeStore.DeleteWithTxArgsForCall(0)
+		Expect(deleteTx).To(Equal(tx))
+		Expect(deleted).To(ConsistOf(narrow, overlapA, overlapB))
+
+		Expect(tx.CommitCallCount()).To(Equal(1))
+	})
+
+	Context("when there is nothing to compact", func() {
+		BeforeEach(func() {
+			fakeStore.AllWithTxReturns([]store.Policy{wide}, nil)
+		})
+
+		It("ends the transaction without changes", func() {
+			result, err := compactor.Compact()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Created).To(BeEmpty())
+			Expect(result.Deleted).To(BeEmpty())
+			Expect(fakeStore.CreateWithTxCallCount()).To(Equal(0))
+			Expect(fakeStore.DeleteWithTxCallCount()).To(Equal(0))
+			Expect(tx.RollbackCallCount()).To(Equal(1))
+			Expect(tx.CommitCallCount()).To(Equal(0))
+		})
+
+		Context("when ending the transaction fails", func() {
+			BeforeEach(func() {
+				tx.RollbackReturns(errors.New("banana"))
+			})
+
+			It("returns a helpful error", func() {
+				_, err := compactor.Compact()
+				Expect(err).To(MatchError("database rollback: banana"))
+			})
+		})
+	})
+
+	Context("when listing policies fails", func() {
+		BeforeEach(func() {
+			fakeStore.AllWithTxReturns(nil, errors.New("potato"))
+		})
+
+		It("rolls back and returns a helpful error", func() {
+			_, err := compactor.Compact()
+			Expect(err).To(MatchError("listing policies: potato"))
+			Expect(tx.RollbackCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when beginning the transaction fails", func() {
+		BeforeEach(func() {
+			fakeDb.BeginxReturns(nil, errors.New("potato"))
+		})
+
+		It("returns a helpful error", func() {
+			_, err := compactor.Compact()
+			Expect(err).To(MatchError("begin transaction: potato"))
+		})
+	})
+
+	Context("when creating the merged policies fails", func() {
+		BeforeEach(func() {
+			fakeStore.CreateWithTxReturns(errors.New("potato"))
+		})
+
+		It("rolls back and returns a helpful error", func() {
+			_, err := compactor.Compact()
+			Expect(err).To(MatchError("creating merged policies: potato"))
+			Expect(tx.RollbackCallCount()).To(Equal(1))
+			Expect(fakeStore.DeleteWithTxCallCount()).To(Equal(0))
+		})
+
+		Context("when the rollback fails", func() {
+			BeforeEach(func() {
+				tx.RollbackReturns(errors.New("banana"))
+			})
+
+			It("returns both errors", func() {
+				_, err := compactor.Compact()
+				Expect(err).To(MatchError("database rollback: banana (sql error: creating merged policies: potato)"))
+			})
+		})
+	})
+
+	Context("when deleting the redundant policies fails", func() {
+		BeforeEach(func() {
+			fakeStore.DeleteWithTxReturns(errors.New("potato"))
+		})
+
+		It("returns a helpful error", func() {
+			_, err := compactor.Compact()
+			Expect(err).To(MatchError("deleting redundant policies: potato"))
+			Expect(tx.CommitCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when committing fails", func() {
+		BeforeEach(func() {
+			tx.CommitReturns(errors.New("potato"))
+		})
+
+		It("returns a helpful error", func() {
+			_, err := compactor.Compact()
+			Expect(err).To(MatchError("commit transaction: potato"))
+		})
+	})
+})
@@ -0,0 +1,181 @@
+package redundancy
+
+import (
+	"policy-server/store"
+	"sort"
+)
+
+type Finding struct {
+	Policy  store.Policy
+	Related store.Policy
+}
+
+// Report lists the policies that grant access already granted by another
+// policy between the same source, destination and protocol.
+//
+// Duplicates cover the same port range, e.g. a v0 policy stored with `port`
+// and a v1 policy stored with `ports`. A shadowed policy's ports lie entirely
+// within the related policy's ports. Overlapping policies share some, but
+// not all, ports.
+type Report struct {
+	Duplicates  []Finding
+	Shadowed    []Finding
+	Overlapping []Finding
+}
+
+type portRange struct {
+	start int
+	end   int
+}
+
+func (r portRange) contains(other portRange) bool {
+	return r.start <= other.start && other.end <= r.end
+}
+
+func (r portRange) intersects(other portRange) bool {
+	return r.start <= other.end && other.start <= r.end
+}
+
+type groupKey struct {
+	source      string
+	destination string
+	protocol    string
+}
+
+// effectivePorts normalizes the v0 and v1 representations of a destination.
+// Rows written before port ranges were introduced only have `port` set.
+func effectivePorts(policy store.Policy) portRange {
+	if policy.Destination.Ports.Start == 0 && policy.Destination.Ports.End == 0 {
+		return portRange{start: policy.Destination.Port, end: policy.Destination.Port}
+	}
+	return portRange{start: policy.Destination.Ports.Start, end: policy.Destination.Ports.End}
+}
+
+func group(policies []store.Policy) [][]store.Policy {
+	groups := map[groupKey][]store.Policy{}
+	keys := []groupKey{}
+	for _, policy := range policies {
+		key := groupKey{
+			source:      policy.Source.ID,
+			destination: policy.Destination.ID,
+			protocol:    policy.Destination.Protocol,
+		}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], policy)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].source != keys[j].source {
+			return keys[i].source < keys[j].source
+		}
+		if keys[i].destination != keys[j].destination {
+			return keys[i].destination < keys[j].destination
+		}
+		return keys[i].protocol < keys[j].protocol
+	})
+
+	result := make([][]store.Policy, 0, len(keys))
+	for _, key := range keys {
+		members := groups[key]
+		sort.SliceStable(members, func(i, j int) bool {
+			a, b := effectivePorts(members[i]), effectivePorts(members[j])
+			if a.start != b.start {
+				return a.start < b.start
+			}
+			return a.end > b.end
+		})
+		result = append(result, members)
+	}
+	return result
+}
+
+func Analyze(policies []store.Policy) Report {
+	report := Report{
+		Duplicates:  []Finding{},
+		Shadowed:    []Finding{},
+		Overlapping: []Finding{},
+	}
+
+	for _, members := range group(policies) {
+		for i := 0; i < len(members); i++ {
+			for j := i + 1; j < len(members); j++ {
+				a, b := effectivePorts(members[i]), effectivePorts(members[j])
+				finding := Finding{Policy: members[j], Related: members[i]}
+				switch {
+				case a == b:
+					report.Duplicates = append(report.Duplicates, finding)
+				case a.contains(b):
+					report.Shadowed = append(report.Shadowed, finding)
+				case a.intersects(b):
+					report.Overlapping = append(report.Overlapping, finding)
+				}
+			}
+		}
+	}
+
+	return report
+}
+
+// Plan returns the policies to create and delete so that no two remaining
+// policies overlap. Overlapping policies are replaced by one policy covering
+// their combined port range. Where an existing policy already covers that
+// range it is kept rather than recreated.
+func Plan(policies []store.Policy) (toCreate []store.Policy, toDelete []store.Policy) {
+	toCreate = []store.Policy{}
+	toDelete = []store.Policy{}
+
+	for _, members := range group(policies) {
+		for start := 0; start < len(members); {
+			merged := effectivePorts(members[start])
+			end := start + 1
+			for end < len(members) && merged.intersects(effectivePorts(members[end])) {
+				if r := effectivePorts(members[end]); r.end > merged.end {
+					merged.end = r.end
+				}
+				end++
+			}
+
+			if end-start > 1 {
+				kept := -1
+				for i := start; i < end; i++ {
+					if effectivePorts(members[i]) == merged {
+						kept = i
+						break
+					}
+				}
+				if kept == -1 {
+					toCreate = append(toCreate, mergedPolicy(members[start], merged))
+				}
+				for i := start; i < end; i++ {
+					if i != kept {
+						toDelete = append(toDelete, members[i])
+					}
+				}
+			}
+			start = end
+		}
+	}
+
+	return toCreate, toDelete
+}
+
+func mergedPolicy(policy store.Policy, ports portRange) store.Policy {
+	port := 0
+	if ports.start == ports.end {
+		port = ports.start
+	}
+	return store.Policy{
+		Source: store.Source{ID: policy.Source.ID},
+		Destination: store.Destination{
+			ID:       policy.Destination.ID,
+			Protocol: policy.Destination.Protocol,
+			Port:     port,
+			Ports: store.Ports{
+				Start: ports.start,
+				End:   ports.end,
+			},
+		},
+	}
+}
@@ -0,0 +1,13 @@
+package redundancy_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestRedundancy(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Redundancy Suite")
+}
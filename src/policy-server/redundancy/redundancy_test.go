@@ -0,0 +1,96 @@
+package redundancy_test
+
+import (
+	"policy-server/redundancy"
+	"policy-server/store"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func policy(source, destination, protocol string, port, start, end int) store.Policy {
+	return store.Policy{
+		Source: store.Source{ID: source},
+		Destination: store.Destination{
+			ID:       destination,
+			Protocol: protocol,
+			Port:     port,
+			Ports:    store.Ports{Start: start, End: end},
+		},
+	}
+}
+
+var _ = Describe("Redundancy", func() {
+	var (
+		wide       store.Policy
+		narrow     store.Policy
+		legacy     store.Policy
+		current    store.Policy
+		overlapA   store.Policy
+		overlapB   store.Policy
+		unrelated  store.Policy
+		otherProto store.Policy
+		policies   []store.Policy
+	)
+
+	BeforeEach(func() {
+		wide = policy("a", "b", "tcp", 0, 8080, 8090)
+		narrow = policy("a", "b", "tcp", 8085, 8085, 8085)
+		legacy = policy("a", "c", "tcp", 9000, 0, 0)
+		current = policy("a", "c", "tcp", 9000, 9000, 9000)
+		overlapA = policy("b", "c", "udp", 0, 100, 200)
+		overlapB = policy("b", "c", "udp", 0, 150, 300)
+		unrelated = policy("b", "c", "udp", 0, 400, 500)
+		otherProto = policy("a", "b", "udp", 8085, 8085, 8085)
+
+		policies = []store.Policy{
+			narrow, overlapB, wide, current, unrelated, legacy, otherProto, overlapA,
+		}
+	})
+
+	Describe("Analyze", func() {
+		It("reports duplicate, shadowed and overlapping policies", func() {
+			report := redundancy.Analyze(policies)
+
+			Expect(report.Duplicates).To(Equal([]redundancy.Finding{
+				{Policy: legacy, Related: current},
+			}))
+			Expect(report.Shadowed).To(Equal([]redundancy.Finding{
+				{Policy: narrow, Related: wide},
+			}))
+			Expect(report.Overlapping).To(Equal([]redundancy.Finding{
+				{Policy: overlapB, Related: overlapA},
+			}))
+		})
+
+		Context("when there are no redundant policies", func() {
+			It("returns an empty report", func() {
+				report := redundancy.Analyze([]store.Policy{wide, otherProto, unrelated})
+
+				Expect(report.Duplicates).To(BeEmpty())
+				Expect(report.Shadowed).To(BeEmpty())
+				Expect(report.Overlapping).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("Plan", func() {
+		It("merges redundant policies", func() {
+			toCreate, toDelete := redundancy.Plan(policies)
+
+			Expect(toCreate).To(Equal([]store.Policy{
+				policy("b", "c", "udp", 0, 100, 300),
+			}))
+			Expect(toDelete).To(ConsistOf(narrow, legacy, overlapA, overlapB))
+		})
+
+		Context("when there are no redundant policies", func() {
+			It("does nothing", func() {
+				toCreate, toDelete := redundancy.Plan([]store.Policy{wide, otherProto, unrelated})
+
+				Expect(toCreate).To(BeEmpty())
+				Expect(toDelete).To(BeEmpty())
+			})
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package rpc_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestRpc(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Rpc Suite")
+}
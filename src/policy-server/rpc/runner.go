@@ -0,0 +1,59 @@
+package rpc
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+
+	"code.cloudfoundry.org/lager"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// Runner is an ifrit runner that serves the Internal service on Address
+// with the given TLS config. On a signal it stops Watcher, which ends the
+// open WatchPolicies streams, before it stops the server gracefully.
+type Runner struct {
+	Logger    lager.Logger
+	Address   string
+	TLSConfig *tls.Config
+	Server    InternalServer
+	Watcher   *SnapshotWatcher
+}
+
+func (r *Runner) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	listener, err := net.Listen("tcp", r.Address)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %s", r.Address, err)
+	}
+
+	server := NewGRPCServer(r.Server, grpc.Creds(credentials.NewTLS(r.TLSConfig)))
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- server.Serve(listener)
+	}()
+
+	r.Logger.Info("grpc-server-started", lager.Data{"address": r.Address})
+	close(ready)
+
+	select {
+	case <-signals:
+		if r.Watcher != nil {
+			r.Watcher.Stop()
+		}
+		server.GracefulStop()
+		return nil
+	case err := <-errChan:
+		return err
+	}
+}
+
+// NewGRPCServer returns a grpc.Server with srv registered as the Internal
+// service.
+func NewGRPCServer(srv InternalServer, opts ...grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(opts...)
+	RegisterInternalServer(server, srv)
+	return server
+}
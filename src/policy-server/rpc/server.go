@@ -0,0 +1,155 @@
+package rpc
+
+import (
+	"context"
+	"policy-server/api"
+	"policy-server/store"
+
+	"code.cloudfoundry.org/lager"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//go:generate protoc --proto_path=.. --gogo_out=plugins=grpc,Mapi/policies.proto=policy-server/api:.. rpc/policy_server.proto
+
+//go:generate counterfeiter -o fakes/policy_store.go --fake-name PolicyStore . policyStore
+type policyStore interface {
+	ByGuids([]string, []string, bool) ([]store.Policy, error)
+}
+
+//go:generate counterfeiter -o fakes/egress_policy_store.go --fake-name EgressPolicyStore . egressPolicyStore
+type egressPolicyStore interface {
+	ByGuids([]string) ([]store.EgressPolicy, error)
+}
+
+//go:generate counterfeiter -o fakes/policy_snapshots.go --fake-name PolicySnapshots . policySnapshots
+type policySnapshots interface {
+	Snapshot() (*store.PolicySnapshot, error)
+}
+
+//go:generate counterfeiter -o fakes/tag_store.go --fake-name TagStore . tagStore
+type tagStore interface {
+	CreateTag(string, string) (store.Tag, error)
+}
+
+//go:generate counterfeiter -o fakes/database_checker.go --fake-name DatabaseChecker . databaseChecker
+type databaseChecker interface {
+	CheckDatabase() error
+}
+
+// Server implements the Internal gRPC service on top of the same stores as
+// the internal HTTP API. WatchPolicies sends the policies again whenever
+// Watcher sees a reloaded snapshot.
+type Server struct {
+	Logger          lager.Logger
+	Store           policyStore
+	EgressStore     egressPolicyStore
+	Snapshots       policySnapshots
+	Watcher         *SnapshotWatcher
+	TagStore        tagStore
+	DatabaseChecker databaseChecker
+}
+
+func (s *Server) ListPolicies(ctx context.Context, req *ListPoliciesRequest) (*PoliciesResponse, error) {
+	logger := s.Logger.Session("list-policies")
+
+	if len(req.Ids) == 0 {
+		snapshot, err := s.Snapshots.Snapshot()
+		if err != nil {
+			logger.Error("failed-reading-snapshot", err)
+			return nil, status.Error(codes.Internal, "database read failed")
+		}
+		return policiesResponse(snapshot.Revision, snapshot.Policies, snapshot.EgressPolicies), nil
+	}
+
+	policies, err := s.Store.ByGuids(req.Ids, req.Ids, false)
+	if err != nil {
+		logger.Error("failed-reading-database", err)
+		return nil, status.Error(codes.Internal, "database read failed")
+	}
+
+	egressPolicies, err := s.EgressStore.ByGuids(req.Ids)
+	if err != nil {
+		logger.Error("failed-reading-egress-database", err)
+		return nil, status.Error(codes.Internal, "egress database read failed")
+	}
+
+	return policiesResponse(0, policies, egressPolicies), nil
+}
+
+func (s *Server) WatchPolicies(req *WatchPoliciesRequest, stream Internal_WatchPoliciesServer) error {
+	logger := s.Logger.Session("watch-policies")
+
+	// subscribe before reading the first snapshot so that a reload in
+	// between is not missed
+	snapshots, unsubscribe := s.Watcher.Subscribe()
+	defer unsubscribe()
+
+	snapshot, err := s.Snapshots.Snapshot()
+	if err != nil {
+		logger.Error("failed-reading-snapshot", err)
+		return status.Error(codes.Internal, "database read failed")
+	}
+
+	var sent *store.PolicySnapshot
+	for {
+		if snapshot != sent {
+			policies, egressPolicies := snapshot.Filter(req.Ids)
+			err = stream.Send(policiesResponse(snapshot.Revision, policies, egressPolicies))
+			if err != nil {
+				logger.Error("failed-sending-policies", err)
+				return err
+			}
+			sent = snapshot
+		}
+
+		var ok bool
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case snapshot, ok = <-snapshots:
+			if !ok {
+				return status.Error(codes.Unavailable, "server is stopping")
+			}
+		}
+	}
+}
+
+func (s *Server) CreateTag(ctx context.Context, req *CreateTagRequest) (*TagResponse, error) {
+	logger := s.Logger.Session("create-tag")
+
+	tag, err := s.TagStore.CreateTag(req.Id, req.Type)
+	if err == store.ErrTagSpaceExhausted {
+		logger.Error("failed-creating-tag", err)
+		return nil, status.Error(codes.ResourceExhausted, "database create failed: tag space exhausted")
+	}
+	if err == store.ErrTagsQuarantined {
+		logger.Error("failed-creating-tag", err)
+		return nil, status.Error(codes.Unavailable, "database create failed: all free tags are quarantined, try again later")
+	}
+	if err != nil {
+		logger.Error("failed-creating-tag", err)
+		return nil, status.Error(codes.Internal, "database create failed")
+	}
+
+	apiTag := api.MapStoreTag(tag)
+	return &TagResponse{Id: apiTag.ID, Type: apiTag.Type, Tag: apiTag.Tag}, nil
+}
+
+func (s *Server) Health(ctx context.Context, req *HealthRequest) (*HealthResponse, error) {
+	err := s.DatabaseChecker.CheckDatabase()
+	if err != nil {
+		s.Logger.Error("check-database-failed", err)
+		return &HealthResponse{Healthy: false, Message: "check database failed"}, nil
+	}
+	return &HealthResponse{Healthy: true}, nil
+}
+
+func policiesResponse(revision int64, policies []store.Policy, egressPolicies []store.EgressPolicy) *PoliciesResponse {
+	message := api.AsPoliciesMessage(policies, egressPolicies)
+	return &PoliciesResponse{
+		Revision:       revision,
+		Policies:       message.Policies,
+		EgressPolicies: message.EgressPolicies,
+	}
+}
@@ -0,0 +1,263 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+
+	"policy-server/api"
+	"policy-server/rpc"
+	"policy-server/rpc/fakes"
+	"policy-server/store"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ = Describe("Server", func() {
+	var (
+		fakeStore           *fakes.PolicyStore
+		fakeEgressStore     *fakes.EgressPolicyStore
+		fakeSnapshots       *fakes.PolicySnapshots
+		fakeTagStore        *fakes.TagStore
+		fakeDatabaseChecker *fakes.DatabaseChecker
+		watcher             *rpc.SnapshotWatcher
+		logger              *lagertest.TestLogger
+
+		grpcServer *grpc.Server
+		conn       *grpc.ClientConn
+		client     rpc.InternalClient
+
+		policies       []store.Policy
+		egressPolicies []store.EgressPolicy
+		snapshot       *store.PolicySnapshot
+	)
+
+	BeforeEach(func() {
+		policies = []store.Policy{{
+			Source: store.Source{ID: "some-app-guid", Tag: "0001"},
+			Destination: store.Destination{
+				ID:       "some-other-app-guid",
+				Tag:      "0002",
+				Protocol: "tcp",
+				Port:     8080,
+				Ports:    store.Ports{Start: 8080, End: 8080},
+			},
+		}, {
+			Source: store.Source{ID: "another-app-guid", Tag: "0003"},
+			Destination: store.Destination{
+				ID:       "yet-another-app-guid",
+				Tag:      "0004",
+				Protocol: "udp",
+				Ports:    store.Ports{Start: 1000, End: 2000},
+			},
+		}}
+		egressPolicies = []store.EgressPolicy{{
+			Source: store.EgressSource{ID: "some-app-guid"},
+			Destination: store.EgressDestination{
+				Protocol: "tcp",
+				IPRanges: []store.IPRange{{Start: "1.2.3.4", End: "1.2.3.5"}},
+			},
+		}}
+		snapshot = &store.PolicySnapshot{Revision: 7, Policies: policies, EgressPolicies: egressPolicies}
+
+		fakeStore = &fakes.PolicyStore{}
+		fakeStore.ByGuidsReturns(policies[:1], nil)
+		fakeEgressStore = &fakes.EgressPolicyStore{}
+		fakeEgressStore.ByGuidsReturns(egressPolicies, nil)
+		fakeSnapshots = &fakes.PolicySnapshots{}
+		fakeSnapshots.SnapshotReturns(snapshot, nil)
+		fakeTagStore = &fakes.TagStore{}
+		fakeDatabaseChecker = &fakes.DatabaseChecker{}
+		logger = lagertest.NewTestLogger("test")
+		watcher = &rpc.SnapshotWatcher{Logger: logger, Snapshots: fakeSnapshots}
+
+		grpcServer = rpc.NewGRPCServer(&rpc.Server{
+			Logger:          logger,
+			Store:           fakeStore,
+			EgressStore:     fakeEgressStore,
+			Snapshots:       fakeSnapshots,
+			Watcher:         watcher,
+			TagStore:        fakeTagStore,
+			DatabaseChecker: fakeDatabaseChecker,
+		})
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		go grpcServer.Serve(listener)
+
+		conn, err = grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+		Expect(err).NotTo(HaveOccurred())
+		client = rpc.NewInternalClient(conn)
+	})
+
+	AfterEach(func() {
+		conn.Close()
+		grpcServer.Stop()
+	})
+
+	Describe("ListPolicies", func() {
+		It("returns the policies from the current snapshot", func() {
+			resp, err := client.ListPolicies(context.Background(), &rpc.ListPoliciesRequest{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Revision).To(Equal(int64(7)))
+
+			apiPolicies, apiEgressPolicies := api.PoliciesFromMessage(resp.Policies, resp.EgressPolicies)
+			Expect(apiPolicies).To(Equal([]api.Policy{api.MapStorePolicy(policies[0]), api.MapStorePolicy(policies[1])}))
+			Expect(apiEgressPolicies).To(Equal([]api.EgressPolicy{api.MapStoreEgressPolicy(egressPolicies[0])}))
+			Expect(fakeStore.ByGuidsCallCount()).To(Equal(0))
+		})
+
+		Context("when ids are given", func() {
+			It("reads the policies for those ids from the stores", func() {
+				resp, err := client.ListPolicies(context.Background(), &rpc.ListPoliciesRequest{Ids: []string{"some-app-guid"}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.Policies).To(HaveLen(1))
+				Expect(resp.EgressPolicies).To(HaveLen(1))
+
+				srcGuids, dstGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
+				Expect(srcGuids).To(Equal([]string{"some-app-guid"}))
+				Expect(dstGuids).To(Equal([]string{"some-app-guid"}))
+				Expect(inSourceAndDest).To(BeFalse())
+				Expect(fakeEgressStore.ByGuidsArgsForCall(0)).To(Equal([]string{"some-app-guid"}))
+			})
+		})
+
+		Context("when reading the snapshot fails", func() {
+			BeforeEach(func() {
+				fakeSnapshots.SnapshotReturns(nil, errors.New("potato"))
+			})
+
+			It("returns an Internal error", func() {
+				_, err := client.ListPolicies(context.Background(), &rpc.ListPoliciesRequest{})
+				Expect(status.Code(err)).To(Equal(codes.Internal))
+				Expect(status.Convert(err).Message()).To(Equal("database read failed"))
+			})
+		})
+
+		Context("when reading the egress policies fails", func() {
+			BeforeEach(func() {
+				fakeEgressStore.ByGuidsReturns(nil, errors.New("potato"))
+			})
+
+			It("returns an Internal error", func() {
+				_, err := client.ListPolicies(context.Background(), &rpc.ListPoliciesRequest{Ids: []string{"some-app-guid"}})
+				Expect(status.Code(err)).To(Equal(codes.Internal))
+				Expect(status.Convert(err).Message()).To(Equal("egress database read failed"))
+			})
+		})
+	})
+
+	Describe("WatchPolicies", func() {
+		It("sends the policies for the ids and again when the snapshot changes", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			stream, err := client.WatchPolicies(ctx, &rpc.WatchPoliciesRequest{Ids: []string{"yet-another-app-guid"}})
+			Expect(err).NotTo(HaveOccurred())
+
+			resp, err := stream.Recv()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Revision).To(Equal(int64(7)))
+			Expect(resp.Policies).To(HaveLen(1))
+			Expect(resp.Policies[0].SourceId).To(Equal("another-app-guid"))
+			Expect(resp.EgressPolicies).To(BeEmpty())
+
+			fakeSnapshots.SnapshotReturns(&store.PolicySnapshot{Revision: 8}, nil)
+			Expect(watcher.Poll()).To(Succeed())
+
+			resp, err = stream.Recv()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Revision).To(Equal(int64(8)))
+			Expect(resp.Policies).To(BeEmpty())
+		})
+
+		It("ends the stream with Unavailable when the watcher stops, so the server can stop gracefully", func() {
+			stream, err := client.WatchPolicies(context.Background(), &rpc.WatchPoliciesRequest{})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = stream.Recv()
+			Expect(err).NotTo(HaveOccurred())
+
+			watcher.Stop()
+
+			_, err = stream.Recv()
+			Expect(status.Code(err)).To(Equal(codes.Unavailable))
+
+			stopped := make(chan struct{})
+			go func() {
+				grpcServer.GracefulStop()
+				close(stopped)
+			}()
+			Eventually(stopped).Should(BeClosed())
+		})
+
+		Context("when reading the snapshot fails", func() {
+			BeforeEach(func() {
+				fakeSnapshots.SnapshotReturns(nil, errors.New("potato"))
+			})
+
+			It("ends the stream with an Internal error", func() {
+				stream, err := client.WatchPolicies(context.Background(), &rpc.WatchPoliciesRequest{})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = stream.Recv()
+				Expect(err).NotTo(Equal(io.EOF))
+				Expect(status.Code(err)).To(Equal(codes.Internal))
+			})
+		})
+	})
+
+	Describe("CreateTag", func() {
+		BeforeEach(func() {
+			fakeTagStore.CreateTagReturns(store.Tag{ID: "some-guid", Type: "router", Tag: "0001"}, nil)
+		})
+
+		It("creates the tag", func() {
+			resp, err := client.CreateTag(context.Background(), &rpc.CreateTagRequest{Id: "some-guid", Type: "router"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&rpc.TagResponse{Id: "some-guid", Type: "router", Tag: "0001"}))
+
+			id, groupType := fakeTagStore.CreateTagArgsForCall(0)
+			Expect(id).To(Equal("some-guid"))
+			Expect(groupType).To(Equal("router"))
+		})
+
+		Context("when the store fails", func() {
+			BeforeEach(func() {
+				fakeTagStore.CreateTagReturns(store.Tag{}, errors.New("potato"))
+			})
+
+			It("returns an Internal error", func() {
+				_, err := client.CreateTag(context.Background(), &rpc.CreateTagRequest{Id: "some-guid", Type: "router"})
+				Expect(status.Code(err)).To(Equal(codes.Internal))
+				Expect(status.Convert(err).Message()).To(Equal("database create failed"))
+			})
+		})
+	})
+
+	Describe("Health", func() {
+		It("reports healthy", func() {
+			resp, err := client.Health(context.Background(), &rpc.HealthRequest{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Healthy).To(BeTrue())
+		})
+
+		Context("when the database check fails", func() {
+			BeforeEach(func() {
+				fakeDatabaseChecker.CheckDatabaseReturns(errors.New("potato"))
+			})
+
+			It("reports unhealthy", func() {
+				resp, err := client.Health(context.Background(), &rpc.HealthRequest{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.Healthy).To(BeFalse())
+				Expect(resp.Message).To(Equal("check database failed"))
+			})
+		})
+	})
+})
@@ -0,0 +1,87 @@
+package rpc
+
+import (
+	"policy-server/store"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// SnapshotWatcher reads Snapshots once per Poll and passes every new
+// snapshot on to the subscribed WatchPolicies streams, so the number of open
+// streams does not change how often the snapshot is read. Run Poll from a
+// poller.Poller.
+type SnapshotWatcher struct {
+	Logger    lager.Logger
+	Snapshots policySnapshots
+
+	lock        sync.Mutex
+	current     *store.PolicySnapshot
+	subscribers map[chan *store.PolicySnapshot]struct{}
+	stopped     bool
+}
+
+func (w *SnapshotWatcher) Poll() error {
+	snapshot, err := w.Snapshots.Snapshot()
+	if err != nil {
+		return err
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if snapshot == w.current {
+		return nil
+	}
+	w.current = snapshot
+
+	for subscriber := range w.subscribers {
+		// a stream that has not picked up the previous snapshot yet only
+		// needs the latest one
+		select {
+		case <-subscriber:
+		default:
+		}
+		subscriber <- snapshot
+	}
+	w.Logger.Debug("broadcast-snapshot", lager.Data{"revision": snapshot.Revision, "subscribers": len(w.subscribers)})
+	return nil
+}
+
+// Subscribe returns a channel that receives every snapshot Poll reads from
+// now on, and a function that stops the subscription. The channel is closed
+// when the watcher is stopped.
+func (w *SnapshotWatcher) Subscribe() (<-chan *store.PolicySnapshot, func()) {
+	subscriber := make(chan *store.PolicySnapshot, 1)
+
+	w.lock.Lock()
+	if w.stopped {
+		w.lock.Unlock()
+		close(subscriber)
+		return subscriber, func() {}
+	}
+	if w.subscribers == nil {
+		w.subscribers = map[chan *store.PolicySnapshot]struct{}{}
+	}
+	w.subscribers[subscriber] = struct{}{}
+	w.lock.Unlock()
+
+	return subscriber, func() {
+		w.lock.Lock()
+		delete(w.subscribers, subscriber)
+		w.lock.Unlock()
+	}
+}
+
+// Stop closes the channels of all subscriptions, and of the ones made later,
+// so that the WatchPolicies streams end and the gRPC server can stop.
+func (w *SnapshotWatcher) Stop() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.stopped = true
+	for subscriber := range w.subscribers {
+		close(subscriber)
+		delete(w.subscribers, subscriber)
+	}
+}
@@ -0,0 +1,97 @@
+package rpc_test
+
+import (
+	"errors"
+
+	"policy-server/rpc"
+	"policy-server/rpc/fakes"
+	"policy-server/store"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SnapshotWatcher", func() {
+	var (
+		fakeSnapshots *fakes.PolicySnapshots
+		watcher       *rpc.SnapshotWatcher
+		snapshot      *store.PolicySnapshot
+	)
+
+	BeforeEach(func() {
+		snapshot = &store.PolicySnapshot{Revision: 1}
+		fakeSnapshots = &fakes.PolicySnapshots{}
+		fakeSnapshots.SnapshotReturns(snapshot, nil)
+		watcher = &rpc.SnapshotWatcher{
+			Logger:    lagertest.NewTestLogger("test"),
+			Snapshots: fakeSnapshots,
+		}
+	})
+
+	It("reads the snapshot once per poll and sends it to every subscriber", func() {
+		first, _ := watcher.Subscribe()
+		second, _ := watcher.Subscribe()
+
+		Expect(watcher.Poll()).To(Succeed())
+
+		Expect(fakeSnapshots.SnapshotCallCount()).To(Equal(1))
+		Expect(first).To(Receive(Equal(snapshot)))
+		Expect(second).To(Receive(Equal(snapshot)))
+	})
+
+	It("only sends a snapshot once", func() {
+		subscriber, _ := watcher.Subscribe()
+
+		Expect(watcher.Poll()).To(Succeed())
+		Expect(subscriber).To(Receive())
+
+		Expect(watcher.Poll()).To(Succeed())
+		Expect(subscriber).NotTo(Receive())
+	})
+
+	It("keeps only the latest snapshot for a subscriber that has not received", func() {
+		subscriber, _ := watcher.Subscribe()
+		Expect(watcher.Poll()).To(Succeed())
+
+		newSnapshot := &store.PolicySnapshot{Revision: 2}
+		fakeSnapshots.SnapshotReturns(newSnapshot, nil)
+		Expect(watcher.Poll()).To(Succeed())
+
+		Expect(subscriber).To(Receive(Equal(newSnapshot)))
+		Expect(subscriber).NotTo(Receive())
+	})
+
+	It("stops sending after unsubscribing", func() {
+		subscriber, unsubscribe := watcher.Subscribe()
+		unsubscribe()
+
+		Expect(watcher.Poll()).To(Succeed())
+		Expect(subscriber).NotTo(Receive())
+	})
+
+	It("closes the subscriptions when stopped", func() {
+		subscriber, unsubscribe := watcher.Subscribe()
+		watcher.Stop()
+		unsubscribe()
+
+		Expect(subscriber).To(BeClosed())
+
+		later, _ := watcher.Subscribe()
+		Expect(later).To(BeClosed())
+		Expect(watcher.Poll()).To(Succeed())
+	})
+
+	Context("when reading the snapshot fails", func() {
+		BeforeEach(func() {
+			fakeSnapshots.SnapshotReturns(nil, errors.New("potato"))
+		})
+
+		It("returns the error", func() {
+			subscriber, _ := watcher.Subscribe()
+
+			Expect(watcher.Poll()).To(MatchError("potato"))
+			Expect(subscriber).NotTo(Receive())
+		})
+	})
+})
@@ -0,0 +1,195 @@
+package server_metrics
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+	"unicode"
+
+	"code.cloudfoundry.org/cf-networking-helpers/metrics"
+	"code.cloudfoundry.org/lager"
+)
+
+// DurationBuckets are the upper bounds, in seconds, of the histogram buckets
+// for durations.
+var DurationBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Registry keeps the counters and durations sent to it, and serves them with
+// the values of Sources in the Prometheus text exposition format. Metric
+// names are the names sent to it in snake case, prefixed with Namespace:
+// counters get a _total suffix, and durations become histograms with a
+// _seconds suffix.
+//
+// Sources are read on every scrape.
+type Registry struct {
+	Namespace string
+	Sources   []metrics.MetricSource
+	Logger    lager.Logger
+
+	mutex      sync.Mutex
+	counters   map[string]float64
+	histograms map[string]*histogram
+}
+
+type histogram struct {
+	buckets []uint64
+	count   uint64
+	sum     float64
+}
+
+func (r *Registry) IncrementCounter(name string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.counters == nil {
+		r.counters = map[string]float64{}
+	}
+	r.counters[r.metricName(name)+"_total"]++
+}
+
+func (r *Registry) SendDuration(name string, duration time.Duration) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.histograms == nil {
+		r.histograms = map[string]*histogram{}
+	}
+	metricName := r.metricName(name) + "_seconds"
+	h, ok := r.histograms[metricName]
+	if !ok {
+		h = &histogram{buckets: make([]uint64, len(DurationBuckets))}
+		r.histograms[metricName] = h
+	}
+
+	seconds := duration.Seconds()
+	for i, upperBound := range DurationBuckets {
+		if seconds <= upperBound {
+			h.buckets[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var body bytes.Buffer
+	r.writeGauges(&body)
+	r.writeCounters(&body)
+	r.writeHistograms(&body)
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body.Bytes())
+}
+
+func (r *Registry) writeGauges(body *bytes.Buffer) {
+	for _, source := range r.Sources {
+		value, err := source.Getter()
+		if err != nil {
+			r.Logger.Error("reading-metric-source", err, lager.Data{"source": source.Name})
+			continue
+		}
+		name := r.metricName(source.Name)
+		fmt.Fprintf(body, "# TYPE %s gauge\n", name)
+		fmt.Fprintf(body, "%s %s\n", name, formatFloat(value))
+	}
+}
+
+func (r *Registry) writeCounters(body *bytes.Buffer) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, name := range sortedKeys(r.counters) {
+		fmt.Fprintf(body, "# TYPE %s counter\n", name)
+		fmt.Fprintf(body, "%s %s\n", name, formatFloat(r.counters[name]))
+	}
+}
+
+func (r *Registry) writeHistograms(body *bytes.Buffer) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	names := []string{}
+	for name := range r.histograms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		h := r.histograms[name]
+		fmt.Fprintf(body, "# TYPE %s histogram\n", name)
+		for i, upperBound := range DurationBuckets {
+			fmt.Fprintf(body, "%s_bucket{le=\"%s\"} %d\n", name, formatFloat(upperBound), h.buckets[i])
+		}
+		fmt.Fprintf(body, "%s_bucket{le=\"+Inf\"} %d\n", name, h.count)
+		fmt.Fprintf(body, "%s_sum %s\n", name, formatFloat(h.sum))
+		fmt.Fprintf(body, "%s_count %d\n", name, h.count)
+	}
+}
+
+func (r *Registry) metricName(name string) string {
+	if r.Namespace == "" {
+		return snakeCase(name)
+	}
+	return r.Namespace + "_" + snakeCase(name)
+}
+
+// snakeCase turns names like CCGetAppSpacesSuccessTime and totalPolicies into
+// cc_get_app_spaces_success_time and total_policies.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var out []rune
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			previous := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextIsLower) {
+				out = append(out, '_')
+			}
+		}
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			r = '_'
+		}
+		out = append(out, unicode.ToLower(r))
+	}
+	return string(out)
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func sortedKeys(m map[string]float64) []string {
+	keys := []string{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Senders sends each metric to all of its senders, such as the dropsonde
+// metrics sender and a Registry.
+type Senders []metricsSender
+
+//go:generate counterfeiter -o fakes/metrics_sender.go --fake-name MetricsSender . metricsSender
+type metricsSender interface {
+	IncrementCounter(string)
+	SendDuration(string, time.Duration)
+}
+
+func (s Senders) IncrementCounter(name string) {
+	for _, sender := range s {
+		sender.IncrementCounter(name)
+	}
+}
+
+func (s Senders) SendDuration(name string, duration time.Duration) {
+	for _, sender := range s {
+		sender.SendDuration(name, duration)
+	}
+}
@@ -0,0 +1,126 @@
+package server_metrics_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"policy-server/server_metrics"
+	"policy-server/server_metrics/fakes"
+	"time"
+
+	"code.cloudfoundry.org/cf-networking-helpers/metrics"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/onsi/gomega/gbytes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Registry", func() {
+	var (
+		registry *server_metrics.Registry
+		logger   *lagertest.TestLogger
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		registry = &server_metrics.Registry{
+			Namespace: "policy_server",
+			Logger:    logger,
+		}
+	})
+
+	scrape := func() *httptest.ResponseRecorder {
+		request, err := http.NewRequest("GET", "/metrics", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		resp := httptest.NewRecorder()
+		registry.ServeHTTP(resp, request)
+		return resp
+	}
+
+	It("serves the values of its sources as gauges", func() {
+		registry.Sources = []metrics.MetricSource{{
+			Name:   "totalPolicies",
+			Getter: func() (float64, error) { return 42, nil },
+		}}
+
+		resp := scrape()
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(resp.Header().Get("Content-Type")).To(Equal("text/plain; version=0.0.4"))
+		Expect(resp.Body.String()).To(Equal(
+			"# TYPE policy_server_total_policies gauge\n" +
+				"policy_server_total_policies 42\n",
+		))
+	})
+
+	It("skips sources that fail and logs the error", func() {
+		registry.Sources = []metrics.MetricSource{{
+			Name:   "tagsUsed",
+			Getter: func() (float64, error) { return 0, errors.New("banana") },
+		}, {
+			Name:   "tagsFree",
+			Getter: func() (float64, error) { return 7, nil },
+		}}
+
+		Expect(scrape().Body.String()).To(Equal(
+			"# TYPE policy_server_tags_free gauge\n" +
+				"policy_server_tags_free 7\n",
+		))
+		Expect(logger).To(gbytes.Say("reading-metric-source.*banana"))
+	})
+
+	It("serves counters, sorted by name", func() {
+		registry.IncrementCounter("StoreAllError")
+		registry.IncrementCounter("CCGetAppSpacesError")
+		registry.IncrementCounter("StoreAllError")
+
+		Expect(scrape().Body.String()).To(Equal(
+			"# TYPE policy_server_cc_get_app_spaces_error_total counter\n" +
+				"policy_server_cc_get_app_spaces_error_total 1\n" +
+				"# TYPE policy_server_store_all_error_total counter\n" +
+				"policy_server_store_all_error_total 2\n",
+		))
+	})
+
+	It("serves durations as histograms in seconds", func() {
+		registry.SendDuration("UAAGetTokenSuccessTime", 20*time.Millisecond)
+		registry.SendDuration("UAAGetTokenSuccessTime", 3*time.Second)
+
+		Expect(scrape().Body.String()).To(Equal(
+			"# TYPE policy_server_uaa_get_token_success_time_seconds histogram\n" +
+				"policy_server_uaa_get_token_success_time_seconds_bucket{le=\"0.005\"} 0\n" +
+				"policy_server_uaa_get_token_success_time_seconds_bucket{le=\"0.01\"} 0\n" +
+				"policy_server_uaa_get_token_success_time_seconds_bucket{le=\"0.025\"} 1\n" +
+				"policy_server_uaa_get_token_success_time_seconds_bucket{le=\"0.05\"} 1\n" +
+				"policy_server_uaa_get_token_success_time_seconds_bucket{le=\"0.1\"} 1\n" +
+				"policy_server_uaa_get_token_success_time_seconds_bucket{le=\"0.25\"} 1\n" +
+				"policy_server_uaa_get_token_success_time_seconds_bucket{le=\"0.5\"} 1\n" +
+				"policy_server_uaa_get_token_success_time_seconds_bucket{le=\"1\"} 1\n" +
+				"policy_server_uaa_get_token_success_time_seconds_bucket{le=\"2.5\"} 1\n" +
+				"policy_server_uaa_get_token_success_time_seconds_bucket{le=\"5\"} 2\n" +
+				"policy_server_uaa_get_token_success_time_seconds_bucket{le=\"10\"} 2\n" +
+				"policy_server_uaa_get_token_success_time_seconds_bucket{le=\"+Inf\"} 2\n" +
+				"policy_server_uaa_get_token_success_time_seconds_sum 3.02\n" +
+				"policy_server_uaa_get_token_success_time_seconds_count 2\n",
+		))
+	})
+})
+
+var _ = Describe("Senders", func() {
+	It("sends each metric to all of its senders", func() {
+		sender1 := &fakes.MetricsSender{}
+		sender2 := &fakes.MetricsSender{}
+		senders := server_metrics.Senders{sender1, sender2}
+
+		senders.IncrementCounter("SomeError")
+		senders.SendDuration("SomeTime", time.Second)
+
+		for _, sender := range []*fakes.MetricsSender{sender1, sender2} {
+			Expect(sender.IncrementCounterArgsForCall(0)).To(Equal("SomeError"))
+			name, duration := sender.SendDurationArgsForCall(0)
+			Expect(name).To(Equal("SomeTime"))
+			Expect(duration).To(Equal(time.Second))
+		}
+	})
+})
@@ -20,3 +20,32 @@ func NewTotalPoliciesSource(lister listStore) metrics.MetricSource {
 		},
 	}
 }
+
+//go:generate counterfeiter -o fakes/tag_usage_store.go --fake-name TagUsageStore . tagUsageStore
+type tagUsageStore interface {
+	TagUsage() (store.TagUsage, error)
+}
+
+func NewTagsUsedSource(tagStore tagUsageStore) metrics.MetricSource {
+	return metrics.MetricSource{
+		Name: "tagsUsed",
+		Unit: "",
+		Getter: func() (float64, error) {
+			usage, err := tagStore.TagUsage()
+			return float64(usage.Used), err
+		},
+	}
+}
+
+// NewTagsFreeSource reports the tags that can be allocated right now. Tags in
+// quarantine are not counted.
+func NewTagsFreeSource(tagStore tagUsageStore) metrics.MetricSource {
+	return metrics.MetricSource{
+		Name: "tagsFree",
+		Unit: "",
+		Getter: func() (float64, error) {
+			usage, err := tagStore.TagUsage()
+			return float64(usage.Free), err
+		},
+	}
+}
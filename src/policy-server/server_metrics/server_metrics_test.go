@@ -1,6 +1,7 @@
 package server_metrics_test
 
 import (
+	"errors"
 	"policy-server/server_metrics"
 	"policy-server/server_metrics/fakes"
 
@@ -56,3 +57,41 @@ var _ = Describe("NewTotalPoliciesSource", func() {
 		})
 	})
 })
+
+var _ = Describe("Tag usage sources", func() {
+	var fakeTagStore *fakes.TagUsageStore
+
+	BeforeEach(func() {
+		fakeTagStore = &fakes.TagUsageStore{}
+		fakeTagStore.TagUsageReturns(store.TagUsage{Used: 3, Free: 250, Quarantined: 2}, nil)
+	})
+
+	It("returns the number of used tags", func() {
+		source := server_metrics.NewTagsUsedSource(fakeTagStore)
+		Expect(source.Name).To(Equal("tagsUsed"))
+
+		value, err := source.Getter()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value).To(Equal(3.0))
+	})
+
+	It("returns the number of free tags", func() {
+		source := server_metrics.NewTagsFreeSource(fakeTagStore)
+		Expect(source.Name).To(Equal("tagsFree"))
+
+		value, err := source.Getter()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value).To(Equal(250.0))
+	})
+
+	Context("when counting the tags fails", func() {
+		BeforeEach(func() {
+			fakeTagStore.TagUsageReturns(store.TagUsage{}, errors.New("banana"))
+		})
+
+		It("returns the error", func() {
+			_, err := server_metrics.NewTagsFreeSource(fakeTagStore).Getter()
+			Expect(err).To(MatchError("banana"))
+		})
+	})
+})
@@ -1,6 +1,9 @@
 package store
 
-import "policy-server/db"
+import (
+	"policy-server/db"
+	"policy-server/store/helpers"
+)
 
 //go:generate counterfeiter -o fakes/destination_repo.go --fake-name DestinationRepo . DestinationRepo
 type DestinationRepo interface {
@@ -59,6 +62,8 @@ func (d *DestinationTable) GetID(tx db.Transaction, destinationGroupId, port, st
 	lockStatement := " FOR UPDATE "
 	if tx.DriverName() == "mysql" {
 		lockStatement = " LOCK IN SHARE MODE "
+	} else if tx.DriverName() == helpers.SQLite {
+		lockStatement = ""
 	}
 	err := tx.QueryRow(tx.Rebind(`
 		SELECT id FROM destinations
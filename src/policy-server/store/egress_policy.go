@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"policy-server/db"
+	"policy-server/store/helpers"
 	"strings"
 )
 
@@ -14,7 +15,7 @@ type EgressPolicyTable struct {
 func (e *EgressPolicyTable) CreateTerminal(tx db.Transaction) (int64, error) {
 	driverName := tx.DriverName()
 
-	if driverName == "mysql" {
+	if driverName == "mysql" || driverName == helpers.SQLite {
 		result, err := tx.Exec("INSERT INTO terminals (id) VALUES (NULL)")
 		if err != nil {
 			return -1, err
@@ -38,7 +39,7 @@ func (e *EgressPolicyTable) CreateTerminal(tx db.Transaction) (int64, error) {
 func (e *EgressPolicyTable) CreateApp(tx db.Transaction, sourceTerminalID int64, appGUID string) (int64, error) {
 	driverName := tx.DriverName()
 
-	if driverName == "mysql" {
+	if driverName == "mysql" || driverName == helpers.SQLite {
 		result, err := tx.Exec(tx.Rebind(`
 			INSERT INTO apps (terminal_id, app_guid) 
 			VALUES (?,?)
@@ -74,7 +75,7 @@ func (e *EgressPolicyTable) CreateApp(tx db.Transaction, sourceTerminalID int64,
 
 func (e *EgressPolicyTable) CreateIPRange(tx db.Transaction, destinationTerminalID int64, startIP, endIP, protocol string) (int64, error) {
 	driverName := tx.DriverName()
-	if driverName == "mysql" {
+	if driverName == "mysql" || driverName == helpers.SQLite {
 		result, err := tx.Exec(tx.Rebind(`
 			INSERT INTO ip_ranges (protocol, start_ip, end_ip, terminal_id) 
 			VALUES (?,?,?,?)
@@ -116,7 +117,7 @@ func (e *EgressPolicyTable) CreateIPRange(tx db.Transaction, destinationTerminal
 
 func (e *EgressPolicyTable) CreateEgressPolicy(tx db.Transaction, sourceTerminalID, destinationTerminalID int64) (int64, error) {
 	driverName := tx.DriverName()
-	if driverName == "mysql" {
+	if driverName == "mysql" || driverName == helpers.SQLite {
 		result, err := tx.Exec(tx.Rebind(`
 			INSERT INTO egress_policies (source_id, destination_id) 
 			VALUES (?,?)
@@ -65,6 +65,10 @@ func (e *EgressPolicyStore) CreateWithTx(tx db.Transaction, policies []EgressPol
 			return fmt.Errorf("failed to create egress policy: %s", err)
 		}
 	}
+
+	if len(policies) > 0 {
+		return bumpRevision(tx)
+	}
 	return nil
 }
 
@@ -108,6 +112,9 @@ func (e *EgressPolicyStore) DeleteWithTx(tx db.Transaction, egressPolicies []Egr
 		}
 	}
 
+	if len(egressPolicies) > 0 {
+		return bumpRevision(tx)
+	}
 	return nil
 }
 
@@ -178,6 +178,28 @@ var _ = Describe("EgressPolicyStore", func() {
 			err := egressPolicyStore.CreateWithTx(tx, egressPolicies)
 			Expect(err).To(MatchError("failed to get terminal by app guid: OMG WHY DID THIS FAIL"))
 		})
+
+		It("increments the policy revision", func() {
+			err := egressPolicyStore.CreateWithTx(tx, egressPolicies)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(tx.ExecCallCount()).To(Equal(1))
+			query, _ := tx.ExecArgsForCall(0)
+			Expect(query).To(Equal("UPDATE policy_revision SET revision = revision + 1 WHERE id = 1"))
+		})
+
+		It("does not increment the policy revision when there are no policies", func() {
+			err := egressPolicyStore.CreateWithTx(tx, []store.EgressPolicy{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tx.ExecCallCount()).To(Equal(0))
+		})
+
+		It("returns an error when incrementing the policy revision fails", func() {
+			tx.ExecReturns(nil, errors.New("OMG WHY DID THIS FAIL"))
+
+			err := egressPolicyStore.CreateWithTx(tx, egressPolicies)
+			Expect(err).To(MatchError("updating policy revision: OMG WHY DID THIS FAIL"))
+		})
 	})
 
 	Describe("DeleteWithTx", func() {
@@ -258,6 +280,15 @@ var _ = Describe("EgressPolicyStore", func() {
 			Expect(passedSrcTerminalID).To(Equal(srcTerminalID))
 		})
 
+		It("increments the policy revision", func() {
+			err := egressPolicyStore.DeleteWithTx(tx, egressPoliciesToDelete)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(tx.ExecCallCount()).To(Equal(1))
+			query, _ := tx.ExecArgsForCall(0)
+			Expect(query).To(Equal("UPDATE policy_revision SET revision = revision + 1 WHERE id = 1"))
+		})
+
 		Context("when there are multiple egress policies", func() {
 			BeforeEach(func() {
 				egressPoliciesToDelete = append(egressPoliciesToDelete, store.EgressPolicy{
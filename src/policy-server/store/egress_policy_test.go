@@ -16,7 +16,6 @@ import (
 	dbfakes "policy-server/db/fakes"
 
 	dbHelper "code.cloudfoundry.org/cf-networking-helpers/db"
-	"code.cloudfoundry.org/cf-networking-helpers/testsupport"
 	"code.cloudfoundry.org/lager"
 )
 
@@ -35,8 +34,8 @@ var _ = Describe("Egress Policy Table", func() {
 		var err error
 		mockDb = &fakes.Db{}
 
-		dbConf = testsupport.GetDBConfig()
-		dbConf.DatabaseName = fmt.Sprintf("store_test_node_%d", time.Now().UnixNano())
+		dbConf = testhelpers.GetDBConfig()
+		dbConf.DatabaseName = testhelpers.DatabaseName(dbConf, fmt.Sprintf("store_test_node_%d", time.Now().UnixNano()))
 		dbConf.Timeout = 30
 		testhelpers.CreateDatabase(dbConf)
 
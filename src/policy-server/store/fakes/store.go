@@ -31,6 +31,19 @@ type Store struct {
 		result1 []store.Policy
 		result2 error
 	}
+	AllWithTxStub        func(db.Transaction) ([]store.Policy, error)
+	allWithTxMutex       sync.RWMutex
+	allWithTxArgsForCall []struct {
+		arg1 db.Transaction
+	}
+	allWithTxReturns struct {
+		result1 []store.Policy
+		result2 error
+	}
+	allWithTxReturnsOnCall map[int]struct {
+		result1 []store.Policy
+		result2 error
+	}
 	DeleteStub        func([]store.Policy) error
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
@@ -179,6 +192,57 @@ func (fake *Store) AllReturnsOnCall(i int, result1 []store.Policy, result2 error
 	}{result1, result2}
 }
 
+func (fake *Store) AllWithTx(arg1 db.Transaction) ([]store.Policy, error) {
+	fake.allWithTxMutex.Lock()
+	ret, specificReturn := fake.allWithTxReturnsOnCall[len(fake.allWithTxArgsForCall)]
+	fake.allWithTxArgsForCall = append(fake.allWithTxArgsForCall, struct {
+		arg1 db.Transaction
+	}{arg1})
+	fake.recordInvocation("AllWithTx", []interface{}{arg1})
+	fake.allWithTxMutex.Unlock()
+	if fake.AllWithTxStub != nil {
+		return fake.AllWithTxStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.allWithTxReturns.result1, fake.allWithTxReturns.result2
+}
+
+func (fake *Store) AllWithTxCallCount() int {
+	fake.allWithTxMutex.RLock()
+	defer fake.allWithTxMutex.RUnlock()
+	return len(fake.allWithTxArgsForCall)
+}
+
+func (fake *Store) AllWithTxArgsForCall(i int) db.Transaction {
+	fake.allWithTxMutex.RLock()
+	defer fake.allWithTxMutex.RUnlock()
+	return fake.allWithTxArgsForCall[i].arg1
+}
+
+func (fake *Store) AllWithTxReturns(result1 []store.Policy, result2 error) {
+	fake.AllWithTxStub = nil
+	fake.allWithTxReturns = struct {
+		result1 []store.Policy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Store) AllWithTxReturnsOnCall(i int, result1 []store.Policy, result2 error) {
+	fake.AllWithTxStub = nil
+	if fake.allWithTxReturnsOnCall == nil {
+		fake.allWithTxReturnsOnCall = make(map[int]struct {
+			result1 []store.Policy
+			result2 error
+		})
+	}
+	fake.allWithTxReturnsOnCall[i] = struct {
+		result1 []store.Policy
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Store) Delete(arg1 []store.Policy) error {
 	var arg1Copy []store.Policy
 	if arg1 != nil {
@@ -396,6 +460,8 @@ func (fake *Store) Invocations() map[string][][]interface{} {
 	defer fake.createWithTxMutex.RUnlock()
 	fake.allMutex.RLock()
 	defer fake.allMutex.RUnlock()
+	fake.allWithTxMutex.RLock()
+	defer fake.allWithTxMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
 	fake.deleteWithTxMutex.RLock()
@@ -32,6 +32,43 @@ type TagStore struct {
 		result1 []store.Tag
 		result2 error
 	}
+	TagsByGuidsStub        func([]string) ([]store.Tag, error)
+	tagsByGuidsMutex       sync.RWMutex
+	tagsByGuidsArgsForCall []struct {
+		arg1 []string
+	}
+	tagsByGuidsReturns struct {
+		result1 []store.Tag
+		result2 error
+	}
+	tagsByGuidsReturnsOnCall map[int]struct {
+		result1 []store.Tag
+		result2 error
+	}
+	ReleaseTagsStub        func([]store.Tag) ([]store.Tag, error)
+	releaseTagsMutex       sync.RWMutex
+	releaseTagsArgsForCall []struct {
+		arg1 []store.Tag
+	}
+	releaseTagsReturns struct {
+		result1 []store.Tag
+		result2 error
+	}
+	releaseTagsReturnsOnCall map[int]struct {
+		result1 []store.Tag
+		result2 error
+	}
+	TagUsageStub        func() (store.TagUsage, error)
+	tagUsageMutex       sync.RWMutex
+	tagUsageArgsForCall []struct{}
+	tagUsageReturns     struct {
+		result1 store.TagUsage
+		result2 error
+	}
+	tagUsageReturnsOnCall map[int]struct {
+		result1 store.TagUsage
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -131,6 +168,161 @@ func (fake *TagStore) TagsReturnsOnCall(i int, result1 []store.Tag, result2 erro
 	}{result1, result2}
 }
 
+func (fake *TagStore) TagsByGuids(arg1 []string) ([]store.Tag, error) {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.tagsByGuidsMutex.Lock()
+	ret, specificReturn := fake.tagsByGuidsReturnsOnCall[len(fake.tagsByGuidsArgsForCall)]
+	fake.tagsByGuidsArgsForCall = append(fake.tagsByGuidsArgsForCall, struct {
+		arg1 []string
+	}{arg1Copy})
+	fake.recordInvocation("TagsByGuids", []interface{}{arg1Copy})
+	fake.tagsByGuidsMutex.Unlock()
+	if fake.TagsByGuidsStub != nil {
+		return fake.TagsByGuidsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.tagsByGuidsReturns.result1, fake.tagsByGuidsReturns.result2
+}
+
+func (fake *TagStore) TagsByGuidsCallCount() int {
+	fake.tagsByGuidsMutex.RLock()
+	defer fake.tagsByGuidsMutex.RUnlock()
+	return len(fake.tagsByGuidsArgsForCall)
+}
+
+func (fake *TagStore) TagsByGuidsArgsForCall(i int) []string {
+	fake.tagsByGuidsMutex.RLock()
+	defer fake.tagsByGuidsMutex.RUnlock()
+	return fake.tagsByGuidsArgsForCall[i].arg1
+}
+
+func (fake *TagStore) TagsByGuidsReturns(result1 []store.Tag, result2 error) {
+	fake.TagsByGuidsStub = nil
+	fake.tagsByGuidsReturns = struct {
+		result1 []store.Tag
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TagStore) TagsByGuidsReturnsOnCall(i int, result1 []store.Tag, result2 error) {
+	fake.TagsByGuidsStub = nil
+	if fake.tagsByGuidsReturnsOnCall == nil {
+		fake.tagsByGuidsReturnsOnCall = make(map[int]struct {
+			result1 []store.Tag
+			result2 error
+		})
+	}
+	fake.tagsByGuidsReturnsOnCall[i] = struct {
+		result1 []store.Tag
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TagStore) ReleaseTags(arg1 []store.Tag) ([]store.Tag, error) {
+	var arg1Copy []store.Tag
+	if arg1 != nil {
+		arg1Copy = make([]store.Tag, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.releaseTagsMutex.Lock()
+	ret, specificReturn := fake.releaseTagsReturnsOnCall[len(fake.releaseTagsArgsForCall)]
+	fake.releaseTagsArgsForCall = append(fake.releaseTagsArgsForCall, struct {
+		arg1 []store.Tag
+	}{arg1Copy})
+	fake.recordInvocation("ReleaseTags", []interface{}{arg1Copy})
+	fake.releaseTagsMutex.Unlock()
+	if fake.ReleaseTagsStub != nil {
+		return fake.ReleaseTagsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.releaseTagsReturns.result1, fake.releaseTagsReturns.result2
+}
+
+func (fake *TagStore) ReleaseTagsCallCount() int {
+	fake.releaseTagsMutex.RLock()
+	defer fake.releaseTagsMutex.RUnlock()
+	return len(fake.releaseTagsArgsForCall)
+}
+
+func (fake *TagStore) ReleaseTagsArgsForCall(i int) []store.Tag {
+	fake.releaseTagsMutex.RLock()
+	defer fake.releaseTagsMutex.RUnlock()
+	return fake.releaseTagsArgsForCall[i].arg1
+}
+
+func (fake *TagStore) ReleaseTagsReturns(result1 []store.Tag, result2 error) {
+	fake.ReleaseTagsStub = nil
+	fake.releaseTagsReturns = struct {
+		result1 []store.Tag
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TagStore) ReleaseTagsReturnsOnCall(i int, result1 []store.Tag, result2 error) {
+	fake.ReleaseTagsStub = nil
+	if fake.releaseTagsReturnsOnCall == nil {
+		fake.releaseTagsReturnsOnCall = make(map[int]struct {
+			result1 []store.Tag
+			result2 error
+		})
+	}
+	fake.releaseTagsReturnsOnCall[i] = struct {
+		result1 []store.Tag
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TagStore) TagUsage() (store.TagUsage, error) {
+	fake.tagUsageMutex.Lock()
+	ret, specificReturn := fake.tagUsageReturnsOnCall[len(fake.tagUsageArgsForCall)]
+	fake.tagUsageArgsForCall = append(fake.tagUsageArgsForCall, struct{}{})
+	fake.recordInvocation("TagUsage", []interface{}{})
+	fake.tagUsageMutex.Unlock()
+	if fake.TagUsageStub != nil {
+		return fake.TagUsageStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.tagUsageReturns.result1, fake.tagUsageReturns.result2
+}
+
+func (fake *TagStore) TagUsageCallCount() int {
+	fake.tagUsageMutex.RLock()
+	defer fake.tagUsageMutex.RUnlock()
+	return len(fake.tagUsageArgsForCall)
+}
+
+func (fake *TagStore) TagUsageReturns(result1 store.TagUsage, result2 error) {
+	fake.TagUsageStub = nil
+	fake.tagUsageReturns = struct {
+		result1 store.TagUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TagStore) TagUsageReturnsOnCall(i int, result1 store.TagUsage, result2 error) {
+	fake.TagUsageStub = nil
+	if fake.tagUsageReturnsOnCall == nil {
+		fake.tagUsageReturnsOnCall = make(map[int]struct {
+			result1 store.TagUsage
+			result2 error
+		})
+	}
+	fake.tagUsageReturnsOnCall[i] = struct {
+		result1 store.TagUsage
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *TagStore) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -138,6 +330,12 @@ func (fake *TagStore) Invocations() map[string][][]interface{} {
 	defer fake.createTagMutex.RUnlock()
 	fake.tagsMutex.RLock()
 	defer fake.tagsMutex.RUnlock()
+	fake.tagsByGuidsMutex.RLock()
+	defer fake.tagsByGuidsMutex.RUnlock()
+	fake.releaseTagsMutex.RLock()
+	defer fake.releaseTagsMutex.RUnlock()
+	fake.tagUsageMutex.RLock()
+	defer fake.tagUsageMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -2,10 +2,22 @@ package store
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"policy-server/db"
+	"policy-server/store/helpers"
+	"time"
 )
 
+// ErrTagSpaceExhausted is returned, unwrapped, when there is no free tag to
+// allocate, so that callers can compare against it.
+var ErrTagSpaceExhausted = errors.New("tag space exhausted")
+
+// ErrTagsQuarantined is returned, unwrapped, when every free tag was released
+// too recently to be allocated again. Unlike ErrTagSpaceExhausted it clears
+// once the quarantine has passed.
+var ErrTagsQuarantined = errors.New("all free tags are quarantined")
+
 //go:generate counterfeiter -o fakes/group_repo.go --fake-name GroupRepo . GroupRepo
 type GroupRepo interface {
 	Create(db.Transaction, string, string) (int, error)
@@ -13,7 +25,11 @@ type GroupRepo interface {
 	GetID(db.Transaction, string) (int, error)
 }
 
+// GroupTable allocates tags from the pre-populated groups table. A released
+// tag is not handed out again until Quarantine has passed, so that agents
+// still enforcing the old policies have time to drop them.
 type GroupTable struct {
+	Quarantine time.Duration
 }
 
 func (g *GroupTable) Create(tx db.Transaction, guid, groupType string) (int, error) {
@@ -21,7 +37,9 @@ func (g *GroupTable) Create(tx db.Transaction, guid, groupType string) (int, err
 	if err != nil {
 		if err == sql.ErrNoRows {
 			id, err = g.firstBlankRow(tx)
-			if err != nil {
+			if err == sql.ErrNoRows {
+				return -1, g.noBlankRowError(tx)
+			} else if err != nil {
 				return -1, fmt.Errorf("failed to find available tag: %s", err.Error())
 			} else {
 				err = g.updateRow(tx, id, guid, groupType)
@@ -50,21 +68,42 @@ func (g *GroupTable) findRowByGUID(tx db.Transaction, guid, groupType string) (i
 }
 
 func (g *GroupTable) firstBlankRow(tx db.Transaction) (int, error) {
+	lockStatement := "FOR UPDATE"
+	if tx.DriverName() == helpers.SQLite {
+		lockStatement = ""
+	}
+
 	var id int
 	err := tx.QueryRow(
-		`SELECT id FROM groups
+		tx.Rebind(`SELECT id FROM groups
 		WHERE guid is NULL
+		AND (released_at IS NULL OR released_at <= ?)
 		ORDER BY id
 		LIMIT 1
-		FOR UPDATE
-	`).Scan(&id)
+		`+lockStatement),
+		time.Now().Add(-g.Quarantine).Unix(),
+	).Scan(&id)
 	return id, err
 }
 
+// noBlankRowError tells a full tag space apart from one whose free rows are
+// all still quarantined.
+func (g *GroupTable) noBlankRowError(tx db.Transaction) error {
+	var released int
+	err := tx.QueryRow(`SELECT COUNT(*) FROM groups WHERE guid IS NULL`).Scan(&released)
+	if err != nil {
+		return fmt.Errorf("failed to find available tag: %s", err.Error())
+	}
+	if released > 0 {
+		return ErrTagsQuarantined
+	}
+	return ErrTagSpaceExhausted
+}
+
 func (g *GroupTable) updateRow(tx db.Transaction, id int, guid, groupType string) error {
 	_, err := tx.Exec(
 		tx.Rebind(`
-			UPDATE groups SET guid = ?, type =  ?
+			UPDATE groups SET guid = ?, type =  ?, released_at = NULL
 			WHERE id = ?
 		`),
 		guid,
@@ -76,7 +115,8 @@ func (g *GroupTable) updateRow(tx db.Transaction, id int, guid, groupType string
 
 func (g *GroupTable) Delete(tx db.Transaction, id int) error {
 	_, err := tx.Exec(
-		tx.Rebind(`UPDATE groups SET guid = NULL, type = NULL WHERE id = ?`),
+		tx.Rebind(`UPDATE groups SET guid = NULL, type = NULL, released_at = ? WHERE id = ?`),
+		time.Now().Unix(),
 		id,
 	)
 	return err
@@ -8,6 +8,7 @@ import (
 const (
 	MySQL    = "mysql"
 	Postgres = "postgres"
+	SQLite   = "sqlite3"
 )
 
 func QuestionMarks(count int) string {
@@ -18,7 +19,7 @@ func QuestionMarks(count int) string {
 }
 
 func RebindForSQLDialect(query, dialect string) string {
-	if dialect == MySQL {
+	if dialect == MySQL || dialect == SQLite {
 		return query
 	}
 	if dialect != Postgres {
@@ -56,6 +56,19 @@ func (mw *MetricsWrapper) Delete(policies []Policy) error {
 	return err
 }
 
+func (mw *MetricsWrapper) AllWithTx(tx db.Transaction) ([]Policy, error) {
+	startTime := time.Now()
+	policies, err := mw.Store.AllWithTx(tx)
+	allTimeDuration := time.Now().Sub(startTime)
+	if err != nil {
+		mw.MetricsSender.IncrementCounter("StoreAllWithTxError")
+		mw.MetricsSender.SendDuration("StoreAllWithTxErrorTime", allTimeDuration)
+	} else {
+		mw.MetricsSender.SendDuration("StoreAllWithTxSuccessTime", allTimeDuration)
+	}
+	return policies, err
+}
+
 func (mw *MetricsWrapper) DeleteWithTx(tx db.Transaction, policies []Policy) error {
 	startTime := time.Now()
 	err := mw.Store.DeleteWithTx(tx, policies)
@@ -82,6 +95,19 @@ func (mw *MetricsWrapper) Tags() ([]Tag, error) {
 	return tags, err
 }
 
+func (mw *MetricsWrapper) TagsByGuids(guids []string) ([]Tag, error) {
+	startTime := time.Now()
+	tags, err := mw.TagStore.TagsByGuids(guids)
+	tagsTimeDuration := time.Now().Sub(startTime)
+	if err != nil {
+		mw.MetricsSender.IncrementCounter("StoreTagsByGuidsError")
+		mw.MetricsSender.SendDuration("StoreTagsByGuidsErrorTime", tagsTimeDuration)
+	} else {
+		mw.MetricsSender.SendDuration("StoreTagsByGuidsSuccessTime", tagsTimeDuration)
+	}
+	return tags, err
+}
+
 func (mw *MetricsWrapper) CreateTag(groupGuid, groupType string) (Tag, error) {
 	startTime := time.Now()
 	tag, err := mw.TagStore.CreateTag(groupGuid, groupType)
@@ -95,6 +121,32 @@ func (mw *MetricsWrapper) CreateTag(groupGuid, groupType string) (Tag, error) {
 	return tag, err
 }
 
+func (mw *MetricsWrapper) ReleaseTags(tags []Tag) ([]Tag, error) {
+	startTime := time.Now()
+	released, err := mw.TagStore.ReleaseTags(tags)
+	releaseTagsTimeDuration := time.Now().Sub(startTime)
+	if err != nil {
+		mw.MetricsSender.IncrementCounter("StoreReleaseTagsError")
+		mw.MetricsSender.SendDuration("StoreReleaseTagsErrorTime", releaseTagsTimeDuration)
+	} else {
+		mw.MetricsSender.SendDuration("StoreReleaseTagsSuccessTime", releaseTagsTimeDuration)
+	}
+	return released, err
+}
+
+func (mw *MetricsWrapper) TagUsage() (TagUsage, error) {
+	startTime := time.Now()
+	usage, err := mw.TagStore.TagUsage()
+	tagUsageTimeDuration := time.Now().Sub(startTime)
+	if err != nil {
+		mw.MetricsSender.IncrementCounter("StoreTagUsageError")
+		mw.MetricsSender.SendDuration("StoreTagUsageErrorTime", tagUsageTimeDuration)
+	} else {
+		mw.MetricsSender.SendDuration("StoreTagUsageSuccessTime", tagUsageTimeDuration)
+	}
+	return usage, err
+}
+
 func (mw *MetricsWrapper) ByGuids(srcGuids, dstGuids []string, inSourceAndDest bool) ([]Policy, error) {
 	startTime := time.Now()
 	policies, err := mw.Store.ByGuids(srcGuids, dstGuids, inSourceAndDest)
@@ -183,6 +183,46 @@ var _ = Describe("MetricsWrapper", func() {
 		})
 	})
 
+	Describe("AllWithTx", func() {
+		BeforeEach(func() {
+			fakeStore.AllWithTxReturns(policies, nil)
+		})
+		It("returns the result of AllWithTx on the Store", func() {
+			returnedPolicies, err := metricsWrapper.AllWithTx(tx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(returnedPolicies).To(Equal(policies))
+
+			Expect(fakeStore.AllWithTxCallCount()).To(Equal(1))
+			Expect(fakeStore.AllWithTxArgsForCall(0)).To(Equal(tx))
+		})
+
+		It("emits a metric", func() {
+			_, err := metricsWrapper.AllWithTx(tx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeMetricsSender.SendDurationCallCount()).To(Equal(1))
+			name, _ := fakeMetricsSender.SendDurationArgsForCall(0)
+			Expect(name).To(Equal("StoreAllWithTxSuccessTime"))
+		})
+
+		Context("when there is an error", func() {
+			BeforeEach(func() {
+				fakeStore.AllWithTxReturns(nil, errors.New("banana"))
+			})
+			It("emits an error metric", func() {
+				_, err := metricsWrapper.AllWithTx(tx)
+				Expect(err).To(MatchError("banana"))
+
+				Expect(fakeMetricsSender.IncrementCounterCallCount()).To(Equal(1))
+				Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("StoreAllWithTxError"))
+
+				Expect(fakeMetricsSender.SendDurationCallCount()).To(Equal(1))
+				name, _ := fakeMetricsSender.SendDurationArgsForCall(0)
+				Expect(name).To(Equal("StoreAllWithTxErrorTime"))
+			})
+		})
+	})
+
 	Describe("ByGuids", func() {
 		BeforeEach(func() {
 			fakeStore.ByGuidsReturns(policies, nil)
@@ -376,4 +416,122 @@ var _ = Describe("MetricsWrapper", func() {
 			})
 		})
 	})
+
+	Describe("TagsByGuids", func() {
+		BeforeEach(func() {
+			fakeTagStore.TagsByGuidsReturns(tags, nil)
+		})
+		It("calls TagsByGuids on the Store", func() {
+			returnedTags, err := metricsWrapper.TagsByGuids([]string{"some-guid"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(returnedTags).To(Equal(tags))
+
+			Expect(fakeTagStore.TagsByGuidsCallCount()).To(Equal(1))
+			Expect(fakeTagStore.TagsByGuidsArgsForCall(0)).To(Equal([]string{"some-guid"}))
+		})
+
+		It("emits a metric", func() {
+			_, err := metricsWrapper.TagsByGuids([]string{"some-guid"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeMetricsSender.SendDurationCallCount()).To(Equal(1))
+			name, _ := fakeMetricsSender.SendDurationArgsForCall(0)
+			Expect(name).To(Equal("StoreTagsByGuidsSuccessTime"))
+		})
+
+		Context("when there is an error", func() {
+			BeforeEach(func() {
+				fakeTagStore.TagsByGuidsReturns(nil, errors.New("banana"))
+			})
+			It("emits an error metric", func() {
+				_, err := metricsWrapper.TagsByGuids([]string{"some-guid"})
+				Expect(err).To(MatchError("banana"))
+
+				Expect(fakeMetricsSender.IncrementCounterCallCount()).To(Equal(1))
+				Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("StoreTagsByGuidsError"))
+
+				Expect(fakeMetricsSender.SendDurationCallCount()).To(Equal(1))
+				name, _ := fakeMetricsSender.SendDurationArgsForCall(0)
+				Expect(name).To(Equal("StoreTagsByGuidsErrorTime"))
+			})
+		})
+	})
+
+	Describe("ReleaseTags", func() {
+		BeforeEach(func() {
+			fakeTagStore.ReleaseTagsReturns(tags, nil)
+		})
+		It("calls ReleaseTags on the Store", func() {
+			releasedTags, err := metricsWrapper.ReleaseTags(tags)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(releasedTags).To(Equal(tags))
+
+			Expect(fakeTagStore.ReleaseTagsCallCount()).To(Equal(1))
+			Expect(fakeTagStore.ReleaseTagsArgsForCall(0)).To(Equal(tags))
+		})
+
+		It("emits a metric", func() {
+			_, err := metricsWrapper.ReleaseTags(tags)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeMetricsSender.SendDurationCallCount()).To(Equal(1))
+			name, _ := fakeMetricsSender.SendDurationArgsForCall(0)
+			Expect(name).To(Equal("StoreReleaseTagsSuccessTime"))
+		})
+
+		Context("when there is an error", func() {
+			BeforeEach(func() {
+				fakeTagStore.ReleaseTagsReturns(nil, errors.New("banana"))
+			})
+			It("emits an error metric", func() {
+				_, err := metricsWrapper.ReleaseTags(tags)
+				Expect(err).To(MatchError("banana"))
+
+				Expect(fakeMetricsSender.IncrementCounterCallCount()).To(Equal(1))
+				Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("StoreReleaseTagsError"))
+
+				Expect(fakeMetricsSender.SendDurationCallCount()).To(Equal(1))
+				name, _ := fakeMetricsSender.SendDurationArgsForCall(0)
+				Expect(name).To(Equal("StoreReleaseTagsErrorTime"))
+			})
+		})
+	})
+
+	Describe("TagUsage", func() {
+		BeforeEach(func() {
+			fakeTagStore.TagUsageReturns(store.TagUsage{Used: 3, Free: 250, Quarantined: 2}, nil)
+		})
+		It("calls TagUsage on the Store", func() {
+			usage, err := metricsWrapper.TagUsage()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usage).To(Equal(store.TagUsage{Used: 3, Free: 250, Quarantined: 2}))
+			Expect(fakeTagStore.TagUsageCallCount()).To(Equal(1))
+		})
+
+		It("emits a metric", func() {
+			_, err := metricsWrapper.TagUsage()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeMetricsSender.SendDurationCallCount()).To(Equal(1))
+			name, _ := fakeMetricsSender.SendDurationArgsForCall(0)
+			Expect(name).To(Equal("StoreTagUsageSuccessTime"))
+		})
+
+		Context("when there is an error", func() {
+			BeforeEach(func() {
+				fakeTagStore.TagUsageReturns(store.TagUsage{}, errors.New("banana"))
+			})
+			It("emits an error metric", func() {
+				_, err := metricsWrapper.TagUsage()
+				Expect(err).To(MatchError("banana"))
+
+				Expect(fakeMetricsSender.IncrementCounterCallCount()).To(Equal(1))
+				Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("StoreTagUsageError"))
+
+				Expect(fakeMetricsSender.SendDurationCallCount()).To(Equal(1))
+				name, _ := fakeMetricsSender.SendDurationArgsForCall(0)
+				Expect(name).To(Equal("StoreTagUsageErrorTime"))
+			})
+		})
+	})
 })
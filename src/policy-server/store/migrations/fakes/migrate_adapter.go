@@ -26,6 +26,20 @@ type MigrateAdapter struct {
 		result1 int
 		result2 error
 	}
+	GetMigrationRecordsStub        func(db migrations.MigrationDb, dialect string) ([]*migrate.MigrationRecord, error)
+	getMigrationRecordsMutex       sync.RWMutex
+	getMigrationRecordsArgsForCall []struct {
+		db      migrations.MigrationDb
+		dialect string
+	}
+	getMigrationRecordsReturns struct {
+		result1 []*migrate.MigrationRecord
+		result2 error
+	}
+	getMigrationRecordsReturnsOnCall map[int]struct {
+		result1 []*migrate.MigrationRecord
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -85,11 +99,65 @@ func (fake *MigrateAdapter) ExecMaxReturnsOnCall(i int, result1 int, result2 err
 	}{result1, result2}
 }
 
+func (fake *MigrateAdapter) GetMigrationRecords(db migrations.MigrationDb, dialect string) ([]*migrate.MigrationRecord, error) {
+	fake.getMigrationRecordsMutex.Lock()
+	ret, specificReturn := fake.getMigrationRecordsReturnsOnCall[len(fake.getMigrationRecordsArgsForCall)]
+	fake.getMigrationRecordsArgsForCall = append(fake.getMigrationRecordsArgsForCall, struct {
+		db      migrations.MigrationDb
+		dialect string
+	}{db, dialect})
+	fake.recordInvocation("GetMigrationRecords", []interface{}{db, dialect})
+	fake.getMigrationRecordsMutex.Unlock()
+	if fake.GetMigrationRecordsStub != nil {
+		return fake.GetMigrationRecordsStub(db, dialect)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getMigrationRecordsReturns.result1, fake.getMigrationRecordsReturns.result2
+}
+
+func (fake *MigrateAdapter) GetMigrationRecordsCallCount() int {
+	fake.getMigrationRecordsMutex.RLock()
+	defer fake.getMigrationRecordsMutex.RUnlock()
+	return len(fake.getMigrationRecordsArgsForCall)
+}
+
+func (fake *MigrateAdapter) GetMigrationRecordsArgsForCall(i int) (migrations.MigrationDb, string) {
+	fake.getMigrationRecordsMutex.RLock()
+	defer fake.getMigrationRecordsMutex.RUnlock()
+	return fake.getMigrationRecordsArgsForCall[i].db, fake.getMigrationRecordsArgsForCall[i].dialect
+}
+
+func (fake *MigrateAdapter) GetMigrationRecordsReturns(result1 []*migrate.MigrationRecord, result2 error) {
+	fake.GetMigrationRecordsStub = nil
+	fake.getMigrationRecordsReturns = struct {
+		result1 []*migrate.MigrationRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *MigrateAdapter) GetMigrationRecordsReturnsOnCall(i int, result1 []*migrate.MigrationRecord, result2 error) {
+	fake.GetMigrationRecordsStub = nil
+	if fake.getMigrationRecordsReturnsOnCall == nil {
+		fake.getMigrationRecordsReturnsOnCall = make(map[int]struct {
+			result1 []*migrate.MigrationRecord
+			result2 error
+		})
+	}
+	fake.getMigrationRecordsReturnsOnCall[i] = struct {
+		result1 []*migrate.MigrationRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *MigrateAdapter) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.execMaxMutex.RLock()
 	defer fake.execMaxMutex.RUnlock()
+	fake.getMigrationRecordsMutex.RLock()
+	defer fake.getMigrationRecordsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
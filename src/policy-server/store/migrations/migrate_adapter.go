@@ -1,7 +1,6 @@
 package migrations
 
 import (
-	"errors"
 	"time"
 
 	"github.com/cf-container-networking/sql-migrate"
@@ -11,9 +10,14 @@ type MigrateAdapter struct {
 }
 
 func (ma *MigrateAdapter) ExecMax(db MigrationDb, dialect string, m migrate.MigrationSource, dir migrate.MigrationDirection, max int) (int, error) {
-	if dir == migrate.Down {
-		return 0, errors.New("down migration not supported")
+	if dialect == "sqlite3" {
+		// the migration lock is not supported by sqlite, which only allows a single writer anyway
+		return migrate.ExecMax(db.RawConnection().DB, dialect, m, dir, max)
 	}
 
 	return migrate.ExecMaxWithLock(db.RawConnection().DB, dialect, m, dir, max, 1*time.Minute) // tested through integration
 }
+
+func (ma *MigrateAdapter) GetMigrationRecords(db MigrationDb, dialect string) ([]*migrate.MigrationRecord, error) {
+	return migrate.GetMigrationRecords(db.RawConnection().DB, dialect) // tested through integration
+}
@@ -2,47 +2,66 @@ package migrations
 
 var MigrationsToPerform = PolicyServerMigrations{
 	PolicyServerMigration{
-		"1",
-		migration_v0001,
+		Id: "1",
+		Up: migration_v0001,
 	},
 	PolicyServerMigration{
-		"2",
-		migration_v0002,
+		Id: "2",
+		Up: migration_v0002,
 	},
 	PolicyServerMigration{
-		"3",
-		migration_v0003,
+		Id: "3",
+		Up: migration_v0003,
 	},
 	PolicyServerMigration{
-		"4",
-		migration_v0004,
+		Id: "4",
+		Up: migration_v0004,
 	},
 	PolicyServerMigration{
-		"5",
-		migration_v0005,
+		Id: "5",
+		Up: migration_v0005,
 	},
 	PolicyServerMigration{
-		"6",
-		migration_v0006,
+		Id: "6",
+		Up: migration_v0006,
 	},
 	PolicyServerMigration{
-		"7",
-		migration_v0007,
+		Id: "7",
+		Up: migration_v0007,
 	},
 	PolicyServerMigration{
-		"8",
-		migration_v0008,
+		Id:   "8",
+		Up:   migration_v0008,
+		Down: migration_v0008_down,
 	},
 	PolicyServerMigration{
-		"9",
-		migration_v0009,
+		Id:   "9",
+		Up:   migration_v0009,
+		Down: migration_v0009_down,
 	},
 	PolicyServerMigration{
-		"10",
-		migration_v0010,
+		Id:   "10",
+		Up:   migration_v0010,
+		Down: migration_v0010_down,
 	},
 	PolicyServerMigration{
-		"11",
-		migration_v0011,
+		Id:   "11",
+		Up:   migration_v0011,
+		Down: migration_v0011_down,
+	},
+	PolicyServerMigration{
+		Id:   "12",
+		Up:   migration_v0012,
+		Down: migration_v0012_down,
+	},
+	PolicyServerMigration{
+		Id:   "13",
+		Up:   migration_v0013,
+		Down: migration_v0013_down,
+	},
+	PolicyServerMigration{
+		Id:   "14",
+		Up:   migration_v0014,
+		Down: migration_v0014_down,
 	},
 }
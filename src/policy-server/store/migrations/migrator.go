@@ -3,6 +3,9 @@ package migrations
 import (
 	"database/sql"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/cf-container-networking/sql-migrate"
 	"github.com/jmoiron/sqlx"
@@ -11,6 +14,7 @@ import (
 //go:generate counterfeiter -o fakes/migrate_adapter.go --fake-name MigrateAdapter . migrateAdapter
 type migrateAdapter interface {
 	ExecMax(db MigrationDb, dialect string, m migrate.MigrationSource, dir migrate.MigrationDirection, maxNumMigrations int) (int, error)
+	GetMigrationRecords(db MigrationDb, dialect string) ([]*migrate.MigrationRecord, error)
 }
 
 //go:generate counterfeiter -o fakes/migration_db.go --fake-name MigrationDb . MigrationDb
@@ -47,6 +51,170 @@ func (m *Migrator) PerformMigrations(driverName string, migrationDb MigrationDb,
 	return numMigrations, nil
 }
 
+// MigrationStatus reports whether a migration has been applied to the
+// database, and when.
+type MigrationStatus struct {
+	Id        string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+func (m *Migrator) Status(driverName string, migrationDb MigrationDb) ([]MigrationStatus, error) {
+	applied, err := m.appliedMigrations(driverName, migrationDb)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []MigrationStatus{}
+	for _, migration := range MigrationsToPerform {
+		appliedAt, ok := applied[migration.Id]
+		statuses = append(statuses, MigrationStatus{
+			Id:        migration.Id,
+			Applied:   ok,
+			AppliedAt: appliedAt,
+		})
+	}
+	return statuses, nil
+}
+
+// PlanMigrations returns the migrations PerformMigrations would apply, in
+// order, without applying them.
+func (m *Migrator) PlanMigrations(driverName string, migrationDb MigrationDb, maxNumMigrations int) ([]*migrate.Migration, error) {
+	statuses, err := m.Status(driverName, migrationDb)
+	if err != nil {
+		return nil, err
+	}
+
+	planned := []*migrate.Migration{}
+	for i, status := range statuses {
+		if status.Applied {
+			continue
+		}
+		if maxNumMigrations > 0 && len(planned) == maxNumMigrations {
+			break
+		}
+		planned = append(planned, MigrationsToPerform[i].forDriver(driverName))
+	}
+	return planned, nil
+}
+
+// PlanRollback returns the last numMigrations applied migrations, newest
+// first, as RollbackMigrations would undo them. It fails if any of them has no
+// down migration for the driver.
+func (m *Migrator) PlanRollback(driverName string, migrationDb MigrationDb, numMigrations int) ([]*migrate.Migration, error) {
+	if numMigrations < 1 {
+		return nil, fmt.Errorf("invalid number of migrations to roll back: %d", numMigrations)
+	}
+
+	statuses, err := m.Status(driverName, migrationDb)
+	if err != nil {
+		return nil, err
+	}
+
+	planned := []*migrate.Migration{}
+	for i := len(statuses) - 1; i >= 0 && len(planned) < numMigrations; i-- {
+		if !statuses[i].Applied {
+			continue
+		}
+		migration := MigrationsToPerform[i]
+		if !migration.supportsDown(driverName) {
+			return nil, fmt.Errorf("migration %s cannot be rolled back", migration.Id)
+		}
+		planned = append(planned, migration.forDriver(driverName))
+	}
+	return planned, nil
+}
+
+// RollbackMigrations runs the down migrations of the last numMigrations
+// applied migrations.
+func (m *Migrator) RollbackMigrations(driverName string, migrationDb MigrationDb, numMigrations int) (int, error) {
+	planned, err := m.PlanRollback(driverName, migrationDb, numMigrations)
+	if err != nil {
+		return 0, err
+	}
+	if len(planned) == 0 {
+		return 0, nil
+	}
+
+	numRolledBack, err := m.MigrateAdapter.ExecMax(
+		migrationDb,
+		driverName,
+		migrate.MemoryMigrationSource{
+			Migrations: MigrationsToPerform.ForDriver(driverName),
+		},
+		migrate.Down,
+		len(planned),
+	)
+	if err != nil {
+		return numRolledBack, fmt.Errorf("executing down migration: %s", err)
+	}
+	return numRolledBack, nil
+}
+
+// CheckSchema returns an error when some of the migrations this binary knows
+// about are not applied yet. Migrations it does not know about are accepted,
+// since they are applied by a newer version during a rolling deploy.
+func (m *Migrator) CheckSchema(driverName string, migrationDb MigrationDb) error {
+	applied, err := m.appliedMigrations(driverName, migrationDb)
+	if err != nil {
+		return err
+	}
+
+	pending := []string{}
+	for _, migration := range MigrationsToPerform {
+		if _, ok := applied[migration.Id]; !ok {
+			pending = append(pending, migration.Id)
+		}
+	}
+
+	if len(pending) > 0 {
+		return fmt.Errorf("incompatible database schema: migrations %s are not applied", strings.Join(pending, ", "))
+	}
+	return nil
+}
+
+// SchemaChecker checks the schema of a single database. Check queries the
+// database and keeps the result, which Err returns without a query.
+type SchemaChecker struct {
+	Migrator *Migrator
+	DB       MigrationDb
+
+	mutex sync.Mutex
+	err   error
+}
+
+func (c *SchemaChecker) Check() error {
+	err := c.Migrator.CheckSchema(c.DB.DriverName(), c.DB)
+
+	c.mutex.Lock()
+	c.err = err
+	c.mutex.Unlock()
+	return err
+}
+
+func (c *SchemaChecker) Err() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.err
+}
+
+func (m *Migrator) appliedMigrations(driverName string, migrationDb MigrationDb) (map[string]time.Time, error) {
+	if !MigrationsToPerform.supportsDriver(driverName) {
+		return nil, fmt.Errorf("unsupported driver: %s", driverName)
+	}
+
+	records, err := m.MigrateAdapter.GetMigrationRecords(migrationDb, driverName)
+	if err != nil {
+		return nil, fmt.Errorf("getting migration records: %s", err)
+	}
+
+	applied := map[string]time.Time{}
+	for _, record := range records {
+		applied[record.Id] = record.AppliedAt
+	}
+	return applied, nil
+}
+
 type PolicyServerMigrations []PolicyServerMigration
 
 func (s PolicyServerMigrations) ForDriver(driverName string) []*migrate.Migration {
@@ -67,15 +235,19 @@ func (s PolicyServerMigrations) supportsDriver(driverName string) bool {
 	return true
 }
 
+// PolicyServerMigration holds the statements of a migration per driver. Down
+// is only set for migrations that can be rolled back.
 type PolicyServerMigration struct {
-	Id string
-	Up map[string][]string
+	Id   string
+	Up   map[string][]string
+	Down map[string][]string
 }
 
 func (psm *PolicyServerMigration) forDriver(driverName string) *migrate.Migration {
 	return &migrate.Migration{
-		Id: psm.Id,
-		Up: psm.Up[driverName],
+		Id:   psm.Id,
+		Up:   psm.Up[driverName],
+		Down: psm.Down[driverName],
 	}
 }
 
@@ -83,3 +255,8 @@ func (psm *PolicyServerMigration) supportsDriver(driverName string) bool {
 	_, foundUp := psm.Up[driverName]
 	return foundUp
 }
+
+func (psm *PolicyServerMigration) supportsDown(driverName string) bool {
+	_, foundDown := psm.Down[driverName]
+	return foundDown
+}
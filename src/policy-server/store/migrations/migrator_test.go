@@ -14,7 +14,6 @@ import (
 	"time"
 
 	dbHelper "code.cloudfoundry.org/cf-networking-helpers/db"
-	"code.cloudfoundry.org/cf-networking-helpers/testsupport"
 	"code.cloudfoundry.org/lager"
 	"github.com/cf-container-networking/sql-migrate"
 	. "github.com/onsi/ginkgo"
@@ -40,8 +39,8 @@ var _ = Describe("migrations", func() {
 
 	BeforeEach(func() {
 		mockDb = &fakes.Db{}
-		dbConf = testsupport.GetDBConfig()
-		dbConf.DatabaseName = fmt.Sprintf("migrator_test_node_%d", time.Now().UnixNano())
+		dbConf = testhelpers.GetDBConfig()
+		dbConf.DatabaseName = testhelpers.DatabaseName(dbConf, fmt.Sprintf("migrator_test_node_%d", time.Now().UnixNano()))
 		dbConf.Timeout = 30
 		testhelpers.CreateDatabase(dbConf)
 
@@ -792,6 +791,93 @@ var _ = Describe("migrations", func() {
 			})
 		})
 
+		Describe("V12", func() {
+			BeforeEach(func() {
+				numMigrations, err := migrator.PerformMigrations(realDb.DriverName(), realDb, 12)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(numMigrations).To(Equal(12))
+			})
+
+			It("creates the deleted_policies table", func() {
+				By("verifying there are no rows")
+				rows, err := realDb.Query(`SELECT count(*) FROM deleted_policies`)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(scanCountRow(rows)).To(Equal(0))
+
+				By("inserting new data")
+				_, err = realDb.Exec(realDb.RawConnection().Rebind(`
+					INSERT INTO deleted_policies
+					(source_guid, destination_guid, protocol, port, start_port, end_port, reason, deleted_at)
+					VALUES ('some-source', 'some-destination', 'tcp', 0, 8080, 8081, 'user', ?)`), 1500000000)
+				Expect(err).NotTo(HaveOccurred())
+
+				By("verifying new row exists")
+				rows, err = realDb.Query(`SELECT id FROM deleted_policies WHERE source_guid='some-source' AND deleted_at=1500000000`)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(scanCountRow(rows)).To(Equal(1))
+			})
+		})
+
+		Describe("V13", func() {
+			BeforeEach(func() {
+				numMigrations, err := migrator.PerformMigrations(realDb.DriverName(), realDb, 13)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(numMigrations).To(Equal(13))
+			})
+
+			It("adds a released_at column to groups", func() {
+				_, err := realDb.Exec(`INSERT INTO groups (guid, type, released_at) VALUES (NULL, NULL, 1500000000)`)
+				Expect(err).NotTo(HaveOccurred())
+
+				rows, err := realDb.Query(`SELECT count(*) FROM groups WHERE released_at = 1500000000`)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(scanCountRow(rows)).To(Equal(1))
+			})
+
+			It("keeps the groups and the policies referencing them when rolled back", func() {
+				_, err := realDb.Exec(`INSERT INTO groups (guid, type, released_at) VALUES ('some-guid', 'app', 1500000000)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = realDb.Exec(`INSERT INTO destinations (group_id, port, protocol, start_port, end_port) VALUES (1, 8080, 'tcp', 8080, 8080)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = realDb.Exec(`INSERT INTO policies (group_id, destination_id) VALUES (1, 1)`)
+				Expect(err).NotTo(HaveOccurred())
+
+				numRolledBack, err := migrator.RollbackMigrations(realDb.DriverName(), realDb, 1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(numRolledBack).To(Equal(1))
+
+				rows, err := realDb.Query(`SELECT count(*) FROM groups WHERE guid = 'some-guid' AND type = 'app'`)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(scanCountRow(rows)).To(Equal(1))
+
+				rows, err = realDb.Query(`SELECT count(*) FROM policies p JOIN groups g ON p.group_id = g.id`)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(scanCountRow(rows)).To(Equal(1))
+
+				_, err = realDb.Exec(`SELECT released_at FROM groups`)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Describe("V14", func() {
+			BeforeEach(func() {
+				numMigrations, err := migrator.PerformMigrations(realDb.DriverName(), realDb, 14)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(numMigrations).To(Equal(14))
+			})
+
+			It("creates the policy_revision table with a single row", func() {
+				var revision int64
+				err := realDb.QueryRow(`SELECT revision FROM policy_revision WHERE id = 1`).Scan(&revision)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(revision).To(Equal(int64(0)))
+
+				rows, err := realDb.Query(`SELECT count(*) FROM policy_revision`)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(scanCountRow(rows)).To(Equal(1))
+			})
+		})
+
 		Context("when migrating in parallel", func() {
 			Context("mysql", func() {
 				BeforeEach(func() {
@@ -872,33 +958,215 @@ var _ = Describe("migrations", func() {
 		})
 	})
 
-	Describe("Down Migration", func() {
-		It("should no-op", func() {
-			adapter := &migrations.MigrateAdapter{}
-
-			_, err := adapter.ExecMax(
-				realDb,
-				realDb.DriverName(),
-				migrate.MemoryMigrationSource{
-					Migrations: migrations.MigrationsToPerform.ForDriver(realDb.DriverName()),
-				},
-				migrate.Up,
-				0,
-			)
+	Describe("Status", func() {
+		It("reports which migrations have been applied", func() {
+			_, err := migrator.PerformMigrations(realDb.DriverName(), realDb, 12)
 			Expect(err).NotTo(HaveOccurred())
 
-			numberOfMigrations, err := adapter.ExecMax(
-				realDb,
-				realDb.DriverName(),
-				migrate.MemoryMigrationSource{
-					Migrations: migrations.MigrationsToPerform.ForDriver(realDb.DriverName()),
-				},
-				migrate.Down,
-				0,
-			)
-
-			Expect(err).To(MatchError("down migration not supported"))
-			Expect(numberOfMigrations).To(Equal(0))
+			statuses, err := migrator.Status(realDb.DriverName(), realDb)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statuses).To(HaveLen(len(migrations.MigrationsToPerform)))
+			for i, status := range statuses {
+				Expect(status.Id).To(Equal(migrations.MigrationsToPerform[i].Id))
+			}
+			Expect(statuses[11].Applied).To(BeTrue())
+			Expect(statuses[11].AppliedAt).NotTo(BeZero())
+			Expect(statuses[12].Applied).To(BeFalse())
+			Expect(statuses[12].AppliedAt).To(BeZero())
+		})
+
+		Context("when the driver name is not mysql or postgres", func() {
+			It("returns an error", func() {
+				_, err := migrator.Status("etcd", mockDb)
+				Expect(err).To(MatchError("unsupported driver: etcd"))
+			})
+		})
+
+		Context("when getting the migration records fails", func() {
+			BeforeEach(func() {
+				migrator.MigrateAdapter = mockMigrateAdapter
+				mockMigrateAdapter.GetMigrationRecordsReturns(nil, errors.New("banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := migrator.Status(realDb.DriverName(), mockDb)
+				Expect(err).To(MatchError("getting migration records: banana"))
+			})
+		})
+	})
+
+	Describe("CheckSchema", func() {
+		BeforeEach(func() {
+			migrator.MigrateAdapter = mockMigrateAdapter
+		})
+
+		allRecords := func() []*migrate.MigrationRecord {
+			records := []*migrate.MigrationRecord{}
+			for _, migration := range migrations.MigrationsToPerform {
+				records = append(records, &migrate.MigrationRecord{Id: migration.Id})
+			}
+			return records
+		}
+
+		It("succeeds when the known migrations are applied", func() {
+			mockMigrateAdapter.GetMigrationRecordsReturns(allRecords(), nil)
+			Expect(migrator.CheckSchema("postgres", mockDb)).To(Succeed())
+		})
+
+		Context("when migrations are pending", func() {
+			It("returns an error", func() {
+				records := allRecords()
+				mockMigrateAdapter.GetMigrationRecordsReturns(records[:len(records)-2], nil)
+				err := migrator.CheckSchema("postgres", mockDb)
+				Expect(err).To(MatchError("incompatible database schema: migrations 13, 14 are not applied"))
+			})
+		})
+
+		Context("when a newer version has applied migrations this one does not know", func() {
+			It("succeeds", func() {
+				records := append(allRecords(), &migrate.MigrationRecord{Id: "99"}, &migrate.MigrationRecord{Id: "100"})
+				mockMigrateAdapter.GetMigrationRecordsReturns(records, nil)
+				Expect(migrator.CheckSchema("mysql", mockDb)).To(Succeed())
+			})
+		})
+
+		Context("when getting the migration records fails", func() {
+			It("returns an error", func() {
+				mockMigrateAdapter.GetMigrationRecordsReturns(nil, errors.New("banana"))
+				err := migrator.CheckSchema("mysql", mockDb)
+				Expect(err).To(MatchError("getting migration records: banana"))
+			})
+		})
+	})
+
+	Describe("SchemaChecker", func() {
+		It("keeps the result of the last check", func() {
+			migrator.MigrateAdapter = mockMigrateAdapter
+			mockDb.DriverNameReturns("postgres")
+			mockMigrateAdapter.GetMigrationRecordsReturns(nil, nil)
+			schemaChecker := &migrations.SchemaChecker{Migrator: migrator, DB: mockDb}
+
+			Expect(schemaChecker.Check()).To(MatchError(ContainSubstring("are not applied")))
+			Expect(schemaChecker.Err()).To(MatchError(ContainSubstring("are not applied")))
+			Expect(mockMigrateAdapter.GetMigrationRecordsCallCount()).To(Equal(1))
+
+			records := []*migrate.MigrationRecord{}
+			for _, migration := range migrations.MigrationsToPerform {
+				records = append(records, &migrate.MigrationRecord{Id: migration.Id})
+			}
+			mockMigrateAdapter.GetMigrationRecordsReturns(records, nil)
+
+			Expect(schemaChecker.Check()).To(Succeed())
+			Expect(schemaChecker.Err()).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("PlanMigrations", func() {
+		BeforeEach(func() {
+			migrator.MigrateAdapter = mockMigrateAdapter
+			mockMigrateAdapter.GetMigrationRecordsReturns([]*migrate.MigrationRecord{
+				{Id: "1"}, {Id: "2"}, {Id: "3"}, {Id: "4"}, {Id: "5"}, {Id: "6"},
+				{Id: "7"}, {Id: "8"}, {Id: "9"}, {Id: "10"}, {Id: "11"},
+			}, nil)
+		})
+
+		It("returns the pending migrations for the driver", func() {
+			planned, err := migrator.PlanMigrations("postgres", mockDb, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(planned).To(HaveLen(3))
+			Expect(planned[0].Id).To(Equal("12"))
+			Expect(planned[0].Up).To(Equal(migrations.MigrationsToPerform[11].Up["postgres"]))
+			Expect(planned[1].Id).To(Equal("13"))
+			Expect(planned[2].Id).To(Equal("14"))
+			Expect(mockMigrateAdapter.ExecMaxCallCount()).To(Equal(0))
+		})
+
+		It("has a sqlite variant of every migration", func() {
+			mockMigrateAdapter.GetMigrationRecordsReturns(nil, nil)
+
+			planned, err := migrator.PlanMigrations("sqlite3", mockDb, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(planned).To(HaveLen(len(migrations.MigrationsToPerform)))
+			for _, migration := range planned {
+				Expect(migration.Up).NotTo(BeEmpty())
+			}
+		})
+
+		It("limits the plan to the max number of migrations", func() {
+			planned, err := migrator.PlanMigrations("mysql", mockDb, 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(planned).To(HaveLen(1))
+			Expect(planned[0].Id).To(Equal("12"))
+		})
+	})
+
+	Describe("RollbackMigrations", func() {
+		It("undoes the last migrations", func() {
+			_, err := migrator.PerformMigrations(realDb.DriverName(), realDb, 0)
+			Expect(err).NotTo(HaveOccurred())
+
+			numRolledBack, err := migrator.RollbackMigrations(realDb.DriverName(), realDb, 3)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(numRolledBack).To(Equal(3))
+
+			statuses, err := migrator.Status(realDb.DriverName(), realDb)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statuses[10].Applied).To(BeTrue())
+			Expect(statuses[11].Applied).To(BeFalse())
+			Expect(statuses[12].Applied).To(BeFalse())
+			Expect(statuses[13].Applied).To(BeFalse())
+
+			_, err = realDb.Exec(`SELECT revision FROM policy_revision`)
+			Expect(err).To(HaveOccurred())
+			_, err = realDb.Exec(`SELECT released_at FROM groups`)
+			Expect(err).To(HaveOccurred())
+			_, err = realDb.Exec(`SELECT id FROM deleted_policies`)
+			Expect(err).To(HaveOccurred())
+
+			By("migrating up again")
+			numMigrations, err := migrator.PerformMigrations(realDb.DriverName(), realDb, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(numMigrations).To(Equal(3))
+		})
+
+		Context("when a migration cannot be rolled back", func() {
+			It("returns an error and rolls back nothing", func() {
+				_, err := migrator.PerformMigrations(realDb.DriverName(), realDb, 0)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = migrator.RollbackMigrations(realDb.DriverName(), realDb, 8)
+				Expect(err).To(MatchError("migration 7 cannot be rolled back"))
+
+				statuses, err := migrator.Status(realDb.DriverName(), realDb)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(statuses[13].Applied).To(BeTrue())
+			})
+		})
+
+		Context("when the number of migrations is invalid", func() {
+			It("returns an error", func() {
+				_, err := migrator.RollbackMigrations(realDb.DriverName(), mockDb, 0)
+				Expect(err).To(MatchError("invalid number of migrations to roll back: 0"))
+			})
+		})
+
+		Context("when the down migration fails", func() {
+			BeforeEach(func() {
+				migrator.MigrateAdapter = mockMigrateAdapter
+				mockMigrateAdapter.GetMigrationRecordsReturns([]*migrate.MigrationRecord{{Id: "12"}, {Id: "13"}}, nil)
+				mockMigrateAdapter.ExecMaxReturns(0, errors.New("banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := migrator.RollbackMigrations(realDb.DriverName(), mockDb, 1)
+				Expect(err).To(MatchError("executing down migration: banana"))
+
+				Expect(mockMigrateAdapter.ExecMaxCallCount()).To(Equal(1))
+				_, driverName, _, migrationDir, numMigrations := mockMigrateAdapter.ExecMaxArgsForCall(0)
+				Expect(driverName).To(Equal(realDb.DriverName()))
+				Expect(migrationDir).To(Equal(migrate.Down))
+				Expect(numMigrations).To(Equal(1))
+			})
 		})
 	})
 })
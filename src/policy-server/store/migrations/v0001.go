@@ -44,4 +44,24 @@ var migration_v0001 = map[string][]string{
 		UNIQUE (group_id, destination_id)
 	);`,
 	},
+	"sqlite3": {
+		`CREATE TABLE IF NOT EXISTS groups (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		guid text,
+		UNIQUE (guid)
+	);`,
+		`CREATE TABLE IF NOT EXISTS destinations (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		group_id int REFERENCES groups(id),
+		port int,
+		protocol text,
+		UNIQUE (group_id, port, protocol)
+	);`,
+		`CREATE TABLE IF NOT EXISTS policies (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		group_id int REFERENCES groups(id),
+		destination_id int REFERENCES destinations(id),
+		UNIQUE (group_id, destination_id)
+	);`,
+	},
 }
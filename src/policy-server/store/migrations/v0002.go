@@ -43,4 +43,23 @@ END;`,
 	`,
 		`ALTER TABLE destinations ADD CONSTRAINT unique_destination UNIQUE (group_id, start_port, end_port, protocol);`,
 	},
+	"sqlite3": {
+		`ALTER TABLE destinations ADD COLUMN start_port int;`,
+		`ALTER TABLE destinations ADD COLUMN end_port int;`,
+		`UPDATE destinations SET start_port = port;`,
+		`UPDATE destinations SET end_port = port;`,
+		`CREATE TABLE destinations_new (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		group_id int REFERENCES groups(id),
+		port int,
+		protocol text,
+		start_port int,
+		end_port int,
+		CONSTRAINT unique_destination UNIQUE (group_id, start_port, end_port, protocol)
+	);`,
+		`INSERT INTO destinations_new (id, group_id, port, protocol, start_port, end_port)
+		SELECT id, group_id, port, protocol, start_port, end_port FROM destinations;`,
+		`DROP TABLE destinations;`,
+		`ALTER TABLE destinations_new RENAME TO destinations;`,
+	},
 }
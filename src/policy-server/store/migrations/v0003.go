@@ -10,4 +10,9 @@ var migration_v0003 = map[string][]string{
 		`ALTER TABLE groups ADD COLUMN type text DEFAULT 'app'`,
 		`CREATE INDEX idx_type ON groups (type)`,
 	},
+
+	"sqlite3": {
+		`ALTER TABLE groups ADD COLUMN type text DEFAULT 'app'`,
+		`CREATE INDEX idx_type ON groups (type)`,
+	},
 }
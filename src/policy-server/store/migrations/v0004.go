@@ -12,4 +12,9 @@ var migration_v0004 = map[string][]string{
 		id SERIAL PRIMARY KEY
 	);`,
 	},
+	"sqlite3": {
+		`CREATE TABLE IF NOT EXISTS terminals (
+		id INTEGER PRIMARY KEY AUTOINCREMENT
+	);`,
+	},
 }
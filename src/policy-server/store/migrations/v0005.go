@@ -26,4 +26,11 @@ var migration_v0005 = map[string][]string{
         FOREIGN KEY (destination_id) references terminals(id)
 	);`,
 	},
+	"sqlite3": {
+		`CREATE TABLE IF NOT EXISTS egress_policies (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		source_id int REFERENCES terminals(id),
+		destination_id int REFERENCES terminals(id)
+	);`,
+	},
 }
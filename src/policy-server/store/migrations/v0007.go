@@ -23,4 +23,11 @@ var migration_v0007 = map[string][]string{
 		app_guid text CONSTRAINT apps_app_guid_unique UNIQUE
 	);`,
 	},
+	"sqlite3": {
+		`CREATE TABLE IF NOT EXISTS apps (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		terminal_id int REFERENCES terminals(id),
+		app_guid text CONSTRAINT apps_app_guid_unique UNIQUE
+	);`,
+	},
 }
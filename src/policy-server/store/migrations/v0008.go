@@ -5,4 +5,17 @@ var migration_v0008 = map[string][]string{
 	"postgres": {
 		`CREATE INDEX source_terminal_id_idx ON egress_policies (source_id);`,
 	},
+	"sqlite3": {
+		`CREATE INDEX source_terminal_id_idx ON egress_policies (source_id);`,
+	},
+}
+
+var migration_v0008_down = map[string][]string{
+	"mysql": {},
+	"postgres": {
+		`DROP INDEX source_terminal_id_idx;`,
+	},
+	"sqlite3": {
+		`DROP INDEX source_terminal_id_idx;`,
+	},
 }
@@ -5,4 +5,17 @@ var migration_v0009 = map[string][]string{
 	"postgres": {
 		`CREATE INDEX destination_terminal_id_idx ON egress_policies (destination_id);`,
 	},
+	"sqlite3": {
+		`CREATE INDEX destination_terminal_id_idx ON egress_policies (destination_id);`,
+	},
+}
+
+var migration_v0009_down = map[string][]string{
+	"mysql": {},
+	"postgres": {
+		`DROP INDEX destination_terminal_id_idx;`,
+	},
+	"sqlite3": {
+		`DROP INDEX destination_terminal_id_idx;`,
+	},
 }
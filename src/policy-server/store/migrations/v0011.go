@@ -5,4 +5,17 @@ var migration_v0011 = map[string][]string{
 	"postgres": {
 		`CREATE INDEX app_terminal_id_idx ON apps (terminal_id);`,
 	},
+	"sqlite3": {
+		`CREATE INDEX app_terminal_id_idx ON apps (terminal_id);`,
+	},
+}
+
+var migration_v0011_down = map[string][]string{
+	"mysql": {},
+	"postgres": {
+		`DROP INDEX app_terminal_id_idx;`,
+	},
+	"sqlite3": {
+		`DROP INDEX app_terminal_id_idx;`,
+	},
 }
@@ -0,0 +1,59 @@
+package migrations
+
+var migration_v0012 = map[string][]string{
+	"mysql": {
+		`CREATE TABLE IF NOT EXISTS deleted_policies (
+		id int NOT NULL AUTO_INCREMENT,
+		source_guid varchar(255) NOT NULL,
+		destination_guid varchar(255) NOT NULL,
+		protocol varchar(255) NOT NULL,
+		port int NOT NULL DEFAULT 0,
+		start_port int NOT NULL DEFAULT 0,
+		end_port int NOT NULL DEFAULT 0,
+		reason varchar(255) NOT NULL,
+		deleted_at bigint NOT NULL,
+		PRIMARY KEY (id)
+	);`,
+		`CREATE INDEX deleted_policies_deleted_at_idx ON deleted_policies (deleted_at);`,
+	},
+	"postgres": {
+		`CREATE TABLE IF NOT EXISTS deleted_policies (
+		id SERIAL PRIMARY KEY,
+		source_guid text NOT NULL,
+		destination_guid text NOT NULL,
+		protocol text NOT NULL,
+		port int NOT NULL DEFAULT 0,
+		start_port int NOT NULL DEFAULT 0,
+		end_port int NOT NULL DEFAULT 0,
+		reason text NOT NULL,
+		deleted_at bigint NOT NULL
+	);`,
+		`CREATE INDEX deleted_policies_deleted_at_idx ON deleted_policies (deleted_at);`,
+	},
+	"sqlite3": {
+		`CREATE TABLE IF NOT EXISTS deleted_policies (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		source_guid text NOT NULL,
+		destination_guid text NOT NULL,
+		protocol text NOT NULL,
+		port int NOT NULL DEFAULT 0,
+		start_port int NOT NULL DEFAULT 0,
+		end_port int NOT NULL DEFAULT 0,
+		reason text NOT NULL,
+		deleted_at bigint NOT NULL
+	);`,
+		`CREATE INDEX deleted_policies_deleted_at_idx ON deleted_policies (deleted_at);`,
+	},
+}
+
+var migration_v0012_down = map[string][]string{
+	"mysql": {
+		`DROP TABLE IF EXISTS deleted_policies;`,
+	},
+	"postgres": {
+		`DROP TABLE IF EXISTS deleted_policies;`,
+	},
+	"sqlite3": {
+		`DROP TABLE IF EXISTS deleted_policies;`,
+	},
+}
@@ -0,0 +1,46 @@
+package migrations
+
+var migration_v0013 = map[string][]string{
+	"mysql": {
+		`ALTER TABLE groups ADD COLUMN released_at bigint;`,
+	},
+	"postgres": {
+		`ALTER TABLE groups ADD COLUMN released_at bigint;`,
+	},
+	"sqlite3": {
+		`ALTER TABLE groups ADD COLUMN released_at bigint;`,
+	},
+}
+
+var migration_v0013_down = map[string][]string{
+	"mysql": {
+		`ALTER TABLE groups DROP COLUMN released_at;`,
+	},
+	"postgres": {
+		`ALTER TABLE groups DROP COLUMN released_at;`,
+	},
+	"sqlite3": {
+		// groups is dropped and created again, rather than replaced by a
+		// renamed copy, so that the deferred foreign keys of destinations and
+		// policies hold again once its rows are restored
+		`PRAGMA defer_foreign_keys = ON;`,
+		`CREATE TABLE groups_backup (
+		id int,
+		guid text,
+		type text
+	);`,
+		`INSERT INTO groups_backup (id, guid, type)
+		SELECT id, guid, type FROM groups;`,
+		`DROP TABLE groups;`,
+		`CREATE TABLE groups (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		guid text,
+		type text DEFAULT 'app',
+		UNIQUE (guid)
+	);`,
+		`INSERT INTO groups (id, guid, type)
+		SELECT id, guid, type FROM groups_backup;`,
+		`DROP TABLE groups_backup;`,
+		`CREATE INDEX idx_type ON groups (type);`,
+	},
+}
@@ -0,0 +1,38 @@
+package migrations
+
+var migration_v0014 = map[string][]string{
+	"mysql": {
+		`CREATE TABLE IF NOT EXISTS policy_revision (
+		id int NOT NULL,
+		revision bigint NOT NULL DEFAULT 0,
+		PRIMARY KEY (id)
+	);`,
+		`INSERT INTO policy_revision (id, revision) VALUES (1, 0);`,
+	},
+	"postgres": {
+		`CREATE TABLE IF NOT EXISTS policy_revision (
+		id int PRIMARY KEY,
+		revision bigint NOT NULL DEFAULT 0
+	);`,
+		`INSERT INTO policy_revision (id, revision) VALUES (1, 0);`,
+	},
+	"sqlite3": {
+		`CREATE TABLE IF NOT EXISTS policy_revision (
+		id int PRIMARY KEY,
+		revision bigint NOT NULL DEFAULT 0
+	);`,
+		`INSERT INTO policy_revision (id, revision) VALUES (1, 0);`,
+	},
+}
+
+var migration_v0014_down = map[string][]string{
+	"mysql": {
+		`DROP TABLE IF EXISTS policy_revision;`,
+	},
+	"postgres": {
+		`DROP TABLE IF EXISTS policy_revision;`,
+	},
+	"sqlite3": {
+		`DROP TABLE IF EXISTS policy_revision;`,
+	},
+}
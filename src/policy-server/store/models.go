@@ -1,5 +1,7 @@
 package store
 
+import "time"
+
 type PolicyCollection struct {
 	Policies       []Policy
 	EgressPolicies []EgressPolicy
@@ -34,6 +36,12 @@ type Tag struct {
 	Type string
 }
 
+type TagUsage struct {
+	Used        int
+	Free        int
+	Quarantined int
+}
+
 type EgressPolicy struct {
 	Source      EgressSource
 	Destination EgressDestination
@@ -60,3 +68,10 @@ type EgressPolicyIDCollection struct {
 	SourceTerminalID      int64
 	SourceAppID           int64
 }
+
+type DeletedPolicy struct {
+	ID        int
+	Policy    Policy
+	Reason    string
+	DeletedAt time.Time
+}
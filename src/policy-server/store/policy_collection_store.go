@@ -13,10 +13,18 @@ type egressPolicyStore interface {
 	ByGuids(srcGuids []string) ([]EgressPolicy, error)
 }
 
+//go:generate counterfeiter -o fakes/trash_recorder.go --fake-name TrashRecorder . trashRecorder
+type trashRecorder interface {
+	AddWithTx(db.Transaction, []Policy, string) error
+}
+
 type PolicyCollectionStore struct {
 	Conn              Database
 	PolicyStore       Store
 	EgressPolicyStore egressPolicyStore
+	// Trash, when set, records deleted c2c policies so they can be restored.
+	Trash        trashRecorder
+	DeleteReason string
 }
 
 func (p *PolicyCollectionStore) Create(policyCollection PolicyCollection) error {
@@ -44,6 +52,13 @@ func (p *PolicyCollectionStore) Delete(policyCollection PolicyCollection) error
 		return fmt.Errorf("begin transaction: %s", err)
 	}
 
+	if p.Trash != nil && len(policyCollection.Policies) > 0 {
+		err = p.Trash.AddWithTx(tx, policyCollection.Policies, p.DeleteReason)
+		if err != nil {
+			return rollback(tx, err)
+		}
+	}
+
 	err = p.PolicyStore.DeleteWithTx(tx, policyCollection.Policies)
 	if err != nil {
 		return err
@@ -2,6 +2,7 @@ package store_test
 
 import (
 	"errors"
+	"policy-server/db"
 	dbfakes "policy-server/db/fakes"
 	"policy-server/store"
 	"policy-server/store/fakes"
@@ -188,6 +189,53 @@ var _ = Describe("PolicyCollectionStore", func() {
 
 		})
 
+		Context("when a trash is configured", func() {
+			var trash *fakes.TrashRecorder
+
+			BeforeEach(func() {
+				trash = &fakes.TrashRecorder{}
+				policyCollectionStore.Trash = trash
+				policyCollectionStore.DeleteReason = "user"
+			})
+
+			It("records the policies in the trash before deleting them", func() {
+				trash.AddWithTxStub = func(db.Transaction, []store.Policy, string) error {
+					Expect(policyStore.DeleteWithTxCallCount()).To(Equal(0))
+					return nil
+				}
+
+				Expect(policyCollectionStore.Delete(policyCollection)).To(Succeed())
+				Expect(trash.AddWithTxCallCount()).To(Equal(1))
+				passedTx, passedPolicies, reason := trash.AddWithTxArgsForCall(0)
+				Expect(passedTx).To(Equal(tx))
+				Expect(passedPolicies).To(Equal(policyCollection.Policies))
+				Expect(reason).To(Equal("user"))
+				Expect(policyStore.DeleteWithTxCallCount()).To(Equal(1))
+				Expect(tx.CommitCallCount()).To(Equal(1))
+			})
+
+			Context("when there are no c2c policies", func() {
+				It("does not touch the trash", func() {
+					policyCollection.Policies = nil
+					Expect(policyCollectionStore.Delete(policyCollection)).To(Succeed())
+					Expect(trash.AddWithTxCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when recording in the trash fails", func() {
+				BeforeEach(func() {
+					trash.AddWithTxReturns(errors.New("trash is full"))
+				})
+
+				It("rolls back without deleting", func() {
+					Expect(policyCollectionStore.Delete(policyCollection)).To(MatchError("trash is full"))
+					Expect(policyStore.DeleteWithTxCallCount()).To(Equal(0))
+					Expect(tx.RollbackCallCount()).To(Equal(1))
+					Expect(tx.CommitCallCount()).To(Equal(0))
+				})
+			})
+		})
+
 		Context("when the transaction fails to begin", func() {
 			It("returns an error", func() {
 				mockDB.BeginxReturns(nil, errors.New("potato"))
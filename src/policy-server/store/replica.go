@@ -0,0 +1,256 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:generate counterfeiter -o fakes/egress_policy_reader.go --fake-name EgressPolicyReader . egressPolicyReader
+type egressPolicyReader interface {
+	All() ([]EgressPolicy, error)
+	ByGuids(srcGuids []string) ([]EgressPolicy, error)
+}
+
+// ReadReplica is a read-only copy of the primary database, with stores that
+// read from it.
+type ReadReplica struct {
+	Name        string
+	Conn        Database
+	Store       Store
+	EgressStore egressPolicyReader
+}
+
+// ReplicaSet keeps track of which read replicas are healthy. A replica is
+// healthy when it answers queries and lags the primary by no more than MaxLag.
+// CheckHealth is meant to be called periodically.
+type ReplicaSet struct {
+	Replicas []*ReadReplica
+	MaxLag   time.Duration
+
+	mutex   sync.Mutex
+	healthy map[*ReadReplica]bool
+	next    int
+}
+
+func NewReplicaSet(replicas []*ReadReplica, maxLag time.Duration) *ReplicaSet {
+	return &ReplicaSet{
+		Replicas: replicas,
+		MaxLag:   maxLag,
+		healthy:  map[*ReadReplica]bool{},
+	}
+}
+
+// CheckHealth checks every replica and returns an error listing the ones that
+// are unhealthy.
+func (r *ReplicaSet) CheckHealth() error {
+	problems := []string{}
+	for _, replica := range r.Replicas {
+		err := r.check(replica)
+		r.setHealthy(replica, err == nil)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", replica.Name, err))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("unhealthy read replicas: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// Pick returns the next healthy replica, or nil if there is none.
+func (r *ReplicaSet) Pick() *ReadReplica {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i := 0; i < len(r.Replicas); i++ {
+		replica := r.Replicas[(r.next+i)%len(r.Replicas)]
+		if r.healthy[replica] {
+			r.next = (r.next + i + 1) % len(r.Replicas)
+			return replica
+		}
+	}
+	return nil
+}
+
+// MarkUnhealthy stops reads from going to the replica until the next
+// successful health check.
+func (r *ReplicaSet) MarkUnhealthy(replica *ReadReplica) {
+	r.setHealthy(replica, false)
+}
+
+func (r *ReplicaSet) setHealthy(replica *ReadReplica, healthy bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.healthy[replica] = healthy
+}
+
+func (r *ReplicaSet) check(replica *ReadReplica) error {
+	err := replica.Store.CheckDatabase()
+	if err != nil {
+		return fmt.Errorf("check database: %s", err)
+	}
+
+	lag, err := replicationLag(replica.Conn)
+	if err != nil {
+		return fmt.Errorf("replication lag: %s", err)
+	}
+	if lag > r.MaxLag {
+		return fmt.Errorf("lagging %s behind the primary", lag)
+	}
+	return nil
+}
+
+func replicationLag(conn Database) (time.Duration, error) {
+	switch conn.DriverName() {
+	case "postgres":
+		return postgresReplicationLag(conn)
+	case "mysql":
+		return mysqlReplicationLag(conn)
+	default:
+		return 0, nil
+	}
+}
+
+func postgresReplicationLag(conn Database) (time.Duration, error) {
+	var inRecovery, caughtUp bool
+	var sinceReplay sql.NullFloat64
+	err := conn.QueryRow(`
+		SELECT pg_is_in_recovery(),
+		COALESCE(pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn(), false),
+		EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp())
+	`).Scan(&inRecovery, &caughtUp, &sinceReplay)
+	if err != nil {
+		return 0, err
+	}
+
+	// the last replayed transaction gets older while the primary is idle, so
+	// a replica that has replayed everything it received is not lagging
+	if !inRecovery || caughtUp || !sinceReplay.Valid {
+		return 0, nil
+	}
+	return time.Duration(sinceReplay.Float64 * float64(time.Second)), nil
+}
+
+func mysqlReplicationLag(conn Database) (time.Duration, error) {
+	rows, err := conn.Query(`SHOW SLAVE STATUS`)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close() // untested
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+
+	if !rows.Next() {
+		// not configured as a replica
+		return 0, rows.Err()
+	}
+
+	values := make([]sql.RawBytes, len(columns))
+	scanArgs := make([]interface{}, len(columns))
+	for i := range values {
+		scanArgs[i] = &values[i]
+	}
+	err = rows.Scan(scanArgs...)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, column := range columns {
+		if column != "Seconds_Behind_Master" {
+			continue
+		}
+		if values[i] == nil {
+			return 0, fmt.Errorf("replication is not running")
+		}
+		seconds, err := strconv.Atoi(string(values[i]))
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(seconds) * time.Second, nil
+	}
+	return 0, fmt.Errorf("missing Seconds_Behind_Master")
+}
+
+// ReplicaStore reads policies from a healthy read replica and falls back to
+// the primary store when there is none or the read fails. Every other call
+// goes to the primary store.
+type ReplicaStore struct {
+	Store
+	Replicas      *ReplicaSet
+	MetricsSender metricsSender
+}
+
+func (s *ReplicaStore) All() ([]Policy, error) {
+	replica := s.Replicas.Pick()
+	if replica != nil {
+		policies, err := replica.Store.All()
+		if err == nil {
+			s.MetricsSender.IncrementCounter("StoreAllReadFromReplica")
+			return policies, nil
+		}
+		s.Replicas.MarkUnhealthy(replica)
+	}
+
+	s.MetricsSender.IncrementCounter("StoreAllReadFromPrimary")
+	return s.Store.All()
+}
+
+func (s *ReplicaStore) ByGuids(srcGuids, destGuids []string, inSourceAndDest bool) ([]Policy, error) {
+	replica := s.Replicas.Pick()
+	if replica != nil {
+		policies, err := replica.Store.ByGuids(srcGuids, destGuids, inSourceAndDest)
+		if err == nil {
+			s.MetricsSender.IncrementCounter("StoreByGuidsReadFromReplica")
+			return policies, nil
+		}
+		s.Replicas.MarkUnhealthy(replica)
+	}
+
+	s.MetricsSender.IncrementCounter("StoreByGuidsReadFromPrimary")
+	return s.Store.ByGuids(srcGuids, destGuids, inSourceAndDest)
+}
+
+// ReplicaEgressStore is the ReplicaStore counterpart for egress policies.
+type ReplicaEgressStore struct {
+	Primary       egressPolicyReader
+	Replicas      *ReplicaSet
+	MetricsSender metricsSender
+}
+
+func (s *ReplicaEgressStore) All() ([]EgressPolicy, error) {
+	replica := s.Replicas.Pick()
+	if replica != nil {
+		policies, err := replica.EgressStore.All()
+		if err == nil {
+			s.MetricsSender.IncrementCounter("EgressPolicyStoreAllReadFromReplica")
+			return policies, nil
+		}
+		s.Replicas.MarkUnhealthy(replica)
+	}
+
+	s.MetricsSender.IncrementCounter("EgressPolicyStoreAllReadFromPrimary")
+	return s.Primary.All()
+}
+
+func (s *ReplicaEgressStore) ByGuids(ids []string) ([]EgressPolicy, error) {
+	replica := s.Replicas.Pick()
+	if replica != nil {
+		policies, err := replica.EgressStore.ByGuids(ids)
+		if err == nil {
+			s.MetricsSender.IncrementCounter("EgressPolicyStoreByGuidsReadFromReplica")
+			return policies, nil
+		}
+		s.Replicas.MarkUnhealthy(replica)
+	}
+
+	s.MetricsSender.IncrementCounter("EgressPolicyStoreByGuidsReadFromPrimary")
+	return s.Primary.ByGuids(ids)
+}
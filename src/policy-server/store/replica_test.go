@@ -0,0 +1,330 @@
+package store_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"time"
+
+	"policy-server/store"
+	"policy-server/store/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Read replicas", func() {
+	var (
+		replicaSet        *store.ReplicaSet
+		firstReplica      *store.ReadReplica
+		secondReplica     *store.ReadReplica
+		firstStore        *fakes.Store
+		secondStore       *fakes.Store
+		firstEgressStore  *fakes.EgressPolicyReader
+		secondEgressStore *fakes.EgressPolicyReader
+		primaryStore      *fakes.Store
+		primaryEgress     *fakes.EgressPolicyReader
+		fakeMetricsSender *fakes.MetricsSender
+	)
+
+	newReplica := func(name string, replicaStore *fakes.Store, egressStore *fakes.EgressPolicyReader) *store.ReadReplica {
+		conn := &fakes.Db{}
+		conn.DriverNameReturns("sqlite3")
+		return &store.ReadReplica{
+			Name:        name,
+			Conn:        conn,
+			Store:       replicaStore,
+			EgressStore: egressStore,
+		}
+	}
+
+	BeforeEach(func() {
+		firstStore = &fakes.Store{}
+		secondStore = &fakes.Store{}
+		firstEgressStore = &fakes.EgressPolicyReader{}
+		secondEgressStore = &fakes.EgressPolicyReader{}
+		primaryStore = &fakes.Store{}
+		primaryEgress = &fakes.EgressPolicyReader{}
+		fakeMetricsSender = &fakes.MetricsSender{}
+
+		firstReplica = newReplica("first", firstStore, firstEgressStore)
+		secondReplica = newReplica("second", secondStore, secondEgressStore)
+		replicaSet = store.NewReplicaSet([]*store.ReadReplica{firstReplica, secondReplica}, 10*time.Second)
+	})
+
+	Describe("ReplicaSet", func() {
+		It("does not pick replicas before they have been checked", func() {
+			Expect(replicaSet.Pick()).To(BeNil())
+		})
+
+		It("picks the healthy replicas in turn", func() {
+			Expect(replicaSet.CheckHealth()).To(Succeed())
+
+			Expect(replicaSet.Pick()).To(Equal(firstReplica))
+			Expect(replicaSet.Pick()).To(Equal(secondReplica))
+			Expect(replicaSet.Pick()).To(Equal(firstReplica))
+		})
+
+		Context("when a replica fails the database check", func() {
+			BeforeEach(func() {
+				firstStore.CheckDatabaseReturns(errors.New("potato"))
+			})
+
+			It("returns an error and stops picking it", func() {
+				err := replicaSet.CheckHealth()
+				Expect(err).To(MatchError("unhealthy read replicas: first: check database: potato"))
+
+				Expect(replicaSet.Pick()).To(Equal(secondReplica))
+				Expect(replicaSet.Pick()).To(Equal(secondReplica))
+			})
+
+			It("picks it again once it recovers", func() {
+				replicaSet.CheckHealth()
+				firstStore.CheckDatabaseReturns(nil)
+				Expect(replicaSet.CheckHealth()).To(Succeed())
+
+				Expect(replicaSet.Pick()).To(Equal(firstReplica))
+			})
+		})
+
+		Context("when a replica is on postgres", func() {
+			BeforeEach(func() {
+				replicaDB, err := sql.Open("fake-replica", "")
+				Expect(err).NotTo(HaveOccurred())
+				conn := &fakes.Db{}
+				conn.DriverNameReturns("postgres")
+				conn.QueryRowStub = replicaDB.QueryRow
+				firstReplica.Conn = conn
+			})
+
+			It("is not lagging while the primary is idle and it has replayed everything", func() {
+				fakeReplicaDriver.row = []driver.Value{true, true, float64(3600)}
+
+				Expect(replicaSet.CheckHealth()).To(Succeed())
+				Expect(replicaSet.Pick()).To(Equal(firstReplica))
+			})
+
+			It("lags by the age of the last replayed transaction while it is replaying", func() {
+				fakeReplicaDriver.row = []driver.Value{true, false, float64(3600)}
+
+				err := replicaSet.CheckHealth()
+				Expect(err).To(MatchError("unhealthy read replicas: first: lagging 1h0m0s behind the primary"))
+				Expect(replicaSet.Pick()).To(Equal(secondReplica))
+			})
+
+			It("is not lagging when it is not in recovery", func() {
+				fakeReplicaDriver.row = []driver.Value{false, false, nil}
+
+				Expect(replicaSet.CheckHealth()).To(Succeed())
+			})
+		})
+
+		Context("when a replica is marked unhealthy", func() {
+			It("stops picking it until the next check", func() {
+				replicaSet.CheckHealth()
+				replicaSet.MarkUnhealthy(firstReplica)
+
+				Expect(replicaSet.Pick()).To(Equal(secondReplica))
+				Expect(replicaSet.Pick()).To(Equal(secondReplica))
+			})
+		})
+	})
+
+	Describe("ReplicaStore", func() {
+		var replicaStore *store.ReplicaStore
+
+		BeforeEach(func() {
+			replicaStore = &store.ReplicaStore{
+				Store:         primaryStore,
+				Replicas:      replicaSet,
+				MetricsSender: fakeMetricsSender,
+			}
+			firstStore.AllReturns([]store.Policy{{Source: store.Source{ID: "from-replica"}}}, nil)
+			primaryStore.AllReturns([]store.Policy{{Source: store.Source{ID: "from-primary"}}}, nil)
+		})
+
+		It("reads from a healthy replica", func() {
+			replicaSet.CheckHealth()
+
+			policies, err := replicaStore.All()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policies[0].Source.ID).To(Equal("from-replica"))
+			Expect(primaryStore.AllCallCount()).To(Equal(0))
+
+			Expect(fakeMetricsSender.IncrementCounterCallCount()).To(Equal(1))
+			Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("StoreAllReadFromReplica"))
+		})
+
+		It("reads from the primary when there is no healthy replica", func() {
+			policies, err := replicaStore.All()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policies[0].Source.ID).To(Equal("from-primary"))
+
+			Expect(fakeMetricsSender.IncrementCounterCallCount()).To(Equal(1))
+			Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("StoreAllReadFromPrimary"))
+		})
+
+		Context("when the replica read fails", func() {
+			BeforeEach(func() {
+				replicaSet.CheckHealth()
+				firstStore.AllReturns(nil, errors.New("potato"))
+			})
+
+			It("falls back to the primary and marks the replica unhealthy", func() {
+				policies, err := replicaStore.All()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(policies[0].Source.ID).To(Equal("from-primary"))
+				Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("StoreAllReadFromPrimary"))
+
+				Expect(replicaSet.Pick()).To(Equal(secondReplica))
+				Expect(replicaSet.Pick()).To(Equal(secondReplica))
+			})
+		})
+
+		It("reads policies by guid from a healthy replica", func() {
+			replicaSet.CheckHealth()
+			firstStore.ByGuidsReturns([]store.Policy{{Source: store.Source{ID: "from-replica"}}}, nil)
+
+			policies, err := replicaStore.ByGuids([]string{"some-guid"}, []string{"other-guid"}, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policies[0].Source.ID).To(Equal("from-replica"))
+			Expect(primaryStore.ByGuidsCallCount()).To(Equal(0))
+
+			srcGuids, destGuids, inSourceAndDest := firstStore.ByGuidsArgsForCall(0)
+			Expect(srcGuids).To(Equal([]string{"some-guid"}))
+			Expect(destGuids).To(Equal([]string{"other-guid"}))
+			Expect(inSourceAndDest).To(BeTrue())
+			Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("StoreByGuidsReadFromReplica"))
+		})
+
+		It("falls back to the primary when reading policies by guid from the replica fails", func() {
+			replicaSet.CheckHealth()
+			firstStore.ByGuidsReturns(nil, errors.New("potato"))
+			primaryStore.ByGuidsReturns([]store.Policy{{Source: store.Source{ID: "from-primary"}}}, nil)
+
+			policies, err := replicaStore.ByGuids([]string{"some-guid"}, nil, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policies[0].Source.ID).To(Equal("from-primary"))
+			Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("StoreByGuidsReadFromPrimary"))
+			Expect(replicaSet.Pick()).To(Equal(secondReplica))
+		})
+
+		It("sends other calls to the primary", func() {
+			replicaSet.CheckHealth()
+
+			err := replicaStore.Delete([]store.Policy{{Source: store.Source{ID: "some-guid"}}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(primaryStore.DeleteCallCount()).To(Equal(1))
+			Expect(firstStore.DeleteCallCount()).To(Equal(0))
+		})
+	})
+
+	Describe("ReplicaEgressStore", func() {
+		var replicaEgressStore *store.ReplicaEgressStore
+
+		BeforeEach(func() {
+			replicaEgressStore = &store.ReplicaEgressStore{
+				Primary:       primaryEgress,
+				Replicas:      replicaSet,
+				MetricsSender: fakeMetricsSender,
+			}
+			firstEgressStore.AllReturns([]store.EgressPolicy{{Source: store.EgressSource{ID: "from-replica"}}}, nil)
+			primaryEgress.AllReturns([]store.EgressPolicy{{Source: store.EgressSource{ID: "from-primary"}}}, nil)
+		})
+
+		It("reads from a healthy replica", func() {
+			replicaSet.CheckHealth()
+
+			policies, err := replicaEgressStore.All()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policies[0].Source.ID).To(Equal("from-replica"))
+			Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("EgressPolicyStoreAllReadFromReplica"))
+		})
+
+		It("falls back to the primary when the replica read fails", func() {
+			replicaSet.CheckHealth()
+			firstEgressStore.AllReturns(nil, errors.New("potato"))
+
+			policies, err := replicaEgressStore.All()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policies[0].Source.ID).To(Equal("from-primary"))
+			Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("EgressPolicyStoreAllReadFromPrimary"))
+		})
+
+		It("reads policies by guid from a healthy replica", func() {
+			replicaSet.CheckHealth()
+			firstEgressStore.ByGuidsReturns([]store.EgressPolicy{{Source: store.EgressSource{ID: "from-replica"}}}, nil)
+
+			policies, err := replicaEgressStore.ByGuids([]string{"some-guid"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policies[0].Source.ID).To(Equal("from-replica"))
+			Expect(firstEgressStore.ByGuidsArgsForCall(0)).To(Equal([]string{"some-guid"}))
+			Expect(primaryEgress.ByGuidsCallCount()).To(Equal(0))
+			Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("EgressPolicyStoreByGuidsReadFromReplica"))
+		})
+
+		It("falls back to the primary when reading policies by guid from the replica fails", func() {
+			replicaSet.CheckHealth()
+			firstEgressStore.ByGuidsReturns(nil, errors.New("potato"))
+
+			_, err := replicaEgressStore.ByGuids([]string{"some-guid"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(primaryEgress.ByGuidsCallCount()).To(Equal(1))
+			Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("EgressPolicyStoreByGuidsReadFromPrimary"))
+		})
+	})
+})
+
+// fakeRowDriver answers every query with a single row, standing in for a
+// replica whose lag is measured.
+type fakeRowDriver struct {
+	row []driver.Value
+}
+
+var fakeReplicaDriver = &fakeRowDriver{}
+
+func init() {
+	sql.Register("fake-replica", fakeReplicaDriver)
+}
+
+func (d *fakeRowDriver) Open(string) (driver.Conn, error) { return &fakeRowConn{driver: d}, nil }
+
+type fakeRowConn struct {
+	driver *fakeRowDriver
+}
+
+func (c *fakeRowConn) Prepare(string) (driver.Stmt, error) {
+	return &fakeRowStmt{driver: c.driver}, nil
+}
+func (c *fakeRowConn) Close() error              { return nil }
+func (c *fakeRowConn) Begin() (driver.Tx, error) { return nil, errors.New("not supported") }
+
+type fakeRowStmt struct {
+	driver *fakeRowDriver
+}
+
+func (s *fakeRowStmt) Close() error  { return nil }
+func (s *fakeRowStmt) NumInput() int { return -1 }
+func (s *fakeRowStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+func (s *fakeRowStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeRows{values: s.driver.row}, nil
+}
+
+type fakeRows struct {
+	values []driver.Value
+	done   bool
+}
+
+func (r *fakeRows) Columns() []string { return make([]string, len(r.values)) }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	copy(dest, r.values)
+	r.done = true
+	return nil
+}
@@ -0,0 +1,29 @@
+package store
+
+import (
+	"fmt"
+	"policy-server/db"
+)
+
+// RevisionTable reads the policy revision, a counter that is incremented in
+// the same transaction as every change to the c2c or egress policies.
+type RevisionTable struct {
+	Conn Database
+}
+
+func (r *RevisionTable) Revision() (int64, error) {
+	var revision int64
+	err := r.Conn.QueryRow(`SELECT revision FROM policy_revision WHERE id = 1`).Scan(&revision)
+	if err != nil {
+		return 0, fmt.Errorf("reading policy revision: %s", err)
+	}
+	return revision, nil
+}
+
+func bumpRevision(tx db.Transaction) error {
+	_, err := tx.Exec(`UPDATE policy_revision SET revision = revision + 1 WHERE id = 1`)
+	if err != nil {
+		return fmt.Errorf("updating policy revision: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package store
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+//go:generate counterfeiter -o fakes/revision_reader.go --fake-name RevisionReader . revisionReader
+type revisionReader interface {
+	Revision() (int64, error)
+}
+
+//go:generate counterfeiter -o fakes/policy_reader.go --fake-name PolicyReader . policyReader
+type policyReader interface {
+	All() ([]Policy, error)
+}
+
+// PolicySnapshot is the complete set of c2c and egress policies at a policy
+// revision.
+type PolicySnapshot struct {
+	Revision       int64
+	Policies       []Policy
+	EgressPolicies []EgressPolicy
+	LoadedAt       time.Time
+}
+
+// Filter keeps the policies with a source or destination in ids, and the
+// egress policies with a source in ids, as ByGuids does. An empty ids keeps
+// everything.
+func (s *PolicySnapshot) Filter(ids []string) ([]Policy, []EgressPolicy) {
+	if len(ids) == 0 {
+		return s.Policies, s.EgressPolicies
+	}
+
+	wanted := map[string]bool{}
+	for _, id := range ids {
+		wanted[id] = true
+	}
+
+	policies := []Policy{}
+	for _, policy := range s.Policies {
+		if wanted[policy.Source.ID] || wanted[policy.Destination.ID] {
+			policies = append(policies, policy)
+		}
+	}
+
+	egressPolicies := []EgressPolicy{}
+	for _, egressPolicy := range s.EgressPolicies {
+		if wanted[egressPolicy.Source.ID] {
+			egressPolicies = append(egressPolicies, egressPolicy)
+		}
+	}
+	return policies, egressPolicies
+}
+
+// SnapshotCache keeps the last PolicySnapshot in memory and only reloads it
+// when the policy revision in the database has changed, or when it is older
+// than MaxAge. MaxAge bounds how long data read from a lagging replica can be
+// served; zero means snapshots never expire.
+type SnapshotCache struct {
+	Revisions   revisionReader
+	Store       policyReader
+	EgressStore egressPolicyReader
+	MaxAge      time.Duration
+
+	mutex    sync.Mutex
+	snapshot *PolicySnapshot
+}
+
+// Snapshot returns the cached snapshot if it is still current. Callers must
+// not modify it.
+func (c *SnapshotCache) Snapshot() (*PolicySnapshot, error) {
+	revision, err := c.Revisions.Revision()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.snapshot != nil && c.snapshot.Revision == revision && !c.expired(c.snapshot) {
+		return c.snapshot, nil
+	}
+
+	policies, err := c.Store.All()
+	if err != nil {
+		return nil, fmt.Errorf("loading policies: %s", err)
+	}
+
+	egressPolicies, err := c.EgressStore.All()
+	if err != nil {
+		return nil, fmt.Errorf("loading egress policies: %s", err)
+	}
+
+	c.snapshot = &PolicySnapshot{
+		Revision:       revision,
+		Policies:       policies,
+		EgressPolicies: egressPolicies,
+		LoadedAt:       time.Now(),
+	}
+	return c.snapshot, nil
+}
+
+func (c *SnapshotCache) expired(snapshot *PolicySnapshot) bool {
+	return c.MaxAge > 0 && time.Since(snapshot.LoadedAt) > c.MaxAge
+}
@@ -0,0 +1,159 @@
+package store_test
+
+import (
+	"errors"
+	"time"
+
+	"policy-server/store"
+	"policy-server/store/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SnapshotCache", func() {
+	var (
+		cache           *store.SnapshotCache
+		fakeRevisions   *fakes.RevisionReader
+		fakeStore       *fakes.PolicyReader
+		fakeEgressStore *fakes.EgressPolicyReader
+		policies        []store.Policy
+		egressPolicies  []store.EgressPolicy
+	)
+
+	BeforeEach(func() {
+		policies = []store.Policy{{Source: store.Source{ID: "some-app-guid"}}}
+		egressPolicies = []store.EgressPolicy{{Source: store.EgressSource{ID: "some-egress-app-guid"}}}
+
+		fakeRevisions = &fakes.RevisionReader{}
+		fakeRevisions.RevisionReturns(7, nil)
+		fakeStore = &fakes.PolicyReader{}
+		fakeStore.AllReturns(policies, nil)
+		fakeEgressStore = &fakes.EgressPolicyReader{}
+		fakeEgressStore.AllReturns(egressPolicies, nil)
+
+		cache = &store.SnapshotCache{
+			Revisions:   fakeRevisions,
+			Store:       fakeStore,
+			EgressStore: fakeEgressStore,
+		}
+	})
+
+	It("loads the policies at the current revision", func() {
+		snapshot, err := cache.Snapshot()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(snapshot.Revision).To(Equal(int64(7)))
+		Expect(snapshot.Policies).To(Equal(policies))
+		Expect(snapshot.EgressPolicies).To(Equal(egressPolicies))
+	})
+
+	It("does not reload the policies while the revision is unchanged", func() {
+		first, err := cache.Snapshot()
+		Expect(err).NotTo(HaveOccurred())
+		second, err := cache.Snapshot()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(second).To(BeIdenticalTo(first))
+		Expect(fakeRevisions.RevisionCallCount()).To(Equal(2))
+		Expect(fakeStore.AllCallCount()).To(Equal(1))
+		Expect(fakeEgressStore.AllCallCount()).To(Equal(1))
+	})
+
+	It("reloads the policies when the revision changes", func() {
+		_, err := cache.Snapshot()
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeRevisions.RevisionReturns(8, nil)
+		snapshot, err := cache.Snapshot()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(snapshot.Revision).To(Equal(int64(8)))
+		Expect(fakeStore.AllCallCount()).To(Equal(2))
+		Expect(fakeEgressStore.AllCallCount()).To(Equal(2))
+	})
+
+	Context("when the snapshot is older than MaxAge", func() {
+		BeforeEach(func() {
+			cache.MaxAge = time.Millisecond
+		})
+
+		It("reloads the policies", func() {
+			_, err := cache.Snapshot()
+			Expect(err).NotTo(HaveOccurred())
+
+			time.Sleep(5 * time.Millisecond)
+			_, err = cache.Snapshot()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeStore.AllCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("when reading the revision fails", func() {
+		BeforeEach(func() {
+			fakeRevisions.RevisionReturns(0, errors.New("potato"))
+		})
+
+		It("returns the error", func() {
+			_, err := cache.Snapshot()
+			Expect(err).To(MatchError("potato"))
+		})
+	})
+
+	Context("when loading the policies fails", func() {
+		BeforeEach(func() {
+			fakeStore.AllReturns(nil, errors.New("potato"))
+		})
+
+		It("returns the error and caches nothing", func() {
+			_, err := cache.Snapshot()
+			Expect(err).To(MatchError("loading policies: potato"))
+
+			fakeStore.AllReturns(policies, nil)
+			_, err = cache.Snapshot()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeStore.AllCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("when loading the egress policies fails", func() {
+		BeforeEach(func() {
+			fakeEgressStore.AllReturns(nil, errors.New("potato"))
+		})
+
+		It("returns the error", func() {
+			_, err := cache.Snapshot()
+			Expect(err).To(MatchError("loading egress policies: potato"))
+		})
+	})
+})
+
+var _ = Describe("PolicySnapshot", func() {
+	Describe("Filter", func() {
+		var snapshot *store.PolicySnapshot
+
+		BeforeEach(func() {
+			snapshot = &store.PolicySnapshot{
+				Policies: []store.Policy{
+					{Source: store.Source{ID: "app-a"}, Destination: store.Destination{ID: "app-b"}},
+					{Source: store.Source{ID: "app-b"}, Destination: store.Destination{ID: "app-c"}},
+					{Source: store.Source{ID: "app-c"}, Destination: store.Destination{ID: "app-d"}},
+				},
+				EgressPolicies: []store.EgressPolicy{
+					{Source: store.EgressSource{ID: "app-a"}},
+					{Source: store.EgressSource{ID: "app-c"}},
+				},
+			}
+		})
+
+		It("keeps the policies with a source or destination in ids", func() {
+			policies, egressPolicies := snapshot.Filter([]string{"app-a", "app-d"})
+			Expect(policies).To(Equal([]store.Policy{snapshot.Policies[0], snapshot.Policies[2]}))
+			Expect(egressPolicies).To(Equal([]store.EgressPolicy{snapshot.EgressPolicies[0]}))
+		})
+
+		It("keeps everything when ids is empty", func() {
+			policies, egressPolicies := snapshot.Filter(nil)
+			Expect(policies).To(Equal(snapshot.Policies))
+			Expect(egressPolicies).To(Equal(snapshot.EgressPolicies))
+		})
+	})
+})
@@ -21,6 +21,7 @@ type Migrator interface {
 type Store interface {
 	CreateWithTx(db.Transaction, []Policy) error
 	All() ([]Policy, error)
+	AllWithTx(db.Transaction) ([]Policy, error)
 	Delete([]Policy) error
 	DeleteWithTx(db.Transaction, []Policy) error
 	ByGuids([]string, []string, bool) ([]Policy, error)
@@ -84,12 +85,12 @@ func (s *store) CreateWithTx(tx db.Transaction, policies []Policy) error {
 	for _, policy := range policies {
 		sourceGroupId, err := s.group.Create(tx, policy.Source.ID, "app")
 		if err != nil {
-			return fmt.Errorf("creating group: %s", err)
+			return groupCreateError(err)
 		}
 
 		destinationGroupId, err := s.group.Create(tx, policy.Destination.ID, "app")
 		if err != nil {
-			return fmt.Errorf("creating group: %s", err)
+			return groupCreateError(err)
 		}
 
 		destinationId, err := s.destination.Create(
@@ -109,9 +110,22 @@ func (s *store) CreateWithTx(tx db.Transaction, policies []Policy) error {
 			return fmt.Errorf("creating policy: %s", err)
 		}
 	}
+
+	if len(policies) > 0 {
+		return bumpRevision(tx)
+	}
 	return nil
 }
 
+// groupCreateError adds context to a failure to create a group, except
+// ErrTagSpaceExhausted and ErrTagsQuarantined, which are returned as is.
+func groupCreateError(err error) error {
+	if err == ErrTagSpaceExhausted || err == ErrTagsQuarantined {
+		return err
+	}
+	return fmt.Errorf("creating group: %s", err)
+}
+
 func (s *store) Delete(policies []Policy) error {
 	tx, err := s.conn.Beginx()
 	if err != nil {
@@ -192,6 +206,13 @@ func (s *store) DeleteWithTx(tx db.Transaction, policies []Policy) error {
 			return rollback(tx, fmt.Errorf("deleting group row: %s", err))
 		}
 	}
+
+	if len(policies) > 0 {
+		err := bumpRevision(tx)
+		if err != nil {
+			return rollback(tx, err)
+		}
+	}
 	return nil
 }
 
@@ -217,19 +238,22 @@ func (s *store) deleteGroupRowIfLast(tx db.Transaction, groupId int) error {
 }
 
 func (s *store) policiesQuery(query string, args ...interface{}) ([]Policy, error) {
-	var policies []Policy
 	rebindedQuery := helpers.RebindForSQLDialect(query, s.conn.DriverName())
 
 	rows, err := s.conn.Query(rebindedQuery, args...)
 	if err != nil {
 		return nil, fmt.Errorf("listing all: %s", err)
 	}
+	return s.scanPolicies(rows)
+}
 
+func (s *store) scanPolicies(rows *sql.Rows) ([]Policy, error) {
+	var policies []Policy
 	defer rows.Close() // untested
 	for rows.Next() {
 		var sourceId, destinationId, protocol string
 		var port, startPort, endPort, sourceTag, destinationTag int
-		err = rows.Scan(
+		err := rows.Scan(
 			&sourceId,
 			&sourceTag,
 			&destinationId,
@@ -260,7 +284,7 @@ func (s *store) policiesQuery(query string, args ...interface{}) ([]Policy, erro
 			},
 		})
 	}
-	err = rows.Err()
+	err := rows.Err()
 	if err != nil {
 		return nil, fmt.Errorf("listing all, getting next row: %s", err) // untested
 	}
@@ -320,7 +344,28 @@ func (s *store) ByGuids(srcGuids, destGuids []string, inSourceAndDest bool) ([]P
 }
 
 func (s *store) All() ([]Policy, error) {
-	return s.policiesQuery(`
+	return s.policiesQuery(allPoliciesQuery + ";")
+}
+
+// AllWithTx lists the policies inside tx and locks them, where the database
+// supports it, so that they cannot be deleted or changed before tx ends.
+func (s *store) AllWithTx(tx db.Transaction) ([]Policy, error) {
+	var lockStatement string
+	switch tx.DriverName() {
+	case helpers.Postgres:
+		lockStatement = " FOR UPDATE OF policies"
+	case helpers.MySQL:
+		lockStatement = " FOR UPDATE"
+	}
+
+	rows, err := tx.Query(allPoliciesQuery + lockStatement)
+	if err != nil {
+		return nil, fmt.Errorf("listing all: %s", err)
+	}
+	return s.scanPolicies(rows)
+}
+
+const allPoliciesQuery = `
 		select
 			src_grp.guid,
 			src_grp.id,
@@ -333,8 +378,7 @@ func (s *store) All() ([]Policy, error) {
 		from policies
 		left outer join groups as src_grp on (policies.group_id = src_grp.id)
 		left outer join destinations on (destinations.id = policies.destination_id)
-		left outer join groups as dst_grp on (destinations.group_id = dst_grp.id);`)
-}
+		left outer join groups as dst_grp on (destinations.group_id = dst_grp.id)`
 
 func (s *store) tagIntToString(tag int) string {
 	return fmt.Sprintf("%"+fmt.Sprintf("0%d", s.tagLength*2)+"X", tag)
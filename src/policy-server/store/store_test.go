@@ -18,6 +18,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"policy-server/db"
+	dbfakes "policy-server/db/fakes"
 	"test-helpers"
 )
 
@@ -41,8 +42,8 @@ var _ = Describe("Store", func() {
 	BeforeEach(func() {
 		mockDb = &fakes.Db{}
 
-		dbConf = testsupport.GetDBConfig()
-		dbConf.DatabaseName = fmt.Sprintf("store_test_node_%d", time.Now().UnixNano())
+		dbConf = testhelpers.GetDBConfig()
+		dbConf.DatabaseName = testhelpers.DatabaseName(dbConf, fmt.Sprintf("store_test_node_%d", time.Now().UnixNano()))
 
 		testhelpers.CreateDatabase(dbConf)
 
@@ -139,7 +140,7 @@ var _ = Describe("Store", func() {
 			tagLength = 1
 			migrateAndPopulateTags(realDb, tagLength)
 			dataStore = store.New(realDb, group, destination, policy, tagLength)
-			tagDataStore = store.NewTagStore(realDb, group, tagLength)
+			tagDataStore = store.NewTagStore(realDb, group, tagLength, 0)
 		})
 
 		It("saves the policies", func() {
@@ -173,6 +174,22 @@ var _ = Describe("Store", func() {
 			Expect(len(p)).To(Equal(2))
 		})
 
+		It("increments the policy revision", func() {
+			revisionTable := &store.RevisionTable{Conn: realDb}
+			before, err := revisionTable.Revision()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = createPolicies(realDb, dataStore, []store.Policy{{
+				Source:      store.Source{ID: "some-app-guid"},
+				Destination: store.Destination{ID: "some-other-app-guid", Protocol: "tcp", Port: 8080},
+			}})
+			Expect(err).NotTo(HaveOccurred())
+
+			after, err := revisionTable.Revision()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(after).To(Equal(before + 1))
+		})
+
 		Context("when a policy with the same content already exists", func() {
 			It("does not duplicate table rows", func() {
 				policies := []store.Policy{{
@@ -244,7 +261,7 @@ var _ = Describe("Store", func() {
 				}}
 
 				err := createPolicies(realDb, dataStore, policies)
-				Expect(err).To(MatchError(ContainSubstring("failed to find available tag")))
+				Expect(err).To(Equal(store.ErrTagSpaceExhausted))
 			})
 		})
 
@@ -304,6 +321,89 @@ var _ = Describe("Store", func() {
 			})
 		})
 
+		Context("when a freed tag is still in quarantine", func() {
+			BeforeEach(func() {
+				group = &store.GroupTable{Quarantine: time.Hour}
+				dataStore = store.New(realDb, group, destination, policy, 1)
+			})
+
+			It("does not reuse the tag", func() {
+				policies := []store.Policy{{
+					Source: store.Source{ID: "some-app-guid"},
+					Destination: store.Destination{
+						ID:       "some-other-app-guid",
+						Protocol: "tcp",
+						Port:     8080,
+					},
+				}}
+
+				err := createPolicies(realDb, dataStore, policies)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = dataStore.Delete(policies)
+				Expect(err).NotTo(HaveOccurred())
+
+				newPolicies := []store.Policy{{
+					Source: store.Source{ID: "yet-another-app-guid"},
+					Destination: store.Destination{
+						ID:       "some-other-app-guid",
+						Protocol: "tcp",
+						Port:     8080,
+					},
+				}}
+
+				err = createPolicies(realDb, dataStore, newPolicies)
+				Expect(err).NotTo(HaveOccurred())
+
+				tags, err := tagDataStore.Tags()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(tags).To(ConsistOf([]store.Tag{
+					{ID: "yet-another-app-guid", Tag: "03", Type: "app"},
+					{ID: "some-other-app-guid", Tag: "04", Type: "app"},
+				}))
+			})
+		})
+
+		Context("when the only free tags are still in quarantine", func() {
+			var policies []store.Policy
+
+			BeforeEach(func() {
+				group = &store.GroupTable{Quarantine: time.Hour}
+				dataStore = store.New(realDb, group, destination, policy, 1)
+
+				policies = nil
+				for i := 1; i < 256; i++ {
+					policies = append(policies, store.Policy{
+						Source: store.Source{ID: fmt.Sprintf("%d", i)},
+						Destination: store.Destination{
+							ID:       fmt.Sprintf("%d", i),
+							Protocol: "tcp",
+							Port:     8080,
+						},
+					})
+				}
+				err := createPolicies(realDb, dataStore, policies)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = dataStore.Delete(policies[:1])
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns ErrTagsQuarantined", func() {
+				newPolicies := []store.Policy{{
+					Source: store.Source{ID: "some-app-guid"},
+					Destination: store.Destination{
+						ID:       "some-app-guid",
+						Protocol: "tcp",
+						Port:     8080,
+					},
+				}}
+
+				err := createPolicies(realDb, dataStore, newPolicies)
+				Expect(err).To(Equal(store.ErrTagsQuarantined))
+			})
+		})
+
 		Context("when a Group create record fails", func() {
 			var fakeGroup *fakes.GroupRepo
 			var err error
@@ -514,6 +614,50 @@ var _ = Describe("Store", func() {
 		})
 	})
 
+	Describe("AllWithTx", func() {
+		var expectedPolicies []store.Policy
+
+		BeforeEach(func() {
+			expectedPolicies = []store.Policy{{
+				Source: store.Source{ID: "some-app-guid", Tag: "01"},
+				Destination: store.Destination{
+					ID:       "some-other-app-guid",
+					Tag:      "02",
+					Protocol: "tcp",
+					Ports: store.Ports{
+						Start: 5000,
+						End:   6000,
+					},
+				},
+			}}
+			migrateAndPopulateTags(realDb, 1)
+			dataStore = store.New(realDb, group, destination, policy, 1)
+
+			err := createPolicies(realDb, dataStore, expectedPolicies)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the policies inside the transaction", func() {
+			tx, err := realDb.Beginx()
+			Expect(err).NotTo(HaveOccurred())
+			defer tx.Rollback()
+
+			policies, err := dataStore.AllWithTx(tx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policies).To(ConsistOf(expectedPolicies))
+		})
+
+		Context("when the query fails", func() {
+			It("should return a sensible error", func() {
+				tx := &dbfakes.Transaction{}
+				tx.QueryReturns(nil, errors.New("some query error"))
+
+				_, err := dataStore.AllWithTx(tx)
+				Expect(err).To(MatchError("listing all: some query error"))
+			})
+		})
+	})
+
 	Describe("ByGuids", func() {
 		var allPolicies []store.Policy
 		var expectedPolicies []store.Policy
@@ -742,7 +886,7 @@ var _ = Describe("Store", func() {
 			tagLength = 1
 			migrateAndPopulateTags(realDb, tagLength)
 			dataStore = store.New(realDb, group, destination, policy, tagLength)
-			tagDataStore = store.NewTagStore(realDb, group, tagLength)
+			tagDataStore = store.NewTagStore(realDb, group, tagLength, 0)
 
 			policies := []store.Policy{
 				{
@@ -767,6 +911,22 @@ var _ = Describe("Store", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
+		It("increments the policy revision", func() {
+			revisionTable := &store.RevisionTable{Conn: realDb}
+			before, err := revisionTable.Revision()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = dataStore.Delete([]store.Policy{{
+				Source:      store.Source{ID: "some-app-guid"},
+				Destination: store.Destination{ID: "some-other-app-guid", Protocol: "tcp", Port: 8080},
+			}})
+			Expect(err).NotTo(HaveOccurred())
+
+			after, err := revisionTable.Revision()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(after).To(Equal(before + 1))
+		})
+
 		It("deletes the specified policies", func() {
 			err := dataStore.Delete([]store.Policy{{
 				Source: store.Source{ID: "some-app-guid"},
@@ -1140,6 +1300,7 @@ func createPolicies(realDb store.Database, dataStore store.Store, policies []sto
 
 	err = dataStore.CreateWithTx(tx, policies)
 	if err != nil {
+		tx.Rollback()
 		return err
 	}
 	return tx.Commit()
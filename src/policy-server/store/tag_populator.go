@@ -44,3 +44,53 @@ func (t *TagPopulator) PopulateTables(tl int) error {
 
 	return nil
 }
+
+// ExpandTables grows the groups table so that tags of the given length can be
+// allocated. New rows are appended after the existing ones, so the values of
+// tags already in use do not change and it is safe to run while policy
+// servers are serving requests. Rows are inserted batchSize at a time. The
+// number of rows added is returned.
+func (t *TagPopulator) ExpandTables(tl int, batchSize int) (int, error) {
+	if batchSize < 1 {
+		return 0, fmt.Errorf("expanding tables: invalid batch size %d", batchSize)
+	}
+
+	var maxID int
+	err := t.DBConnection.QueryRow(`SELECT COALESCE(MAX(id), 0) FROM groups`).Scan(&maxID)
+	if err != nil {
+		return 0, fmt.Errorf("expanding tables: %s", err)
+	}
+
+	lastID := int(math.Exp2(float64(tl*8))) - 1
+	added := 0
+	for start := maxID + 1; start <= lastID; start += batchSize {
+		end := start + batchSize - 1
+		if end > lastID {
+			end = lastID
+		}
+
+		var b bytes.Buffer
+		b.WriteString("INSERT INTO groups (id) VALUES ")
+		for id := start; id <= end; id++ {
+			if id > start {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(&b, "(%d)", id)
+		}
+
+		_, err = t.DBConnection.Exec(b.String())
+		if err != nil {
+			return added, fmt.Errorf("expanding tables: %s", err)
+		}
+		added += end - start + 1
+	}
+
+	if added > 0 && t.DBConnection.DriverName() == "postgres" {
+		_, err = t.DBConnection.Exec(`SELECT setval('groups_id_seq', (SELECT MAX(id) FROM groups))`)
+		if err != nil {
+			return added, fmt.Errorf("expanding tables: updating sequence: %s", err)
+		}
+	}
+
+	return added, nil
+}
@@ -2,7 +2,6 @@ package store_test
 
 import (
 	dbHelper "code.cloudfoundry.org/cf-networking-helpers/db"
-	"code.cloudfoundry.org/cf-networking-helpers/testsupport"
 	"code.cloudfoundry.org/lager"
 	"database/sql"
 	"errors"
@@ -33,8 +32,8 @@ var _ = Describe("Tag Populator", func() {
 
 		BeforeEach(func() {
 
-			dbConf = testsupport.GetDBConfig()
-			dbConf.DatabaseName = fmt.Sprintf("tag_populator_test_node_%d", time.Now().UnixNano())
+			dbConf = testhelpers.GetDBConfig()
+			dbConf.DatabaseName = testhelpers.DatabaseName(dbConf, fmt.Sprintf("tag_populator_test_node_%d", time.Now().UnixNano()))
 
 			testhelpers.CreateDatabase(dbConf)
 
@@ -87,6 +86,60 @@ var _ = Describe("Tag Populator", func() {
 				Expect(id).To(Equal(255))
 			})
 		})
+
+		Describe("ExpandTables", func() {
+			BeforeEach(func() {
+				Expect(tagPopulator.PopulateTables(1)).To(Succeed())
+
+				_, err := realDb.Exec(realDb.Rebind(`UPDATE groups SET guid = 'some-app-guid', type = 'app' WHERE id = ?`), 3)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("adds rows up to 2^(tag_length * 8) - 1 without touching existing ones", func() {
+				added, err := tagPopulator.ExpandTables(2, 1000)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(added).To(Equal(65535 - 255))
+
+				var count, maxID int
+				err = realDb.QueryRow(`SELECT COUNT(*), MAX(id) FROM groups`).Scan(&count, &maxID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(count).To(Equal(65535))
+				Expect(maxID).To(Equal(65535))
+
+				var guid string
+				err = realDb.QueryRow(`SELECT guid FROM groups WHERE id = 3`).Scan(&guid)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(guid).To(Equal("some-app-guid"))
+			})
+
+			It("allows the new rows to be allocated as tags", func() {
+				_, err := tagPopulator.ExpandTables(2, 1000)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = realDb.Exec(`UPDATE groups SET guid = id, type = 'app' WHERE id <= 255 AND guid IS NULL`)
+				Expect(err).NotTo(HaveOccurred())
+
+				tagStore := store.NewTagStore(realDb, &store.GroupTable{}, 2, 0)
+				tag, err := tagStore.CreateTag("new-app-guid", "app")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(tag.Tag).To(Equal("0100"))
+			})
+
+			Context("when the table is already big enough", func() {
+				It("adds nothing", func() {
+					added, err := tagPopulator.ExpandTables(1, 1000)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(added).To(Equal(0))
+				})
+			})
+
+			Context("when the batch size is invalid", func() {
+				It("returns an error", func() {
+					_, err := tagPopulator.ExpandTables(2, 0)
+					Expect(err).To(MatchError("expanding tables: invalid batch size 0"))
+				})
+			})
+		})
 	})
 
 	Context("when the groups table fails to populate", func() {
@@ -2,25 +2,34 @@ package store
 
 import (
 	"fmt"
+	"policy-server/store/helpers"
+	"time"
 )
 
 //go:generate counterfeiter -o fakes/tag_store.go --fake-name TagStore . TagStore
 type TagStore interface {
 	CreateTag(string, string) (Tag, error)
 	Tags() ([]Tag, error)
+	TagsByGuids([]string) ([]Tag, error)
+	ReleaseTags([]Tag) ([]Tag, error)
+	TagUsage() (TagUsage, error)
 }
 
 type tagStore struct {
-	conn      Database
-	group     GroupRepo
-	tagLength int
+	conn       Database
+	group      GroupRepo
+	tagLength  int
+	quarantine time.Duration
 }
 
-func NewTagStore(dbConnectionPool Database, groupRepo GroupRepo, tagLength int) *tagStore {
+// NewTagStore returns a TagStore. The quarantine should match the one of the
+// group repo; it is only used to report how many tags are free.
+func NewTagStore(dbConnectionPool Database, groupRepo GroupRepo, tagLength int, quarantine time.Duration) *tagStore {
 	return &tagStore{
-		conn:      dbConnectionPool,
-		group:     groupRepo,
-		tagLength: tagLength,
+		conn:       dbConnectionPool,
+		group:      groupRepo,
+		tagLength:  tagLength,
+		quarantine: quarantine,
 	}
 }
 
@@ -48,13 +57,36 @@ func (s *tagStore) CreateTag(groupGuid, groupType string) (Tag, error) {
 }
 
 func (s *tagStore) Tags() ([]Tag, error) {
-	var tags []Tag
-
-	rows, err := s.conn.Query(`
+	return s.queryTags(`
 		SELECT guid, id, type FROM groups
 		WHERE guid IS NOT NULL
 		ORDER BY id
 	`)
+}
+
+// TagsByGuids returns the tags allocated to the given guids. Guids without a
+// tag are skipped.
+func (s *tagStore) TagsByGuids(guids []string) ([]Tag, error) {
+	if len(guids) == 0 {
+		return []Tag{}, nil
+	}
+
+	args := make([]interface{}, len(guids))
+	for i, guid := range guids {
+		args[i] = guid
+	}
+
+	return s.queryTags(fmt.Sprintf(`
+		SELECT guid, id, type FROM groups
+		WHERE guid IN (%s)
+		ORDER BY id
+	`, helpers.QuestionMarks(len(guids))), args...)
+}
+
+func (s *tagStore) queryTags(query string, args ...interface{}) ([]Tag, error) {
+	var tags []Tag
+
+	rows, err := s.conn.Query(s.conn.Rebind(query), args...)
 	if err != nil {
 		return nil, fmt.Errorf("listing tags: %s", err)
 	}
@@ -84,6 +116,68 @@ func (s *tagStore) Tags() ([]Tag, error) {
 	return tags, nil
 }
 
+// ReleaseTags frees the groups rows of the given tags so that they can be
+// allocated again. Rows still referenced by a policy are left alone. The tags
+// that were actually released are returned.
+func (s *tagStore) ReleaseTags(tags []Tag) ([]Tag, error) {
+	tx, err := s.conn.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %s", err)
+	}
+
+	released := []Tag{}
+	for _, tag := range tags {
+		result, err := tx.Exec(tx.Rebind(`
+			UPDATE groups SET guid = NULL, type = NULL, released_at = ?
+			WHERE guid = ? AND type = ?
+			AND NOT EXISTS (SELECT 1 FROM policies WHERE policies.group_id = groups.id)
+			AND NOT EXISTS (SELECT 1 FROM destinations WHERE destinations.group_id = groups.id)
+		`),
+			time.Now().Unix(),
+			tag.ID,
+			tag.Type,
+		)
+		if err != nil {
+			return nil, rollback(tx, fmt.Errorf("releasing tag: %s", err))
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return nil, rollback(tx, fmt.Errorf("releasing tag: %s", err))
+		}
+		if rowsAffected > 0 {
+			released = append(released, tag)
+		}
+	}
+
+	err = commit(tx)
+	if err != nil {
+		return nil, rollback(tx, err)
+	}
+
+	return released, nil
+}
+
+// TagUsage counts the allocated tags, the tags that can be allocated right
+// now and the released tags that are still in quarantine.
+func (s *tagStore) TagUsage() (TagUsage, error) {
+	var usage TagUsage
+	err := s.conn.QueryRow(s.conn.Rebind(`
+		SELECT
+			COUNT(CASE WHEN guid IS NOT NULL THEN 1 END),
+			COUNT(CASE WHEN guid IS NULL AND (released_at IS NULL OR released_at <= ?) THEN 1 END),
+			COUNT(CASE WHEN guid IS NULL AND released_at > ? THEN 1 END)
+		FROM groups
+	`),
+		time.Now().Add(-s.quarantine).Unix(),
+		time.Now().Add(-s.quarantine).Unix(),
+	).Scan(&usage.Used, &usage.Free, &usage.Quarantined)
+	if err != nil {
+		return TagUsage{}, fmt.Errorf("counting tags: %s", err)
+	}
+	return usage, nil
+}
+
 func (s *tagStore) tagIntToString(tag int) string {
 	return fmt.Sprintf("%"+fmt.Sprintf("0%d", s.tagLength*2)+"X", tag)
 }
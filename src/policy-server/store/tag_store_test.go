@@ -7,10 +7,10 @@ import (
 	dbFakes "policy-server/db/fakes"
 	"policy-server/store"
 	"policy-server/store/fakes"
+	"test-helpers"
 	"time"
 
 	dbHelper "code.cloudfoundry.org/cf-networking-helpers/db"
-	"code.cloudfoundry.org/cf-networking-helpers/testsupport"
 
 	"policy-server/store/migrations"
 
@@ -40,10 +40,10 @@ var _ = Describe("TagStore", func() {
 		tagLength = 1
 		mockDb = &fakes.Db{}
 
-		dbConf = testsupport.GetDBConfig()
-		dbConf.DatabaseName = fmt.Sprintf("tag_store_test_node_%d", time.Now().UnixNano())
+		dbConf = testhelpers.GetDBConfig()
+		dbConf.DatabaseName = testhelpers.DatabaseName(dbConf, fmt.Sprintf("tag_store_test_node_%d", time.Now().UnixNano()))
 
-		testsupport.CreateDatabase(dbConf)
+		testhelpers.CreateDatabase(dbConf)
 
 		logger := lager.NewLogger("Tag Store Test")
 
@@ -68,7 +68,7 @@ var _ = Describe("TagStore", func() {
 		if realDb != nil {
 			Expect(realDb.Close()).To(Succeed())
 		}
-		testsupport.RemoveDatabase(dbConf)
+		testhelpers.RemoveDatabase(dbConf)
 	})
 
 	Describe("CreateTag", func() {
@@ -78,7 +78,7 @@ var _ = Describe("TagStore", func() {
 		)
 
 		BeforeEach(func() {
-			tagStore = store.NewTagStore(realDb, group, tagLength)
+			tagStore = store.NewTagStore(realDb, group, tagLength, 0)
 			groupGuid, groupType = "meow-guid", "meow-type"
 		})
 
@@ -124,7 +124,7 @@ var _ = Describe("TagStore", func() {
 				mockTx = &dbFakes.Transaction{}
 				mockDb.BeginxReturns(mockTx, nil)
 
-				tagStore = store.NewTagStore(mockDb, mockGroup, tagLength)
+				tagStore = store.NewTagStore(mockDb, mockGroup, tagLength, 0)
 			})
 
 			It("returns an error", func() {
@@ -151,7 +151,7 @@ var _ = Describe("TagStore", func() {
 				mockTx.CommitReturns(errors.New("transaction commit failed"))
 				mockDb.BeginxReturns(mockTx, nil)
 
-				tagStore = store.NewTagStore(mockDb, mockGroup, tagLength)
+				tagStore = store.NewTagStore(mockDb, mockGroup, tagLength, 0)
 			})
 
 			It("returns an error", func() {
@@ -168,7 +168,7 @@ var _ = Describe("TagStore", func() {
 
 	Describe("Tags", func() {
 		BeforeEach(func() {
-			tagStore = store.NewTagStore(realDb, group, tagLength)
+			tagStore = store.NewTagStore(realDb, group, tagLength, 0)
 			dataStore = store.New(realDb, group, destination, policy, 1)
 		})
 
@@ -209,7 +209,7 @@ var _ = Describe("TagStore", func() {
 			})
 
 			It("should return a sensible error", func() {
-				store := store.NewTagStore(mockDb, group, tagLength)
+				store := store.NewTagStore(mockDb, group, tagLength, 0)
 
 				_, err := store.Tags()
 				Expect(err).To(MatchError("listing tags: some query error"))
@@ -232,11 +232,185 @@ var _ = Describe("TagStore", func() {
 			})
 
 			It("should return a sensible error", func() {
-				store := store.NewTagStore(mockDb, group, tagLength)
+				store := store.NewTagStore(mockDb, group, tagLength, 0)
 
 				_, err := store.Tags()
 				Expect(err).To(MatchError(ContainSubstring("listing tags: sql: expected")))
 			})
 		})
 	})
+
+	Describe("TagsByGuids", func() {
+		BeforeEach(func() {
+			tagStore = store.NewTagStore(realDb, group, tagLength, 0)
+
+			_, err := tagStore.CreateTag("some-app-guid", "app")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = tagStore.CreateTag("some-space-guid", "space")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = tagStore.CreateTag("another-app-guid", "app")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the tags of the given guids", func() {
+			tags, err := tagStore.TagsByGuids([]string{"another-app-guid", "some-space-guid", "unknown-guid"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tags).To(Equal([]store.Tag{
+				{ID: "some-space-guid", Tag: "02", Type: "space"},
+				{ID: "another-app-guid", Tag: "03", Type: "app"},
+			}))
+		})
+
+		Context("when no guids are given", func() {
+			It("returns no tags", func() {
+				tags, err := tagStore.TagsByGuids([]string{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(tags).To(BeEmpty())
+			})
+		})
+
+		Context("when the db operation fails", func() {
+			BeforeEach(func() {
+				mockDb.QueryReturns(nil, errors.New("some query error"))
+			})
+
+			It("should return a sensible error", func() {
+				store := store.NewTagStore(mockDb, group, tagLength, 0)
+
+				_, err := store.TagsByGuids([]string{"some-app-guid"})
+				Expect(err).To(MatchError("listing tags: some query error"))
+			})
+		})
+	})
+
+	Describe("ReleaseTags", func() {
+		BeforeEach(func() {
+			tagStore = store.NewTagStore(realDb, group, tagLength, 0)
+			dataStore = store.New(realDb, group, destination, policy, tagLength)
+
+			err := createPolicies(realDb, dataStore, []store.Policy{{
+				Source: store.Source{ID: "some-app-guid"},
+				Destination: store.Destination{
+					ID:       "some-other-app-guid",
+					Protocol: "tcp",
+					Port:     8080,
+				},
+			}})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = tagStore.CreateTag("orphaned-app-guid", "app")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("releases tags that are not used by any policy", func() {
+			released, err := tagStore.ReleaseTags([]store.Tag{
+				{ID: "orphaned-app-guid", Tag: "03", Type: "app"},
+				{ID: "some-app-guid", Tag: "01", Type: "app"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(released).To(Equal([]store.Tag{
+				{ID: "orphaned-app-guid", Tag: "03", Type: "app"},
+			}))
+
+			tags, err := tagStore.Tags()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tags).To(ConsistOf(
+				store.Tag{ID: "some-app-guid", Tag: "01", Type: "app"},
+				store.Tag{ID: "some-other-app-guid", Tag: "02", Type: "app"},
+			))
+		})
+
+		It("allows the released tag to be allocated again", func() {
+			_, err := tagStore.ReleaseTags([]store.Tag{{ID: "orphaned-app-guid", Tag: "03", Type: "app"}})
+			Expect(err).NotTo(HaveOccurred())
+
+			tag, err := tagStore.CreateTag("new-app-guid", "app")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tag.Tag).To(Equal("03"))
+		})
+
+		Context("when the group repo has a quarantine", func() {
+			BeforeEach(func() {
+				group = &store.GroupTable{Quarantine: time.Hour}
+				tagStore = store.NewTagStore(realDb, group, tagLength, time.Hour)
+			})
+
+			It("does not allocate the released tag again", func() {
+				_, err := tagStore.ReleaseTags([]store.Tag{{ID: "orphaned-app-guid", Tag: "03", Type: "app"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				tag, err := tagStore.CreateTag("new-app-guid", "app")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(tag.Tag).To(Equal("04"))
+			})
+		})
+
+		Context("when the type does not match", func() {
+			It("does not release the tag", func() {
+				released, err := tagStore.ReleaseTags([]store.Tag{{ID: "orphaned-app-guid", Type: "router"}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(released).To(BeEmpty())
+			})
+		})
+
+		Context("when the transaction cannot be started", func() {
+			BeforeEach(func() {
+				mockDb.BeginxReturns(nil, errors.New("some begin error"))
+			})
+
+			It("returns an error", func() {
+				tagStore = store.NewTagStore(mockDb, group, tagLength, 0)
+				_, err := tagStore.ReleaseTags([]store.Tag{{ID: "orphaned-app-guid", Type: "app"}})
+				Expect(err).To(MatchError("begin transaction: some begin error"))
+			})
+		})
+
+		Context("when the update fails", func() {
+			var mockTx *dbFakes.Transaction
+
+			BeforeEach(func() {
+				mockTx = &dbFakes.Transaction{}
+				mockTx.ExecReturns(nil, errors.New("some exec error"))
+				mockDb.BeginxReturns(mockTx, nil)
+				tagStore = store.NewTagStore(mockDb, group, tagLength, 0)
+			})
+
+			It("rolls back and returns an error", func() {
+				_, err := tagStore.ReleaseTags([]store.Tag{{ID: "orphaned-app-guid", Type: "app"}})
+				Expect(err).To(MatchError("releasing tag: some exec error"))
+				Expect(mockTx.RollbackCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("TagUsage", func() {
+		BeforeEach(func() {
+			tagStore = store.NewTagStore(realDb, group, tagLength, time.Hour)
+
+			_, err := tagStore.CreateTag("some-app-guid", "app")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = tagStore.CreateTag("another-app-guid", "app")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = tagStore.ReleaseTags([]store.Tag{{ID: "another-app-guid", Type: "app"}})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("counts used, free and quarantined tags", func() {
+			usage, err := tagStore.TagUsage()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usage).To(Equal(store.TagUsage{Used: 1, Free: 253, Quarantined: 1}))
+		})
+
+		Context("when the query fails", func() {
+			BeforeEach(func() {
+				tagStore = store.NewTagStore(mockDb, group, tagLength, 0)
+				mockDb.QueryRowReturns(realDb.QueryRow("SELECT nonexistent FROM nowhere"))
+			})
+
+			It("returns an error", func() {
+				_, err := tagStore.TagUsage()
+				Expect(err).To(MatchError(ContainSubstring("counting tags:")))
+			})
+		})
+	})
 })
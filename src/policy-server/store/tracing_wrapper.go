@@ -0,0 +1,115 @@
+package store
+
+import (
+	"context"
+	"lib/tracing"
+)
+
+// TracingWrapper runs the policy reads of handlers in store.<Method> spans
+// that are children of the span in the context they are given.
+type TracingWrapper struct {
+	Store  Store
+	Tracer *tracing.Tracer
+}
+
+func (tw *TracingWrapper) All(ctx context.Context) ([]Policy, error) {
+	_, span := tw.Tracer.Start(ctx, "store.All")
+	policies, err := tw.Store.All()
+	span.End(err)
+	return policies, err
+}
+
+func (tw *TracingWrapper) ByGuids(ctx context.Context, srcGuids, destGuids []string, inSourceAndDest bool) ([]Policy, error) {
+	_, span := tw.Tracer.Start(ctx, "store.ByGuids")
+	policies, err := tw.Store.ByGuids(srcGuids, destGuids, inSourceAndDest)
+	span.End(err)
+	return policies, err
+}
+
+// PolicyCollectionTracingWrapper runs creates and deletes in store.Create and
+// store.Delete spans.
+type PolicyCollectionTracingWrapper struct {
+	Store  policyCollectionStore
+	Tracer *tracing.Tracer
+}
+
+func (tw *PolicyCollectionTracingWrapper) Create(ctx context.Context, policyCollection PolicyCollection) error {
+	_, span := tw.Tracer.Start(ctx, "store.Create")
+	err := tw.Store.Create(policyCollection)
+	span.End(err)
+	return err
+}
+
+func (tw *PolicyCollectionTracingWrapper) Delete(ctx context.Context, policyCollection PolicyCollection) error {
+	_, span := tw.Tracer.Start(ctx, "store.Delete")
+	err := tw.Store.Delete(policyCollection)
+	span.End(err)
+	return err
+}
+
+// EgressPolicyTracingWrapper runs egress policy reads in egress-store.<Method>
+// spans.
+type EgressPolicyTracingWrapper struct {
+	Store  egressPolicyReader
+	Tracer *tracing.Tracer
+}
+
+func (tw *EgressPolicyTracingWrapper) All(ctx context.Context) ([]EgressPolicy, error) {
+	_, span := tw.Tracer.Start(ctx, "egress-store.All")
+	egressPolicies, err := tw.Store.All()
+	span.End(err)
+	return egressPolicies, err
+}
+
+func (tw *EgressPolicyTracingWrapper) ByGuids(ctx context.Context, ids []string) ([]EgressPolicy, error) {
+	_, span := tw.Tracer.Start(ctx, "egress-store.ByGuids")
+	egressPolicies, err := tw.Store.ByGuids(ids)
+	span.End(err)
+	return egressPolicies, err
+}
+
+// TagTracingWrapper runs tag reads and writes in tag-store.<Method> spans.
+type TagTracingWrapper struct {
+	Store  TagStore
+	Tracer *tracing.Tracer
+}
+
+func (tw *TagTracingWrapper) CreateTag(ctx context.Context, groupGuid, groupType string) (Tag, error) {
+	_, span := tw.Tracer.Start(ctx, "tag-store.CreateTag")
+	tag, err := tw.Store.CreateTag(groupGuid, groupType)
+	span.End(err)
+	return tag, err
+}
+
+func (tw *TagTracingWrapper) TagsByGuids(ctx context.Context, guids []string) ([]Tag, error) {
+	_, span := tw.Tracer.Start(ctx, "tag-store.TagsByGuids")
+	tags, err := tw.Store.TagsByGuids(guids)
+	span.End(err)
+	return tags, err
+}
+
+func (tw *TagTracingWrapper) ReleaseTags(ctx context.Context, tags []Tag) ([]Tag, error) {
+	_, span := tw.Tracer.Start(ctx, "tag-store.ReleaseTags")
+	released, err := tw.Store.ReleaseTags(tags)
+	span.End(err)
+	return released, err
+}
+
+//go:generate counterfeiter -o fakes/policy_snapshots.go --fake-name PolicySnapshots . policySnapshots
+type policySnapshots interface {
+	Snapshot() (*PolicySnapshot, error)
+}
+
+// SnapshotTracingWrapper runs Snapshot, which reloads the snapshot when the
+// policy revision has changed, in a store.Snapshot span.
+type SnapshotTracingWrapper struct {
+	Snapshots policySnapshots
+	Tracer    *tracing.Tracer
+}
+
+func (tw *SnapshotTracingWrapper) Snapshot(ctx context.Context) (*PolicySnapshot, error) {
+	_, span := tw.Tracer.Start(ctx, "store.Snapshot")
+	snapshot, err := tw.Snapshots.Snapshot()
+	span.End(err)
+	return snapshot, err
+}
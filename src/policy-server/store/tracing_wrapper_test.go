@@ -0,0 +1,202 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	libfakes "lib/fakes"
+	"lib/tracing"
+	"policy-server/store"
+	"policy-server/store/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TracingWrapper", func() {
+	var (
+		tracingWrapper *store.TracingWrapper
+		fakeStore      *fakes.Store
+		fakeExporter   *libfakes.SpanExporter
+		tracer         *tracing.Tracer
+	)
+
+	BeforeEach(func() {
+		fakeStore = &fakes.Store{}
+		fakeExporter = &libfakes.SpanExporter{}
+		tracer = &tracing.Tracer{Service: "some-service", Exporter: fakeExporter}
+		tracingWrapper = &store.TracingWrapper{
+			Store:  fakeStore,
+			Tracer: tracer,
+		}
+	})
+
+	Describe("ByGuids", func() {
+		It("calls ByGuids in a child span of the one in the context", func() {
+			fakeStore.ByGuidsReturns([]store.Policy{{Source: store.Source{ID: "some-app"}}}, nil)
+			ctx, parent := tracer.Start(context.Background(), "parent")
+
+			policies, err := tracingWrapper.ByGuids(ctx, []string{"some-app"}, nil, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policies).To(HaveLen(1))
+
+			srcGuids, destGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
+			Expect(srcGuids).To(Equal([]string{"some-app"}))
+			Expect(destGuids).To(BeNil())
+			Expect(inSourceAndDest).To(BeFalse())
+
+			span := fakeExporter.ExportSpanArgsForCall(0)
+			Expect(span.Name).To(Equal("store.ByGuids"))
+			Expect(span.ParentSpanID).To(Equal(parent.SpanContext().SpanID))
+		})
+
+		Context("when there is an error", func() {
+			It("records the error on the span", func() {
+				fakeStore.ByGuidsReturns(nil, errors.New("banana"))
+
+				_, err := tracingWrapper.ByGuids(context.Background(), nil, nil, false)
+				Expect(err).To(MatchError("banana"))
+
+				Expect(fakeExporter.ExportSpanArgsForCall(0).Error).To(Equal("banana"))
+			})
+		})
+	})
+})
+
+var _ = Describe("PolicyCollectionTracingWrapper", func() {
+	var (
+		tracingWrapper  *store.PolicyCollectionTracingWrapper
+		collectionStore *fakes.PolicyCollectionStore
+		fakeExporter    *libfakes.SpanExporter
+	)
+
+	BeforeEach(func() {
+		collectionStore = &fakes.PolicyCollectionStore{}
+		fakeExporter = &libfakes.SpanExporter{}
+		tracingWrapper = &store.PolicyCollectionTracingWrapper{
+			Store:  collectionStore,
+			Tracer: &tracing.Tracer{Service: "some-service", Exporter: fakeExporter},
+		}
+	})
+
+	It("calls Create in a store.Create span", func() {
+		err := tracingWrapper.Create(context.Background(), store.PolicyCollection{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(collectionStore.CreateCallCount()).To(Equal(1))
+		Expect(fakeExporter.ExportSpanArgsForCall(0).Name).To(Equal("store.Create"))
+	})
+
+	It("calls Delete in a store.Delete span", func() {
+		collectionStore.DeleteReturns(errors.New("banana"))
+
+		err := tracingWrapper.Delete(context.Background(), store.PolicyCollection{})
+		Expect(err).To(MatchError("banana"))
+
+		span := fakeExporter.ExportSpanArgsForCall(0)
+		Expect(span.Name).To(Equal("store.Delete"))
+		Expect(span.Error).To(Equal("banana"))
+	})
+})
+
+var _ = Describe("EgressPolicyTracingWrapper", func() {
+	var (
+		tracingWrapper *store.EgressPolicyTracingWrapper
+		egressStore    *fakes.EgressPolicyReader
+		fakeExporter   *libfakes.SpanExporter
+	)
+
+	BeforeEach(func() {
+		egressStore = &fakes.EgressPolicyReader{}
+		fakeExporter = &libfakes.SpanExporter{}
+		tracingWrapper = &store.EgressPolicyTracingWrapper{
+			Store:  egressStore,
+			Tracer: &tracing.Tracer{Service: "some-service", Exporter: fakeExporter},
+		}
+	})
+
+	It("calls All in an egress-store.All span", func() {
+		egressStore.AllReturns([]store.EgressPolicy{{Source: store.EgressSource{ID: "some-app"}}}, nil)
+
+		egressPolicies, err := tracingWrapper.All(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(egressPolicies).To(HaveLen(1))
+
+		Expect(fakeExporter.ExportSpanArgsForCall(0).Name).To(Equal("egress-store.All"))
+	})
+
+	It("calls ByGuids in an egress-store.ByGuids span", func() {
+		egressStore.ByGuidsReturns(nil, errors.New("banana"))
+
+		_, err := tracingWrapper.ByGuids(context.Background(), []string{"some-app"})
+		Expect(err).To(MatchError("banana"))
+		Expect(egressStore.ByGuidsArgsForCall(0)).To(Equal([]string{"some-app"}))
+
+		span := fakeExporter.ExportSpanArgsForCall(0)
+		Expect(span.Name).To(Equal("egress-store.ByGuids"))
+		Expect(span.Error).To(Equal("banana"))
+	})
+})
+
+var _ = Describe("TagTracingWrapper", func() {
+	var (
+		tracingWrapper *store.TagTracingWrapper
+		tagStore       *fakes.TagStore
+		fakeExporter   *libfakes.SpanExporter
+	)
+
+	BeforeEach(func() {
+		tagStore = &fakes.TagStore{}
+		fakeExporter = &libfakes.SpanExporter{}
+		tracingWrapper = &store.TagTracingWrapper{
+			Store:  tagStore,
+			Tracer: &tracing.Tracer{Service: "some-service", Exporter: fakeExporter},
+		}
+	})
+
+	It("calls CreateTag in a tag-store.CreateTag span", func() {
+		tagStore.CreateTagReturns(store.Tag{ID: "some-app", Tag: "0001"}, nil)
+
+		tag, err := tracingWrapper.CreateTag(context.Background(), "some-app", "app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tag.Tag).To(Equal("0001"))
+
+		Expect(fakeExporter.ExportSpanArgsForCall(0).Name).To(Equal("tag-store.CreateTag"))
+	})
+
+	It("calls TagsByGuids in a tag-store.TagsByGuids span", func() {
+		_, err := tracingWrapper.TagsByGuids(context.Background(), []string{"some-app"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tagStore.TagsByGuidsArgsForCall(0)).To(Equal([]string{"some-app"}))
+
+		Expect(fakeExporter.ExportSpanArgsForCall(0).Name).To(Equal("tag-store.TagsByGuids"))
+	})
+
+	It("calls ReleaseTags in a tag-store.ReleaseTags span", func() {
+		tagStore.ReleaseTagsReturns(nil, errors.New("banana"))
+
+		_, err := tracingWrapper.ReleaseTags(context.Background(), []store.Tag{{ID: "some-app"}})
+		Expect(err).To(MatchError("banana"))
+
+		span := fakeExporter.ExportSpanArgsForCall(0)
+		Expect(span.Name).To(Equal("tag-store.ReleaseTags"))
+		Expect(span.Error).To(Equal("banana"))
+	})
+})
+
+var _ = Describe("SnapshotTracingWrapper", func() {
+	It("calls Snapshot in a store.Snapshot span", func() {
+		snapshots := &fakes.PolicySnapshots{}
+		snapshots.SnapshotReturns(&store.PolicySnapshot{Revision: 3}, nil)
+		fakeExporter := &libfakes.SpanExporter{}
+		tracingWrapper := &store.SnapshotTracingWrapper{
+			Snapshots: snapshots,
+			Tracer:    &tracing.Tracer{Service: "some-service", Exporter: fakeExporter},
+		}
+
+		snapshot, err := tracingWrapper.Snapshot(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(snapshot.Revision).To(Equal(int64(3)))
+
+		Expect(fakeExporter.ExportSpanArgsForCall(0).Name).To(Equal("store.Snapshot"))
+	})
+})
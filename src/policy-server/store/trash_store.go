@@ -0,0 +1,222 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"policy-server/db"
+	"policy-server/store/helpers"
+	"time"
+)
+
+const (
+	DeleteReasonUser    = "user"
+	DeleteReasonCleaner = "cleaner"
+)
+
+//go:generate counterfeiter -o fakes/trash_store.go --fake-name TrashStore . TrashStore
+type TrashStore interface {
+	AddWithTx(db.Transaction, []Policy, string) error
+	All() ([]DeletedPolicy, error)
+	Restore([]int) ([]Policy, error)
+	Purge(time.Time) (int, error)
+}
+
+type trashStore struct {
+	conn        Database
+	policyStore Store
+}
+
+// NewTrashStore returns a store for the deleted_policies table. Restored
+// policies are recreated through the given policy store.
+func NewTrashStore(dbConnectionPool Database, policyStore Store) *trashStore {
+	return &trashStore{
+		conn:        dbConnectionPool,
+		policyStore: policyStore,
+	}
+}
+
+// AddWithTx records the given policies as deleted. It must be called before
+// the policies are removed, because only policies that still exist are
+// recorded. The caller owns the transaction.
+func (t *trashStore) AddWithTx(tx db.Transaction, policies []Policy, reason string) error {
+	deletedAt := time.Now().Unix()
+	for _, p := range policies {
+		var count int
+		err := tx.QueryRow(tx.Rebind(`
+			SELECT COUNT(*) FROM policies
+			JOIN groups AS src_grp ON (policies.group_id = src_grp.id)
+			JOIN destinations ON (destinations.id = policies.destination_id)
+			JOIN groups AS dst_grp ON (destinations.group_id = dst_grp.id)
+			WHERE src_grp.guid = ? AND dst_grp.guid = ?
+			AND destinations.protocol = ? AND destinations.port = ?
+			AND destinations.start_port = ? AND destinations.end_port = ?`),
+			p.Source.ID,
+			p.Destination.ID,
+			p.Destination.Protocol,
+			p.Destination.Port,
+			p.Destination.Ports.Start,
+			p.Destination.Ports.End,
+		).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("finding policy: %s", err)
+		}
+		if count == 0 {
+			continue
+		}
+
+		_, err = tx.Exec(tx.Rebind(`
+			INSERT INTO deleted_policies
+			(source_guid, destination_guid, protocol, port, start_port, end_port, reason, deleted_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`),
+			p.Source.ID,
+			p.Destination.ID,
+			p.Destination.Protocol,
+			p.Destination.Port,
+			p.Destination.Ports.Start,
+			p.Destination.Ports.End,
+			reason,
+			deletedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("recording deleted policy: %s", err)
+		}
+	}
+	return nil
+}
+
+func (t *trashStore) All() ([]DeletedPolicy, error) {
+	return t.query(`
+		SELECT id, source_guid, destination_guid, protocol, port, start_port, end_port, reason, deleted_at
+		FROM deleted_policies
+		ORDER BY id`)
+}
+
+// Restore recreates the deleted policies with the given ids, along with any
+// groups and destinations they need, and removes them from the trash. Unknown
+// ids are ignored. The restored policies are returned.
+func (t *trashStore) Restore(ids []int) ([]Policy, error) {
+	if len(ids) == 0 {
+		return []Policy{}, nil
+	}
+
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	tx, err := t.conn.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %s", err)
+	}
+
+	// The rows are read and locked in the transaction, so that two restores
+	// of the same ids cannot both recreate the policies.
+	lockStatement := " FOR UPDATE"
+	if tx.DriverName() == helpers.SQLite {
+		lockStatement = ""
+	}
+	rows, err := tx.Query(tx.Rebind(fmt.Sprintf(`
+		SELECT id, source_guid, destination_guid, protocol, port, start_port, end_port, reason, deleted_at
+		FROM deleted_policies
+		WHERE id IN (%s)
+		ORDER BY id`, helpers.QuestionMarks(len(ids)))+lockStatement), args...)
+	if err != nil {
+		return nil, rollback(tx, fmt.Errorf("listing deleted policies: %s", err))
+	}
+	deleted, err := scanDeletedPolicies(rows)
+	if err != nil {
+		return nil, rollback(tx, err)
+	}
+	if len(deleted) == 0 {
+		err = commit(tx)
+		if err != nil {
+			return nil, rollback(tx, err)
+		}
+		return []Policy{}, nil
+	}
+
+	policies := []Policy{}
+	for _, d := range deleted {
+		policies = append(policies, d.Policy)
+	}
+
+	err = t.policyStore.CreateWithTx(tx, policies)
+	if err != nil {
+		return nil, rollback(tx, fmt.Errorf("restoring policies: %s", err))
+	}
+
+	_, err = tx.Exec(tx.Rebind(fmt.Sprintf(`DELETE FROM deleted_policies WHERE id IN (%s)`,
+		helpers.QuestionMarks(len(ids)))), args...)
+	if err != nil {
+		return nil, rollback(tx, fmt.Errorf("removing restored policies: %s", err))
+	}
+
+	err = commit(tx)
+	if err != nil {
+		return nil, rollback(tx, err)
+	}
+
+	return policies, nil
+}
+
+// Purge permanently removes policies deleted before the given time and returns
+// how many were removed.
+func (t *trashStore) Purge(before time.Time) (int, error) {
+	result, err := t.conn.Exec(t.conn.Rebind(`DELETE FROM deleted_policies WHERE deleted_at < ?`), before.Unix())
+	if err != nil {
+		return 0, fmt.Errorf("purging deleted policies: %s", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("purging deleted policies: %s", err)
+	}
+
+	return int(rowsAffected), nil
+}
+
+func (t *trashStore) query(query string, args ...interface{}) ([]DeletedPolicy, error) {
+	rows, err := t.conn.Query(t.conn.Rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("listing deleted policies: %s", err)
+	}
+	return scanDeletedPolicies(rows)
+}
+
+func scanDeletedPolicies(rows *sql.Rows) ([]DeletedPolicy, error) {
+	defer rows.Close() // untested
+	deleted := []DeletedPolicy{}
+	for rows.Next() {
+		var id, port, startPort, endPort int
+		var sourceGuid, destinationGuid, protocol, reason string
+		var deletedAt int64
+		err := rows.Scan(&id, &sourceGuid, &destinationGuid, &protocol, &port, &startPort, &endPort, &reason, &deletedAt)
+		if err != nil {
+			return nil, fmt.Errorf("listing deleted policies: %s", err)
+		}
+
+		deleted = append(deleted, DeletedPolicy{
+			ID: id,
+			Policy: Policy{
+				Source: Source{ID: sourceGuid},
+				Destination: Destination{
+					ID:       destinationGuid,
+					Protocol: protocol,
+					Port:     port,
+					Ports: Ports{
+						Start: startPort,
+						End:   endPort,
+					},
+				},
+			},
+			Reason:    reason,
+			DeletedAt: time.Unix(deletedAt, 0).UTC(),
+		})
+	}
+	err := rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("listing deleted policies, getting next row: %s", err) // untested
+	}
+
+	return deleted, nil
+}
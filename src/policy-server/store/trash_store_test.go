@@ -0,0 +1,229 @@
+package store_test
+
+import (
+	"errors"
+	"fmt"
+	"policy-server/store"
+	"policy-server/store/fakes"
+	"test-helpers"
+	"time"
+
+	dbHelper "code.cloudfoundry.org/cf-networking-helpers/db"
+
+	"policy-server/store/migrations"
+
+	"code.cloudfoundry.org/lager"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"policy-server/db"
+	dbfakes "policy-server/db/fakes"
+)
+
+var _ = Describe("TrashStore", func() {
+	var (
+		dataStore  store.Store
+		dbConf     dbHelper.Config
+		realDb     *db.ConnWrapper
+		trashStore store.TrashStore
+		policies   []store.Policy
+	)
+
+	BeforeEach(func() {
+		dbConf = testhelpers.GetDBConfig()
+		dbConf.DatabaseName = testhelpers.DatabaseName(dbConf, fmt.Sprintf("trash_store_test_node_%d", time.Now().UnixNano()))
+
+		testhelpers.CreateDatabase(dbConf)
+
+		logger := lager.NewLogger("Trash Store Test")
+		realDb = db.NewConnectionPool(dbConf, 200, 200, "Trash Store Test", "Trash Store Test", logger)
+
+		realMigrator := &migrations.Migrator{MigrateAdapter: &migrations.MigrateAdapter{}}
+		_, err := realMigrator.PerformMigrations(realDb.DriverName(), realDb, 0)
+		Expect(err).NotTo(HaveOccurred())
+
+		tagPopulator := &store.TagPopulator{DBConnection: realDb}
+		Expect(tagPopulator.PopulateTables(1)).To(Succeed())
+
+		dataStore = store.New(realDb, &store.GroupTable{}, &store.DestinationTable{}, &store.PolicyTable{}, 1)
+		trashStore = store.NewTrashStore(realDb, dataStore)
+
+		policies = []store.Policy{{
+			Source: store.Source{ID: "some-app-guid"},
+			Destination: store.Destination{
+				ID:       "some-other-app-guid",
+				Protocol: "tcp",
+				Port:     8080,
+				Ports:    store.Ports{Start: 8080, End: 8080},
+			},
+		}, {
+			Source: store.Source{ID: "some-app-guid"},
+			Destination: store.Destination{
+				ID:       "another-app-guid",
+				Protocol: "udp",
+				Ports:    store.Ports{Start: 5555, End: 5560},
+			},
+		}}
+
+		Expect(createPolicies(realDb, dataStore, policies)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		if realDb != nil {
+			Expect(realDb.Close()).To(Succeed())
+		}
+		testhelpers.RemoveDatabase(dbConf)
+	})
+
+	deleteWithTrash := func(toDelete []store.Policy) {
+		collectionStore := &store.PolicyCollectionStore{
+			Conn:              realDb,
+			PolicyStore:       dataStore,
+			EgressPolicyStore: &fakes.EgressPolicyStore{},
+			Trash:             trashStore,
+			DeleteReason:      store.DeleteReasonUser,
+		}
+		Expect(collectionStore.Delete(store.PolicyCollection{Policies: toDelete})).To(Succeed())
+	}
+
+	Describe("AddWithTx", func() {
+		It("records deleted policies", func() {
+			before := time.Now().Add(-time.Second)
+			deleteWithTrash(policies[:1])
+
+			deleted, err := trashStore.All()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(HaveLen(1))
+			Expect(deleted[0].Policy).To(Equal(store.Policy{
+				Source: store.Source{ID: "some-app-guid"},
+				Destination: store.Destination{
+					ID:       "some-other-app-guid",
+					Protocol: "tcp",
+					Port:     8080,
+					Ports:    store.Ports{Start: 8080, End: 8080},
+				},
+			}))
+			Expect(deleted[0].Reason).To(Equal("user"))
+			Expect(deleted[0].DeletedAt).To(BeTemporally(">=", before.Truncate(time.Second)))
+
+			remaining, err := dataStore.All()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(remaining).To(HaveLen(1))
+		})
+
+		It("does not record policies that do not exist", func() {
+			deleteWithTrash([]store.Policy{{
+				Source: store.Source{ID: "some-app-guid"},
+				Destination: store.Destination{
+					ID:       "some-other-app-guid",
+					Protocol: "udp",
+					Ports:    store.Ports{Start: 1, End: 2},
+				},
+			}})
+
+			deleted, err := trashStore.All()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(BeEmpty())
+		})
+	})
+
+	Describe("Restore", func() {
+		BeforeEach(func() {
+			deleteWithTrash(policies)
+
+			remaining, err := dataStore.All()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(remaining).To(BeEmpty())
+		})
+
+		It("recreates the policies and removes them from the trash", func() {
+			deleted, err := trashStore.All()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(HaveLen(2))
+
+			restored, err := trashStore.Restore([]int{deleted[1].ID, 9999})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restored).To(Equal([]store.Policy{deleted[1].Policy}))
+
+			current, err := dataStore.All()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(current).To(HaveLen(1))
+			Expect(current[0].Source.ID).To(Equal("some-app-guid"))
+			Expect(current[0].Destination.ID).To(Equal("another-app-guid"))
+			Expect(current[0].Destination.Ports).To(Equal(store.Ports{Start: 5555, End: 5560}))
+
+			remaining, err := trashStore.All()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(remaining).To(HaveLen(1))
+			Expect(remaining[0].ID).To(Equal(deleted[0].ID))
+		})
+
+		Context("when no ids match", func() {
+			It("restores nothing", func() {
+				restored, err := trashStore.Restore([]int{9999})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(restored).To(BeEmpty())
+			})
+		})
+
+		Context("when the deleted policies cannot be read", func() {
+			var tx *dbfakes.Transaction
+
+			BeforeEach(func() {
+				tx = &dbfakes.Transaction{}
+				tx.QueryReturns(nil, errors.New("potato"))
+				mockDb := &fakes.Db{}
+				mockDb.BeginxReturns(tx, nil)
+				trashStore = store.NewTrashStore(mockDb, dataStore)
+			})
+
+			It("reads them inside the transaction and rolls it back", func() {
+				_, err := trashStore.Restore([]int{1})
+				Expect(err).To(MatchError("listing deleted policies: potato"))
+
+				Expect(tx.QueryCallCount()).To(Equal(1))
+				Expect(tx.RollbackCallCount()).To(Equal(1))
+				Expect(tx.CommitCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when recreating the policies fails", func() {
+			BeforeEach(func() {
+				failingStore := &fakes.Store{}
+				failingStore.CreateWithTxReturns(errors.New("potato"))
+				trashStore = store.NewTrashStore(realDb, failingStore)
+			})
+
+			It("returns an error and leaves the trash alone", func() {
+				deleted, err := trashStore.All()
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = trashStore.Restore([]int{deleted[0].ID})
+				Expect(err).To(MatchError("restoring policies: potato"))
+
+				remaining, err := trashStore.All()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(remaining).To(HaveLen(2))
+			})
+		})
+	})
+
+	Describe("Purge", func() {
+		BeforeEach(func() {
+			deleteWithTrash(policies)
+		})
+
+		It("removes policies deleted before the given time", func() {
+			count, err := trashStore.Purge(time.Now().Add(-time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(0))
+
+			count, err = trashStore.Purge(time.Now().Add(time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(2))
+
+			deleted, err := trashStore.All()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(BeEmpty())
+		})
+	})
+})
@@ -36,6 +36,7 @@ type CheckTokenResponse struct {
 	Scope    []string `json:"scope"`
 	UserID   string   `json:"user_id"`
 	UserName string   `json:"user_name"`
+	ClientID string   `json:"client_id"`
 }
 
 func (c *Client) GetToken() (string, error) {
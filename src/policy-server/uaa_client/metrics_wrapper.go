@@ -0,0 +1,55 @@
+package uaa_client
+
+import "time"
+
+//go:generate counterfeiter -o fakes/metrics_sender.go --fake-name MetricsSender . metricsSender
+type metricsSender interface {
+	IncrementCounter(string)
+	SendDuration(string, time.Duration)
+}
+
+//go:generate counterfeiter -o fakes/uaa_client.go --fake-name UAAClient . uaaClient
+type uaaClient interface {
+	GetToken() (string, error)
+	CheckToken(token string) (CheckTokenResponse, error)
+	GetTokenKeys() ([]TokenKey, error)
+}
+
+// MetricsWrapper sends the duration of each UAA call as
+// UAA<Method>SuccessTime or UAA<Method>ErrorTime, and counts errors as
+// UAA<Method>Error.
+type MetricsWrapper struct {
+	Client        uaaClient
+	MetricsSender metricsSender
+}
+
+func (mw *MetricsWrapper) GetToken() (string, error) {
+	startTime := time.Now()
+	token, err := mw.Client.GetToken()
+	mw.send("GetToken", startTime, err)
+	return token, err
+}
+
+func (mw *MetricsWrapper) CheckToken(token string) (CheckTokenResponse, error) {
+	startTime := time.Now()
+	tokenData, err := mw.Client.CheckToken(token)
+	mw.send("CheckToken", startTime, err)
+	return tokenData, err
+}
+
+func (mw *MetricsWrapper) GetTokenKeys() ([]TokenKey, error) {
+	startTime := time.Now()
+	keys, err := mw.Client.GetTokenKeys()
+	mw.send("GetTokenKeys", startTime, err)
+	return keys, err
+}
+
+func (mw *MetricsWrapper) send(method string, startTime time.Time, err error) {
+	duration := time.Now().Sub(startTime)
+	if err != nil {
+		mw.MetricsSender.IncrementCounter("UAA" + method + "Error")
+		mw.MetricsSender.SendDuration("UAA"+method+"ErrorTime", duration)
+	} else {
+		mw.MetricsSender.SendDuration("UAA"+method+"SuccessTime", duration)
+	}
+}
@@ -0,0 +1,85 @@
+package uaa_client_test
+
+import (
+	"errors"
+	"policy-server/uaa_client"
+	"policy-server/uaa_client/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MetricsWrapper", func() {
+	var (
+		metricsWrapper    *uaa_client.MetricsWrapper
+		fakeClient        *fakes.UAAClient
+		fakeMetricsSender *fakes.MetricsSender
+	)
+
+	BeforeEach(func() {
+		fakeClient = &fakes.UAAClient{}
+		fakeMetricsSender = &fakes.MetricsSender{}
+		metricsWrapper = &uaa_client.MetricsWrapper{
+			Client:        fakeClient,
+			MetricsSender: fakeMetricsSender,
+		}
+	})
+
+	Describe("GetToken", func() {
+		It("calls GetToken on the Client and emits a metric", func() {
+			fakeClient.GetTokenReturns("some-token", nil)
+
+			token, err := metricsWrapper.GetToken()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token).To(Equal("some-token"))
+			Expect(fakeClient.GetTokenCallCount()).To(Equal(1))
+
+			Expect(fakeMetricsSender.SendDurationCallCount()).To(Equal(1))
+			name, _ := fakeMetricsSender.SendDurationArgsForCall(0)
+			Expect(name).To(Equal("UAAGetTokenSuccessTime"))
+		})
+
+		Context("when there is an error", func() {
+			It("emits an error metric", func() {
+				fakeClient.GetTokenReturns("", errors.New("banana"))
+
+				_, err := metricsWrapper.GetToken()
+				Expect(err).To(MatchError("banana"))
+
+				Expect(fakeMetricsSender.IncrementCounterCallCount()).To(Equal(1))
+				Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("UAAGetTokenError"))
+
+				Expect(fakeMetricsSender.SendDurationCallCount()).To(Equal(1))
+				name, _ := fakeMetricsSender.SendDurationArgsForCall(0)
+				Expect(name).To(Equal("UAAGetTokenErrorTime"))
+			})
+		})
+	})
+
+	Describe("CheckToken", func() {
+		It("calls CheckToken on the Client and emits a metric", func() {
+			fakeClient.CheckTokenReturns(uaa_client.CheckTokenResponse{UserID: "some-user"}, nil)
+
+			tokenData, err := metricsWrapper.CheckToken("some-token")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokenData.UserID).To(Equal("some-user"))
+			Expect(fakeClient.CheckTokenArgsForCall(0)).To(Equal("some-token"))
+
+			name, _ := fakeMetricsSender.SendDurationArgsForCall(0)
+			Expect(name).To(Equal("UAACheckTokenSuccessTime"))
+		})
+	})
+
+	Describe("GetTokenKeys", func() {
+		It("calls GetTokenKeys on the Client and emits a metric", func() {
+			fakeClient.GetTokenKeysReturns([]uaa_client.TokenKey{{KeyID: "some-key"}}, nil)
+
+			keys, err := metricsWrapper.GetTokenKeys()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(Equal([]uaa_client.TokenKey{{KeyID: "some-key"}}))
+
+			name, _ := fakeMetricsSender.SendDurationArgsForCall(0)
+			Expect(name).To(Equal("UAAGetTokenKeysSuccessTime"))
+		})
+	})
+})
@@ -0,0 +1,39 @@
+package uaa_client
+
+import (
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// TokenKey is a verification key as published by UAA at /token_keys.
+type TokenKey struct {
+	KeyID     string `json:"kid"`
+	KeyType   string `json:"kty"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+	Value     string `json:"value"`
+	N         string `json:"n"`
+	E         string `json:"e"`
+}
+
+func (c *Client) GetTokenKeys() ([]TokenKey, error) {
+	reqURL := fmt.Sprintf("%s/token_keys", c.BaseURL)
+	request, err := http.NewRequest("GET", reqURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Logger.Debug("get-token-keys", lager.Data{"URL": request.URL})
+
+	type tokenKeysResponse struct {
+		Keys []TokenKey `json:"keys"`
+	}
+	response := &tokenKeysResponse{}
+	err = c.makeRequest(request, response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Keys, nil
+}
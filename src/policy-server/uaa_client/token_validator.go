@@ -0,0 +1,293 @@
+package uaa_client
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+//go:generate counterfeiter -o fakes/token_keys_getter.go --fake-name TokenKeysGetter . tokenKeysGetter
+type tokenKeysGetter interface {
+	GetTokenKeys() ([]TokenKey, error)
+}
+
+//go:generate counterfeiter -o fakes/token_checker.go --fake-name TokenChecker . tokenChecker
+type tokenChecker interface {
+	CheckToken(token string) (CheckTokenResponse, error)
+}
+
+var signingHashes = map[string]crypto.Hash{
+	"RS256": crypto.SHA256,
+	"RS384": crypto.SHA384,
+	"RS512": crypto.SHA512,
+}
+
+// unverifiableTokenError means the token could not be checked locally, as
+// opposed to having been checked and found invalid.
+type unverifiableTokenError struct {
+	reason string
+}
+
+func (e unverifiableTokenError) Error() string {
+	return e.reason
+}
+
+// TokenValidator validates UAA-issued JWTs locally against the keys UAA
+// publishes at /token_keys, instead of calling check_token per request.
+//
+// Keys are cached by key id. A token signed with an unknown key id triggers
+// a refetch, which picks up rotated keys. Keys older than MaxKeyAge are
+// refetched before they are used again, so keys UAA has stopped publishing
+// stop validating tokens. Refetches are attempted at most once per
+// MinKeyRefreshInterval, whether they succeed or not, and when one fails the
+// cached keys are kept in use. Tokens that cannot be verified locally (opaque
+// tokens, unknown keys, UAA unreachable) are passed to Fallback when it is
+// set.
+type TokenValidator struct {
+	Keys                  tokenKeysGetter
+	Issuer                string
+	Fallback              tokenChecker
+	MinKeyRefreshInterval time.Duration
+	MaxKeyAge             time.Duration
+	Logger                lager.Logger
+	Now                   func() time.Time
+
+	mutex       sync.Mutex
+	keys        map[string]*rsa.PublicKey
+	lastRefresh time.Time
+	lastAttempt time.Time
+	refreshErr  error
+	refreshing  chan struct{}
+}
+
+type tokenHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+type tokenClaims struct {
+	Issuer    string   `json:"iss"`
+	ExpiresAt *int64   `json:"exp"`
+	Scope     []string `json:"scope"`
+	UserID    string   `json:"user_id"`
+	UserName  string   `json:"user_name"`
+	ClientID  string   `json:"client_id"`
+}
+
+func (v *TokenValidator) CheckToken(token string) (CheckTokenResponse, error) {
+	response, err := v.validate(token)
+	if _, ok := err.(unverifiableTokenError); ok && v.Fallback != nil {
+		v.Logger.Debug("check-token-fallback", lager.Data{"reason": err.Error()})
+		return v.Fallback.CheckToken(token)
+	}
+	return response, err
+}
+
+func (v *TokenValidator) validate(token string) (CheckTokenResponse, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return CheckTokenResponse{}, unverifiableTokenError{"token is not a jwt"}
+	}
+
+	header := tokenHeader{}
+	err := decodeSegment(parts[0], &header)
+	if err != nil {
+		return CheckTokenResponse{}, unverifiableTokenError{fmt.Sprintf("decoding token header: %s", err)}
+	}
+
+	hash, ok := signingHashes[header.Algorithm]
+	if !ok {
+		return CheckTokenResponse{}, unverifiableTokenError{fmt.Sprintf("unsupported signing algorithm: %s", header.Algorithm)}
+	}
+
+	key, err := v.key(header.KeyID)
+	if err != nil {
+		return CheckTokenResponse{}, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return CheckTokenResponse{}, fmt.Errorf("decoding token signature: %s", err)
+	}
+
+	hasher := hash.New()
+	hasher.Write([]byte(parts[0] + "." + parts[1]))
+	err = rsa.VerifyPKCS1v15(key, hash, hasher.Sum(nil), signature)
+	if err != nil {
+		return CheckTokenResponse{}, errors.New("invalid token signature")
+	}
+
+	claims := tokenClaims{}
+	err = decodeSegment(parts[1], &claims)
+	if err != nil {
+		return CheckTokenResponse{}, fmt.Errorf("decoding token claims: %s", err)
+	}
+
+	if claims.Issuer != v.Issuer {
+		return CheckTokenResponse{}, fmt.Errorf("unexpected token issuer: %s", claims.Issuer)
+	}
+
+	if claims.ExpiresAt == nil {
+		return CheckTokenResponse{}, errors.New("token has no expiry")
+	}
+	if !v.now().Before(time.Unix(*claims.ExpiresAt, 0)) {
+		return CheckTokenResponse{}, errors.New("token expired")
+	}
+
+	return CheckTokenResponse{
+		Scope:    claims.Scope,
+		UserID:   claims.UserID,
+		UserName: claims.UserName,
+		ClientID: claims.ClientID,
+	}, nil
+}
+
+// key returns the cached key with the given id, refetching the keys from UAA
+// first if it is not known or the cached keys are older than MaxKeyAge. The
+// keys are fetched without holding the lock, by one caller at a time. Callers
+// that have a cached key use it while another caller fetches; the others wait
+// for the fetch.
+func (v *TokenValidator) key(keyID string) (*rsa.PublicKey, error) {
+	v.mutex.Lock()
+	key, cached := v.cachedKey(keyID)
+	if cached && !v.keysExpired() {
+		v.mutex.Unlock()
+		return key, nil
+	}
+
+	refreshing := v.refreshing
+	if refreshing == nil && v.now().Sub(v.lastAttempt) >= v.MinKeyRefreshInterval {
+		v.refreshing = make(chan struct{})
+		v.lastAttempt = v.now()
+		v.mutex.Unlock()
+		v.refreshKeys()
+		v.mutex.Lock()
+	} else if refreshing != nil && !cached {
+		v.mutex.Unlock()
+		<-refreshing
+		v.mutex.Lock()
+	}
+	defer v.mutex.Unlock()
+
+	if key, ok := v.cachedKey(keyID); ok {
+		return key, nil
+	}
+	if v.refreshErr != nil {
+		return nil, unverifiableTokenError{fmt.Sprintf("refreshing token keys: %s", v.refreshErr)}
+	}
+	return nil, unverifiableTokenError{fmt.Sprintf("unknown token key: %s", keyID)}
+}
+
+func (v *TokenValidator) keysExpired() bool {
+	return v.MaxKeyAge != 0 && v.now().Sub(v.lastRefresh) >= v.MaxKeyAge
+}
+
+// cachedKey looks up a key by id. Tokens without a key id can only be
+// verified while UAA publishes a single key.
+func (v *TokenValidator) cachedKey(keyID string) (*rsa.PublicKey, bool) {
+	if keyID == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key, true
+		}
+	}
+	key, ok := v.keys[keyID]
+	return key, ok
+}
+
+// refreshKeys fetches the keys and ends the refresh started by key. The
+// cached keys are only replaced when the fetch succeeds.
+func (v *TokenValidator) refreshKeys() {
+	keys, err := v.fetchKeys()
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	if err != nil {
+		v.Logger.Error("refresh-token-keys", err)
+	} else {
+		v.keys = keys
+		v.lastRefresh = v.now()
+	}
+	v.refreshErr = err
+	close(v.refreshing)
+	v.refreshing = nil
+}
+
+func (v *TokenValidator) fetchKeys() (map[string]*rsa.PublicKey, error) {
+	tokenKeys, err := v.Keys.GetTokenKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := map[string]*rsa.PublicKey{}
+	for _, tokenKey := range tokenKeys {
+		key, err := parseTokenKey(tokenKey)
+		if err != nil {
+			v.Logger.Error("parse-token-key", err, lager.Data{"kid": tokenKey.KeyID})
+			continue
+		}
+		keys[tokenKey.KeyID] = key
+	}
+	return keys, nil
+}
+
+func (v *TokenValidator) now() time.Time {
+	if v.Now != nil {
+		return v.Now()
+	}
+	return time.Now()
+}
+
+func parseTokenKey(tokenKey TokenKey) (*rsa.PublicKey, error) {
+	if tokenKey.KeyType != "RSA" {
+		return nil, fmt.Errorf("unsupported key type: %s", tokenKey.KeyType)
+	}
+
+	if tokenKey.N != "" && tokenKey.E != "" {
+		n, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(tokenKey.N, "="))
+		if err != nil {
+			return nil, fmt.Errorf("decoding modulus: %s", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(tokenKey.E, "="))
+		if err != nil {
+			return nil, fmt.Errorf("decoding exponent: %s", err)
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	}
+
+	block, _ := pem.Decode([]byte(tokenKey.Value))
+	if block == nil {
+		return nil, errors.New("no pem encoded key")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing public key: %s", err)
+	}
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("not an rsa public key")
+	}
+	return rsaKey, nil
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	bytes, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bytes, v)
+}
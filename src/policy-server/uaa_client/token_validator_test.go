@@ -0,0 +1,360 @@
+package uaa_client_test
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"policy-server/uaa_client"
+	"policy-server/uaa_client/fakes"
+	"sync/atomic"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TokenValidator", func() {
+	var (
+		validator    *uaa_client.TokenValidator
+		uaaServer    *httptest.Server
+		keyRequests  int32
+		failKeys     int32
+		blockKeys    chan struct{}
+		publishedKey atomic.Value
+		signingKey   *rsa.PrivateKey
+		fakeFallback *fakes.TokenChecker
+		now          time.Time
+		claims       map[string]interface{}
+	)
+
+	BeforeEach(func() {
+		var err error
+		signingKey, err = rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).NotTo(HaveOccurred())
+		publishedKey.Store(tokenKeys("key-1", &signingKey.PublicKey))
+		keyRequests = 0
+		failKeys = 0
+		blockKeys = nil
+
+		uaaServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path != "/token_keys" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			atomic.AddInt32(&keyRequests, 1)
+			if blockKeys != nil {
+				<-blockKeys
+			}
+			if atomic.LoadInt32(&failKeys) == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.Write(publishedKey.Load().([]byte))
+		}))
+
+		logger := lagertest.NewTestLogger("test")
+		fakeFallback = &fakes.TokenChecker{}
+		now = time.Unix(1500000000, 0)
+		validator = &uaa_client.TokenValidator{
+			Keys: &uaa_client.Client{
+				BaseURL:    uaaServer.URL,
+				HTTPClient: http.DefaultClient,
+				Logger:     logger,
+			},
+			Issuer:                "https://uaa.example.com/oauth/token",
+			MinKeyRefreshInterval: time.Minute,
+			Logger:                logger,
+			Now:                   func() time.Time { return now },
+		}
+
+		claims = map[string]interface{}{
+			"iss":       "https://uaa.example.com/oauth/token",
+			"exp":       now.Add(time.Hour).Unix(),
+			"scope":     []string{"network.admin", "openid"},
+			"user_id":   "some-user-id",
+			"user_name": "some-user",
+			"client_id": "cf",
+		}
+	})
+
+	AfterEach(func() {
+		uaaServer.Close()
+	})
+
+	It("returns the claims of a valid token", func() {
+		tokenData, err := validator.CheckToken(signToken(signingKey, "key-1", claims))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tokenData).To(Equal(uaa_client.CheckTokenResponse{
+			Scope:    []string{"network.admin", "openid"},
+			UserID:   "some-user-id",
+			UserName: "some-user",
+			ClientID: "cf",
+		}))
+	})
+
+	It("caches the token keys", func() {
+		for i := 0; i < 3; i++ {
+			_, err := validator.CheckToken(signToken(signingKey, "key-1", claims))
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(atomic.LoadInt32(&keyRequests)).To(Equal(int32(1)))
+	})
+
+	It("accepts keys published in pem form", func() {
+		der, err := x509.MarshalPKIXPublicKey(&signingKey.PublicKey)
+		Expect(err).NotTo(HaveOccurred())
+		pemKey := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+		publishedKey.Store([]byte(fmt.Sprintf(`{"keys": [{"kid": "key-1", "kty": "RSA", "alg": "RS256", "value": %q}]}`, pemKey)))
+
+		_, err = validator.CheckToken(signToken(signingKey, "key-1", claims))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("when the keys are rotated", func() {
+		var newKey *rsa.PrivateKey
+
+		BeforeEach(func() {
+			_, err := validator.CheckToken(signToken(signingKey, "key-1", claims))
+			Expect(err).NotTo(HaveOccurred())
+
+			newKey, err = rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).NotTo(HaveOccurred())
+			publishedKey.Store(tokenKeys("key-2", &newKey.PublicKey))
+		})
+
+		It("fetches the keys again for an unknown key id", func() {
+			now = now.Add(2 * time.Minute)
+			_, err := validator.CheckToken(signToken(newKey, "key-2", claims))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(atomic.LoadInt32(&keyRequests)).To(Equal(int32(2)))
+		})
+
+		It("does not fetch the keys more often than MinKeyRefreshInterval", func() {
+			_, err := validator.CheckToken(signToken(newKey, "key-2", claims))
+			Expect(err).To(MatchError("unknown token key: key-2"))
+			Expect(atomic.LoadInt32(&keyRequests)).To(Equal(int32(1)))
+		})
+	})
+
+	Context("when a key is removed from UAA", func() {
+		BeforeEach(func() {
+			validator.MaxKeyAge = 10 * time.Minute
+
+			_, err := validator.CheckToken(signToken(signingKey, "key-1", claims))
+			Expect(err).NotTo(HaveOccurred())
+
+			newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).NotTo(HaveOccurred())
+			publishedKey.Store(tokenKeys("key-2", &newKey.PublicKey))
+		})
+
+		It("keeps using the cached key until it is older than MaxKeyAge", func() {
+			now = now.Add(5 * time.Minute)
+			_, err := validator.CheckToken(signToken(signingKey, "key-1", claims))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(atomic.LoadInt32(&keyRequests)).To(Equal(int32(1)))
+		})
+
+		It("fetches the keys again and stops accepting the removed key", func() {
+			now = now.Add(11 * time.Minute)
+			_, err := validator.CheckToken(signToken(signingKey, "key-1", claims))
+			Expect(err).To(MatchError("unknown token key: key-1"))
+			Expect(atomic.LoadInt32(&keyRequests)).To(Equal(int32(2)))
+		})
+	})
+
+	Context("when the keys cannot be fetched again", func() {
+		BeforeEach(func() {
+			validator.MaxKeyAge = 10 * time.Minute
+
+			_, err := validator.CheckToken(signToken(signingKey, "key-1", claims))
+			Expect(err).NotTo(HaveOccurred())
+
+			atomic.StoreInt32(&failKeys, 1)
+			now = now.Add(11 * time.Minute)
+		})
+
+		It("keeps using the cached keys", func() {
+			_, err := validator.CheckToken(signToken(signingKey, "key-1", claims))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(atomic.LoadInt32(&keyRequests)).To(Equal(int32(2)))
+		})
+
+		It("does not try again more often than MinKeyRefreshInterval", func() {
+			for i := 0; i < 3; i++ {
+				_, err := validator.CheckToken(signToken(signingKey, "key-1", claims))
+				Expect(err).NotTo(HaveOccurred())
+			}
+			Expect(atomic.LoadInt32(&keyRequests)).To(Equal(int32(2)))
+
+			now = now.Add(2 * time.Minute)
+			_, err := validator.CheckToken(signToken(signingKey, "key-1", claims))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(atomic.LoadInt32(&keyRequests)).To(Equal(int32(3)))
+		})
+	})
+
+	Context("while the keys are being fetched", func() {
+		BeforeEach(func() {
+			_, err := validator.CheckToken(signToken(signingKey, "key-1", claims))
+			Expect(err).NotTo(HaveOccurred())
+			now = now.Add(2 * time.Minute)
+		})
+
+		It("validates tokens signed with cached keys without waiting", func() {
+			blockKeys = make(chan struct{})
+			defer close(blockKeys)
+
+			newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).NotTo(HaveOccurred())
+			go validator.CheckToken(signToken(newKey, "key-2", claims))
+			Eventually(func() int32 { return atomic.LoadInt32(&keyRequests) }).Should(Equal(int32(2)))
+
+			done := make(chan error)
+			go func() {
+				_, err := validator.CheckToken(signToken(signingKey, "key-1", claims))
+				done <- err
+			}()
+			Eventually(done).Should(Receive(BeNil()))
+		})
+	})
+
+	Context("when the signature does not match", func() {
+		It("returns an error", func() {
+			otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = validator.CheckToken(signToken(otherKey, "key-1", claims))
+			Expect(err).To(MatchError("invalid token signature"))
+		})
+	})
+
+	Context("when the token has expired", func() {
+		BeforeEach(func() {
+			claims["exp"] = now.Add(-time.Second).Unix()
+		})
+
+		It("returns an error", func() {
+			_, err := validator.CheckToken(signToken(signingKey, "key-1", claims))
+			Expect(err).To(MatchError("token expired"))
+		})
+	})
+
+	Context("when the token has no expiry", func() {
+		BeforeEach(func() {
+			delete(claims, "exp")
+		})
+
+		It("returns an error", func() {
+			_, err := validator.CheckToken(signToken(signingKey, "key-1", claims))
+			Expect(err).To(MatchError("token has no expiry"))
+		})
+	})
+
+	Context("when the token was issued by someone else", func() {
+		BeforeEach(func() {
+			claims["iss"] = "https://evil.example.com/oauth/token"
+		})
+
+		It("returns an error", func() {
+			_, err := validator.CheckToken(signToken(signingKey, "key-1", claims))
+			Expect(err).To(MatchError("unexpected token issuer: https://evil.example.com/oauth/token"))
+		})
+	})
+
+	Context("when the token is not a jwt", func() {
+		It("returns an error", func() {
+			_, err := validator.CheckToken("some-opaque-token")
+			Expect(err).To(MatchError("token is not a jwt"))
+		})
+	})
+
+	Context("when the token keys cannot be fetched", func() {
+		BeforeEach(func() {
+			uaaServer.Close()
+		})
+
+		It("returns an error", func() {
+			_, err := validator.CheckToken(signToken(signingKey, "key-1", claims))
+			Expect(err).To(MatchError(HavePrefix("refreshing token keys: http client:")))
+		})
+	})
+
+	Context("when a fallback is configured", func() {
+		BeforeEach(func() {
+			validator.Fallback = fakeFallback
+			fakeFallback.CheckTokenReturns(uaa_client.CheckTokenResponse{UserName: "from-check-token"}, nil)
+		})
+
+		It("uses check_token for tokens that cannot be verified locally", func() {
+			tokenData, err := validator.CheckToken("some-opaque-token")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokenData.UserName).To(Equal("from-check-token"))
+			Expect(fakeFallback.CheckTokenArgsForCall(0)).To(Equal("some-opaque-token"))
+		})
+
+		It("uses check_token when the token keys cannot be fetched", func() {
+			uaaServer.Close()
+			_, err := validator.CheckToken(signToken(signingKey, "key-1", claims))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeFallback.CheckTokenCallCount()).To(Equal(1))
+		})
+
+		It("does not use check_token for invalid tokens", func() {
+			claims["exp"] = now.Add(-time.Second).Unix()
+			_, err := validator.CheckToken(signToken(signingKey, "key-1", claims))
+			Expect(err).To(MatchError("token expired"))
+			Expect(fakeFallback.CheckTokenCallCount()).To(Equal(0))
+		})
+
+		Context("when check_token fails", func() {
+			BeforeEach(func() {
+				fakeFallback.CheckTokenReturns(uaa_client.CheckTokenResponse{}, errors.New("potato"))
+			})
+
+			It("returns the error", func() {
+				_, err := validator.CheckToken("some-opaque-token")
+				Expect(err).To(MatchError("potato"))
+			})
+		})
+	})
+})
+
+func tokenKeys(keyID string, key *rsa.PublicKey) []byte {
+	body, err := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{{
+			"kid": keyID,
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	})
+	Expect(err).NotTo(HaveOccurred())
+	return body
+}
+
+func signToken(key *rsa.PrivateKey, keyID string, claims map[string]interface{}) string {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "kid": keyID, "typ": "JWT"})
+	Expect(err).NotTo(HaveOccurred())
+	payload, err := json.Marshal(claims)
+	Expect(err).NotTo(HaveOccurred())
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	hash := crypto.SHA256.New()
+	hash.Write([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash.Sum(nil))
+	Expect(err).NotTo(HaveOccurred())
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
@@ -0,0 +1,41 @@
+package uaa_client
+
+import (
+	"context"
+	"lib/tracing"
+)
+
+// TracingWrapper runs each UAA call in a uaa.<Method> span that is a child
+// of the span in the context it is given.
+type TracingWrapper struct {
+	Client uaaClient
+	Tracer *tracing.Tracer
+}
+
+func (tw *TracingWrapper) GetToken(ctx context.Context) (string, error) {
+	_, span := tw.Tracer.Start(ctx, "uaa.GetToken")
+	token, err := tw.Client.GetToken()
+	span.End(err)
+	return token, err
+}
+
+func (tw *TracingWrapper) CheckToken(ctx context.Context, token string) (CheckTokenResponse, error) {
+	_, span := tw.Tracer.Start(ctx, "uaa.CheckToken")
+	tokenData, err := tw.Client.CheckToken(token)
+	span.End(err)
+	return tokenData, err
+}
+
+// TokenCheckerTracingWrapper runs each check of a tokenChecker, such as a
+// TokenValidator, in a uaa.ValidateToken span.
+type TokenCheckerTracingWrapper struct {
+	Checker tokenChecker
+	Tracer  *tracing.Tracer
+}
+
+func (tw *TokenCheckerTracingWrapper) CheckToken(ctx context.Context, token string) (CheckTokenResponse, error) {
+	_, span := tw.Tracer.Start(ctx, "uaa.ValidateToken")
+	tokenData, err := tw.Checker.CheckToken(token)
+	span.End(err)
+	return tokenData, err
+}
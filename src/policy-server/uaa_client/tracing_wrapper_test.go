@@ -0,0 +1,117 @@
+package uaa_client_test
+
+import (
+	"context"
+	"errors"
+	libfakes "lib/fakes"
+	"lib/tracing"
+	"policy-server/uaa_client"
+	"policy-server/uaa_client/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TracingWrapper", func() {
+	var (
+		tracingWrapper *uaa_client.TracingWrapper
+		fakeClient     *fakes.UAAClient
+		fakeExporter   *libfakes.SpanExporter
+		tracer         *tracing.Tracer
+	)
+
+	BeforeEach(func() {
+		fakeClient = &fakes.UAAClient{}
+		fakeExporter = &libfakes.SpanExporter{}
+		tracer = &tracing.Tracer{Service: "some-service", Exporter: fakeExporter}
+		tracingWrapper = &uaa_client.TracingWrapper{
+			Client: fakeClient,
+			Tracer: tracer,
+		}
+	})
+
+	Describe("GetToken", func() {
+		It("calls GetToken in a child span of the one in the context", func() {
+			fakeClient.GetTokenReturns("some-token", nil)
+			ctx, parent := tracer.Start(context.Background(), "parent")
+
+			token, err := tracingWrapper.GetToken(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token).To(Equal("some-token"))
+
+			Expect(fakeExporter.ExportSpanCallCount()).To(Equal(1))
+			span := fakeExporter.ExportSpanArgsForCall(0)
+			Expect(span.Name).To(Equal("uaa.GetToken"))
+			Expect(span.ParentSpanID).To(Equal(parent.SpanContext().SpanID))
+		})
+
+		Context("when there is an error", func() {
+			It("records the error on the span", func() {
+				fakeClient.GetTokenReturns("", errors.New("banana"))
+
+				_, err := tracingWrapper.GetToken(context.Background())
+				Expect(err).To(MatchError("banana"))
+
+				Expect(fakeExporter.ExportSpanArgsForCall(0).Error).To(Equal("banana"))
+			})
+		})
+	})
+
+	Describe("CheckToken", func() {
+		It("calls CheckToken in a uaa.CheckToken span", func() {
+			fakeClient.CheckTokenReturns(uaa_client.CheckTokenResponse{UserName: "some-user"}, nil)
+
+			tokenData, err := tracingWrapper.CheckToken(context.Background(), "some-token")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokenData.UserName).To(Equal("some-user"))
+			Expect(fakeClient.CheckTokenArgsForCall(0)).To(Equal("some-token"))
+
+			Expect(fakeExporter.ExportSpanArgsForCall(0).Name).To(Equal("uaa.CheckToken"))
+		})
+	})
+})
+
+var _ = Describe("TokenCheckerTracingWrapper", func() {
+	var (
+		tracingWrapper *uaa_client.TokenCheckerTracingWrapper
+		fakeChecker    *fakes.TokenChecker
+		fakeExporter   *libfakes.SpanExporter
+		tracer         *tracing.Tracer
+	)
+
+	BeforeEach(func() {
+		fakeChecker = &fakes.TokenChecker{}
+		fakeExporter = &libfakes.SpanExporter{}
+		tracer = &tracing.Tracer{Service: "some-service", Exporter: fakeExporter}
+		tracingWrapper = &uaa_client.TokenCheckerTracingWrapper{
+			Checker: fakeChecker,
+			Tracer:  tracer,
+		}
+	})
+
+	It("checks the token in a child span of the one in the context", func() {
+		fakeChecker.CheckTokenReturns(uaa_client.CheckTokenResponse{UserName: "some-user"}, nil)
+		ctx, parent := tracer.Start(context.Background(), "parent")
+
+		tokenData, err := tracingWrapper.CheckToken(ctx, "some-token")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tokenData.UserName).To(Equal("some-user"))
+		Expect(fakeChecker.CheckTokenArgsForCall(0)).To(Equal("some-token"))
+
+		Expect(fakeExporter.ExportSpanCallCount()).To(Equal(1))
+		span := fakeExporter.ExportSpanArgsForCall(0)
+		Expect(span.Name).To(Equal("uaa.ValidateToken"))
+		Expect(span.ParentSpanID).To(Equal(parent.SpanContext().SpanID))
+	})
+
+	Context("when the token is invalid", func() {
+		It("records the error on the span", func() {
+			fakeChecker.CheckTokenReturns(uaa_client.CheckTokenResponse{}, errors.New("banana"))
+
+			_, err := tracingWrapper.CheckToken(context.Background(), "some-token")
+			Expect(err).To(MatchError("banana"))
+
+			Expect(fakeExporter.ExportSpanArgsForCall(0).Error).To(Equal("banana"))
+		})
+	})
+})
@@ -7,46 +7,39 @@ import (
 	"time"
 
 	configHelper "code.cloudfoundry.org/cf-networking-helpers/db"
-	"code.cloudfoundry.org/lager"
+	"github.com/jmoiron/sqlx"
 	"github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
-	"policy-server/db"
 )
 
+// sqliteType is the database type of SQLite test databases, which need no
+// database server.
+const sqliteType = "sqlite3"
+
 func CreateDatabase(config configHelper.Config) {
+	if config.Type == sqliteType {
+		return
+	}
 	config.Timeout = 120
 	dbToCreate := config.DatabaseName
 	config.DatabaseName = ""
 	println(time.Now().String() + " Creating database " + dbToCreate)
-	logger := lager.NewLogger("Test Support")
-	connectionPool := db.NewConnectionPool(
-		config,
-		200,
-		200,
-		"testsupport",
-		"db-helper",
-		logger,
-	)
+	connectionPool := connectToServer(config)
 	defer connectionPool.Close()
 	_, err := connectionPool.Exec(fmt.Sprintf("CREATE DATABASE %s", dbToCreate))
 	Expect(err).NotTo(HaveOccurred())
 }
 
 func RemoveDatabase(config configHelper.Config) {
+	if config.Type == sqliteType {
+		return
+	}
 	config.Timeout = 120
 
 	dbToDrop := config.DatabaseName
 	config.DatabaseName = ""
 
-	logger := lager.NewLogger("Test Support")
-	connectionPool := db.NewConnectionPool(
-		config,
-		200,
-		200,
-		"testsupport",
-		"db-helper",
-		logger,
-	)
+	connectionPool := connectToServer(config)
 	defer connectionPool.Close()
 	_, err := connectionPool.Exec(fmt.Sprintf("DROP DATABASE %s", dbToDrop))
 	if err != nil {
@@ -54,6 +47,18 @@ func RemoveDatabase(config configHelper.Config) {
 	}
 }
 
+func connectToServer(config configHelper.Config) *sqlx.DB {
+	connector := configHelper.RetriableConnector{
+		Connector:     configHelper.GetConnectionPool,
+		Sleeper:       configHelper.SleeperFunc(time.Sleep),
+		RetryInterval: 3 * time.Second,
+		MaxRetries:    10,
+	}
+	connectionPool, err := connector.GetConnectionPool(config)
+	Expect(err).NotTo(HaveOccurred())
+	return connectionPool
+}
+
 const DefaultDBTimeout = 5
 
 func getPostgresDBConfig() configHelper.Config {
@@ -78,6 +83,25 @@ func getMySQLDBConfig() configHelper.Config {
 	}
 }
 
+func getSQLiteDBConfig() configHelper.Config {
+	return configHelper.Config{
+		Type:    sqliteType,
+		Timeout: DefaultDBTimeout,
+	}
+}
+
+// DatabaseName returns the database_name of the test database called name.
+// SQLite test databases are kept in memory, so that nothing is left on disk;
+// they need no CreateDatabase or RemoveDatabase.
+func DatabaseName(config configHelper.Config, name string) string {
+	if config.Type == sqliteType {
+		return fmt.Sprintf("file:%s?mode=memory", name)
+	}
+	return name
+}
+
+// GetDBConfig returns the config of the database named by the DB environment
+// variable: mysql, postgres, or sqlite. SQLite needs the sqlite build tag.
 func GetDBConfig() configHelper.Config {
 	dbEnv := os.Getenv("DB")
 	switch {
@@ -85,6 +109,8 @@ func GetDBConfig() configHelper.Config {
 		return getMySQLDBConfig()
 	case strings.HasPrefix(dbEnv, "postgres"):
 		return getPostgresDBConfig()
+	case dbEnv == "sqlite":
+		return getSQLiteDBConfig()
 	default:
 		panic("unable to determine database to use.  Set environment variable DB")
 	}